@@ -0,0 +1,293 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/LiveRamp/gazette/v2/pkg/allocator"
+	"github.com/LiveRamp/gazette/v2/pkg/broker"
+	"github.com/LiveRamp/gazette/v2/pkg/consumer"
+	"github.com/LiveRamp/gazette/v2/pkg/keyspace"
+	mbp "github.com/LiveRamp/gazette/v2/pkg/mainboilerplate"
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	"github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/etcdserver/etcdserverpb"
+	"github.com/coreos/etcd/mvcc/mvccpb"
+	"github.com/olekukonko/tablewriter"
+)
+
+type cmdAllocatorPlan struct {
+	Type         string      `long:"type" choice:"broker" choice:"consumer" default:"broker" description:"Type of the allocator KeySpace"`
+	Prefix       string      `long:"prefix" default:"/gazette/brokers" description:"Etcd prefix of the allocator KeySpace (eg, /gazette/consumers/myApplication)"`
+	Etcd         pb.Endpoint `long:"etcd" default:"http://localhost:2379" description:"Etcd service address endpoint from which the KeySpace is loaded"`
+	Snapshot     string      `long:"snapshot" description:"Path to a KeySpace snapshot to load instead of Etcd, as output by 'etcdctl get --prefix -w json'"`
+	AddMember    []string    `long:"add-member" description:"Add a member, as zone#suffix=limit"`
+	RemoveMember []string    `long:"remove-member" description:"Remove a member, as zone#suffix"`
+	MemberLimit  []string    `long:"member-limit" description:"Change the item limit of a member, as zone#suffix=limit"`
+	Replication  []string    `long:"replication" description:"Change the desired replication of an item, as item-id=replication"`
+	ShowAll      bool        `long:"all" description:"Output all desired assignments, and not only changes"`
+}
+
+func (cmd *cmdAllocatorPlan) Execute([]string) error {
+	startup()
+
+	var specs = planSpecsOf(cmd.Type)
+	var ks = specs.newKeySpace(cmd.Prefix)
+	var state = allocator.NewObservedState(ks, "")
+
+	if cmd.Snapshot != "" {
+		var resp etcdserverpb.RangeResponse
+
+		var b, err = ioutil.ReadFile(cmd.Snapshot)
+		mbp.Must(err, "failed to read snapshot", "path", cmd.Snapshot)
+		mbp.Must(json.Unmarshal(b, &resp), "failed to decode snapshot", "path", cmd.Snapshot)
+
+		if resp.Header == nil {
+			resp.Header = new(etcdserverpb.ResponseHeader)
+		}
+		mbp.Must(ks.LoadSnapshot(*resp.Header, resp.Kvs), "failed to load snapshot")
+	} else {
+		var etcd, err = clientv3.NewFromURL(string(cmd.Etcd))
+		mbp.Must(err, "failed to build Etcd client")
+		mbp.Must(ks.Load(context.Background(), etcd, 0), "failed to load KeySpace")
+	}
+
+	// Build and apply a synthetic WatchResponse of all hypothetical changes.
+	// Changes are never written to Etcd.
+	ks.Mu.RLock()
+	var wr = clientv3.WatchResponse{Header: ks.Header}
+	wr.Header.Revision++
+
+	for _, arg := range cmd.AddMember {
+		var id, limit = parseMemberArg(arg, true)
+		var key = allocator.MemberKey(ks, id.Zone, id.Suffix)
+
+		if _, ok := ks.Search(key); ok {
+			mbp.Must(fmt.Errorf("member already exists"), "failed to add member", "member", arg)
+		}
+		wr.Events = append(wr.Events, planPutEvent(key, specs.newMember(id, limit), nil, wr.Header.Revision))
+	}
+	for _, arg := range cmd.RemoveMember {
+		var id, _ = parseMemberArg(arg, false)
+		var kv = mustFindKeyValue(ks, allocator.MemberKey(ks, id.Zone, id.Suffix), "member", arg)
+
+		wr.Events = append(wr.Events, &clientv3.Event{
+			Type: clientv3.EventTypeDelete,
+			Kv:   &mvccpb.KeyValue{Key: kv.Raw.Key, ModRevision: wr.Header.Revision},
+		})
+	}
+	for _, arg := range cmd.MemberLimit {
+		var id, limit = parseMemberArg(arg, true)
+		var kv = mustFindKeyValue(ks, allocator.MemberKey(ks, id.Zone, id.Suffix), "member", arg)
+		var spec = specs.setMemberLimit(kv.Decoded.(allocator.Member).MemberValue, limit)
+
+		wr.Events = append(wr.Events, planPutEvent(string(kv.Raw.Key), spec, &kv.Raw, wr.Header.Revision))
+	}
+	for _, arg := range cmd.Replication {
+		var ind = strings.LastIndexByte(arg, '=')
+		if ind == -1 {
+			mbp.Must(fmt.Errorf("expected item-id=replication"), "failed to parse replication", "arg", arg)
+		}
+		var r, err = strconv.Atoi(arg[ind+1:])
+		mbp.Must(err, "failed to parse replication", "arg", arg)
+
+		var kv = mustFindKeyValue(ks, allocator.ItemKey(ks, arg[:ind]), "item", arg)
+		var spec = specs.setItemReplication(kv.Decoded.(allocator.Item).ItemValue, r)
+
+		wr.Events = append(wr.Events, planPutEvent(string(kv.Raw.Key), spec, &kv.Raw, wr.Header.Revision))
+	}
+	ks.Mu.RUnlock()
+
+	if len(wr.Events) != 0 {
+		mbp.Must(ks.Apply(wr), "failed to apply hypothetical changes")
+	}
+
+	ks.Mu.RLock()
+	var plan = allocator.NewPlan(state)
+	cmd.output(state, plan)
+	ks.Mu.RUnlock()
+
+	return nil
+}
+
+func (cmd *cmdAllocatorPlan) output(state *allocator.State, plan allocator.Plan) {
+	var table = tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Item", "Member", "Change"})
+
+	var add = func(a allocator.Assignment, change string) {
+		table.Append([]string{a.ItemID, a.MemberZone + allocator.Sep + a.MemberSuffix, change})
+	}
+	if cmd.ShowAll {
+		var added = make(map[allocator.Assignment]bool)
+		for _, a := range plan.Added {
+			added[allocator.Assignment{ItemID: a.ItemID, MemberZone: a.MemberZone, MemberSuffix: a.MemberSuffix}] = true
+		}
+		for _, a := range plan.Desired {
+			if added[a] {
+				add(a, "add")
+			} else {
+				add(a, "")
+			}
+		}
+	} else {
+		for _, a := range plan.Added {
+			add(a, "add")
+		}
+	}
+	for _, a := range plan.Removed {
+		add(a, "remove")
+	}
+	table.Render()
+
+	fmt.Printf(`
+Items:              %d
+Members:            %d
+Item slots:         %d
+Unattainable slots: %d
+Added:              %d
+Removed:            %d
+Moves:              %d
+`, len(state.Items), len(state.Members), state.ItemSlots, plan.UnattainableSlots,
+		len(plan.Added), len(plan.Removed), plan.Moves)
+}
+
+// planSpecs adapts hypothetical (or, via "brokers" and "consumers" commands,
+// actual) changes to the spec types of broker or consumer allocator KeySpaces.
+type planSpecs struct {
+	newKeySpace        func(prefix string) *keyspace.KeySpace
+	newMember          func(id pb.ProcessSpec_ID, limit int) planSpec
+	setMemberLimit     func(member allocator.MemberValue, limit int) planSpec
+	setMemberCordon    func(member allocator.MemberValue, cordon bool) planSpec
+	drainMember        func(member allocator.MemberValue) planSpec
+	setItemReplication func(item allocator.ItemValue, r int) planSpec
+}
+
+// planSpec is a marshal-able spec.
+type planSpec interface {
+	Marshal() ([]byte, error)
+}
+
+func planSpecsOf(typ string) planSpecs {
+	if typ == "consumer" {
+		return planSpecs{
+			newKeySpace: consumer.NewKeySpace,
+			newMember: func(id pb.ProcessSpec_ID, limit int) planSpec {
+				return &consumer.ConsumerSpec{
+					ProcessSpec: pb.ProcessSpec{Id: id, Endpoint: planEndpoint},
+					ShardLimit:  uint32(limit),
+				}
+			},
+			setMemberLimit: func(member allocator.MemberValue, limit int) planSpec {
+				var spec = *member.(*consumer.ConsumerSpec)
+				spec.ShardLimit = uint32(limit)
+				return &spec
+			},
+			setMemberCordon: func(member allocator.MemberValue, cordon bool) planSpec {
+				var spec = *member.(*consumer.ConsumerSpec)
+				spec.Cordon = cordon
+				return &spec
+			},
+			drainMember: func(member allocator.MemberValue) planSpec {
+				var spec = *member.(*consumer.ConsumerSpec)
+				spec.ZeroLimit()
+				return &spec
+			},
+			setItemReplication: func(item allocator.ItemValue, r int) planSpec {
+				var spec = *item.(*consumer.ShardSpec)
+				if r == 0 {
+					spec.Disable = true
+				} else {
+					spec.Disable, spec.HotStandbys = false, uint32(r-1)
+				}
+				return &spec
+			},
+		}
+	}
+	return planSpecs{
+		newKeySpace: broker.NewKeySpace,
+		newMember: func(id pb.ProcessSpec_ID, limit int) planSpec {
+			return &pb.BrokerSpec{
+				ProcessSpec:  pb.ProcessSpec{Id: id, Endpoint: planEndpoint},
+				JournalLimit: uint32(limit),
+			}
+		},
+		setMemberLimit: func(member allocator.MemberValue, limit int) planSpec {
+			var spec = *member.(*pb.BrokerSpec)
+			spec.JournalLimit = uint32(limit)
+			return &spec
+		},
+		setMemberCordon: func(member allocator.MemberValue, cordon bool) planSpec {
+			var spec = *member.(*pb.BrokerSpec)
+			spec.Cordon = cordon
+			return &spec
+		},
+		drainMember: func(member allocator.MemberValue) planSpec {
+			var spec = *member.(*pb.BrokerSpec)
+			spec.ZeroLimit()
+			return &spec
+		},
+		setItemReplication: func(item allocator.ItemValue, r int) planSpec {
+			var spec = *item.(*pb.JournalSpec)
+			spec.Replication = int32(r)
+			return &spec
+		},
+	}
+}
+
+// parseMemberArg parses "zone#suffix" or, if |withLimit|, "zone#suffix=limit".
+func parseMemberArg(arg string, withLimit bool) (id pb.ProcessSpec_ID, limit int) {
+	var err error
+	var member = arg
+
+	if withLimit {
+		var ind = strings.LastIndexByte(arg, '=')
+		if ind == -1 {
+			mbp.Must(fmt.Errorf("expected zone#suffix=limit"), "failed to parse member", "arg", arg)
+		}
+		member = arg[:ind]
+
+		limit, err = strconv.Atoi(arg[ind+1:])
+		mbp.Must(err, "failed to parse member limit", "arg", arg)
+	}
+	if p := strings.Split(member, allocator.Sep); len(p) != 2 {
+		mbp.Must(fmt.Errorf("expected zone#suffix"), "failed to parse member", "arg", arg)
+	} else {
+		id = pb.ProcessSpec_ID{Zone: p[0], Suffix: p[1]}
+	}
+	return
+}
+
+func mustFindKeyValue(ks *keyspace.KeySpace, key, kind, arg string) keyspace.KeyValue {
+	var ind, ok = ks.Search(key)
+	if !ok {
+		mbp.Must(fmt.Errorf("%s not found", kind), "failed to apply change", "arg", arg)
+	}
+	return ks.KeyValues[ind]
+}
+
+// planPutEvent returns a PUT Event of the |spec| to |key| at |revision|,
+// which updates |prev| (if non-nil) or otherwise creates the key.
+func planPutEvent(key string, spec planSpec, prev *mvccpb.KeyValue, revision int64) *clientv3.Event {
+	var value, err = spec.Marshal()
+	mbp.Must(err, "failed to marshal spec")
+
+	var kv = &mvccpb.KeyValue{
+		Key:            []byte(key),
+		Value:          value,
+		CreateRevision: revision,
+		ModRevision:    revision,
+		Version:        1,
+	}
+	if prev != nil {
+		kv.CreateRevision, kv.Version = prev.CreateRevision, prev.Version+1
+	}
+	return &clientv3.Event{Type: clientv3.EventTypePut, Kv: kv}
+}
+
+// planEndpoint is the Endpoint of hypothetical members added by a plan.
+const planEndpoint = "http://planned.invalid/"
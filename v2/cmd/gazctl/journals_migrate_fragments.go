@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/LiveRamp/gazette/v2/pkg/client"
+	"github.com/LiveRamp/gazette/v2/pkg/fragment"
+	mbp "github.com/LiveRamp/gazette/v2/pkg/mainboilerplate"
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	log "github.com/sirupsen/logrus"
+)
+
+type cmdJournalsMigrateFragments struct {
+	Selector   string `long:"selector" short:"l" required:"true" description:"Label Selector of journals to migrate"`
+	To         string `long:"to" required:"true" description:"Fragment store to which fragments are migrated"`
+	Codec      string `long:"codec" description:"CompressionCodec with which migrated fragments are re-encoded (eg, SNAPPY). If not set, fragments retain their current codec"`
+	KeepSource bool   `long:"keep-source" description:"Don't remove fragments from source stores after they've been migrated"`
+	FileRoot   string `long:"file-root" description:"Filesystem path which roots file:// fragment stores"`
+	DryRun     bool   `long:"dry-run" description:"Log fragments which would be migrated or removed, without migrating or removing them"`
+}
+
+func (cmd *cmdJournalsMigrateFragments) Execute([]string) error {
+	startup()
+
+	var to = pb.FragmentStore(cmd.To)
+	mbp.Must(to.Validate(), "invalid --to fragment store", "store", cmd.To)
+
+	var codec pb.CompressionCodec
+	if cmd.Codec != "" {
+		codec = pb.CompressionCodec(pb.CompressionCodec_value[cmd.Codec])
+		mbp.Must(codec.Validate(), "invalid --codec", "codec", cmd.Codec)
+	}
+	if cmd.FileRoot != "" {
+		fragment.FileSystemStoreRoot = cmd.FileRoot
+	}
+
+	var ctx = context.Background()
+	var req pb.ListRequest
+	var err error
+
+	req.Selector, err = pb.ParseLabelSelector(cmd.Selector)
+	mbp.Must(err, "failed to parse label selector", "selector", cmd.Selector)
+
+	resp, err := client.ListAll(ctx, pb.NewJournalClient(journalsCfg.Broker.Dial(ctx)), req)
+	mbp.Must(err, "failed to list journals")
+
+	// A failure to migrate one journal doesn't prevent migrating the others.
+	var failed []pb.Journal
+	for _, j := range resp.Journals {
+		if err = cmd.migrate(ctx, j.Spec, to, codec); err != nil {
+			log.WithFields(log.Fields{"journal": j.Spec.Name, "err": err}).
+				Error("failed to migrate journal fragments")
+			failed = append(failed, j.Spec.Name)
+		}
+	}
+	if len(failed) != 0 {
+		mbp.Must(fmt.Errorf("%d of %d journals failed", len(failed), len(resp.Journals)),
+			"failed to migrate fragments", "journals", failed)
+	}
+	return nil
+}
+
+// migrate fragments of the journal from its other stores to |to|.
+func (cmd *cmdJournalsMigrateFragments) migrate(ctx context.Context, spec pb.JournalSpec, to pb.FragmentStore, codec pb.CompressionCodec) error {
+	var sources []pb.FragmentStore
+	var found bool
+
+	for _, store := range spec.Fragment.Stores {
+		if store == to {
+			found = true
+		} else {
+			sources = append(sources, store)
+		}
+	}
+	if !found {
+		// Brokers index only the stores of the JournalSpec, and fragments
+		// migrated to another store would be effectively lost.
+		log.WithFields(log.Fields{"journal": spec.Name, "to": to}).
+			Warn("--to is not a fragment store of the journal (skipping)")
+		return nil
+	} else if len(sources) == 0 {
+		return nil // Nothing to migrate.
+	}
+
+	var dst, err = fragment.WalkAllStores(ctx, spec.Name, []pb.FragmentStore{to})
+	if err != nil {
+		return fmt.Errorf("listing destination fragments: %s", err)
+	}
+	src, err := fragment.WalkAllStores(ctx, spec.Name, sources)
+	if err != nil {
+		return fmt.Errorf("listing source fragments: %s", err)
+	}
+
+	// Copy source fragments having content not already in the destination store.
+	for _, f := range fragment.CoverSetDifference(src, dst) {
+		var toCodec = codec
+		if toCodec == pb.CompressionCodec_INVALID {
+			toCodec = f.CompressionCodec
+		}
+		var fields = log.Fields{
+			"journal":  spec.Name,
+			"fragment": f.ContentName(),
+			"from":     f.BackingStore,
+			"to":       to,
+			"codec":    toCodec,
+		}
+		if cmd.DryRun {
+			log.WithFields(fields).Info("would migrate fragment (dry-run)")
+			continue
+		}
+
+		copied, err := fragment.Copy(ctx, f.Fragment, to, toCodec)
+		if err != nil {
+			return fmt.Errorf("migrating fragment %s: %s", f.ContentName(), err)
+		}
+
+		dst, _ = dst.Add(fragment.Fragment{Fragment: copied})
+		log.WithFields(fields).Info("migrated fragment")
+	}
+
+	if cmd.KeepSource {
+		return nil
+	}
+	// Remove source fragments having content which is covered by the
+	// destination store. Note this includes source fragments not migrated
+	// because they were redundant with other fragments.
+	for _, store := range sources {
+		var remove []pb.Fragment
+
+		err = fragment.List(ctx, store, spec.Name.String()+"/", func(f pb.Fragment) {
+			if len(fragment.CoverSetDifference(fragment.CoverSet{{Fragment: f}}, dst)) == 0 {
+				remove = append(remove, f)
+			}
+		})
+		if err != nil {
+			return fmt.Errorf("listing source fragments of %s: %s", store, err)
+		}
+
+		for _, f := range remove {
+			var fields = log.Fields{"journal": spec.Name, "fragment": f.ContentName(), "store": store}
+
+			if cmd.DryRun {
+				log.WithFields(fields).Info("would remove fragment (dry-run)")
+				continue
+			}
+			if err = fragment.Remove(ctx, f); err != nil {
+				return fmt.Errorf("removing fragment %s: %s", f.ContentName(), err)
+			}
+			log.WithFields(fields).Info("removed migrated fragment")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	mbp "github.com/LiveRamp/gazette/v2/pkg/mainboilerplate"
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	"github.com/gogo/protobuf/proto"
+	"github.com/olekukonko/tablewriter"
+)
+
+type cmdJournalsReplicas struct {
+	Selector string `long:"selector" short:"l" description:"Label Selector query to filter on"`
+	Format   string `long:"format" short:"o" choice:"table" choice:"json" choice:"proto" default:"table" description:"Output format"`
+}
+
+func (cmd *cmdJournalsReplicas) Execute([]string) error {
+	startup()
+
+	var err error
+	var req pb.ReplicasRequest
+	var ctx = context.Background()
+
+	req.Selector, err = pb.ParseLabelSelector(cmd.Selector)
+	mbp.Must(err, "failed to parse label selector", "selector", cmd.Selector)
+
+	// Replicas are local to the dialed broker, so the RPC is never proxied.
+	var ac = pb.NewAdminClient(journalsCfg.Broker.Dial(ctx))
+	resp, err := ac.Replicas(pb.WithDispatchDefault(ctx), &req)
+	if err == nil {
+		err = resp.Validate()
+	}
+	if err == nil && resp.Status != pb.Status_OK {
+		err = errors.New(resp.Status.String())
+	}
+	mbp.Must(err, "failed to fetch broker replicas")
+
+	switch cmd.Format {
+	case "table":
+		cmd.outputTable(resp)
+	case "json":
+		mbp.Must(json.NewEncoder(os.Stdout).Encode(resp), "failed to encode to json")
+	case "proto":
+		mbp.Must(proto.MarshalText(os.Stdout, resp), "failed to write output")
+	}
+	return nil
+}
+
+func (cmd *cmdJournalsReplicas) outputTable(resp *pb.ReplicasResponse) {
+	fmt.Printf("Broker %s at revision %d. Persister has %d queued spools (%d bytes).\n",
+		&resp.Header.ProcessId, resp.Header.Etcd.Revision,
+		resp.PersisterQueuedSpools, resp.PersisterQueuedBytes)
+
+	var table = tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Name", "Primary", "Spool", "Pipeline",
+		"Fragments (Local)", "Offsets", "Refreshed"})
+
+	for _, r := range resp.Replicas {
+		var primary = "no"
+		if r.Route.Primary != -1 && r.Route.Members[r.Route.Primary] == resp.Header.ProcessId {
+			primary = "yes"
+		}
+		var pipeline = r.PipelineState.String()
+		if r.PipelineState == pb.ReplicasResponse_Replica_AWAITING_REVISION {
+			pipeline = fmt.Sprintf("%s (%d)", pipeline, r.PipelineReadThroughRevision)
+		}
+		var refreshed = "<never>"
+		if !r.IndexRefreshTime.IsZero() {
+			refreshed = fmt.Sprintf("%s ago", time.Since(r.IndexRefreshTime).Round(time.Second))
+		}
+
+		table.Append([]string{
+			r.Spec.Name.String(),
+			primary,
+			fmt.Sprintf("%d-%d (%d bytes)", r.Spool.Begin, r.Spool.End, r.Spool.ContentLength()),
+			pipeline,
+			fmt.Sprintf("%d (%d)", r.IndexFragments, r.IndexLocalFragments),
+			fmt.Sprintf("%d-%d", r.IndexBeginOffset, r.IndexEndOffset),
+			refreshed,
+		})
+	}
+	table.Render()
+}
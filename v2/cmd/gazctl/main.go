@@ -2,11 +2,12 @@ package main
 
 import (
 	"errors"
+	"fmt"
 	"io/ioutil"
 	"os"
 
 	mbp "github.com/LiveRamp/gazette/v2/pkg/mainboilerplate"
-	"github.com/LiveRamp/gazette/v2/pkg/protocol"
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
 	"github.com/jessevdk/go-flags"
 	"gopkg.in/yaml.v2"
 )
@@ -22,6 +23,19 @@ var (
 	shardsCfg = new(struct {
 		Consumer mbp.AddressConfig `group:"Consumer" namespace:"consumer" env-namespace:"CONSUMER"`
 	})
+	brokersCfg = new(struct {
+		Etcd   pb.Endpoint `long:"etcd" env:"ETCD" default:"http://localhost:2379" description:"Etcd service address endpoint"`
+		Prefix string      `long:"prefix" default:"/gazette/brokers" description:"Etcd prefix of the broker KeySpace"`
+	})
+	consumersCfg = new(struct {
+		Etcd   pb.Endpoint `long:"etcd" env:"ETCD" default:"http://localhost:2379" description:"Etcd service address endpoint"`
+		Prefix string      `long:"prefix" required:"true" description:"Etcd prefix of the consumer application KeySpace (eg, /gazette/consumers/myApplication)"`
+	})
+	recoverylogCfg = new(struct {
+		Broker   mbp.AddressConfig `group:"Broker" namespace:"broker" env-namespace:"BROKER"`
+		Consumer mbp.AddressConfig `group:"Consumer" namespace:"consumer" env-namespace:"CONSUMER"`
+		Etcd     pb.Endpoint       `long:"etcd" env:"ETCD" default:"http://localhost:2379" description:"Etcd service address endpoint"`
+	})
 )
 
 // ListConfig is common configuration of list operations.
@@ -61,7 +75,7 @@ func (cfg ApplyConfig) decode(into interface{}) error {
 
 func startup() {
 	mbp.InitLog(baseCfg.Log)
-	protocol.RegisterGRPCDispatcher(baseCfg.Zone)
+	pb.RegisterGRPCDispatcher(baseCfg.Zone)
 }
 
 func main() {
@@ -75,6 +89,10 @@ func main() {
 
 	var cmdJournals = addCmd(parser.Command, "journals", "Interact with broker journals", "", journalsCfg)
 	var cmdShards = addCmd(parser.Command, "shards", "Interact with consumer shards", "", shardsCfg)
+	var cmdBrokers = addCmd(parser.Command, "brokers", "Interact with broker members", "", brokersCfg)
+	var cmdConsumers = addCmd(parser.Command, "consumers", "Interact with consumer members", "", consumersCfg)
+	var cmdAllocator = addCmd(parser.Command, "allocator", "Inspect allocator decisions", "", new(struct{}))
+	var cmdRecoveryLog = addCmd(parser.Command, "recoverylog", "Inspect shard recovery logs", "", recoverylogCfg)
 
 	_ = addCmd(cmdJournals, "list", "List journals", `
 List journal specifications and status.
@@ -140,6 +158,45 @@ in the YAML, and not to other JournalSpecs which may exist with the prefix but
 are not enumerated.
 `, &cmdJournalsApply{})
 
+	_ = addCmd(cmdJournals, "migrate-fragments", "Migrate journal fragments to a fragment store", `
+Migrate persisted fragments of selected journals to a fragment store.
+
+JournalSpecs may list multiple fragment stores, where new fragments are
+persisted to the first store and all stores are indexed for reads. This allows
+for incremental migration to a new store, by listing it first. However, the
+fragments of prior stores must still be retained.
+
+migrate-fragments walks the fragments of every other store of each selected
+journal, and copies fragments having content not already present in the --to
+store. Copied content is verified against the SHA1 sum of each fragment's
+content name, and may be re-encoded using a different --codec. Thereafter,
+source fragments whose content is covered by the --to store are removed
+(unless --keep-source is set).
+
+--to must already be a fragment store of each migrated journal; journals
+which don't list it are skipped. A journal which fails to migrate is logged,
+and migration continues with other journals. The command then logs a summary
+of the failed journals, and exits with a non-zero status.
+
+Migrate fragments of journals having a name prefix to a new bucket:
+>    --selector "prefix = my/prefix/" --to "s3://new-bucket/path/"
+`, &cmdJournalsMigrateFragments{})
+
+	_ = addCmd(cmdJournals, "replicas", "Inspect journal replicas of a broker", `
+Inspect the local journal replicas of the broker given by --broker.address.
+
+For each journal assigned to the broker, its current Spool, the state of its
+replication pipeline, and a summary of its Fragment index are shown. The
+number of Spools queued by the broker for persistence to fragment stores
+is also shown.
+
+Use --selector to supply a LabelSelector which constrains the set of returned
+replicas. As with "journals list", meta-labels "name" and "prefix" are supported.
+
+Inspect replicas of journals having a name prefix:
+>    --broker.address http://broker-abc:8080 --selector "prefix = my/prefix/"
+`, &cmdJournalsReplicas{})
+
 	_ = addCmd(cmdShards, "apply", "Apply shard specifications", `
 Apply a collection of ShardSpec creations, updates, or deletions.
 
@@ -154,6 +211,133 @@ ShardSpecs may be created by setting "revision" to zero or omitting it altogethe
 ShardSpecs may be deleted by setting their field "delete" to true.
 `, &cmdShardsApply{})
 
+	_ = addCmd(cmdShards, "restart", "Restart FAILED replicas of a shard", `
+Restart the FAILED replicas of a shard.
+
+Each assignment of the shard having a FAILED status is cleared in Etcd, and
+its count of restarts is incremented. The consumer process of the assignment
+observes the cleared status, tears down its failed replica, and then re-plays
+the shard recovery log with a new replica. Replicas which aren't FAILED are
+unaffected.
+
+Consumers also restart FAILED replicas automatically, up to the "max_restarts"
+of the ShardSpec. Use "gazctl shards list" to view current shard status.
+
+Restart a FAILED shard:
+>    --prefix /gazette/consumers/my-app my-shard-000
+`, &cmdShardsRestart{})
+
+	_ = addCmd(cmdAllocator, "plan", "Plan allocator assignments of a KeySpace", `
+Plan the assignments of a broker or consumer allocator KeySpace, optionally
+after applying hypothetical changes to its members and items.
+
+The KeySpace is loaded from Etcd, or from a --snapshot file produced by
+"etcdctl get --prefix -w json <prefix>". Hypothetical changes are applied to
+the loaded KeySpace only, and the same prioritized maximum-flow solver used by
+the allocator is run to determine the desired assignment of every item. The
+resulting difference from current assignments is printed, along with the
+number of item replicas which would move between members. Nothing is written
+to Etcd.
+
+Evaluate the effect of removing a broker:
+>    --prefix /gazette/brokers --remove-member "us-east-1#broker-abc"
+
+Evaluate adding consumers and increasing shard replication:
+>    --type consumer --prefix /gazette/consumers/my-app \
+>    --add-member "us-east-1#new-1=100" --add-member "us-east-1#new-2=100" \
+>    --replication "my-shard-000=2"
+
+Note that the allocator converges towards desired assignments incrementally,
+and only as replication constraints allow.
+`, &cmdAllocatorPlan{})
+
+	_ = addCmd(cmdRecoveryLog, "dump", "Dump operations of a recovery log", `
+Dump the recorded file-system operations of a recovery log journal.
+
+Each RecordedOp of the log between --offset and --end-offset (or the current
+write head, if not set) is decoded and printed with its offset range, sequence
+number, author, and checksum. Content of Write operations is not printed.
+
+Every operation of the log is printed, including operations of dead branches
+of its history (eg, those of a Recorder which lost a race to another). Frames
+which can't be decoded are logged and skipped.
+
+Dump the first 1MB of a recovery log:
+>    --broker.address http://broker-abc:8080 --end-offset 1048576 my/recovery/log
+
+Results can be output as text (one operation per line), or as json.
+`, &cmdRecoveryLogDump{})
+
+	_ = addCmd(cmdRecoveryLog, "hints", "Inspect recovery log hints of a shard", `
+Inspect the FSMHints stored under each of the hint keys of a shard.
+
+The ShardSpec is fetched from the consumer given by --consumer.address, and
+each of its hint keys is read from Etcd. Hints which were persisted to a
+fragment store (rather than stored in Etcd directly) are fetched from the
+store. Hints are then played against the recovery log (without recovering
+file content) to determine the links and size of each live file. Live files
+are printed with the log Segments which must be read to recover them.
+
+Inspect hints of a shard:
+>    --consumer.address http://consumer-abc:8080 \
+>    --broker.address http://broker-abc:8080 my-shard-000
+`, &cmdRecoveryLogHints{})
+
+	for _, m := range []struct {
+		cmd       *flags.Command
+		typ, noun string
+	}{
+		{cmdBrokers, "broker", "brokers"},
+		{cmdConsumers, "consumer", "consumers"},
+	} {
+		_ = addCmd(m.cmd, "list", "List "+m.noun, fmt.Sprintf(`
+List %[1]s members of the allocator KeySpace, with their item limits, current
+assignments, cordon status, and labels.
+
+Members are read directly from Etcd. "Assigned" and "Primary" are the number
+of items currently assigned to the member, and for which it's the primary.
+"Weight" is the summed weight of its assigned items.
+`, m.typ), &cmdMembersList{membersCmd{m.typ}})
+
+		_ = addCmd(m.cmd, "cordon", "Cordon a "+m.typ, fmt.Sprintf(`
+Cordon a %[1]s, such that it's assigned no new items.
+
+The cordon is applied by updating the %[1]s specification in Etcd. Items
+currently assigned to the %[1]s remain so, and it continues to serve them.
+Cordoning is useful in preparation for draining a set of %[2]s, as items of
+one drained %[1]s won't be moved to another which is soon to be drained.
+
+A cordon lasts only for the lifetime of the %[1]s process. Its specification
+is attached to the process's Etcd lease and is removed when the process exits,
+and a restarted process announces a new specification from its configuration,
+which is not cordoned.
+
+Members are identified by their zone and suffix:
+>    gazctl %[2]s cordon us-east-1/member-abc
+`, m.typ, m.noun), &cmdMembersCordon{membersCmd: membersCmd{m.typ}})
+
+		_ = addCmd(m.cmd, "uncordon", "Uncordon a "+m.typ, fmt.Sprintf(`
+Uncordon a %[1]s previously cordoned by "gazctl %[2]s cordon", such that it
+may again be assigned new items.
+`, m.typ, m.noun), &cmdMembersUncordon{membersCmd: membersCmd{m.typ}})
+
+		_ = addCmd(m.cmd, "drain", "Drain a "+m.typ, fmt.Sprintf(`
+Drain a %[1]s of its assigned items.
+
+The item limit of the %[1]s specification is zeroed in Etcd, and the allocator
+moves its items to other %[2]s as replication constraints allow. This is
+equivalent to signaling the %[1]s process with SIGTERM, and as with SIGTERM,
+the process exits once it has no remaining assignments. Consider cordoning
+other %[2]s which are also to be drained.
+
+As with a cordon, a drain lasts only for the lifetime of the %[1]s process.
+If the process is restarted, it announces its configured item limit anew.
+
+drain waits for, and reports progress of, the removal of %[1]s assignments
+unless --no-wait is set.
+`, m.typ, m.noun), &cmdMembersDrain{membersCmd: membersCmd{m.typ}})
+	}
+
 	mbp.MustParseConfig(parser, iniFilename)
 }
 
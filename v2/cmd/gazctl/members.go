@@ -0,0 +1,233 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/LiveRamp/gazette/v2/pkg/allocator"
+	"github.com/LiveRamp/gazette/v2/pkg/consumer"
+	"github.com/LiveRamp/gazette/v2/pkg/keyspace"
+	mbp "github.com/LiveRamp/gazette/v2/pkg/mainboilerplate"
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	"github.com/coreos/etcd/clientv3"
+	"github.com/olekukonko/tablewriter"
+)
+
+// membersCmd is common to commands of "brokers" and "consumers", and
+// determines the allocator KeySpace and Etcd address of the command.
+type membersCmd struct {
+	typ string // "broker" or "consumer".
+}
+
+// loadKeySpace loads and returns the command's allocator KeySpace and State,
+// as well as the Etcd client from which it was loaded.
+func (cmd membersCmd) loadKeySpace(localKey func(*keyspace.KeySpace) string) (*clientv3.Client, *allocator.State) {
+	var address, prefix = brokersCfg.Etcd, brokersCfg.Prefix
+	if cmd.typ == "consumer" {
+		address, prefix = consumersCfg.Etcd, consumersCfg.Prefix
+	}
+	var etcd, err = clientv3.NewFromURL(string(address))
+	mbp.Must(err, "failed to build Etcd client")
+
+	var ks = planSpecsOf(cmd.typ).newKeySpace(prefix)
+	var key string
+	if localKey != nil {
+		key = localKey(ks)
+	}
+	var state = allocator.NewObservedState(ks, key)
+	mbp.Must(ks.Load(context.Background(), etcd, 0), "failed to load KeySpace", "prefix", prefix)
+
+	return etcd, state
+}
+
+// update the member |arg| with the |update| of its current spec. The update
+// is conditioned on the member being unmodified since it was loaded. As the
+// member key is attached to the lease of its process, the update lasts only
+// for the lifetime of that process: a restarted process announces its spec
+// from its own configuration.
+func (cmd membersCmd) update(arg string, update func(planSpecs, allocator.MemberValue) planSpec) (*clientv3.Client, *allocator.State) {
+	var id = parseMemberID(arg)
+	var etcd, state = cmd.loadKeySpace(func(ks *keyspace.KeySpace) string {
+		return allocator.MemberKey(ks, id.Zone, id.Suffix)
+	})
+
+	state.KS.Mu.RLock()
+	var kv = mustFindKeyValue(state.KS, state.LocalKey, "member", arg)
+	state.KS.Mu.RUnlock()
+
+	var value, err = update(planSpecsOf(cmd.typ), kv.Decoded.(allocator.Member).MemberValue).Marshal()
+	mbp.Must(err, "failed to marshal spec")
+
+	// The member key is attached to the lease of its process, which must be
+	// retained by the update.
+	resp, err := etcd.Txn(context.Background()).
+		If(clientv3.Compare(clientv3.ModRevision(state.LocalKey), "=", kv.Raw.ModRevision)).
+		Then(clientv3.OpPut(state.LocalKey, string(value), clientv3.WithIgnoreLease())).
+		Commit()
+
+	if err == nil && !resp.Succeeded {
+		err = fmt.Errorf("member was modified concurrently (retry)")
+	}
+	mbp.Must(err, "failed to update member", "member", arg)
+
+	return etcd, state
+}
+
+type cmdMembersList struct {
+	membersCmd
+}
+
+func (cmd *cmdMembersList) Execute([]string) error {
+	startup()
+
+	var _, state = cmd.loadKeySpace(nil)
+
+	var table = tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Member", "Endpoint", "Limit", "Weight Limit",
+		"Assigned", "Primary", "Weight", "Cordoned", "Labels"})
+
+	state.KS.Mu.RLock()
+	for i, kv := range state.Members {
+		var member = kv.Decoded.(allocator.Member)
+		var process = processSpecOf(member.MemberValue)
+
+		var cordoned string
+		if member.Cordoned() {
+			cordoned = "yes"
+		}
+		table.Append([]string{
+			member.Zone + "/" + member.Suffix,
+			string(process.Endpoint),
+			strconv.Itoa(member.ItemLimit()),
+			strconv.Itoa(member.WeightLimit()),
+			strconv.Itoa(state.MemberTotalCount[i]),
+			strconv.Itoa(state.MemberPrimaryCount[i]),
+			strconv.Itoa(state.MemberTotalWeight[i]),
+			cordoned,
+			strings.TrimSuffix(process.MemberLabels(), ","),
+		})
+	}
+	state.KS.Mu.RUnlock()
+
+	table.Render()
+	return nil
+}
+
+type cmdMembersCordon struct {
+	membersCmd
+	Args struct {
+		Member string `positional-arg-name:"zone/suffix" required:"yes"`
+	} `positional-args:"yes" required:"yes"`
+}
+
+func (cmd *cmdMembersCordon) Execute([]string) error {
+	startup()
+
+	cmd.update(cmd.Args.Member, func(specs planSpecs, member allocator.MemberValue) planSpec {
+		return specs.setMemberCordon(member, true)
+	})
+	fmt.Printf("Cordoned %s. It will be assigned no new items.\n", cmd.Args.Member)
+	return nil
+}
+
+type cmdMembersUncordon struct {
+	membersCmd
+	Args struct {
+		Member string `positional-arg-name:"zone/suffix" required:"yes"`
+	} `positional-args:"yes" required:"yes"`
+}
+
+func (cmd *cmdMembersUncordon) Execute([]string) error {
+	startup()
+
+	cmd.update(cmd.Args.Member, func(specs planSpecs, member allocator.MemberValue) planSpec {
+		return specs.setMemberCordon(member, false)
+	})
+	fmt.Printf("Uncordoned %s.\n", cmd.Args.Member)
+	return nil
+}
+
+type cmdMembersDrain struct {
+	membersCmd
+	NoWait  bool          `long:"no-wait" description:"Don't wait for assigned items to move from the member"`
+	Timeout time.Duration `long:"timeout" default:"0s" description:"Maximum duration to wait for assigned items to move, or zero for no limit"`
+	Args    struct {
+		Member string `positional-arg-name:"zone/suffix" required:"yes"`
+	} `positional-args:"yes" required:"yes"`
+}
+
+func (cmd *cmdMembersDrain) Execute([]string) error {
+	startup()
+
+	var etcd, state = cmd.update(cmd.Args.Member, func(specs planSpecs, member allocator.MemberValue) planSpec {
+		return specs.drainMember(member)
+	})
+	fmt.Printf("Draining %s.\n", cmd.Args.Member)
+
+	if cmd.NoWait {
+		return nil
+	}
+	var ctx, cancel = context.WithCancel(context.Background())
+	if cmd.Timeout != 0 {
+		ctx, cancel = context.WithTimeout(ctx, cmd.Timeout)
+	}
+	defer cancel()
+
+	go func() {
+		if err := state.KS.Watch(ctx, etcd); err != nil && ctx.Err() == nil {
+			mbp.Must(err, "KeySpace Watch failed")
+		}
+	}()
+
+	state.KS.Mu.RLock()
+	defer state.KS.Mu.RUnlock()
+
+	// Report remaining assignments of the member as they change, until none
+	// remain or its key is removed (as the member process exits once drained).
+	var last = -1
+	for {
+		var remaining int
+		if state.LocalMemberInd != -1 {
+			remaining = state.MemberTotalCount[state.LocalMemberInd]
+		}
+		if remaining != last {
+			fmt.Printf("%s has %d assigned items.\n", cmd.Args.Member, remaining)
+			last = remaining
+		}
+		if remaining == 0 {
+			break
+		}
+		mbp.Must(state.KS.WaitForRevision(ctx, state.KS.Header.Revision+1),
+			"failed to await drain of member", "member", cmd.Args.Member)
+	}
+	fmt.Printf("Drained %s.\n", cmd.Args.Member)
+	return nil
+}
+
+// processSpecOf returns the ProcessSpec of a broker or consumer MemberValue.
+func processSpecOf(member allocator.MemberValue) *pb.ProcessSpec {
+	switch m := member.(type) {
+	case *pb.BrokerSpec:
+		return &m.ProcessSpec
+	case *consumer.ConsumerSpec:
+		return &m.ProcessSpec
+	default:
+		panic(fmt.Sprintf("unexpected member type %T", member))
+	}
+}
+
+// parseMemberID parses a member ID given as "zone/suffix" or "zone#suffix".
+func parseMemberID(arg string) (id pb.ProcessSpec_ID) {
+	if strings.Contains(arg, allocator.Sep) {
+		id, _ = parseMemberArg(arg, false)
+	} else if ind := strings.IndexByte(arg, '/'); ind != -1 {
+		id = pb.ProcessSpec_ID{Zone: arg[:ind], Suffix: arg[ind+1:]}
+	} else {
+		mbp.Must(fmt.Errorf("expected zone/suffix"), "failed to parse member", "arg", arg)
+	}
+	return
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	mbp "github.com/LiveRamp/gazette/v2/pkg/mainboilerplate"
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	"github.com/LiveRamp/gazette/v2/pkg/recoverylog"
+)
+
+type cmdRecoveryLogDump struct {
+	Offset    int64  `long:"offset" default:"0" description:"Log offset at which to begin reading"`
+	EndOffset int64  `long:"end-offset" default:"0" description:"Log offset at which to stop reading, or zero to read through the current write head"`
+	Format    string `long:"format" short:"o" choice:"text" choice:"json" default:"text" description:"Output format"`
+	Args      struct {
+		Journal string `positional-arg-name:"journal" required:"yes"`
+	} `positional-args:"yes" required:"yes"`
+}
+
+func (cmd *cmdRecoveryLogDump) Execute([]string) error {
+	startup()
+
+	var ctx = context.Background()
+	var name = pb.Journal(cmd.Args.Journal)
+	mbp.Must(name.Validate(), "invalid journal name")
+
+	var rjc = pb.NewRoutedJournalClient(
+		pb.NewJournalClient(recoverylogCfg.Broker.Dial(ctx)), pb.NoopDispatchRouter{})
+	var enc = json.NewEncoder(os.Stdout)
+
+	if cmd.Format == "text" {
+		fmt.Printf("%-23s %-10s %-8s %-8s %s\n", "OFFSETS", "SEQ_NO", "AUTHOR", "CHECKSUM", "OPERATION")
+	}
+	var err = recoverylog.ReadOperations(ctx, name, cmd.Offset, cmd.EndOffset, rjc,
+		func(op recoverylog.RecordedOp, _ []byte) error {
+			if cmd.Format == "json" {
+				return enc.Encode(&op)
+			}
+			_, err := fmt.Printf("%-23s %-10d %08x %08x %s\n",
+				fmt.Sprintf("%d-%d", op.FirstOffset, op.LastOffset),
+				op.SeqNo, uint32(op.Author), op.Checksum, describeOperation(op))
+			return err
+		})
+	mbp.Must(err, "failed to dump recovery log", "journal", name)
+
+	return nil
+}
+
+// describeOperation returns a succinct, human-readable description of |op|.
+func describeOperation(op recoverylog.RecordedOp) string {
+	switch {
+	case op.Create != nil:
+		return fmt.Sprintf("create fnode %d at %s", op.SeqNo, op.Create.Path)
+	case op.Link != nil:
+		return fmt.Sprintf("link fnode %d to %s", op.Link.Fnode, op.Link.Path)
+	case op.Unlink != nil:
+		return fmt.Sprintf("unlink fnode %d from %s", op.Unlink.Fnode, op.Unlink.Path)
+	case op.Write != nil:
+		return fmt.Sprintf("write fnode %d at %d (%d bytes)", op.Write.Fnode, op.Write.Offset, op.Write.Length)
+	case op.Property != nil:
+		return fmt.Sprintf("property %s (%d bytes)", op.Property.Path, len(op.Property.Content))
+	default:
+		return "no-op"
+	}
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/LiveRamp/gazette/v2/pkg/consumer"
+	mbp "github.com/LiveRamp/gazette/v2/pkg/mainboilerplate"
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	"github.com/LiveRamp/gazette/v2/pkg/recoverylog"
+	"github.com/coreos/etcd/clientv3"
+	"github.com/olekukonko/tablewriter"
+)
+
+type cmdRecoveryLogHints struct {
+	Format string `long:"format" short:"o" choice:"table" choice:"json" default:"table" description:"Output format"`
+	Args   struct {
+		Shard string `positional-arg-name:"shard" required:"yes"`
+	} `positional-args:"yes" required:"yes"`
+}
+
+// hintsOfKey are the decoded FSMHints of a ShardSpec HintKey.
+type hintsOfKey struct {
+	Key      string
+	Revision int64
+	Hints    *recoverylog.FSMHints  `json:",omitempty"`
+	Files    []recoverylog.LiveFile `json:",omitempty"`
+}
+
+func (cmd *cmdRecoveryLogHints) Execute([]string) error {
+	startup()
+
+	var ctx = context.Background()
+	var id = consumer.ShardID(cmd.Args.Shard)
+	mbp.Must(id.Validate(), "invalid shard ID")
+
+	var resp, err = consumer.ListShards(ctx, recoverylogCfg.Consumer.ShardClient(ctx), &consumer.ListRequest{
+		Selector: pb.LabelSelector{Include: pb.MustLabelSet("id", id.String())},
+	})
+	mbp.Must(err, "failed to list shard")
+
+	if len(resp.Shards) == 0 {
+		return fmt.Errorf("shard %s not found", id)
+	}
+	var spec = resp.Shards[0].Spec
+
+	etcd, err := clientv3.NewFromURL(string(recoverylogCfg.Etcd))
+	mbp.Must(err, "failed to build Etcd client")
+
+	var rjc = pb.NewRoutedJournalClient(
+		pb.NewJournalClient(recoverylogCfg.Broker.Dial(ctx)), pb.NoopDispatchRouter{})
+
+	var out []hintsOfKey
+	for _, key := range spec.HintKeys {
+		var resp, err = etcd.Get(ctx, key)
+		mbp.Must(err, "failed to fetch hint key", "key", key)
+
+		var hk = hintsOfKey{Key: key}
+		if len(resp.Kvs) != 0 {
+			hints, err := consumer.DecodeHints(ctx, resp.Kvs[0].Value)
+			mbp.Must(err, "failed to decode FSMHints", "key", key)
+
+			hk.Revision, hk.Hints = resp.Kvs[0].ModRevision, &hints
+			hk.Files, err = recoverylog.LiveFiles(ctx, hints, rjc)
+			mbp.Must(err, "failed to read live files of FSMHints", "key", key)
+		}
+		out = append(out, hk)
+	}
+
+	switch cmd.Format {
+	case "table":
+		cmd.outputTable(spec, out)
+	case "json":
+		mbp.Must(json.NewEncoder(os.Stdout).Encode(out), "failed to encode to json")
+	}
+	return nil
+}
+
+func (cmd *cmdRecoveryLogHints) outputTable(spec consumer.ShardSpec, out []hintsOfKey) {
+	fmt.Printf("Shard %s with recovery log %s.\n", spec.Id, spec.RecoveryLog)
+
+	for _, hk := range out {
+		fmt.Println()
+
+		if hk.Hints == nil {
+			fmt.Printf("Hint key %s is not set.\n", hk.Key)
+			continue
+		}
+		fmt.Printf("Hint key %s at revision %d has %d live files and %d properties.\n",
+			hk.Key, hk.Revision, len(hk.Files), len(hk.Hints.Properties))
+
+		if cp := hk.Hints.Checkpoint; cp != nil {
+			fmt.Printf("Checkpoint of %d files precedes seq_no %d at offset %d.\n",
+				len(cp.Files), cp.NextSeqNo, cp.LogOffset)
+		}
+		for _, p := range hk.Hints.Properties {
+			fmt.Printf("Property %s (%d bytes).\n", p.Path, len(p.Content))
+		}
+
+		var table = tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Fnode", "Links", "Size", "Segments"})
+
+		for _, f := range hk.Files {
+			var segments []string
+			for _, s := range f.Segments {
+				var last = "?"
+				if s.LastOffset != 0 {
+					last = fmt.Sprintf("%d", s.LastOffset)
+				}
+				segments = append(segments, fmt.Sprintf("%08x: seq_no %d-%d, offsets %d-%s",
+					uint32(s.Author), s.FirstSeqNo, s.LastSeqNo, s.FirstOffset, last))
+			}
+			if segments == nil {
+				segments = []string{"<checkpoint>"}
+			}
+			table.Append([]string{
+				fmt.Sprintf("%d", f.Fnode),
+				strings.Join(f.Links, "\n"),
+				fmt.Sprintf("%d", f.Size),
+				strings.Join(segments, "\n"),
+			})
+		}
+		table.Render()
+	}
+}
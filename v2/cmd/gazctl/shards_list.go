@@ -83,6 +83,9 @@ func (cmd *cmdShardsList) outputTable(resp *consumer.ListResponse) {
 			j.Spec.Id.String(),
 			status.Code.String(),
 		}
+		if status.Restarts != 0 {
+			row[1] = fmt.Sprintf("%s (%d restarts)", status.Code, status.Restarts)
+		}
 		if cmd.RF {
 			var rf int
 			if !j.Spec.Disable {
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/LiveRamp/gazette/v2/pkg/allocator"
+	"github.com/LiveRamp/gazette/v2/pkg/consumer"
+	mbp "github.com/LiveRamp/gazette/v2/pkg/mainboilerplate"
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	"github.com/coreos/etcd/clientv3"
+)
+
+type cmdShardsRestart struct {
+	Etcd   pb.Endpoint `long:"etcd" env:"ETCD" default:"http://localhost:2379" description:"Etcd service address endpoint"`
+	Prefix string      `long:"prefix" required:"true" description:"Etcd prefix of the consumer application KeySpace (eg, /gazette/consumers/myApplication)"`
+	Args   struct {
+		Shard string `positional-arg-name:"shard" required:"yes"`
+	} `positional-args:"yes" required:"yes"`
+}
+
+func (cmd *cmdShardsRestart) Execute([]string) error {
+	startup()
+
+	var ctx = context.Background()
+	var id = consumer.ShardID(cmd.Args.Shard)
+	mbp.Must(id.Validate(), "invalid shard ID")
+
+	var etcd, err = clientv3.NewFromURL(string(cmd.Etcd))
+	mbp.Must(err, "failed to build Etcd client")
+
+	var ks = consumer.NewKeySpace(cmd.Prefix)
+	mbp.Must(ks.Load(ctx, etcd, 0), "failed to load KeySpace", "prefix", cmd.Prefix)
+
+	if _, ok := allocator.LookupItem(ks, id.String()); !ok {
+		return fmt.Errorf("shard %s not found", id)
+	}
+
+	var restarted int
+	for _, kv := range ks.KeyValues.Prefixed(allocator.ItemAssignmentsPrefix(ks, id.String())) {
+		var asn = kv.Decoded.(allocator.Assignment)
+		if asn.AssignmentValue.(*consumer.ReplicaStatus).Code != consumer.ReplicaStatus_FAILED {
+			continue
+		}
+		mbp.Must(consumer.ClearFailedStatus(ctx, etcd, kv), "failed to clear FAILED status",
+			"key", string(kv.Raw.Key))
+
+		fmt.Printf("Restarted replica of %s at %s/%s.\n", id, asn.MemberZone, asn.MemberSuffix)
+		restarted++
+	}
+	if restarted == 0 {
+		return fmt.Errorf("shard %s has no FAILED replicas", id)
+	}
+	return nil
+}
@@ -19,7 +19,12 @@ const iniFilename = "gazette.ini"
 var Config = new(struct {
 	Broker struct {
 		mbp.ServiceConfig
-		Limit uint32 `long:"limit" env:"LIMIT" default:"1024" description:"Maximum number of Journals the broker will allocate"`
+		mbp.AllocatorConfig
+		Limit       uint32 `long:"limit" env:"LIMIT" default:"1024" description:"Maximum number of Journals the broker will allocate"`
+		WeightLimit uint32 `long:"weight-limit" env:"WEIGHT_LIMIT" description:"Total weight of Journals the broker will balance towards (defaults to --broker.limit)"`
+
+		JournalMetrics              string `long:"journal-metrics" env:"JOURNAL_METRICS" description:"Collect metrics of individual journals, partitioned on journal name (if \"name\") or the value of the named JournalSpec label (eg, \"topic\"). Disabled if empty"`
+		JournalMetricsMaxPartitions int    `long:"journal-metrics-max-partitions" env:"JOURNAL_METRICS_MAX_PARTITIONS" default:"1000" description:"Maximum number of distinct journal metrics partitions. Journals of further partitions are collected as partition \"_other\""`
 	} `group:"Broker" namespace:"broker" env-namespace:"BROKER"`
 
 	Etcd struct {
@@ -39,6 +44,12 @@ func (serveBroker) Execute(args []string) error {
 
 	log.WithField("config", Config).Info("starting broker")
 	prometheus.MustRegister(metrics.GazetteBrokerCollectors()...)
+	prometheus.MustRegister(metrics.AllocatorCollectors()...)
+	prometheus.MustRegister(metrics.JournalCollectors()...)
+
+	if Config.Broker.JournalMetrics != "" {
+		broker.SetJournalMetrics(Config.Broker.JournalMetrics, Config.Broker.JournalMetricsMaxPartitions)
+	}
 
 	var ks = broker.NewKeySpace(Config.Etcd.Prefix)
 	var allocState = allocator.NewObservedState(ks, Config.Broker.MemberKey(ks))
@@ -49,9 +60,13 @@ func (serveBroker) Execute(args []string) error {
 
 	var lo = protocol.NewJournalClient(srv.Loopback())
 	var service = broker.NewService(allocState, lo, etcd.Etcd)
+	service.Authenticator = Config.Broker.Authenticator()
+	service.PeerToken = Config.Broker.PeerToken()
 	var rjc = protocol.NewRoutedJournalClient(lo, service)
 
 	protocol.RegisterJournalServer(srv.GRPCServer, service)
+	protocol.RegisterAdminServer(srv.GRPCServer, service)
+	srv.Health.AddReadinessCheck("journals", service.Ready)
 	srv.HTTPMux.Handle("/", http_gateway.NewGateway(rjc))
 
 	var persister = fragment.NewPersister()
@@ -59,9 +74,10 @@ func (serveBroker) Execute(args []string) error {
 	broker.SetSharedPersister(persister)
 
 	mbp.AnnounceServeAndAllocate(etcd, srv, allocState, &protocol.BrokerSpec{
-		ProcessSpec:  Config.Broker.ProcessSpec(),
-		JournalLimit: Config.Broker.Limit,
-	})
+		ProcessSpec:        Config.Broker.ProcessSpec(),
+		JournalLimit:       Config.Broker.Limit,
+		JournalWeightLimit: Config.Broker.WeightLimit,
+	}, Config.Broker.AllocatorConfig)
 
 	persister.Finish()
 	log.Info("goodbye")
@@ -77,6 +93,16 @@ exit (via SIGTERM). Upon receiving a signal, the broker will seek to discharge
 its responsible journals and will exit only when it can safely do so.
 `, &serveBroker{})
 
+	parser.AddCommand("mirror", "Mirror journals from a source to a destination cluster", `
+mirror journals of a source Gazette cluster which match a label selector to a
+destination Gazette cluster. Destination journals are created if they don't
+exist, and content is appended to each at the same offsets it has in its
+source journal. Progress is checkpointed to a local file, from which the
+mirror resumes on restart. Mirroring of a journal halts with an error if its
+destination is found to have diverged from the source (eg, because it was
+appended to by another writer).
+`, &mirrorJournals{})
+
 	mbp.AddPrintConfigCmd(parser, iniFilename)
 	mbp.MustParseConfig(parser, iniFilename)
 }
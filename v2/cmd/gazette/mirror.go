@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	mbp "github.com/LiveRamp/gazette/v2/pkg/mainboilerplate"
+	"github.com/LiveRamp/gazette/v2/pkg/mirror"
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	log "github.com/sirupsen/logrus"
+)
+
+type mirrorJournals struct {
+	Source      mbp.ClientConfig `group:"Source" namespace:"source" env-namespace:"SOURCE"`
+	Destination mbp.ClientConfig `group:"Destination" namespace:"destination" env-namespace:"DESTINATION"`
+
+	Selector   string        `long:"selector" env:"SELECTOR" required:"true" description:"Label selector of source journals to mirror"`
+	Rename     string        `long:"rename" env:"RENAME" description:"Rename mirrored journals by replacing a source name prefix with a destination prefix, as 'source/prefix/:destination/prefix/'"`
+	Stores     []string      `long:"store" env:"STORES" env-delim:"," description:"Fragment store of created destination journals. May be repeated. If not set, stores of the source journal are used"`
+	Checkpoint string        `long:"checkpoint" env:"CHECKPOINT" default:"mirror-checkpoint.json" description:"Path of the file to which mirroring progress is checkpointed"`
+	Interval   time.Duration `long:"interval" env:"INTERVAL" default:"30s" description:"Interval between refreshes of selected source journals, and checkpoints of progress"`
+}
+
+func (cmd *mirrorJournals) Execute([]string) error {
+	defer mbp.InitDiagnosticsAndRecover(Config.Diagnostics)()
+	mbp.InitLog(Config.Log)
+
+	var selector, err = pb.ParseLabelSelector(cmd.Selector)
+	mbp.Must(err, "failed to parse label selector", "selector", cmd.Selector)
+
+	var ctx = context.Background()
+	var cfg = mirror.Config{
+		Source:         cmd.Source.RoutedJournalClient(ctx),
+		Destination:    cmd.Destination.RoutedJournalClient(ctx),
+		Selector:       selector,
+		CheckpointPath: cmd.Checkpoint,
+		Interval:       cmd.Interval,
+	}
+	for _, store := range cmd.Stores {
+		cfg.Stores = append(cfg.Stores, pb.FragmentStore(store))
+	}
+	if cmd.Rename != "" {
+		var parts = strings.SplitN(cmd.Rename, ":", 2)
+		if len(parts) != 2 {
+			mbp.Must(fmt.Errorf("expected 'source/prefix/:destination/prefix/'"), "invalid rename", "rename", cmd.Rename)
+		}
+		cfg.Rename = func(name pb.Journal) pb.Journal {
+			if strings.HasPrefix(name.String(), parts[0]) {
+				return pb.Journal(parts[1] + name.String()[len(parts[0]):])
+			}
+			return name
+		}
+	}
+
+	log.WithField("config", cmd).Info("starting mirror")
+
+	m, err := mirror.NewMirror(cfg)
+	mbp.Must(err, "failed to build mirror")
+
+	var signalCh = make(chan os.Signal, 1)
+	signal.Notify(signalCh, syscall.SIGTERM, syscall.SIGINT)
+
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		var sig = <-signalCh
+		log.WithField("signal", sig).Info("caught signal")
+		cancel()
+	}()
+
+	mbp.Must(m.Run(ctx), "mirror failed")
+	log.Info("goodbye")
+	return nil
+}
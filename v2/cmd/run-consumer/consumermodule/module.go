@@ -39,8 +39,13 @@ type BaseConfig struct {
 		Module string `long:"module" env:"MODULE" description:"Path to consumer module to dynamically load"`
 
 		mbp.ServiceConfig
+		mbp.AllocatorConfig
 
-		Limit uint32 `long:"limit" env:"LIMIT" default:"32" description:"Maximum number of Shards this consumer process will allocate"`
+		Limit       uint32 `long:"limit" env:"LIMIT" default:"32" description:"Maximum number of Shards this consumer process will allocate"`
+		WeightLimit uint32 `long:"weight-limit" env:"WEIGHT_LIMIT" description:"Total weight of Shards this consumer process will balance towards (defaults to --consumer.limit)"`
+		LocalDir    string `long:"local-dir" env:"LOCAL_DIR" description:"Directory under which shard recovery logs are played back. If set, standby shard state is retained and re-used across process restarts (defaults to temporary directories)"`
+
+		PersistLargeHints bool `long:"persist-large-hints" env:"PERSIST_LARGE_HINTS" description:"Persist large recovery log hints to a fragment store of the log, storing only a pointer in Etcd. Enable only once all consumer processes are updated to read such pointers"`
 	} `group:"Consumer" namespace:"consumer" env-namespace:"CONSUMER"`
 
 	Broker mbp.ClientConfig `group:"Broker" namespace:"broker" env-namespace:"BROKER"`
@@ -35,6 +35,7 @@ func (serveConsumer) Execute(args []string) error {
 	log.WithField("config", Config).Info("starting consumer")
 	prometheus.MustRegister(metrics.GazetteClientCollectors()...)
 	prometheus.MustRegister(metrics.GazetteConsumerCollectors()...)
+	prometheus.MustRegister(metrics.AllocatorCollectors()...)
 
 	var ks = consumer.NewKeySpace(cfg.Etcd.Prefix)
 	var allocState = allocator.NewObservedState(ks, cfg.Consumer.MemberKey(ks))
@@ -49,14 +50,19 @@ func (serveConsumer) Execute(args []string) error {
 	}
 	var rjc = cfg.Broker.RoutedJournalClient(context.Background())
 	var service = consumer.NewService(app, allocState, rjc, srv.Loopback(), etcd.Etcd)
+	service.Authenticator = cfg.Consumer.Authenticator()
+	service.LocalDir = cfg.Consumer.LocalDir
+	service.PersistLargeHints = cfg.Consumer.PersistLargeHints
 
 	consumer.RegisterShardServer(srv.GRPCServer, service)
+	srv.Health.AddReadinessCheck("shards", service.Ready)
 	Module.Register(Config, app, srv, service)
 
 	mbp.AnnounceServeAndAllocate(etcd, srv, allocState, &consumer.ConsumerSpec{
-		ProcessSpec: cfg.Consumer.ProcessSpec(),
-		ShardLimit:  cfg.Consumer.Limit,
-	})
+		ProcessSpec:      cfg.Consumer.ProcessSpec(),
+		ShardLimit:       cfg.Consumer.Limit,
+		ShardWeightLimit: cfg.Consumer.WeightLimit,
+	}, cfg.Consumer.AllocatorConfig)
 
 	log.Info("goodbye")
 	return nil
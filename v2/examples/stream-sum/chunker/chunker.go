@@ -55,6 +55,7 @@ func (runChunker) Execute(args []string) error {
 	var barrier = as.StartAppend(stream_sum.SumsJournal)
 	barrier.Release()
 	<-barrier.Done()
+	mbp.Must(barrier.Err(), "failed to determine sums write head")
 
 	var rr = client.NewRetryReader(ctx, rjc, pb.ReadRequest{
 		Journal:    barrier.Response().Commit.Journal,
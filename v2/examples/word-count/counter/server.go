@@ -35,25 +35,28 @@ func (srv *server) Query(ctx context.Context, req *word_count.QueryRequest) (res
 		}
 	}
 
-	var res consumer.Resolution
-	if res, err = srv.Service.Resolver.Resolve(consumer.ResolveArgs{
+	var status consumer.Status
+	status, _, err = srv.Service.ServeOrProxy(consumer.ResolveArgs{
 		Context:     ctx,
 		ShardID:     req.Shard,
-		MayProxy:    req.Header == nil, // MayProxy if request hasn't already been proxied.
 		ProxyHeader: req.Header,
-	}); err != nil {
+	}, func(res consumer.Resolution) error {
+		return queryStore(res.Store.(*consumer.RocksDBStore), req, resp)
+	}, func(ctx context.Context, hdr protocol.Header) (err error) {
+		req.Header = &hdr // Proxy to the resolved primary peer.
+		resp, err = word_count.NewNGramClient(srv.Loopback).Query(ctx, req)
 		return
-	} else if res.Status != consumer.Status_OK {
-		err = fmt.Errorf(res.Status.String())
-		return
-	} else if res.Store == nil {
-		req.Header = &res.Header // Proxy to the resolved primary peer.
-		return word_count.NewNGramClient(srv.Loopback).Query(
-			protocol.WithDispatchRoute(ctx, req.Header.Route, req.Header.ProcessId), req)
+	})
+
+	if err == nil && status != consumer.Status_OK {
+		err = fmt.Errorf(status.String())
 	}
-	defer res.Done()
+	return
+}
 
-	var rdb = res.Store.(*consumer.RocksDBStore)
+// queryStore reads NGramCounts of the RocksDBStore which match the request
+// Prefix into |resp|.
+func queryStore(rdb *consumer.RocksDBStore, req *word_count.QueryRequest, resp *word_count.QueryResponse) error {
 	var it = rdb.DB.NewIterator(rdb.ReadOptions)
 	defer it.Close()
 
@@ -69,15 +72,14 @@ func (srv *server) Query(ctx context.Context, req *word_count.QueryRequest) (res
 	for ; it.ValidForPrefix(prefix); it.Next() {
 		var cnt, i = binary.Uvarint(it.Value().Data())
 		if i <= 0 {
-			err = fmt.Errorf("internal error parsing varint (%d)", i)
-			return
+			return fmt.Errorf("internal error parsing varint (%d)", i)
 		}
 		resp.Grams = append(resp.Grams, word_count.NGramCount{
 			NGram: word_count.NGram(it.Key().Data()),
 			Count: cnt,
 		})
 	}
-	return
+	return nil
 }
 
 func (srv *server) prefixToShard(prefix word_count.NGram) (shard consumer.ShardID, err error) {
@@ -58,6 +58,7 @@ func PublishNGrams(mapping message.MappingFunc, ajc client.AsyncJournalClient, t
 	}
 	var buf bytes.Buffer
 	var record = &NGramCount{Count: 1}
+	var pending []*client.AsyncAppend
 
 	for i := 0; i != len(words)+N; i++ {
 		copy(grams, grams[1:])
@@ -77,8 +78,16 @@ func PublishNGrams(mapping message.MappingFunc, ajc client.AsyncJournalClient, t
 		}
 
 		record.NGram = NGram(buf.String())
-		if _, err := message.Publish(ajc, mapping, record); err != nil {
+		if aa, err := message.Publish(ajc, mapping, record); err != nil {
 			return err
+		} else if l := len(pending); l == 0 || pending[l-1] != aa {
+			pending = append(pending, aa)
+		}
+	}
+	// Don't return until published NGramCounts have committed.
+	for _, aa := range pending {
+		if <-aa.Done(); aa.Err() != nil {
+			return aa.Err()
 		}
 	}
 	return nil
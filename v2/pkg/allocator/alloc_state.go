@@ -28,12 +28,22 @@ type State struct {
 	Zones       []string // Sorted and unique Zones of |Members|.
 	ZoneSlots   []int    // Total number of item slots summed across all |Members| of each Zone.
 	ItemSlots   int      // Total desired replication slots summed across all |Items|.
+	ItemWeight  int      // Total weight of desired replication slots summed across all |Items|.
 	NetworkHash uint64   // Content-sum which captures Items & Members, and their constraints.
 
+	// Weighted is true iff any Item has a weight other than one, or any Member
+	// has an ItemWeightLimit other than its ItemLimit. Otherwise, weights are
+	// equivalent to counts and need not be separately balanced.
+	Weighted bool
+
 	// Number of total Assignments, and primary Assignments by Member.
 	// These share cardinality with |Members|.
 	MemberTotalCount   []int
 	MemberPrimaryCount []int
+	// Total weight of Assignments, and primary Assignments by Member.
+	// These also share cardinality with |Members|.
+	MemberTotalWeight   []int
+	MemberPrimaryWeight []int
 }
 
 // NewObservedState returns a *State instance which extracts and updates itself
@@ -66,14 +76,18 @@ func (s *State) observe() {
 	s.Zones = s.Zones[:0]
 	s.ZoneSlots = s.ZoneSlots[:0]
 	s.ItemSlots = 0
+	s.ItemWeight = 0
 	s.NetworkHash = 0
+	s.Weighted = false
 	s.MemberTotalCount = make([]int, len(s.Members))
 	s.MemberPrimaryCount = make([]int, len(s.Members))
+	s.MemberTotalWeight = make([]int, len(s.Members))
+	s.MemberPrimaryWeight = make([]int, len(s.Members))
 
 	// Walk Members to:
 	//  * Group the set of ordered |Zones| across all Members.
 	//  * Initialize |ZoneSlots|.
-	//  * Initialize |NetworkHash|.
+	//  * Initialize |NetworkHash| (including Member labels) and |Weighted|.
 	for i := range s.Members {
 		var m = memberAt(s.Members, i)
 		var slots = m.ItemLimit()
@@ -89,6 +103,17 @@ func (s *State) observe() {
 
 		s.ZoneSlots[zone] += slots
 		s.NetworkHash = foldCRC(s.NetworkHash, s.Members[i].Raw.Key, slots)
+
+		if w := m.WeightLimit(); w != m.ItemLimit() {
+			s.NetworkHash = foldCRC(s.NetworkHash, nil, w)
+			s.Weighted = true
+		}
+		if l := m.Labels(); l != "" {
+			s.NetworkHash = crc64.Update(s.NetworkHash, crcTable, []byte(l))
+		}
+		if m.Cordoned() {
+			s.NetworkHash = foldCRC(s.NetworkHash, []byte("cordon"), 0)
+		}
 	}
 
 	// Fetch |localMember| identified by |LocalKey|.
@@ -99,10 +124,10 @@ func (s *State) observe() {
 	}
 
 	// Left-join Items with their Assignments to:
-	//   * Initialize |ItemSlots|.
-	//   * Initialize |NetworkHash|.
+	//   * Initialize |ItemSlots| and |ItemWeight|.
+	//   * Initialize |NetworkHash| (including Item selectors) and |Weighted|.
 	//   * Collect Items and Assignments which map to the |LocalKey| Member.
-	//   * Accumulate per-Member counts of primary and total Assignments.
+	//   * Accumulate per-Member counts and weights of primary and total Assignments.
 	var it = LeftJoin{
 		LenL: len(s.Items),
 		LenR: len(s.Assignments),
@@ -112,11 +137,20 @@ func (s *State) observe() {
 	}
 	for cur, ok := it.Next(); ok; cur, ok = it.Next() {
 		var item = itemAt(s.Items, cur.Left)
-		var slots = item.DesiredReplication()
+		var slots, weight = item.DesiredReplication(), item.Weight()
 
 		s.ItemSlots += slots
+		s.ItemWeight += slots * weight
 		s.NetworkHash = foldCRC(s.NetworkHash, s.Items[cur.Left].Raw.Key, slots)
 
+		if weight != 1 {
+			s.NetworkHash = foldCRC(s.NetworkHash, nil, weight)
+			s.Weighted = true
+		}
+		if sel := item.Selector(); sel != "" {
+			s.NetworkHash = crc64.Update(s.NetworkHash, crcTable, []byte(sel))
+		}
+
 		for r := cur.RightBegin; r != cur.RightEnd; r++ {
 			var a = assignmentAt(s.Assignments, r)
 			var key = MemberKey(s.KS, a.MemberZone, a.MemberSuffix)
@@ -131,8 +165,10 @@ func (s *State) observe() {
 			if ind, found := s.Members.Search(key); found {
 				if a.Slot == 0 {
 					s.MemberPrimaryCount[ind]++
+					s.MemberPrimaryWeight[ind] += weight
 				}
 				s.MemberTotalCount[ind]++
+				s.MemberTotalWeight[ind] += weight
 			}
 		}
 	}
@@ -177,15 +213,16 @@ func (s *State) debugLog() {
 }
 
 // memberLoadRatio maps an |assignment| to a Member "load ratio". Given all
-// |Members| and their corresponding |counts| (1:1 with |Members|),
+// |Members| and their corresponding |weights| (1:1 with |Members|),
 // memberLoadRatio maps |assignment| to a Member and, if found, returns the
-// ratio of the Member's index in |counts| to the Member's ItemLimit. If the
-// Member is not found, infinity is returned.
-func (s *State) memberLoadRatio(assignment keyspace.KeyValue, counts []int) float32 {
+// ratio of the Member's index in |weights| to the Member's WeightLimit. If the
+// Member is not found, infinity is returned. Where Items and Members aren't
+// weighted, weights are equal to counts and WeightLimit is the ItemLimit.
+func (s *State) memberLoadRatio(assignment keyspace.KeyValue, weights []int) float32 {
 	var a = assignment.Decoded.(Assignment)
 
 	if ind, found := s.Members.Search(MemberKey(s.KS, a.MemberZone, a.MemberSuffix)); found {
-		return float32(counts[ind]) / float32(memberAt(s.Members, ind).ItemLimit())
+		return float32(weights[ind]) / float32(memberAt(s.Members, ind).WeightLimit())
 	}
 	return math.MaxFloat32
 }
@@ -45,6 +45,12 @@ func (s *AllocStateSuite) TestExtractOverFixture(c *gc.C) {
 		// Expect counts for Assignments with missing Items were omitted.
 		c.Check(s.MemberTotalCount, gc.DeepEquals, []int{1, 1, 2})
 		c.Check(s.MemberPrimaryCount, gc.DeepEquals, []int{1, 0, 1})
+
+		// The fixture isn't weighted, and weights are equal to counts.
+		c.Check(s.Weighted, gc.Equals, false)
+		c.Check(s.ItemWeight, gc.Equals, 3)
+		c.Check(s.MemberTotalWeight, gc.DeepEquals, []int{1, 1, 2})
+		c.Check(s.MemberPrimaryWeight, gc.DeepEquals, []int{1, 0, 1})
 	}
 
 	// Examine each state for fields influenced by the pivoted member key
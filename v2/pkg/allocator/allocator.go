@@ -21,6 +21,14 @@ type AllocateArgs struct {
 	State *State
 	// TestHook is an optional testing hook, invoked after each convergence round.
 	TestHook func(round int, isIdle bool)
+	// MaxPendingMoves is the maximum number of Item moves between Members which
+	// may be pending at once, or zero if moves are not limited. A move adds an
+	// Assignment to an Item which already has its desired replication, and is
+	// pending until the new Assignment is consistent and the Item's excess
+	// Assignment is removed. Moves beyond the limit are deferred to later
+	// rounds, so that re-balancing (eg, of a newly joined Member) proceeds in
+	// controlled waves. Additions to under-replicated Items aren't limited.
+	MaxPendingMoves int
 }
 
 // Allocate observes the Allocator KeySpace, and if this Allocator instance is
@@ -51,6 +59,7 @@ func Allocate(args AllocateArgs) error {
 	var ks = args.State.KS
 	var ctx = args.Context
 	var round int
+	var moves = moves{limit: args.MaxPendingMoves}
 
 	defer ks.Mu.RUnlock()
 	ks.Mu.RLock()
@@ -72,15 +81,7 @@ func Allocate(args AllocateArgs) error {
 			if state.NetworkHash != lastNetworkHash {
 				lastNetworkHash = state.NetworkHash
 
-				// Build a prioritized flowNetwork and solve for maximum flow.
-				fn.init(state)
-				push_relabel.FindMaxFlow(&fn.source, &fn.sink)
-
-				// Extract desired max-flow Assignments for each Item.
-				desired = desired[:0]
-				for item := range state.Items {
-					desired = extractItemFlow(state, fn, item, desired)
-				}
+				desired = solveDesired(fn, state, desired[:0])
 
 				if len(desired) < state.ItemSlots {
 					// We cannot assign each Item to the desired number of replicas. Most likely,
@@ -97,7 +98,7 @@ func Allocate(args AllocateArgs) error {
 
 			// Converge the current state towards |desired|.
 			var err error
-			if err = converge(txn, state, desired); err == nil {
+			if err = converge(txn, state, desired, &moves); err == nil {
 				txnResponse, err = txn.Commit()
 			}
 
@@ -105,6 +106,8 @@ func Allocate(args AllocateArgs) error {
 				log.WithFields(log.Fields{"err": err, "round": round, "rev": ks.Header.Revision}).
 					Warn("converge iteration failed (will retry)")
 			} else {
+				moves.publish()
+
 				if args.TestHook != nil {
 					args.TestHook(round, ks.Header.Revision == txnResponse.Header.Revision)
 				}
@@ -125,13 +128,40 @@ func Allocate(args AllocateArgs) error {
 	}
 }
 
+// solveDesired builds a prioritized flowNetwork of the State, solves for its
+// maximum flow, and appends the resulting desired Assignments of each Item to
+// |out|. Desired Assignments are ordered on (ItemID, MemberZone, MemberSuffix),
+// and do not have a Slot.
+func solveDesired(fn *flowNetwork, state *State, out []Assignment) []Assignment {
+	fn.excluded, fn.weightBounds = nil, nil
+	fn.init(state)
+	push_relabel.FindMaxFlow(&fn.source, &fn.sink)
+
+	// If the State is Weighted, re-solve until all Item flows are feasible.
+	// Each solve runs under the KeySpace lock, so bound their number. Remaining
+	// split flows are resolved to their largest Arcs by extractItemFlow.
+	for i := 0; i != maxInfeasibleFlowSolves && fn.excludeInfeasibleFlows(state); i++ {
+		fn.init(state)
+		push_relabel.FindMaxFlow(&fn.source, &fn.sink)
+	}
+
+	for item := range state.Items {
+		out = extractItemFlow(state, fn, item, out)
+	}
+	return out
+}
+
 // converge identifies and applies allowed incremental changes which bring the
 // current state closer to the |desired| state. A change is allowed iff it does
 // not cause any Item or Member replication constraints to be violated (eg, by
 // leaving an Item with too few consistent replicas, or a Member with too many
-// assigned Items).
-func converge(txn checkpointTxn, as *State, desired []Assignment) error {
-	var itemState = itemState{global: as}
+// assigned Items). If |moves| is non-nil, moves of Items between Members are
+// tracked by and limited to it.
+func converge(txn checkpointTxn, as *State, desired []Assignment, moves *moves) error {
+	var itemState = itemState{global: as, moves: moves}
+	if moves != nil {
+		moves.begin(as)
+	}
 	var lastCRE int // cur.RightEnd of the previous iteration.
 
 	// Walk Items, joined with their current Assignments. Simultaneously walk
@@ -320,3 +350,7 @@ func debugLogTxn(cmps []clientv3.Cmp, ops []clientv3.Op) {
 // configuration at runtime with --max-txn-ops. We assume the default and will
 // error if a smaller value is used.
 var maxTxnOps = 128
+
+// maxInfeasibleFlowSolves bounds the number of times a Weighted flowNetwork is
+// re-solved after excluding its infeasible flows.
+var maxInfeasibleFlowSolves = 8
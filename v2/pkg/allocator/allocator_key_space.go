@@ -47,6 +47,54 @@ type ItemValue interface {
 	IsConsistent(assignment keyspace.KeyValue, allAssignments keyspace.KeyValues) bool
 }
 
+// WeightedItem is an optional interface of an ItemValue. The weight of an Item
+// is the relative load of each of its replicas. Items which don't implement
+// WeightedItem have a weight of one.
+type WeightedItem interface {
+	// ItemWeight is the positive weight of each replica of this Item.
+	ItemWeight() int
+}
+
+// WeightedMember is an optional interface of a MemberValue. Members which
+// don't implement WeightedMember have an ItemWeightLimit equal to their
+// ItemLimit.
+type WeightedMember interface {
+	// ItemWeightLimit is the desired maximum total weight of Item replicas
+	// assigned to this Member. Unlike ItemLimit, which is a hard constraint,
+	// ItemWeightLimit is a capacity against which Allocate balances Item
+	// weights across Members.
+	ItemWeightLimit() int
+}
+
+// SelectiveItem is an optional interface of an ItemValue, which constrains
+// the Members to which the Item may be assigned. Items which don't implement
+// SelectiveItem may be assigned to any Member.
+type SelectiveItem interface {
+	// MemberSelector is a canonical representation of the Item's selection of
+	// Members (eg, a label selector), or empty if any Member may be selected.
+	MemberSelector() string
+	// SelectsMember returns whether the Item may be assigned to the Member.
+	// It's called only if MemberSelector is non-empty.
+	SelectsMember(MemberValue) bool
+}
+
+// LabeledMember is an optional interface of a MemberValue. Allocate re-solves
+// its desired Assignments when Member labels change, as SelectiveItems
+// may select Members by their labels.
+type LabeledMember interface {
+	// MemberLabels is a canonical representation of the Member's labels,
+	// or empty if the Member has no labels.
+	MemberLabels() string
+}
+
+// CordonedMember is an optional interface of a MemberValue. A cordoned
+// Member is assigned no new Items, though Items currently assigned to it
+// may remain so.
+type CordonedMember interface {
+	// IsCordoned returns whether the Member is cordoned.
+	IsCordoned() bool
+}
+
 // AssignmentValue is a user-defined Assignment representation.
 type AssignmentValue interface{}
 
@@ -71,6 +119,58 @@ type Member struct {
 	MemberValue
 }
 
+// Weight returns the ItemWeight of the Item if it implements WeightedItem,
+// or one if it doesn't (or if its ItemWeight isn't positive).
+func (i Item) Weight() int {
+	if w, ok := i.ItemValue.(WeightedItem); ok && w.ItemWeight() > 0 {
+		return w.ItemWeight()
+	}
+	return 1
+}
+
+// WeightLimit returns the ItemWeightLimit of the Member if it implements
+// WeightedMember, or its ItemLimit if it doesn't.
+func (m Member) WeightLimit() int {
+	if w, ok := m.MemberValue.(WeightedMember); ok {
+		return w.ItemWeightLimit()
+	}
+	return m.ItemLimit()
+}
+
+// Selector returns the MemberSelector of the Item if it implements
+// SelectiveItem, or empty if it doesn't.
+func (i Item) Selector() string {
+	if s, ok := i.ItemValue.(SelectiveItem); ok {
+		return s.MemberSelector()
+	}
+	return ""
+}
+
+// Selects returns whether the Item may be assigned to the Member.
+func (i Item) Selects(m Member) bool {
+	if s, ok := i.ItemValue.(SelectiveItem); ok && s.MemberSelector() != "" {
+		return s.SelectsMember(m.MemberValue)
+	}
+	return true
+}
+
+// Labels returns the MemberLabels of the Member if it implements
+// LabeledMember, or empty if it doesn't.
+func (m Member) Labels() string {
+	if l, ok := m.MemberValue.(LabeledMember); ok {
+		return l.MemberLabels()
+	}
+	return ""
+}
+
+// Cordoned returns whether the Member implements CordonedMember and is cordoned.
+func (m Member) Cordoned() bool {
+	if c, ok := m.MemberValue.(CordonedMember); ok {
+		return c.IsCordoned()
+	}
+	return false
+}
+
 // Assignment composes an Assignment ItemID, MemberZone, MemberSuffix & Slot
 // with its user-defined AssignmentValue.
 type Assignment struct {
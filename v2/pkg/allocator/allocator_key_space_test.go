@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/LiveRamp/gazette/v2/pkg/etcdtest"
 	"github.com/LiveRamp/gazette/v2/pkg/keyspace"
@@ -346,16 +347,39 @@ func (d testAllocDecoder) DecodeAssignment(itemID, zone, suffix string, slot int
 	}
 }
 
-type testItem struct{ R int }
+type testItem struct {
+	R, W int
+	S    string // Selected Member label, or excluded Member label if prefixed with "!".
+}
 
 func (i testItem) DesiredReplication() int { return i.R }
+func (i testItem) ItemWeight() int         { return i.W }
+func (i testItem) MemberSelector() string  { return i.S }
+func (i testItem) SelectsMember(m MemberValue) bool {
+	if strings.HasPrefix(i.S, "!") {
+		return m.(testMember).L != i.S[1:]
+	}
+	return m.(testMember).L == i.S
+}
 func (i testItem) IsConsistent(assignment keyspace.KeyValue, allAssignments keyspace.KeyValues) bool {
 	return assignment.Decoded.(Assignment).AssignmentValue.(testAssignment).consistent
 }
 
-type testMember struct{ R int }
+type testMember struct {
+	R, W int
+	L    string // Label of the Member.
+	C    bool   // Whether the Member is cordoned.
+}
 
-func (m testMember) ItemLimit() int { return m.R }
+func (m testMember) ItemLimit() int       { return m.R }
+func (m testMember) MemberLabels() string { return m.L }
+func (m testMember) IsCordoned() bool     { return m.C }
+func (m testMember) ItemWeightLimit() int {
+	if m.W == 0 {
+		return m.R
+	}
+	return m.W
+}
 
 type testAssignment struct{ consistent bool }
 
@@ -46,12 +46,39 @@ import (
 //                            |       |
 //                            +-------+
 //
+// If the State is Weighted, a unit of flow is a unit of Item weight rather than
+// a single Assignment: Arcs of each Item carry capacities which are multiples
+// of the Item's weight, and Member capacities are derived from each Member's
+// WeightLimit. Maximum flow then balances Item weights across Members, though
+// it may split the weight of an Item replica across Members, or place more Items
+// with a Member than its ItemLimit allows. Member WeightLimits are bounded by the
+// weight of their ItemLimit of the heaviest Items. Beyond that,
+// excludeInfeasibleFlows resolves splits to the Members having the largest flow
+// and further bounds the WeightLimits of Members over their ItemLimit, and the
+// network is re-solved until all flows are feasible or a bounded number of
+// solves is reached. Assignments which remain beyond an ItemLimit are not
+// applied by converge.
 type flowNetwork struct {
 	source    pr.Node
 	members   []pr.Node
 	items     []pr.Node
 	zoneItems []pr.Node
 	sink      pr.Node
+
+	// ZoneItem => Member Arcs excluded from the network. See excludeInfeasibleFlows.
+	excluded map[memberArc]struct{}
+	// Bounds of Member WeightLimits. See excludeInfeasibleFlows.
+	weightBounds map[int]int
+}
+
+// memberArc identifies an Arc from a ZoneItem to a Member.
+type memberArc struct{ zoneItem, member int }
+
+// memberFlow is a ZoneItem => Member Arc having positive flow.
+type memberFlow struct {
+	memberArc
+	flow     int32
+	priority int8
 }
 
 func (fn *flowNetwork) init(s *State) {
@@ -130,8 +157,46 @@ func (fn *flowNetwork) init(s *State) {
 		buildItemArcs(s, fn, item, itemAssignments, itemSlots, effectiveZones)
 	}
 
-	// Determine scaling factors for each zone.
-	var zsfNum, zsfDenom = zoneScalingFactors(len(s.Items), s.ItemSlots, s.ZoneSlots)
+	// Determine scaling factors for each zone. If the State is Weighted, factors
+	// are determined from Item weights and Member WeightLimits.
+	var zsfNum, zsfDenom []int
+	var weightNum, weightDenom = 1, 1
+	var weightLimits []int // Effective WeightLimit of each Member.
+
+	if !s.Weighted {
+		zsfNum, zsfDenom = zoneScalingFactors(len(s.Items), s.ItemSlots, s.ZoneSlots)
+	} else {
+		var itemWeight, memberWeight, maxItemWeight int
+		var zoneWeights = make([]int, len(s.Zones))
+
+		for item := range s.Items {
+			itemWeight += itemAt(s.Items, item).Weight()
+			maxItemWeight = max(maxItemWeight, itemAt(s.Items, item).Weight())
+		}
+		for member := range s.Members {
+			var m = memberAt(s.Members, member)
+			var zone = sort.SearchStrings(s.Zones, m.Zone)
+
+			// A Member can't hold more weight than its ItemLimit of the heaviest
+			// Items allows. Bound its WeightLimit to match, so that capacity it
+			// can't use isn't directed flow, nor counted towards its zone.
+			var limit = min(m.WeightLimit(), m.ItemLimit()*maxItemWeight)
+			if bound, ok := fn.weightBounds[member]; ok {
+				limit = min(limit, bound)
+			}
+
+			weightLimits = append(weightLimits, limit)
+			zoneWeights[zone] += limit
+			memberWeight += limit
+		}
+		zsfNum, zsfDenom = zoneScalingFactors(itemWeight, s.ItemWeight, zoneWeights)
+
+		// WeightLimits are soft capacities. If Items weigh more than the total
+		// WeightLimit of Members, scale Member capacities up to match.
+		if s.ItemWeight > memberWeight && memberWeight > 0 {
+			weightNum, weightDenom = s.ItemWeight, memberWeight
+		}
+	}
 
 	// Perform a left-join of |Members| with |Zones|. Add Arcs from each Member to sink.
 	it = LeftJoin{
@@ -146,17 +211,15 @@ func (fn *flowNetwork) init(s *State) {
 		var zone = cur.RightBegin
 
 		// Calculate scaled member capacity using integer division, rounded up.
-		var limit = memberAt(s.Members, member).ItemLimit() * zsfNum[zone]
-		if limit == 0 {
-			// Pass.
-		} else if limit%zsfDenom[zone] == 0 {
-			limit = limit / zsfDenom[zone]
-		} else {
-			limit = (limit / zsfDenom[zone]) + 1
+		var limit, prevFlow = memberAt(s.Members, member).ItemLimit(), s.MemberTotalCount[member]
+		if s.Weighted {
+			limit, prevFlow = weightLimits[member], s.MemberTotalWeight[member]
 		}
-		// Arc from Member to Sink, with capacity of the adjusted Member ItemLimit.
-		// Previous flow is the number of current Assignments.
-		addArc(&fn.members[member], &fn.sink, limit, s.MemberTotalCount[member])
+		limit = scaleCeil(scaleCeil(limit, zsfNum[zone], zsfDenom[zone]), weightNum, weightDenom)
+
+		// Arc from Member to Sink, with capacity of the adjusted Member ItemLimit
+		// (or WeightLimit). Previous flow is the number (or weight) of current Assignments.
+		addArc(&fn.members[member], &fn.sink, limit, prevFlow)
 	}
 
 	// Sort all Node Arcs by priority.
@@ -226,6 +289,25 @@ func zoneScalingFactors(itemCount, itemSlots int, zoneSlots []int) (num, denom [
 	return
 }
 
+// scaleCeil scales |n| by |num| / |denom|, using integer division rounded up.
+func scaleCeil(n, num, denom int) int {
+	if n *= num; n == 0 {
+		return 0
+	} else if n%denom == 0 {
+		return n / denom
+	}
+	return (n / denom) + 1
+}
+
+// flowWeight returns the flow of a single Assignment of the |item|:
+// its Weight if the State is Weighted, or one if not.
+func flowWeight(s *State, item int) int {
+	if s.Weighted {
+		return itemAt(s.Items, item).Weight()
+	}
+	return 1
+}
+
 func buildItemArcs(s *State, fn *flowNetwork, item int, itemAssignments keyspace.KeyValues, itemSlots, effectiveZones int) {
 	// Item capacity is defined by its replication factor. Within a zone (and
 	// assuming there are multiple Zones), capacity is the replication factor
@@ -235,10 +317,20 @@ func buildItemArcs(s *State, fn *flowNetwork, item int, itemAssignments keyspace
 	if zoneSlots > 1 && effectiveZones > 1 {
 		zoneSlots--
 	}
+	// Capacities and previous flows are scaled by the weight of each Assignment.
+	var weight = flowWeight(s, item)
 
 	// Arc from Source to Item, with capacity of the total desired item replication.
 	// Previous flow is the number of current Assignments.
-	addArc(&fn.source, &fn.items[item], itemSlots, len(itemAssignments))
+	addArc(&fn.source, &fn.items[item], itemSlots*weight, len(itemAssignments)*weight)
+
+	// If the Item selects its Members, Arcs to Members it doesn't select are
+	// omitted. Note that zone constraints still apply: an Item which selects
+	// Members of only one zone is limited to |zoneSlots| Assignments.
+	var selective, _ = itemAt(s.Items, item).ItemValue.(SelectiveItem)
+	if selective != nil && selective.MemberSelector() == "" {
+		selective = nil
+	}
 
 	// Perform a Left-join of all Zones with |itemAssignments| (also ordered on zone).
 	var zit = LeftJoin{
@@ -261,7 +353,7 @@ func buildItemArcs(s *State, fn *flowNetwork, item int, itemAssignments keyspace
 
 		// Arc from Item to ZoneItem, with capacity of |zoneSlots|, and previous flow being
 		// the total number of current Assignments to Members in this zone.
-		addArc(&fn.items[item], &fn.zoneItems[zoneItem], zoneSlots, len(zoneAssignments))
+		addArc(&fn.items[item], &fn.zoneItems[zoneItem], zoneSlots*weight, len(zoneAssignments)*weight)
 
 		// Perform a Left-join of |Members| with |zoneAssignments| (also ordered on member suffix).
 		var mit = LeftJoin{
@@ -287,9 +379,16 @@ func buildItemArcs(s *State, fn *flowNetwork, item int, itemAssignments keyspace
 				panic("invalid member / zone order")
 			}
 
+			if selective != nil && !selective.SelectsMember(memberAt(s.Members, member).MemberValue) {
+				continue // Current Assignments to this Member (if any) are removed.
+			} else if mcur.RightBegin == mcur.RightEnd && memberAt(s.Members, member).Cordoned() {
+				continue // Cordoned Members may retain, but not add, Assignments.
+			} else if _, ok := fn.excluded[memberArc{zoneItem, member}]; ok {
+				continue // A prior solution of this Arc was infeasible (see excludeInfeasibleFlows).
+			}
 			// Arc from ZoneItem to Member, with capacity of 1 and a previous flow being
 			// the number of current Assignments to this member (which can be zero or one).
-			addArc(&fn.zoneItems[zoneItem], &fn.members[member], 1, mcur.RightEnd-mcur.RightBegin)
+			addArc(&fn.zoneItems[zoneItem], &fn.members[member], weight, (mcur.RightEnd-mcur.RightBegin)*weight)
 		}
 	}
 }
@@ -310,23 +409,18 @@ func addArc(from, to *pr.Node, capacity, prevFlow int) {
 func extractItemFlow(s *State, fn *flowNetwork, item int, out []Assignment) []Assignment {
 	var start = len(out) // First offset of extracted Assignments.
 
-	// Walk Arcs of each of the Item's ZoneItems, to collect
+	// Walk resolved flows of the Item's ZoneItems, to collect
 	// the |desired| Assignment state for this Item.
-	for zone := range s.Zones {
-		var zoneItem = item*len(s.Zones) + zone
+	var resolved, _ = resolveItemFlow(s, fn, item)
 
-		for _, a := range fn.zoneItems[zoneItem].Arcs {
-			if a.Flow <= 0 {
-				continue
-			}
-			var member = memberAt(s.Members, int(a.Target.ID))
+	for _, f := range resolved {
+		var member = memberAt(s.Members, f.member)
 
-			out = append(out, Assignment{
-				ItemID:       itemAt(s.Items, item).ID,
-				MemberZone:   member.Zone,
-				MemberSuffix: member.Suffix,
-			})
-		}
+		out = append(out, Assignment{
+			ItemID:       itemAt(s.Items, item).ID,
+			MemberZone:   member.Zone,
+			MemberSuffix: member.Suffix,
+		})
 	}
 	// Sort the portion just added to |out| under natural Assignment order.
 	sort.Slice(out[start:], func(i, j int) bool {
@@ -335,6 +429,118 @@ func extractItemFlow(s *State, fn *flowNetwork, item int, out []Assignment) []As
 	return out
 }
 
+// resolveItemFlow partitions ZoneItem => Member Arcs of the |item| having flow
+// into those which are |resolved| as Assignments, and those which are not.
+// If the State isn't Weighted, all Arcs having flow are resolved. Otherwise, the
+// weight of an Item Assignment may be split across Members, and only the Arcs
+// having largest flow (preferring those of current Assignments) are resolved,
+// in keeping with the Item's total flow and its zone constraints.
+func resolveItemFlow(s *State, fn *flowNetwork, item int) (resolved, unresolved []memberFlow) {
+	var (
+		weight    = flowWeight(s, item)
+		zoneSlots = make([]int, len(s.Zones))
+		itemFlow  int
+	)
+	for zone := range s.Zones {
+		var zoneItem = item*len(s.Zones) + zone
+
+		for _, a := range fn.zoneItems[zoneItem].Arcs {
+			if a.Flow > 0 {
+				resolved = append(resolved, memberFlow{memberArc{zoneItem, int(a.Target.ID)}, a.Flow, a.Priority})
+				itemFlow += int(a.Flow)
+			}
+		}
+	}
+	if weight == 1 {
+		return // Flows cannot be split.
+	}
+	for _, a := range fn.items[item].Arcs {
+		if a.Capacity > 0 {
+			zoneSlots[int(a.Target.ID)-item*len(s.Zones)] = int(a.Capacity) / weight
+		}
+	}
+	sort.SliceStable(resolved, func(i, j int) bool {
+		if resolved[i].flow != resolved[j].flow {
+			return resolved[i].flow > resolved[j].flow
+		}
+		return resolved[i].priority > resolved[j].priority
+	})
+
+	var flows = resolved
+	resolved = nil
+
+	for remain, i := scaleCeil(itemFlow, 1, weight), 0; i != len(flows); i++ {
+		var zone = flows[i].zoneItem - item*len(s.Zones)
+
+		if remain != 0 && zoneSlots[zone] != 0 {
+			resolved = append(resolved, flows[i])
+			zoneSlots[zone]--
+			remain--
+		} else {
+			unresolved = append(unresolved, flows[i])
+		}
+	}
+	return
+}
+
+// excludeInfeasibleFlows excludes from the network each ZoneItem => Member Arc
+// having flow which isn't resolved as an Assignment, and bounds the WeightLimit
+// of each Member having more resolved Assignments than its ItemLimit, returning
+// true if the network was changed. A solution of the changed network places
+// Item weight only with Members able to hold it, and re-balances remaining
+// Items around them.
+func (fn *flowNetwork) excludeInfeasibleFlows(s *State) bool {
+	if !s.Weighted {
+		return false // Flows aren't split, and Member capacities are ItemLimits.
+	}
+	var excluded bool
+	var memberFlows = make([][]memberFlow, len(s.Members))
+
+	for item := range s.Items {
+		var resolved, unresolved = resolveItemFlow(s, fn, item)
+
+		for _, f := range unresolved {
+			if fn.excluded == nil {
+				fn.excluded = make(map[memberArc]struct{})
+			}
+			fn.excluded[f.memberArc] = struct{}{}
+			excluded = true
+		}
+		for _, f := range resolved {
+			memberFlows[f.member] = append(memberFlows[f.member], f)
+		}
+	}
+	for member, flows := range memberFlows {
+		var limit = memberAt(s.Members, member).ItemLimit()
+		if len(flows) <= limit {
+			continue
+		}
+		// Bound the Member's WeightLimit by the weight of the |limit| Items it
+		// would retain (preferring current Assignments). A bound strictly
+		// decreases with each solve, and Items the Member can't hold are
+		// re-balanced to other Members.
+		sort.SliceStable(flows, func(i, j int) bool {
+			if flows[i].priority != flows[j].priority {
+				return flows[i].priority > flows[j].priority
+			}
+			return flows[i].flow > flows[j].flow
+		})
+		var bound int
+		for _, f := range flows[:limit] {
+			bound += itemAt(s.Items, f.zoneItem/len(s.Zones)).Weight()
+		}
+		if cur, ok := fn.weightBounds[member]; ok && cur <= bound {
+			bound = cur - 1
+		}
+		if fn.weightBounds == nil {
+			fn.weightBounds = make(map[int]int)
+		}
+		fn.weightBounds[member] = bound
+		excluded = true
+	}
+	return excluded
+}
+
 func min(a, b int) int {
 	if a < b {
 		return a
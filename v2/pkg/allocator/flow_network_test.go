@@ -2,9 +2,12 @@ package allocator
 
 import (
 	"context"
+	"fmt"
+	"sort"
 
 	pr "github.com/LiveRamp/gazette/v2/pkg/allocator/push_relabel"
 	"github.com/LiveRamp/gazette/v2/pkg/etcdtest"
+	epb "github.com/coreos/etcd/etcdserver/etcdserverpb"
 	gc "github.com/go-check/check"
 )
 
@@ -146,6 +149,312 @@ func (s *FlowNetworkSuite) TestFlowOverSimpleFixture(c *gc.C) {
 	})
 }
 
+func (s *FlowNetworkSuite) TestFlowOmitsUnselectedMembers(c *gc.C) {
+	var ks = NewAllocatorKeySpace("/root", testAllocDecoder{})
+	var state = NewObservedState(ks, MemberKey(ks, "zone-a", "member-A"))
+
+	var fixture = []string{
+		"/root/items/item-any", `{"R": 1}`,
+		"/root/items/item-off-ssd", `{"R": 1, "S": "!ssd"}`,
+		"/root/items/item-ssd", `{"R": 2, "S": "ssd"}`,
+
+		"/root/members/zone-a#member-A", `{"R": 10, "L": "ssd"}`,
+		"/root/members/zone-a#member-B", `{"R": 10, "L": "hdd"}`,
+		"/root/members/zone-b#member-C", `{"R": 10, "L": "ssd"}`,
+
+		// item-off-ssd is currently assigned to a Member it doesn't select.
+		"/root/assign/item-off-ssd#zone-a#member-A#0", `consistent`,
+	}
+	c.Check(ks.LoadSnapshot(epb.ResponseHeader{Revision: 1}, snapshotKeyValues(fixture)), gc.IsNil)
+
+	var fn flowNetwork
+	fn.init(state)
+
+	// targets returns Nodes of forward (non-residual) Arcs, in ID order.
+	var targets = func(node *pr.Node) (out []*pr.Node) {
+		for _, a := range node.Arcs {
+			if a.Capacity > 0 {
+				out = append(out, a.Target)
+			}
+		}
+		sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+		return
+	}
+	var (
+		MA = &fn.members[0]
+		MB = &fn.members[1]
+		MC = &fn.members[2]
+	)
+	// ZoneItems are indexed on (item * len(Zones) + zone).
+	c.Check(targets(&fn.zoneItems[0]), gc.DeepEquals, []*pr.Node{MA, MB}) // item-any/zone-a.
+	c.Check(targets(&fn.zoneItems[1]), gc.DeepEquals, []*pr.Node{MC})     // item-any/zone-b.
+	c.Check(targets(&fn.zoneItems[2]), gc.DeepEquals, []*pr.Node{MB})     // item-off-ssd/zone-a.
+	c.Check(targets(&fn.zoneItems[3]), gc.HasLen, 0)                      // item-off-ssd/zone-b.
+	c.Check(targets(&fn.zoneItems[4]), gc.DeepEquals, []*pr.Node{MA})     // item-ssd/zone-a.
+	c.Check(targets(&fn.zoneItems[5]), gc.DeepEquals, []*pr.Node{MC})     // item-ssd/zone-b.
+
+	pr.FindMaxFlow(&fn.source, &fn.sink)
+
+	// Expect item-off-ssd moves off of member-A, and item-ssd is placed only
+	// with Members having the "ssd" label.
+	c.Check(extractItemFlow(state, &fn, 1, nil), gc.DeepEquals, []Assignment{
+		{ItemID: "item-off-ssd", MemberZone: "zone-a", MemberSuffix: "member-B"},
+	})
+	c.Check(extractItemFlow(state, &fn, 2, nil), gc.DeepEquals, []Assignment{
+		{ItemID: "item-ssd", MemberZone: "zone-a", MemberSuffix: "member-A"},
+		{ItemID: "item-ssd", MemberZone: "zone-b", MemberSuffix: "member-C"},
+	})
+
+	// Expect the NetworkHash captures Item selectors and Member labels.
+	var hash = state.NetworkHash
+	fixture[9] = `{"R": 10, "L": "nvme"}`
+	c.Check(ks.LoadSnapshot(epb.ResponseHeader{Revision: 2}, snapshotKeyValues(fixture)), gc.IsNil)
+	c.Check(state.NetworkHash, gc.Not(gc.Equals), hash)
+
+	hash = state.NetworkHash
+	fixture[5] = `{"R": 2, "S": "nvme"}`
+	c.Check(ks.LoadSnapshot(epb.ResponseHeader{Revision: 3}, snapshotKeyValues(fixture)), gc.IsNil)
+	c.Check(state.NetworkHash, gc.Not(gc.Equals), hash)
+}
+
+func (s *FlowNetworkSuite) TestFlowRetainsButDoesNotAddCordonedAssignments(c *gc.C) {
+	var ks = NewAllocatorKeySpace("/root", testAllocDecoder{})
+	var state = NewObservedState(ks, MemberKey(ks, "zone-a", "member-A"))
+
+	var fixture = []string{
+		"/root/items/item-1", `{"R": 1}`,
+		"/root/items/item-2", `{"R": 1}`,
+
+		"/root/members/zone-a#member-A", `{"R": 10, "C": true}`,
+		"/root/members/zone-a#member-B", `{"R": 10}`,
+
+		// item-1 is currently assigned to the cordoned member-A.
+		"/root/assign/item-1#zone-a#member-A#0", `consistent`,
+	}
+	c.Check(ks.LoadSnapshot(epb.ResponseHeader{Revision: 1}, snapshotKeyValues(fixture)), gc.IsNil)
+
+	var fn flowNetwork
+	fn.init(state)
+
+	// targets returns Nodes of forward (non-residual) Arcs, in ID order.
+	var targets = func(node *pr.Node) (out []*pr.Node) {
+		for _, a := range node.Arcs {
+			if a.Capacity > 0 {
+				out = append(out, a.Target)
+			}
+		}
+		sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+		return
+	}
+	var (
+		MA = &fn.members[0]
+		MB = &fn.members[1]
+	)
+	// Expect item-1 retains its Arc to cordoned member-A, while item-2 has none.
+	c.Check(targets(&fn.zoneItems[0]), gc.DeepEquals, []*pr.Node{MA, MB})
+	c.Check(targets(&fn.zoneItems[1]), gc.DeepEquals, []*pr.Node{MB})
+
+	pr.FindMaxFlow(&fn.source, &fn.sink)
+
+	c.Check(extractItemFlow(state, &fn, 0, nil), gc.DeepEquals, []Assignment{
+		{ItemID: "item-1", MemberZone: "zone-a", MemberSuffix: "member-A"},
+	})
+	c.Check(extractItemFlow(state, &fn, 1, nil), gc.DeepEquals, []Assignment{
+		{ItemID: "item-2", MemberZone: "zone-a", MemberSuffix: "member-B"},
+	})
+
+	// Expect the NetworkHash captures the cordon.
+	var hash = state.NetworkHash
+	fixture[5] = `{"R": 10}`
+	c.Check(ks.LoadSnapshot(epb.ResponseHeader{Revision: 2}, snapshotKeyValues(fixture)), gc.IsNil)
+	c.Check(state.NetworkHash, gc.Not(gc.Equals), hash)
+}
+
+func (s *FlowNetworkSuite) TestWeightedFlowResolvesSplitItems(c *gc.C) {
+	var ks = NewAllocatorKeySpace("/root", testAllocDecoder{})
+	var state = NewObservedState(ks, MemberKey(ks, "zone-a", "member-A"))
+
+	c.Check(ks.LoadSnapshot(epb.ResponseHeader{Revision: 1}, snapshotKeyValues([]string{
+		"/root/items/heavy-1", `{"R": 1, "W": 6}`,
+		"/root/items/heavy-2", `{"R": 1, "W": 6}`,
+		"/root/items/light-1", `{"R": 1}`,
+		"/root/items/light-2", `{"R": 1}`,
+
+		"/root/members/zone-a#member-A", `{"R": 10, "W": 30}`,
+		"/root/members/zone-a#member-B", `{"R": 10, "W": 10}`,
+	})), gc.IsNil)
+
+	var fn flowNetwork
+	fn.init(state)
+
+	// Expect Item Arcs are scaled by Item weight.
+	for _, a := range fn.source.Arcs {
+		c.Check(a.Capacity, gc.Equals, []int32{6, 6, 1, 1}[a.Target.ID])
+	}
+	for _, a := range fn.zoneItems[0].Arcs {
+		if a.Target != &fn.items[0] {
+			c.Check(a.Capacity, gc.Equals, int32(6))
+		}
+	}
+	// Member capacities are WeightLimits, scaled by 14/40 (rounded up).
+	for m, expect := range []int32{11, 4} {
+		for _, a := range fn.members[m].Arcs {
+			if a.Target == &fn.sink {
+				c.Check(a.Capacity, gc.Equals, expect)
+			}
+		}
+	}
+
+	// The weight of heavy-1 is split across Members. Expect its Arc to the
+	// Member of lesser flow is excluded, and that a re-solved network has
+	// no further splits.
+	pr.FindMaxFlow(&fn.source, &fn.sink)
+	c.Check(fn.excludeInfeasibleFlows(state), gc.Equals, true)
+	c.Check(fn.excluded, gc.HasLen, 1)
+
+	for fn.excludeInfeasibleFlows(state) {
+		fn.init(state)
+		pr.FindMaxFlow(&fn.source, &fn.sink)
+	}
+	for item := range state.Items {
+		var _, unresolved = resolveItemFlow(state, &fn, item)
+		c.Check(unresolved, gc.HasLen, 0)
+	}
+	c.Check(extractItemFlow(state, &fn, 0, nil), gc.DeepEquals, []Assignment{
+		{ItemID: "heavy-1", MemberZone: "zone-a", MemberSuffix: "member-A"},
+	})
+	c.Check(extractItemFlow(state, &fn, 2, nil), gc.DeepEquals, []Assignment{
+		{ItemID: "light-1", MemberZone: "zone-a", MemberSuffix: "member-B"},
+	})
+}
+
+func (s *FlowNetworkSuite) TestHeavyItemsAreSpreadAcrossMembers(c *gc.C) {
+	var ks = NewAllocatorKeySpace("/root", testAllocDecoder{})
+	var state = NewObservedState(ks, MemberKey(ks, "zone-a", "member-A"))
+
+	c.Check(ks.LoadSnapshot(epb.ResponseHeader{Revision: 1}, snapshotKeyValues([]string{
+		"/root/items/heavy-1", `{"R": 1, "W": 10}`,
+		"/root/items/heavy-2", `{"R": 1, "W": 10}`,
+		"/root/items/light-1", `{"R": 1}`,
+		"/root/items/light-2", `{"R": 1}`,
+		"/root/items/light-3", `{"R": 1}`,
+		"/root/items/light-4", `{"R": 1}`,
+
+		"/root/members/zone-a#member-A", `{"R": 10}`,
+		"/root/members/zone-a#member-B", `{"R": 10}`,
+	})), gc.IsNil)
+
+	c.Check(state.Weighted, gc.Equals, true)
+	c.Check(state.ItemWeight, gc.Equals, 24)
+
+	// Expect each Member is assigned one heavy Item and two light ones.
+	var plan = NewPlan(state)
+	c.Check(plan.Desired, gc.HasLen, 6)
+	c.Check(desiredWeights(state, plan.Desired), gc.DeepEquals, map[string]int{
+		"member-A": 12,
+		"member-B": 12,
+	})
+}
+
+func (s *FlowNetworkSuite) TestLoadIsProportionalToWeightLimits(c *gc.C) {
+	var ks = NewAllocatorKeySpace("/root", testAllocDecoder{})
+	var state = NewObservedState(ks, MemberKey(ks, "zone-a", "member-A"))
+
+	c.Check(ks.LoadSnapshot(epb.ResponseHeader{Revision: 1}, snapshotKeyValues([]string{
+		"/root/items/heavy-1", `{"R": 1, "W": 6}`,
+		"/root/items/heavy-2", `{"R": 1, "W": 6}`,
+		"/root/items/light-1", `{"R": 1}`,
+		"/root/items/light-2", `{"R": 1}`,
+
+		// member-A has thrice the weight capacity of member-B.
+		"/root/members/zone-a#member-A", `{"R": 10, "W": 30}`,
+		"/root/members/zone-a#member-B", `{"R": 10, "W": 10}`,
+	})), gc.IsNil)
+
+	// Expect member-A is assigned both heavy Items (a load ratio of 0.4),
+	// and member-B both light ones (a load ratio of 0.2).
+	var plan = NewPlan(state)
+	c.Check(plan.Desired, gc.HasLen, 4)
+	c.Check(desiredWeights(state, plan.Desired), gc.DeepEquals, map[string]int{
+		"member-A": 12,
+		"member-B": 2,
+	})
+}
+
+func (s *FlowNetworkSuite) TestWeightedFlowRespectsItemLimits(c *gc.C) {
+	var ks = NewAllocatorKeySpace("/root", testAllocDecoder{})
+	var state = NewObservedState(ks, MemberKey(ks, "zone-a", "member-A"))
+
+	c.Check(ks.LoadSnapshot(epb.ResponseHeader{Revision: 1}, snapshotKeyValues([]string{
+		"/root/items/heavy-1", `{"R": 1, "W": 6}`,
+		"/root/items/heavy-2", `{"R": 1, "W": 6}`,
+		"/root/items/light-1", `{"R": 1}`,
+		"/root/items/light-2", `{"R": 1}`,
+
+		// member-A has ten times the WeightLimit of member-B, but may hold
+		// only a single Item (and at most the weight of one heavy Item).
+		"/root/members/zone-a#member-A", `{"R": 1, "W": 100}`,
+		"/root/members/zone-a#member-B", `{"R": 10, "W": 10}`,
+	})), gc.IsNil)
+
+	// Expect member-A is assigned one heavy Item, and member-B the rest.
+	var plan = NewPlan(state)
+	c.Check(plan.Desired, gc.HasLen, 4)
+	c.Check(desiredWeights(state, plan.Desired), gc.DeepEquals, map[string]int{
+		"member-A": 6,
+		"member-B": 8,
+	})
+}
+
+func (s *FlowNetworkSuite) TestWeightedFlowAtScale(c *gc.C) {
+	var ks = NewAllocatorKeySpace("/root", testAllocDecoder{})
+	var state = NewObservedState(ks, MemberKey(ks, "zone-0", "member-00"))
+
+	var kvs []string
+	for i := 0; i != 2000; i++ {
+		// Weights are heavily skewed: most Items are light, and a few are heavy.
+		kvs = append(kvs, fmt.Sprintf("/root/items/item-%04d", i),
+			fmt.Sprintf(`{"R": 3, "W": %d}`, 1+(i*i)%97/(1+i%13)))
+	}
+	for i := 0; i != 60; i++ {
+		// Members have differing WeightLimits. Every tenth Member has an ItemLimit
+		// permitting less weight than its WeightLimit.
+		var limit = 400
+		if i%10 == 0 {
+			limit = 20
+		}
+		kvs = append(kvs, fmt.Sprintf("/root/members/zone-%d#member-%02d", i%3, i),
+			fmt.Sprintf(`{"R": %d, "W": %d}`, limit, 500+(i%5)*400))
+	}
+	c.Check(ks.LoadSnapshot(epb.ResponseHeader{Revision: 1}, snapshotKeyValues(kvs)), gc.IsNil)
+	c.Check(state.Weighted, gc.Equals, true)
+
+	// Solves of the network are bounded by maxInfeasibleFlowSolves. Expect all
+	// Items are fully replicated, and no Member is desired to exceed its ItemLimit.
+	var plan = NewPlan(state)
+	c.Check(plan.UnattainableSlots, gc.Equals, 0)
+	c.Check(plan.Desired, gc.HasLen, 6000)
+
+	var counts = make(map[string]int)
+	for _, a := range plan.Desired {
+		counts[a.MemberZone+"#"+a.MemberSuffix]++
+	}
+	for member := range state.Members {
+		var m = memberAt(state.Members, member)
+		c.Check(counts[m.Zone+"#"+m.Suffix] <= m.ItemLimit(), gc.Equals, true, gc.Commentf(m.Suffix))
+	}
+}
+
+// desiredWeights returns the total weight of |desired| Assignments, by MemberSuffix.
+func desiredWeights(state *State, desired []Assignment) map[string]int {
+	var out = make(map[string]int)
+	for _, a := range desired {
+		var ind, _ = state.Items.Search(ItemKey(state.KS, a.ItemID))
+		out[a.MemberSuffix] += itemAt(state.Items, ind).Weight()
+	}
+	return out
+}
+
 func verifyNode(c *gc.C, node, expect *pr.Node) {
 	c.Check(node.ID, gc.Equals, expect.ID)
 	c.Check(node.Height, gc.Equals, expect.Height)
@@ -11,6 +11,7 @@ import (
 // desired changes to its Assignments.
 type itemState struct {
 	global *State
+	moves  *moves // Optional tracking and limiting of Item moves.
 
 	item    int                // Index of current Item within |global.Items|.
 	current keyspace.KeyValues // Sub-slice of Item's current Assignments within |global.Assignments|.
@@ -25,6 +26,7 @@ type itemState struct {
 func (s *itemState) init(item int, current keyspace.KeyValues, desired []Assignment) {
 	*s = itemState{
 		global: s.global,
+		moves:  s.moves,
 
 		item:    item,
 		current: current,
@@ -72,14 +74,17 @@ func (s *itemState) init(item int, current keyspace.KeyValues, desired []Assignm
 	}
 }
 
+// weight returns the Weight of the current Item.
+func (s *itemState) weight() int { return itemAt(s.global.Items, s.item).Weight() }
+
 // constrainRemovals prunes Assignments from |s.remove| which would otherwise violate
 // constraints, moving them to |s.reorder|.
 func (s *itemState) constrainRemovals() {
 	// Order |s.remove| on decreasing member load ratio
-	// (the ratio of the member's total Assignments, vs its item limit).
+	// (the ratio of the member's total Assignment weight, vs its weight limit).
 	sort.Slice(s.remove, func(i, j int) bool {
-		var ri = s.global.memberLoadRatio(s.remove[i], s.global.MemberTotalCount)
-		var rj = s.global.memberLoadRatio(s.remove[j], s.global.MemberTotalCount)
+		var ri = s.global.memberLoadRatio(s.remove[i], s.global.MemberTotalWeight)
+		var rj = s.global.memberLoadRatio(s.remove[j], s.global.MemberTotalWeight)
 		return ri > rj
 	})
 	var item = itemAt(s.global.Items, s.item)
@@ -126,7 +131,7 @@ func (s *itemState) constrainReorders() {
 	// There is no current primary. Select an assignment to promote, preferring:
 	// a) Assignments which are currently consistent, and then
 	// b) Assignments having a lower primary load ratio
-	//    (the ratio of the member's primary Assignments, vs its item limit).
+	//    (the ratio of the member's primary Assignment weight, vs its weight limit).
 
 	var primary = struct {
 		index        int
@@ -137,7 +142,7 @@ func (s *itemState) constrainReorders() {
 
 	for i := range s.reorder {
 		var c = item.IsConsistent(s.reorder[i], s.current)
-		var r = s.global.memberLoadRatio(s.reorder[i], s.global.MemberPrimaryCount)
+		var r = s.global.memberLoadRatio(s.reorder[i], s.global.MemberPrimaryWeight)
 
 		if primary.index == -1 ||
 			c == true && primary.isConsistent == false ||
@@ -154,6 +159,9 @@ func (s *itemState) constrainReorders() {
 
 // constrainAdds prunes Assignments from |s.add| which would otherwise violate constraints.
 func (s *itemState) constrainAdds() {
+	// Additions are moves if the Item already has its desired replication.
+	var isMove = len(s.current) >= itemAt(s.global.Items, s.item).DesiredReplication()
+
 	for i := 0; i != len(s.add); {
 		var a = s.add[i]
 
@@ -166,6 +174,10 @@ func (s *itemState) constrainAdds() {
 			// Addition would violate member's ItemLimit. Remove this Assignment.
 			copy(s.add[i:], s.add[i+1:])
 			s.add = s.add[:len(s.add)-1]
+		} else if isMove && s.moves != nil && !s.moves.mayStart() {
+			// Addition would exceed the limit of pending moves. Defer it.
+			copy(s.add[i:], s.add[i+1:])
+			s.add = s.add[:len(s.add)-1]
 		} else {
 			i++
 		}
@@ -174,6 +186,11 @@ func (s *itemState) constrainAdds() {
 
 // buildRemoveOps adds operations to |txn| removing each of the Assignments in |s.remove|.
 func (s *itemState) buildRemoveOps(txn checkpointTxn) {
+	if s.moves != nil {
+		// Removals of excess Item Assignments complete pending moves.
+		var excess = len(s.current) - itemAt(s.global.Items, s.item).DesiredReplication()
+		s.moves.completed += max(0, min(excess, len(s.remove)))
+	}
 	for i, r := range s.remove {
 		// Verify the Item (and Assignment itself) have not changed. Otherwise, the
 		// Item Replication may have increased (and this removal could violate it).
@@ -189,8 +206,10 @@ func (s *itemState) buildRemoveOps(txn checkpointTxn) {
 		if ind, found := s.global.Members.Search(MemberKey(s.global.KS, a.MemberZone, a.MemberSuffix)); found {
 			if a.Slot == 0 {
 				s.global.MemberPrimaryCount[ind] -= 1
+				s.global.MemberPrimaryWeight[ind] -= s.weight()
 			}
 			s.global.MemberTotalCount[ind] -= 1
+			s.global.MemberTotalWeight[ind] -= s.weight()
 		}
 		// We allow for !found (and do not panic) to gracefully handle assignments
 		// which somehow linger after their corresponding member is deleted (note
@@ -211,6 +230,7 @@ func (s *itemState) buildPromoteOps(txn checkpointTxn) {
 		// Update to reflect the member's primary count has increased.
 		if ind, found := s.global.Members.Search(MemberKey(s.global.KS, a.MemberZone, a.MemberSuffix)); found {
 			s.global.MemberPrimaryCount[ind] += 1
+			s.global.MemberPrimaryWeight[ind] += s.weight()
 		}
 		// Like buildRemoveOps, we allow for the possibility of !found (and do not
 		// panic). Note that a member without a member key will have an infinite
@@ -237,8 +257,10 @@ func (s *itemState) buildAddOps(txn checkpointTxn) {
 		// Update to reflect the member's total count (and potentially primary count) has increased.
 		if a.Slot == 0 {
 			s.global.MemberPrimaryCount[ind] += 1
+			s.global.MemberPrimaryWeight[ind] += s.weight()
 		}
 		s.global.MemberTotalCount[ind] += 1
+		s.global.MemberTotalWeight[ind] += s.weight()
 	}
 }
 
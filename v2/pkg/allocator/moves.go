@@ -0,0 +1,76 @@
+package allocator
+
+import (
+	"strings"
+
+	"github.com/LiveRamp/gazette/v2/pkg/keyspace"
+	"github.com/LiveRamp/gazette/v2/pkg/metrics"
+)
+
+// moves tracks, and optionally limits, moves of Items between Members over a
+// converge round. A move is an Assignment added to an Item which already has
+// at least its desired replication. The move is pending while the Item has
+// Assignments in excess of its desired replication which are not yet
+// consistent, and is completed when the Item's excess Assignment is removed.
+// Additions to Items having less than their desired replication repair the
+// Item, rather than move it, and are never limited.
+type moves struct {
+	limit     int // Maximum number of pending moves, or zero if not limited.
+	pending   int // Pending moves, including those started this round.
+	started   int // Moves started this round.
+	deferred  int // Moves deferred this round, due to |limit|.
+	completed int // Moves completed this round.
+}
+
+// begin a converge round by counting the current pending moves of the State.
+func (m *moves) begin(s *State) {
+	*m = moves{limit: m.limit}
+
+	var it = LeftJoin{
+		LenL: len(s.Items),
+		LenR: len(s.Assignments),
+		Compare: func(l, r int) int {
+			return strings.Compare(itemAt(s.Items, l).ID, assignmentAt(s.Assignments, r).ItemID)
+		},
+	}
+	for cur, ok := it.Next(); ok; cur, ok = it.Next() {
+		m.pending += pendingItemMoves(itemAt(s.Items, cur.Left), s.Assignments[cur.RightBegin:cur.RightEnd])
+	}
+}
+
+// mayStart returns true if a move may be started without exceeding |limit|.
+// If true, the move is tracked as started and pending. Otherwise, it's
+// tracked as deferred.
+func (m *moves) mayStart() bool {
+	if m.limit != 0 && m.pending >= m.limit {
+		m.deferred++
+		return false
+	}
+	m.started++
+	m.pending++
+	return true
+}
+
+// publish the round's moves to metrics.
+func (m *moves) publish() {
+	metrics.AllocatorPendingMoves.Set(float64(m.pending))
+	metrics.AllocatorDeferredMoves.Set(float64(m.deferred))
+	metrics.AllocatorStartedMovesTotal.Add(float64(m.started))
+	metrics.AllocatorCompletedMovesTotal.Add(float64(m.completed))
+}
+
+// pendingItemMoves returns the number of pending moves of the Item, which is
+// the lesser of its excess Assignments and its inconsistent Assignments.
+func pendingItemMoves(item Item, current keyspace.KeyValues) int {
+	var excess = len(current) - item.DesiredReplication()
+	if excess <= 0 {
+		return 0
+	}
+	var inconsistent int
+	for _, a := range current {
+		if !item.IsConsistent(a, current) {
+			inconsistent++
+		}
+	}
+	return min(excess, inconsistent)
+}
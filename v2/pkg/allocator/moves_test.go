@@ -0,0 +1,119 @@
+package allocator
+
+import (
+	"github.com/coreos/etcd/clientv3"
+	epb "github.com/coreos/etcd/etcdserver/etcdserverpb"
+	gc "github.com/go-check/check"
+)
+
+type MovesSuite struct{}
+
+func (s *MovesSuite) TestConvergeLimitsPendingMoves(c *gc.C) {
+	var ks = NewAllocatorKeySpace("/root", testAllocDecoder{})
+	var state = NewObservedState(ks, MemberKey(ks, "zone-a", "member-A"))
+
+	var fixture = []string{
+		"/root/items/item-1", `{"R": 1}`,
+		"/root/items/item-2", `{"R": 1}`,
+		"/root/items/item-3", `{"R": 1}`,
+
+		"/root/members/zone-a#member-A", `{"R": 10}`,
+		"/root/members/zone-a#member-B", `{"R": 10}`,
+
+		"/root/assign/item-1#zone-a#member-A#0", `consistent`,
+		"/root/assign/item-2#zone-a#member-A#0", `consistent`,
+		"/root/assign/item-3#zone-a#member-A#0", `consistent`,
+	}
+	c.Check(ks.LoadSnapshot(epb.ResponseHeader{Revision: 1}, snapshotKeyValues(fixture)), gc.IsNil)
+
+	// We desire to move all Items from member-A to member-B.
+	var desired = []Assignment{
+		{ItemID: "item-1", MemberZone: "zone-a", MemberSuffix: "member-B"},
+		{ItemID: "item-2", MemberZone: "zone-a", MemberSuffix: "member-B"},
+		{ItemID: "item-3", MemberZone: "zone-a", MemberSuffix: "member-B"},
+	}
+	var mv = moves{limit: 1}
+
+	// Expect only the move of item-1 is started.
+	var txn mockTxnBuilder
+	c.Check(converge(&txn, state, desired, &mv), gc.IsNil)
+	c.Check(txn.ops, gc.DeepEquals, []clientv3.Op{
+		clientv3.OpPut("/root/assign/item-1#zone-a#member-B#1", ""),
+	})
+	c.Check(mv, gc.DeepEquals, moves{limit: 1, pending: 1, started: 1, deferred: 2})
+
+	// The move of item-1 is pending while its new Assignment isn't consistent.
+	// Expect no further moves are started.
+	fixture = append(fixture, "/root/assign/item-1#zone-a#member-B#1", ``)
+	c.Check(ks.LoadSnapshot(epb.ResponseHeader{Revision: 2}, snapshotKeyValues(fixture)), gc.IsNil)
+
+	txn = mockTxnBuilder{}
+	c.Check(converge(&txn, state, desired, &mv), gc.IsNil)
+	c.Check(txn.ops, gc.HasLen, 0)
+	c.Check(mv, gc.DeepEquals, moves{limit: 1, pending: 1, deferred: 2})
+
+	// The new Assignment of item-1 becomes consistent. Expect its move is
+	// completed by removing its previous Assignment, and item-2 is moved.
+	fixture[len(fixture)-1] = `consistent`
+	c.Check(ks.LoadSnapshot(epb.ResponseHeader{Revision: 3}, snapshotKeyValues(fixture)), gc.IsNil)
+
+	txn = mockTxnBuilder{}
+	c.Check(converge(&txn, state, desired, &mv), gc.IsNil)
+	c.Check(txn.ops, gc.DeepEquals, []clientv3.Op{
+		clientv3.OpDelete("/root/assign/item-1#zone-a#member-A#0"),
+		// The new Assignment of item-1 is also promoted to primary.
+		clientv3.OpDelete("/root/assign/item-1#zone-a#member-B#1"),
+		clientv3.OpPut("/root/assign/item-1#zone-a#member-B#0", "consistent"),
+		clientv3.OpPut("/root/assign/item-2#zone-a#member-B#1", ""),
+	})
+	c.Check(mv, gc.DeepEquals, moves{limit: 1, pending: 1, started: 1, deferred: 1, completed: 1})
+
+	// Without a limit, all moves are started at once.
+	mv = moves{}
+	txn = mockTxnBuilder{}
+	c.Check(converge(&txn, state, desired, &mv), gc.IsNil)
+	c.Check(txn.ops, gc.HasLen, 5)
+	c.Check(mv, gc.DeepEquals, moves{pending: 2, started: 2, completed: 1})
+}
+
+func (s *MovesSuite) TestRepairsAreNotLimited(c *gc.C) {
+	var ks = NewAllocatorKeySpace("/root", testAllocDecoder{})
+	var state = NewObservedState(ks, MemberKey(ks, "zone-a", "member-A"))
+
+	c.Check(ks.LoadSnapshot(epb.ResponseHeader{Revision: 1}, snapshotKeyValues([]string{
+		"/root/items/item-1", `{"R": 2}`,
+		"/root/items/item-2", `{"R": 2}`,
+		"/root/items/item-3", `{"R": 1}`,
+
+		"/root/members/zone-a#member-A", `{"R": 10}`,
+		"/root/members/zone-a#member-B", `{"R": 10}`,
+
+		// item-1 is being repaired, and its inconsistent Assignment isn't a move.
+		"/root/assign/item-1#zone-a#member-A#0", `consistent`,
+		"/root/assign/item-1#zone-a#member-B#1", ``,
+		// item-2 is under-replicated.
+		"/root/assign/item-2#zone-a#member-A#0", `consistent`,
+		// item-3 is being moved, and saturates the limit of pending moves.
+		"/root/assign/item-3#zone-a#member-A#0", `consistent`,
+		"/root/assign/item-3#zone-a#member-B#1", ``,
+	})), gc.IsNil)
+
+	var mv = moves{limit: 1}
+	var txn mockTxnBuilder
+
+	// Expect item-2 is repaired, despite the saturated limit.
+	c.Check(converge(&txn, state, []Assignment{
+		{ItemID: "item-1", MemberZone: "zone-a", MemberSuffix: "member-A"},
+		{ItemID: "item-1", MemberZone: "zone-a", MemberSuffix: "member-B"},
+		{ItemID: "item-2", MemberZone: "zone-a", MemberSuffix: "member-A"},
+		{ItemID: "item-2", MemberZone: "zone-a", MemberSuffix: "member-B"},
+		{ItemID: "item-3", MemberZone: "zone-a", MemberSuffix: "member-B"},
+	}, &mv), gc.IsNil)
+
+	c.Check(txn.ops, gc.DeepEquals, []clientv3.Op{
+		clientv3.OpPut("/root/assign/item-2#zone-a#member-B#1", ""),
+	})
+	c.Check(mv, gc.DeepEquals, moves{limit: 1, pending: 1})
+}
+
+var _ = gc.Suite(&MovesSuite{})
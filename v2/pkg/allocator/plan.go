@@ -0,0 +1,108 @@
+package allocator
+
+import (
+	"strings"
+
+	"github.com/LiveRamp/gazette/v2/pkg/keyspace"
+)
+
+// Plan is a solved maximum Assignment of a State, and its difference from
+// the State's current Assignments.
+type Plan struct {
+	// Desired Assignments of the State, ordered on (ItemID, MemberZone,
+	// MemberSuffix). Desired Assignments do not have a Slot.
+	Desired []Assignment
+	// Added Assignments are Desired, but do not currently exist.
+	Added []Assignment
+	// Removed Assignments currently exist, but are not Desired.
+	Removed []Assignment
+	// Moves is the number of Item replicas which are re-assigned from one
+	// Member to another. For each Item, it's the lesser of the number of its
+	// Added and Removed Assignments.
+	Moves int
+	// UnattainableSlots is the number of Item slots which cannot be assigned
+	// (eg, because there are too few Members or they're poorly distributed
+	// across zones).
+	UnattainableSlots int
+}
+
+// NewPlan solves for a maximum Assignment of the State using the same
+// prioritized flow network as Allocate, and returns the resulting Plan.
+// NewPlan doesn't modify Etcd: it's intended for offline inspection of
+// allocation decisions, such as the effects of hypothetical changes to the
+// Items or Members of a KeySpace snapshot. As with Allocate, the actual
+// Allocate leader converges towards its desired Assignments incrementally
+// and only as constraints allow. The caller must hold a read-lock of the
+// State KeySpace.
+func NewPlan(state *State) Plan {
+	var desired = solveDesired(new(flowNetwork), state, nil)
+	var plan = Plan{
+		Desired:           desired,
+		UnattainableSlots: max(0, state.ItemSlots-len(desired)),
+	}
+	var lastCRE int // cur.RightEnd of the previous iteration.
+
+	var it = LeftJoin{
+		LenL: len(state.Items),
+		LenR: len(state.Assignments),
+		Compare: func(l, r int) int {
+			return strings.Compare(itemAt(state.Items, l).ID, assignmentAt(state.Assignments, r).ItemID)
+		},
+	}
+	for cur, ok := it.Next(); ok; cur, ok = it.Next() {
+		// Assignments skipped since the last iteration have no Item, and are removed.
+		plan.Removed = appendAssignments(plan.Removed, state.Assignments[lastCRE:cur.RightBegin])
+		lastCRE = cur.RightEnd
+
+		var itemID, limit = itemAt(state.Items, cur.Left).ID, 0
+		// Determine leading sub-slice of |desired| which are Assignments of |itemID|.
+		for ; limit != len(desired) && desired[limit].ItemID == itemID; limit++ {
+		}
+		var added, removed = diffItemAssignments(state.Assignments[cur.RightBegin:cur.RightEnd], desired[:limit])
+
+		plan.Added = append(plan.Added, added...)
+		plan.Removed = append(plan.Removed, removed...)
+		plan.Moves += min(len(added), len(removed))
+
+		desired = desired[limit:]
+	}
+	// Trailing Assignments have no Item, and are removed.
+	plan.Removed = appendAssignments(plan.Removed, state.Assignments[lastCRE:])
+
+	return plan
+}
+
+// diffItemAssignments returns |desired| Assignments which are not |current|,
+// and |current| Assignments which are not |desired|. All Assignments must be
+// of the same Item.
+func diffItemAssignments(current keyspace.KeyValues, desired []Assignment) (added, removed []Assignment) {
+	var memberOf = func(a Assignment) string { return a.MemberZone + Sep + a.MemberSuffix }
+	var isCurrent = make(map[string]bool, len(current))
+	var isDesired = make(map[string]bool, len(desired))
+
+	for _, kv := range current {
+		isCurrent[memberOf(kv.Decoded.(Assignment))] = true
+	}
+	for _, a := range desired {
+		isDesired[memberOf(a)] = true
+	}
+
+	for _, a := range desired {
+		if !isCurrent[memberOf(a)] {
+			added = append(added, a)
+		}
+	}
+	for _, kv := range current {
+		if a := kv.Decoded.(Assignment); !isDesired[memberOf(a)] {
+			removed = append(removed, a)
+		}
+	}
+	return
+}
+
+func appendAssignments(out []Assignment, kvs keyspace.KeyValues) []Assignment {
+	for _, kv := range kvs {
+		out = append(out, kv.Decoded.(Assignment))
+	}
+	return out
+}
@@ -0,0 +1,72 @@
+package allocator
+
+import (
+	epb "github.com/coreos/etcd/etcdserver/etcdserverpb"
+	"github.com/coreos/etcd/mvcc/mvccpb"
+	gc "github.com/go-check/check"
+)
+
+type PlanSuite struct{}
+
+func (s *PlanSuite) TestPlanOfSnapshot(c *gc.C) {
+	var ks = NewAllocatorKeySpace("/root", testAllocDecoder{})
+	var state = NewObservedState(ks, MemberKey(ks, "zone-a", "member-A"))
+
+	var fixture = []string{
+		"/root/items/item-1", `{"R": 1}`,
+		"/root/items/item-2", `{"R": 2}`,
+
+		"/root/members/zone-a#member-A", `{"R": 2}`,
+		"/root/members/zone-b#member-B", `{"R": 2}`,
+
+		"/root/assign/item-1#zone-a#member-A#0", `consistent`,
+		"/root/assign/item-dead#zone-a#member-A#0", `consistent`,
+	}
+	c.Check(ks.LoadSnapshot(epb.ResponseHeader{Revision: 1}, snapshotKeyValues(fixture)), gc.IsNil)
+
+	// Expect item-1 retains its current Assignment, item-2 is placed in both
+	// zones, and the Assignment of a missing Item is removed.
+	var plan = NewPlan(state)
+	c.Check(plan.Desired, gc.DeepEquals, []Assignment{
+		{ItemID: "item-1", MemberZone: "zone-a", MemberSuffix: "member-A"},
+		{ItemID: "item-2", MemberZone: "zone-a", MemberSuffix: "member-A"},
+		{ItemID: "item-2", MemberZone: "zone-b", MemberSuffix: "member-B"},
+	})
+	c.Check(plan.Added, gc.DeepEquals, plan.Desired[1:])
+	c.Check(plan.Removed, gc.DeepEquals, []Assignment{
+		{ItemID: "item-dead", MemberZone: "zone-a", MemberSuffix: "member-A", AssignmentValue: testAssignment{consistent: true}},
+	})
+	c.Check(plan.Moves, gc.Equals, 0)
+	c.Check(plan.UnattainableSlots, gc.Equals, 0)
+
+	// Hypothetically remove member-A. item-1 moves to member-B, and one slot
+	// of item-2 is no longer attainable.
+	c.Check(ks.LoadSnapshot(epb.ResponseHeader{Revision: 2}, snapshotKeyValues(append(
+		fixture[:4:4], fixture[6:]...))), gc.IsNil)
+
+	plan = NewPlan(state)
+	c.Check(plan.Desired, gc.DeepEquals, []Assignment{
+		{ItemID: "item-1", MemberZone: "zone-b", MemberSuffix: "member-B"},
+		{ItemID: "item-2", MemberZone: "zone-b", MemberSuffix: "member-B"},
+	})
+	c.Check(plan.Added, gc.DeepEquals, plan.Desired)
+	c.Check(plan.Removed, gc.HasLen, 2)
+	c.Check(plan.Moves, gc.Equals, 1)
+	c.Check(plan.UnattainableSlots, gc.Equals, 1)
+}
+
+func snapshotKeyValues(keyValues []string) []*mvccpb.KeyValue {
+	var out []*mvccpb.KeyValue
+	for i := 0; i != len(keyValues); i += 2 {
+		out = append(out, &mvccpb.KeyValue{
+			Key:            []byte(keyValues[i]),
+			Value:          []byte(keyValues[i+1]),
+			CreateRevision: 1,
+			ModRevision:    1,
+			Version:        1,
+		})
+	}
+	return out
+}
+
+var _ = gc.Suite(&PlanSuite{})
@@ -0,0 +1,264 @@
+// Package auth authenticates the callers of Gazette RPCs using signed bearer
+// tokens, and authorizes their requests using the Grants of token Claims.
+//
+// A Grant pairs a LabelSelector with Capabilities. A caller may perform an
+// operation over a journal or shard only if one of its Grants both provides
+// the required Capability and selects the labels (including meta-labels,
+// such as "name" and "prefix" of journals or "id" of shards) of the item.
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	"github.com/dgrijalva/jwt-go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Capability is an operation which may be granted over journals or shards.
+type Capability string
+
+const (
+	// Read permits listing journals or shards, and reading journal content.
+	Read Capability = "read"
+	// Append permits appending to journals.
+	Append Capability = "append"
+	// Apply permits creating, updating, or deleting journal or shard specifications.
+	Apply Capability = "apply"
+)
+
+// Validate returns an error if the Capability is not well-formed.
+func (c Capability) Validate() error {
+	switch c {
+	case Read, Append, Apply:
+		return nil
+	default:
+		return pb.NewValidationError("invalid Capability (%s)", string(c))
+	}
+}
+
+// Grant of Capabilities over items having labels matched by the Selector.
+// A Grant is encoded in JSON as, eg:
+//
+//	{"selector": "prefix=my/journals/", "capabilities": ["read", "append"]}
+//
+// where the selector is in the format of protocol.ParseLabelSelector.
+// An empty selector matches all items.
+type Grant struct {
+	Selector     pb.LabelSelector
+	Capabilities []Capability
+}
+
+type jsonGrant struct {
+	Selector     string       `json:"selector"`
+	Capabilities []Capability `json:"capabilities"`
+}
+
+// MarshalJSON encodes the Grant as JSON.
+func (g Grant) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonGrant{Selector: g.Selector.String(), Capabilities: g.Capabilities})
+}
+
+// UnmarshalJSON decodes and validates the Grant from JSON.
+func (g *Grant) UnmarshalJSON(b []byte) error {
+	var j jsonGrant
+	if err := json.Unmarshal(b, &j); err != nil {
+		return err
+	}
+	var sel, err = pb.ParseLabelSelector(j.Selector)
+	if err != nil {
+		return err
+	}
+	*g = Grant{Selector: sel, Capabilities: j.Capabilities}
+	return g.Validate()
+}
+
+// Validate returns an error if the Grant is not well-formed.
+func (g Grant) Validate() error {
+	if err := g.Selector.Validate(); err != nil {
+		return pb.ExtendContext(err, "Selector")
+	}
+	for i, c := range g.Capabilities {
+		if err := c.Validate(); err != nil {
+			return pb.ExtendContext(err, "Capabilities[%d]", i)
+		}
+	}
+	return nil
+}
+
+// provides returns whether the Grant includes Capability |want|.
+func (g Grant) provides(want Capability) bool {
+	for _, c := range g.Capabilities {
+		if c == want {
+			return true
+		}
+	}
+	return false
+}
+
+// Claims of an authenticated caller.
+type Claims struct {
+	// Grants of Capabilities held by the caller.
+	Grants []Grant `json:"grants"`
+
+	jwt.StandardClaims
+}
+
+// Valid implements jwt.Claims, and returns an error if the StandardClaims are
+// invalid (eg, expired) or the Grants are not well-formed.
+func (c *Claims) Valid() error {
+	if err := c.StandardClaims.Valid(); err != nil {
+		return err
+	}
+	for i, g := range c.Grants {
+		if err := g.Validate(); err != nil {
+			return pb.ExtendContext(err, "Grants[%d]", i)
+		}
+	}
+	return nil
+}
+
+// Allows returns whether the Claims grant Capability |want| over an item having
+// |labels|. A nil *Claims, which represents a Service which doesn't perform
+// authentication, allows all operations.
+func (c *Claims) Allows(want Capability, labels pb.LabelSet) bool {
+	if c == nil {
+		return true
+	}
+	for _, g := range c.Grants {
+		if g.provides(want) && g.Selector.Matches(labels) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsAny returns whether the Claims grant Capability |want| over any item.
+func (c *Claims) AllowsAny(want Capability) bool {
+	if c == nil {
+		return true
+	}
+	for _, g := range c.Grants {
+		if g.provides(want) {
+			return true
+		}
+	}
+	return false
+}
+
+// Authenticator authenticates the caller of an RPC, returning its Claims.
+type Authenticator interface {
+	// Authenticate the caller of the RPC |ctx|. If the caller cannot be
+	// authenticated, a gRPC status error with codes.Unauthenticated is returned.
+	Authenticate(ctx context.Context) (*Claims, error)
+}
+
+// Authenticate the caller of the RPC |ctx| using Authenticator |a|. If |a| is
+// nil, authentication is disabled and a nil *Claims is returned, which allows
+// all operations.
+func Authenticate(a Authenticator, ctx context.Context) (*Claims, error) {
+	if a == nil {
+		return nil, nil
+	}
+	return a.Authenticate(ctx)
+}
+
+// NewKeyedAuthenticator returns an Authenticator of bearer tokens which are
+// JWTs signed with HMAC-SHA256 (HS256) using |key|.
+func NewKeyedAuthenticator(key []byte) Authenticator { return keyedAuthenticator(key) }
+
+type keyedAuthenticator []byte
+
+func (k keyedAuthenticator) Authenticate(ctx context.Context) (*Claims, error) {
+	var md, _ = metadata.FromIncomingContext(ctx)
+	var values = md.Get(authorizationKey)
+
+	if len(values) != 1 {
+		return nil, status.Errorf(codes.Unauthenticated, "expected one %s metadata value (got %d)",
+			authorizationKey, len(values))
+	} else if !strings.HasPrefix(values[0], bearerPrefix) {
+		return nil, status.Errorf(codes.Unauthenticated, "expected a %s token", strings.TrimSpace(bearerPrefix))
+	}
+
+	var claims = new(Claims)
+	var _, err = jwt.ParseWithClaims(values[0][len(bearerPrefix):], claims,
+		func(token *jwt.Token) (interface{}, error) {
+			if token.Method != jwt.SigningMethodHS256 {
+				return nil, fmt.Errorf("unexpected signing method %s", token.Header["alg"])
+			}
+			return []byte(k), nil
+		})
+
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid token: %s", err)
+	}
+	return claims, nil
+}
+
+// NewToken returns a bearer token of the Claims, signed with |key| using
+// HMAC-SHA256 and suitable for verification by NewKeyedAuthenticator.
+func NewToken(claims Claims, key []byte) (string, error) {
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, &claims).SignedString(key)
+}
+
+// NewBearerCredentials returns PerRPCCredentials which present |token| as the
+// bearer token of each RPC. gRPC refuses to present the credentials over a
+// connection which isn't secured by TLS, unless |allowInsecure| is set.
+func NewBearerCredentials(token string, allowInsecure bool) credentials.PerRPCCredentials {
+	return bearerCredentials{token: token, allowInsecure: allowInsecure}
+}
+
+type bearerCredentials struct {
+	token         string
+	allowInsecure bool
+}
+
+func (b bearerCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{authorizationKey: bearerPrefix + b.token}, nil
+}
+
+// RequireTransportSecurity returns true, unless insecure use was allowed.
+func (b bearerCredentials) RequireTransportSecurity() bool { return !b.allowInsecure }
+
+// WithBearerToken attaches |token| to outgoing RPCs of the returned Context.
+func WithBearerToken(ctx context.Context, token string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, authorizationKey, bearerPrefix+token)
+}
+
+// ForwardCredentials attaches the credentials presented by the caller of the
+// RPC |ctx| to outgoing RPCs of the returned Context. It's used when proxying
+// a request to a peer, which will authenticate and authorize it in turn.
+func ForwardCredentials(ctx context.Context) context.Context {
+	var md, _ = metadata.FromIncomingContext(ctx)
+
+	for _, v := range md.Get(authorizationKey) {
+		ctx = metadata.AppendToOutgoingContext(ctx, authorizationKey, v)
+	}
+	return ctx
+}
+
+// StripCredentials removes credentials presented by the caller of the RPC
+// |ctx| from the returned Context. It's used before passing |ctx| to a service
+// which has its own notion of credentials, such as Etcd: an in-process Etcd
+// client passes incoming metadata through to the Etcd server, which would
+// otherwise interpret the caller's bearer token as an Etcd auth token.
+func StripCredentials(ctx context.Context) context.Context {
+	var md, ok = metadata.FromIncomingContext(ctx)
+	if !ok || len(md.Get(authorizationKey)) == 0 {
+		return ctx
+	}
+	md = md.Copy()
+	delete(md, authorizationKey)
+	return metadata.NewIncomingContext(ctx, md)
+}
+
+const (
+	authorizationKey = "authorization"
+	bearerPrefix     = "Bearer "
+)
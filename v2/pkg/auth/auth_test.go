@@ -0,0 +1,152 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	"github.com/dgrijalva/jwt-go"
+	gc "github.com/go-check/check"
+	"google.golang.org/grpc/metadata"
+)
+
+type AuthSuite struct{}
+
+func (s *AuthSuite) TestGrantJSONRoundTrip(c *gc.C) {
+	var g Grant
+	c.Check(json.Unmarshal([]byte(`{"selector": "prefix=my/journals/, team in (red, blue)", "capabilities": ["read", "append"]}`), &g), gc.IsNil)
+
+	c.Check(g, gc.DeepEquals, Grant{
+		Selector: pb.LabelSelector{
+			Include: pb.MustLabelSet("prefix", "my/journals/", "team", "blue", "team", "red"),
+		},
+		Capabilities: []Capability{Read, Append},
+	})
+
+	var b, err = json.Marshal(g)
+	c.Check(err, gc.IsNil)
+	c.Check(string(b), gc.Equals, `{"selector":"prefix=my/journals/,team in (blue,red),","capabilities":["read","append"]}`)
+
+	// Case: invalid selectors and capabilities fail to decode.
+	c.Check(json.Unmarshal([]byte(`{"selector": "!!bad"}`), &g),
+		gc.ErrorMatches, `could not match "!!bad" to a label selector expression`)
+	c.Check(json.Unmarshal([]byte(`{"capabilities": ["read", "destroy"]}`), &g),
+		gc.ErrorMatches, `Capabilities\[1\]: invalid Capability \(destroy\)`)
+}
+
+func (s *AuthSuite) TestClaimsAllowCases(c *gc.C) {
+	var claims = &Claims{Grants: []Grant{
+		{
+			Selector:     pb.LabelSelector{Include: pb.MustLabelSet("team", "a")},
+			Capabilities: []Capability{Read, Append},
+		},
+		{
+			Selector:     pb.LabelSelector{Include: pb.MustLabelSet("prefix", "shared/")},
+			Capabilities: []Capability{Read},
+		},
+	}}
+	var teamA = pb.MustLabelSet("prefix", "team-a/", "team", "a")
+	var shared = pb.MustLabelSet("prefix", "shared/", "team", "b")
+
+	c.Check(claims.Allows(Read, teamA), gc.Equals, true)
+	c.Check(claims.Allows(Append, teamA), gc.Equals, true)
+	c.Check(claims.Allows(Apply, teamA), gc.Equals, false)
+	c.Check(claims.Allows(Read, shared), gc.Equals, true)
+	c.Check(claims.Allows(Append, shared), gc.Equals, false)
+
+	c.Check(claims.AllowsAny(Read), gc.Equals, true)
+	c.Check(claims.AllowsAny(Apply), gc.Equals, false)
+
+	// A nil *Claims allows everything.
+	claims = nil
+	c.Check(claims.Allows(Apply, teamA), gc.Equals, true)
+	c.Check(claims.AllowsAny(Apply), gc.Equals, true)
+}
+
+func (s *AuthSuite) TestKeyedAuthenticationCases(c *gc.C) {
+	var key = []byte("shared secret")
+	var a = NewKeyedAuthenticator(key)
+
+	var incoming = func(values ...string) context.Context {
+		var md = metadata.MD{}
+		for _, v := range values {
+			md.Append(authorizationKey, v)
+		}
+		return metadata.NewIncomingContext(context.Background(), md)
+	}
+	var grants = []Grant{{Capabilities: []Capability{Read}}}
+
+	// Case: valid token.
+	var token, err = NewToken(Claims{Grants: grants}, key)
+	c.Assert(err, gc.IsNil)
+
+	claims, err := a.Authenticate(incoming("Bearer " + token))
+	c.Check(err, gc.IsNil)
+	c.Check(claims.Grants, gc.DeepEquals, grants)
+
+	// Case: missing or repeated token.
+	_, err = a.Authenticate(context.Background())
+	c.Check(err, gc.ErrorMatches, `rpc error: code = Unauthenticated desc = expected one authorization metadata value \(got 0\)`)
+	_, err = a.Authenticate(incoming("Bearer "+token, "Bearer "+token))
+	c.Check(err, gc.ErrorMatches, `rpc error: code = Unauthenticated desc = expected one authorization metadata value \(got 2\)`)
+
+	// Case: not a bearer token.
+	_, err = a.Authenticate(incoming("Basic Zm9vOmJhcg=="))
+	c.Check(err, gc.ErrorMatches, `rpc error: code = Unauthenticated desc = expected a Bearer token`)
+
+	// Case: token signed with a different key.
+	token, _ = NewToken(Claims{Grants: grants}, []byte("other secret"))
+	_, err = a.Authenticate(incoming("Bearer " + token))
+	c.Check(err, gc.ErrorMatches, `rpc error: code = Unauthenticated desc = invalid token: signature is invalid`)
+
+	// Case: expired token.
+	token, _ = NewToken(Claims{
+		Grants:         grants,
+		StandardClaims: jwt.StandardClaims{ExpiresAt: time.Now().Add(-time.Minute).Unix()},
+	}, key)
+	_, err = a.Authenticate(incoming("Bearer " + token))
+	c.Check(err, gc.ErrorMatches, `rpc error: code = Unauthenticated desc = invalid token: token is expired .*`)
+
+	// Case: unsigned token.
+	token, _ = jwt.NewWithClaims(jwt.SigningMethodNone, &Claims{Grants: grants}).
+		SignedString(jwt.UnsafeAllowNoneSignatureType)
+	_, err = a.Authenticate(incoming("Bearer " + token))
+	c.Check(err, gc.ErrorMatches, `rpc error: code = Unauthenticated desc = invalid token: unexpected signing method none`)
+
+	// Case: nil Authenticator returns nil Claims.
+	claims, err = Authenticate(nil, context.Background())
+	c.Check(err, gc.IsNil)
+	c.Check(claims, gc.IsNil)
+}
+
+func (s *AuthSuite) TestCredentialPropagation(c *gc.C) {
+	var creds = NewBearerCredentials("a-token", false)
+	var md, err = creds.GetRequestMetadata(context.Background())
+	c.Check(err, gc.IsNil)
+	c.Check(md, gc.DeepEquals, map[string]string{"authorization": "Bearer a-token"})
+
+	// Credentials require TLS, unless insecure use is explicitly allowed.
+	c.Check(creds.RequireTransportSecurity(), gc.Equals, true)
+	c.Check(NewBearerCredentials("a-token", true).RequireTransportSecurity(), gc.Equals, false)
+
+	var ctx = WithBearerToken(context.Background(), "a-token")
+	out, _ := metadata.FromOutgoingContext(ctx)
+	c.Check(out.Get("authorization"), gc.DeepEquals, []string{"Bearer a-token"})
+
+	// Credentials of an incoming RPC are forwarded to outgoing RPCs.
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer b-token"))
+	out, _ = metadata.FromOutgoingContext(ForwardCredentials(ctx))
+	c.Check(out.Get("authorization"), gc.DeepEquals, []string{"Bearer b-token"})
+
+	// Or, they're stripped from the Context, leaving other metadata intact.
+	ctx = metadata.NewIncomingContext(context.Background(),
+		metadata.Pairs("authorization", "Bearer b-token", "other", "value"))
+	in, _ := metadata.FromIncomingContext(StripCredentials(ctx))
+	c.Check(in, gc.DeepEquals, metadata.Pairs("other", "value"))
+}
+
+var _ = gc.Suite(&AuthSuite{})
+
+func Test(t *testing.T) { gc.TestingT(t) }
@@ -0,0 +1,94 @@
+package broker
+
+import (
+	"context"
+
+	"github.com/LiveRamp/gazette/v2/pkg/allocator"
+	"github.com/LiveRamp/gazette/v2/pkg/auth"
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+)
+
+// Replicas dispatches the AdminServer.Replicas API.
+func (srv *Service) Replicas(ctx context.Context, req *pb.ReplicasRequest) (*pb.ReplicasResponse, error) {
+	var s = srv.resolver.state
+
+	var resp = &pb.ReplicasResponse{
+		Status: pb.Status_OK,
+		Header: pb.NewUnroutedHeader(s),
+	}
+	if err := req.Validate(); err != nil {
+		return resp, err
+	}
+	var claims, err = auth.Authenticate(srv.Authenticator, ctx)
+	if err != nil {
+		return resp, err
+	} else if !claims.AllowsAny(auth.Read) {
+		resp.Status = pb.Status_NOT_ALLOWED
+		return resp, nil
+	}
+
+	// Collect JournalSpecs, Routes, and replicas of local assignments.
+	var replicas []*replica
+
+	s.KS.Mu.RLock()
+	for _, li := range s.LocalItems {
+		var spec = li.Item.Decoded.(allocator.Item).ItemValue.(*pb.JournalSpec)
+		var labels = journalLabels(spec)
+
+		if !req.Selector.Matches(labels) || !claims.Allows(auth.Read, labels) {
+			continue
+		}
+		var rep, ok = srv.resolver.replicas[spec.Name]
+		if !ok {
+			continue // Not yet observed by the resolver.
+		}
+		var out = pb.ReplicasResponse_Replica{Spec: *spec}
+		out.Route.Init(li.Assignments)
+		out.Route.AttachEndpoints(s.KS)
+
+		resp.Replicas = append(resp.Replicas, out)
+		replicas = append(replicas, rep)
+	}
+	s.KS.Mu.RUnlock()
+
+	// Inspect the runtime state of each replica, without holding the KeySpace lock.
+	for i, rep := range replicas {
+		var out = &resp.Replicas[i]
+
+		out.Spool = rep.observer.committedSpool()
+		out.PipelineState, out.PipelineRoute, out.PipelineReadThroughRevision = pipelineStatus(rep)
+
+		var fragments, local int
+		fragments, local, out.IndexBeginOffset, out.IndexEndOffset, out.IndexRefreshTime = rep.index.Summary()
+		out.IndexFragments, out.IndexLocalFragments = int32(fragments), int32(local)
+	}
+
+	if sharedPersister != nil {
+		var spools int
+		spools, resp.PersisterQueuedBytes = sharedPersister.Backlog()
+		resp.PersisterQueuedSpools = int64(spools)
+	}
+	return resp, nil
+}
+
+// pipelineStatus returns the state of the replica pipeline. It briefly takes
+// ownership of an idle pipeline to inspect it, and reports a pipeline which is
+// currently owned by another goroutine (or by a stopped replica) as BUSY.
+func pipelineStatus(r *replica) (pb.ReplicasResponse_Replica_PipelineState, *pb.Route, int64) {
+	var pln *pipeline
+
+	select {
+	case pln = <-r.pipelineCh:
+		defer func() { r.pipelineCh <- pln }()
+	default:
+		return pb.ReplicasResponse_Replica_BUSY, nil, 0
+	}
+
+	if pln == nil {
+		return pb.ReplicasResponse_Replica_NONE, nil, 0
+	} else if pln.readThroughRev != 0 {
+		return pb.ReplicasResponse_Replica_AWAITING_REVISION, nil, pln.readThroughRev
+	}
+	var rt = pln.Route.Copy()
+	return pb.ReplicasResponse_Replica_READY, &rt, 0
+}
@@ -0,0 +1,86 @@
+package broker
+
+import (
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	gc "github.com/go-check/check"
+)
+
+type AdminSuite struct{}
+
+func (s *AdminSuite) TestReplicasCases(c *gc.C) {
+	var tf, cleanup = newTestFixture(c)
+	defer cleanup()
+
+	var broker = newTestBroker(c, tf, pb.ProcessSpec_ID{Zone: "local", Suffix: "broker"}, newReadyReplica)
+	var peer = newMockBroker(c, tf, pb.ProcessSpec_ID{Zone: "peer", Suffix: "broker"})
+
+	newTestJournal(c, tf, pb.JournalSpec{Name: "a/journal", Replication: 1}, broker.id)
+	newTestJournal(c, tf, pb.JournalSpec{Name: "b/journal", Replication: 1,
+		LabelSet: pb.MustLabelSet("foo", "bar")}, broker.id)
+	newTestJournal(c, tf, pb.JournalSpec{Name: "peer/journal", Replication: 1}, peer.id)
+
+	// Append to "a/journal", which builds its pipeline and commits to its Spool.
+	var stream, _ = broker.MustClient().Append(pb.WithDispatchDefault(tf.ctx))
+	c.Check(stream.Send(&pb.AppendRequest{Journal: "a/journal"}), gc.IsNil)
+	c.Check(stream.Send(&pb.AppendRequest{Content: []byte("foobar")}), gc.IsNil)
+	c.Check(stream.Send(&pb.AppendRequest{}), gc.IsNil)
+
+	var _, err = stream.CloseAndRecv()
+	c.Check(err, gc.IsNil)
+
+	// Case: Empty selector returns all local replicas.
+	resp, err := broker.svc.Replicas(tf.ctx, &pb.ReplicasRequest{})
+	c.Assert(err, gc.IsNil)
+	c.Check(resp.Validate(), gc.IsNil)
+	c.Check(resp.Status, gc.Equals, pb.Status_OK)
+	c.Check(resp.Header.ProcessId, gc.Equals, broker.id)
+	c.Assert(resp.Replicas, gc.HasLen, 2)
+
+	var a, b = resp.Replicas[0], resp.Replicas[1]
+	c.Check(a.Spec.Name, gc.Equals, pb.Journal("a/journal"))
+	c.Check(a.Route.Members, gc.DeepEquals, []pb.ProcessSpec_ID{broker.id})
+	c.Check(a.Route.Endpoints, gc.DeepEquals, []pb.Endpoint{broker.Endpoint()})
+	c.Check(a.Spool.Journal, gc.Equals, pb.Journal("a/journal"))
+	c.Check(a.Spool.End, gc.Equals, int64(6))
+	c.Check(a.PipelineState, gc.Equals, pb.ReplicasResponse_Replica_READY)
+	c.Check(a.PipelineRoute.Members, gc.DeepEquals, []pb.ProcessSpec_ID{broker.id})
+	c.Check(a.IndexFragments, gc.Equals, int32(1))
+	c.Check(a.IndexLocalFragments, gc.Equals, int32(1))
+	c.Check(a.IndexEndOffset, gc.Equals, int64(6))
+	c.Check(a.IndexRefreshTime.IsZero(), gc.Equals, false)
+
+	c.Check(b.Spec.Name, gc.Equals, pb.Journal("b/journal"))
+	c.Check(b.Spool, gc.DeepEquals, pb.Fragment{Journal: "b/journal", CompressionCodec: pb.CompressionCodec_NONE})
+	c.Check(b.PipelineState, gc.Equals, pb.ReplicasResponse_Replica_NONE)
+	c.Check(b.PipelineRoute, gc.IsNil)
+	c.Check(b.IndexFragments, gc.Equals, int32(0))
+
+	// Case: Selector restricts returned replicas.
+	resp, err = broker.svc.Replicas(tf.ctx, &pb.ReplicasRequest{
+		Selector: pb.LabelSelector{Include: pb.MustLabelSet("foo", "bar")},
+	})
+	c.Assert(err, gc.IsNil)
+	c.Assert(resp.Replicas, gc.HasLen, 1)
+	c.Check(resp.Replicas[0].Spec.Name, gc.Equals, pb.Journal("b/journal"))
+
+	// Case: A pipeline owned by another goroutine is BUSY.
+	tf.ks.Mu.RLock()
+	var rep = broker.replicas["a/journal"]
+	tf.ks.Mu.RUnlock()
+	var pln = <-rep.pipelineCh
+
+	resp, err = broker.svc.Replicas(tf.ctx, &pb.ReplicasRequest{
+		Selector: pb.LabelSelector{Include: pb.MustLabelSet("name", "a/journal")},
+	})
+	c.Assert(err, gc.IsNil)
+	c.Check(resp.Replicas[0].PipelineState, gc.Equals, pb.ReplicasResponse_Replica_BUSY)
+	rep.pipelineCh <- pln
+
+	// Case: Errors on request validation error.
+	_, err = broker.svc.Replicas(tf.ctx, &pb.ReplicasRequest{
+		Selector: pb.LabelSelector{Include: pb.MustLabelSet("prefix", "missing/trailing/slash")},
+	})
+	c.Check(err, gc.ErrorMatches, `Selector.Include.Labels\["prefix"\]: expected trailing '/' (.*)`)
+}
+
+var _ = gc.Suite(&AdminSuite{})
@@ -1,13 +1,17 @@
 package broker
 
 import (
+	"context"
 	"crypto/sha1"
 	"fmt"
 	"hash"
 	"io"
 
+	"github.com/LiveRamp/gazette/v2/pkg/auth"
 	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	"github.com/LiveRamp/gazette/v2/pkg/tracing"
 	log "github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
 	"google.golang.org/grpc"
 )
 
@@ -19,6 +23,10 @@ func (srv *Service) Append(stream pb.Journal_AppendServer) error {
 	} else if err = req.Validate(); err != nil {
 		return err
 	}
+	claims, err := auth.Authenticate(srv.Authenticator, stream.Context())
+	if err != nil {
+		return err
+	}
 
 	var rev int64
 
@@ -39,7 +47,7 @@ func (srv *Service) Append(stream pb.Journal_AppendServer) error {
 		} else if res.status != pb.Status_OK {
 			err = stream.SendAndClose(&pb.AppendResponse{Status: res.status, Header: &res.Header})
 			break
-		} else if !res.journalSpec.Flags.MayWrite() {
+		} else if !res.journalSpec.Flags.MayWrite() || !claims.Allows(auth.Append, journalLabels(res.journalSpec)) {
 			err = stream.SendAndClose(&pb.AppendResponse{Status: pb.Status_NOT_ALLOWED, Header: &res.Header})
 			break
 		} else if res.replica == nil {
@@ -69,11 +77,16 @@ func (srv *Service) Append(stream pb.Journal_AppendServer) error {
 	return nil
 }
 
-// proxyAppend forwards an AppendRequest to a resolved peer broker.
-func proxyAppend(stream grpc.ServerStream, req *pb.AppendRequest, jc pb.JournalClient) error {
-	var ctx = pb.WithDispatchRoute(stream.Context(), req.Header.Route, req.Header.ProcessId)
+// proxyAppend forwards an AppendRequest to a resolved peer broker. The proxied
+// RPC is traced as a child of the client's RPC.
+func proxyAppend(stream grpc.ServerStream, req *pb.AppendRequest, jc pb.JournalClient) (err error) {
+	var ctx, span = tracing.StartSpan(stream.Context(), "proxyAppend", tracing.SpanKindInternal)
+	span.SetAttribute("journal", req.Journal)
+	defer func() { span.Finish(err) }()
 
-	var client, err = jc.Append(ctx)
+	ctx = pb.WithDispatchRoute(auth.ForwardCredentials(ctx), req.Header.Route, req.Header.ProcessId)
+
+	client, err := jc.Append(ctx)
 	if err != nil {
 		return err
 	}
@@ -100,8 +113,9 @@ func serveAppend(stream pb.Journal_AppendServer, req *pb.AppendRequest, res reso
 	// The next offset written is always the furthest known journal extent.
 	// Usually this is the tracked pipeline offset, but it's possible that
 	// a larger offset exists in the fragment index.
-	var offset = pln.spool.Fragment.End
-	if eo := res.replica.index.EndOffset(); eo > offset {
+	var po, eo = pln.spool.Fragment.End, res.replica.index.EndOffset()
+	var offset = po
+	if eo > offset {
 		offset = eo
 	}
 	// If the fragment index contains a larger offset than that of the
@@ -109,19 +123,21 @@ func serveAppend(stream pb.Journal_AppendServer, req *pb.AppendRequest, res reso
 	// at some point (due to too many broker or Etcd failures). Refuse the
 	// append to prevent inadvertently writing an offset more than once,
 	// unless the request provides an explicit offset.
-	if po := pln.spool.Fragment.End; po != offset && req.Offset == 0 {
+	if po != offset && req.Offset == 0 {
 		res.replica.pipelineCh <- pln // Release |pln|.
 		return stream.SendAndClose(&pb.AppendResponse{Status: pb.Status_INDEX_HAS_GREATER_OFFSET, Header: &res.Header})
 	} else if req.Offset == 0 {
 		// Use |offset| (== |po|).
-	} else if req.Offset != offset {
-		// If a request offset is present, it must match |offset|.
+	} else if req.Offset != offset && (po != 0 || eo != 0) {
+		// If a request offset is present, it must match |offset|, unless
+		// neither the pipeline nor the index has written content. Otherwise
+		// the pipeline could be rolled backwards over written offsets.
 		res.replica.pipelineCh <- pln // Release |pln|.
 		return stream.SendAndClose(&pb.AppendResponse{Status: pb.Status_WRONG_APPEND_OFFSET, Header: &res.Header})
-	} else if po != offset {
-		// Send a proposal which rolls the pipeline forward to |offset|.
+	} else if po != req.Offset {
+		// Send a proposal which rolls the pipeline forward to the request offset.
 		var proposal = pln.spool.Fragment.Fragment
-		proposal.Begin, proposal.End, proposal.Sum = offset, offset, pb.SHA1Sum{}
+		proposal.Begin, proposal.End, proposal.Sum = req.Offset, req.Offset, pb.SHA1Sum{}
 
 		pln.scatter(&pb.ReplicateRequest{
 			Proposal:    &proposal,
@@ -131,6 +147,10 @@ func serveAppend(stream pb.Journal_AppendServer, req *pb.AppendRequest, res reso
 
 	// Forward the client's content through the pipeline.
 	var appender = beginAppending(pln, res.journalSpec.Fragment)
+	appender.ctx = stream.Context()
+	appender.maxSize = res.journalSpec.MaxAppendSize
+	appender.limiter = res.replica.appendRateLimiter(res.journalSpec.MaxAppendRate)
+
 	for appender.onRecv(stream.Recv()) {
 	}
 	addTrace(stream.Context(), "read client EOF => %s", appender)
@@ -145,7 +165,14 @@ func serveAppend(stream pb.Journal_AppendServer, req *pb.AppendRequest, res reso
 		return appender.reqErr
 	} else if err != nil {
 		return err
+	} else if appender.reqStatus != pb.Status_OK {
+		return stream.SendAndClose(&pb.AppendResponse{
+			Status: appender.reqStatus,
+			Header: &pln.Header,
+		})
 	} else {
+		res.replica.observer.appended(appender.reqFragment.ContentLength())
+
 		return stream.SendMsg(&pb.AppendResponse{
 			Header: &pln.Header,
 			Commit: appender.reqFragment,
@@ -154,13 +181,20 @@ func serveAppend(stream pb.Journal_AppendServer, req *pb.AppendRequest, res reso
 }
 
 // appender streams Append content through the pipeline, tracking the exact
-// Journal Fragment appended by the RPC and any client error.
+// Journal Fragment appended by the RPC and any client error or limit violation.
 type appender struct {
+	ctx  context.Context
 	pln  *pipeline
 	spec pb.JournalSpec_Fragment
 
+	// Maximum content length of the append, or zero if unlimited.
+	maxSize int64
+	// Limiter of the sustained append rate, or nil if unlimited.
+	limiter *rate.Limiter
+
 	reqCommit   bool
 	reqErr      error
+	reqStatus   pb.Status
 	reqFragment *pb.Fragment
 	reqSummer   hash.Hash
 }
@@ -215,15 +249,21 @@ func (a *appender) onRecv(req *pb.AppendRequest, err error) bool {
 		a.reqCommit = true
 		return true
 	} else if err == nil {
-		// Regular content chunk. Forward it through the pipeline.
-		a.pln.scatter(&pb.ReplicateRequest{
-			Content:      req.Content,
-			ContentDelta: a.reqFragment.ContentLength(),
-		})
-		_, _ = a.reqSummer.Write(req.Content) // Cannot error.
-		a.reqFragment.End += int64(len(req.Content))
-
-		return a.pln.sendErr() == nil
+		// Regular content chunk. Verify it's within configured limits,
+		// throttling as required by the append rate.
+		if a.reqStatus, err = a.checkLimits(len(req.Content)); err == nil && a.reqStatus == pb.Status_OK {
+			// Forward it through the pipeline.
+			a.pln.scatter(&pb.ReplicateRequest{
+				Content:      req.Content,
+				ContentDelta: a.reqFragment.ContentLength(),
+			})
+			_, _ = a.reqSummer.Write(req.Content) // Cannot error.
+			a.reqFragment.End += int64(len(req.Content))
+
+			return a.pln.sendErr() == nil
+		}
+		// A limit was exceeded, or the client's Context was cancelled.
+		// Fall through to roll back the append.
 	}
 
 	// We've reached end-of-input for this Append stream.
@@ -239,8 +279,9 @@ func (a *appender) onRecv(req *pb.AppendRequest, err error) bool {
 		// and commit or return an error.
 		*proposal = a.pln.spool.Next()
 	} else {
-		// A client-side read error occurred. The pipeline is still in a good
-		// state, but any partial spooled content must be rolled back.
+		// A client-side read error occurred, or the append exceeded a limit.
+		// The pipeline is still in a good state, but any partial spooled
+		// content must be rolled back.
 		*proposal = a.pln.spool.Fragment.Fragment
 
 		a.reqErr = err
@@ -254,10 +295,38 @@ func (a *appender) onRecv(req *pb.AppendRequest, err error) bool {
 	return false
 }
 
+// checkLimits returns APPEND_TOO_LARGE if a content chunk of length |n| would
+// exceed the maximum append size. Otherwise, it blocks until the chunk is
+// permitted by the sustained append rate, and returns OK. If the client's
+// deadline would pass before the chunk is permitted, RATE_LIMITED is returned.
+// An error is returned only if the client's Context is cancelled.
+func (a *appender) checkLimits(n int) (pb.Status, error) {
+	if a.maxSize != 0 && a.reqFragment.ContentLength()+int64(n) > a.maxSize {
+		return pb.Status_APPEND_TOO_LARGE, nil
+	} else if a.limiter == nil {
+		return pb.Status_OK, nil
+	}
+	// WaitN refuses requests larger than the bucket size. Wait in
+	// increments of the bucket size instead.
+	for b := a.limiter.Burst(); n != 0; {
+		var m = n
+		if m > b {
+			m = b
+		}
+		if err := a.limiter.WaitN(a.ctx, m); a.ctx.Err() != nil {
+			return pb.Status_OK, a.ctx.Err()
+		} else if err != nil {
+			return pb.Status_RATE_LIMITED, nil // Wait would exceed |ctx| deadline.
+		}
+		n -= m
+	}
+	return pb.Status_OK, nil
+}
+
 // String returns a debugging representation of the appender.
 func (a appender) String() string {
-	return fmt.Sprintf("appender<reqCommit: %t, reqErr: %v, reqFragment: %s>",
-		a.reqCommit, a.reqErr, a.reqFragment.String())
+	return fmt.Sprintf("appender<reqCommit: %t, reqErr: %v, reqStatus: %s, reqFragment: %s>",
+		a.reqCommit, a.reqErr, a.reqStatus, a.reqFragment.String())
 }
 
 // updateProposal applies JournalSpec configuration to a replicated pipeline,
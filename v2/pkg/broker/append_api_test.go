@@ -1,10 +1,13 @@
 package broker
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"io"
+	"time"
 
+	"github.com/LiveRamp/gazette/v2/pkg/auth"
 	"github.com/LiveRamp/gazette/v2/pkg/fragment"
 	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
 	gc "github.com/go-check/check"
@@ -216,6 +219,89 @@ func (s *AppendSuite) TestRollbackCases(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, `rpc error: code = Canceled desc = context canceled`)
 }
 
+func (s *AppendSuite) TestLimitCases(c *gc.C) {
+	var tf, cleanup = newTestFixture(c)
+	defer cleanup()
+
+	var broker = newTestBroker(c, tf, pb.ProcessSpec_ID{Zone: "local", Suffix: "broker"}, newReadyReplica)
+	var peer = newMockBroker(c, tf, pb.ProcessSpec_ID{Zone: "peer", Suffix: "broker"})
+
+	newTestJournal(c, tf, pb.JournalSpec{
+		Name:          "a/journal",
+		Replication:   2,
+		MaxAppendSize: 150,
+		MaxAppendRate: 100,
+	}, broker.id, peer.id)
+	var res, _ = broker.resolve(resolveArgs{ctx: tf.ctx, journal: "a/journal"})
+
+	var ctx = pb.WithDispatchDefault(tf.ctx)
+	var expectProposal = func(end int64, sum pb.SHA1Sum) {
+		c.Check(<-peer.ReplReqCh, gc.DeepEquals, &pb.ReplicateRequest{
+			Proposal: &pb.Fragment{
+				Journal:          "a/journal",
+				End:              end,
+				Sum:              sum,
+				CompressionCodec: pb.CompressionCodec_SNAPPY,
+			},
+			Acknowledge: true,
+		})
+		peer.ReplRespCh <- &pb.ReplicateResponse{Status: pb.Status_OK} // Acknowledge.
+	}
+	var chunk = bytes.Repeat([]byte("x"), 100)
+
+	// Case: append content exceeds the MaxAppendSize of the journal.
+	var stream, _ = broker.MustClient().Append(ctx)
+	c.Check(stream.Send(&pb.AppendRequest{Journal: "a/journal"}), gc.IsNil)
+	expectPipelineSync(c, peer, res.Header)
+	expectUnackedSnappyProposal(c, peer)
+
+	// The first chunk is permitted, and drains the journal's token bucket.
+	c.Check(stream.Send(&pb.AppendRequest{Content: chunk}), gc.IsNil)
+	c.Check(<-peer.ReplReqCh, gc.DeepEquals, &pb.ReplicateRequest{Content: chunk, ContentDelta: 0})
+	c.Check(stream.Send(&pb.AppendRequest{Content: chunk}), gc.IsNil)
+
+	// Expect the peer receives a rollback, and the client an error status.
+	expectProposal(0, pb.SHA1Sum{})
+
+	var resp, err = stream.CloseAndRecv()
+	c.Check(err, gc.IsNil)
+	c.Check(resp, gc.DeepEquals, &pb.AppendResponse{Status: pb.Status_APPEND_TOO_LARGE, Header: &res.Header})
+
+	// Case: append content exceeds the MaxAppendRate of the journal.
+	// Expect the append is throttled until the token bucket refills, and commits.
+	var start = time.Now()
+
+	stream, _ = broker.MustClient().Append(ctx)
+	c.Check(stream.Send(&pb.AppendRequest{Journal: "a/journal"}), gc.IsNil)
+	c.Check(stream.Send(&pb.AppendRequest{Content: chunk[:20]}), gc.IsNil)
+	c.Check(<-peer.ReplReqCh, gc.DeepEquals, &pb.ReplicateRequest{Content: chunk[:20], ContentDelta: 0})
+	c.Check(stream.Send(&pb.AppendRequest{}), gc.IsNil)
+	c.Check(stream.CloseSend(), gc.IsNil)
+
+	expectProposal(20, pb.SHA1SumOf(string(chunk[:20])))
+
+	resp, err = stream.CloseAndRecv()
+	c.Check(err, gc.IsNil)
+	c.Check(resp.Status, gc.Equals, pb.Status_OK)
+	c.Check(resp.Commit.End, gc.Equals, int64(20))
+	c.Check(time.Since(start) >= 100*time.Millisecond, gc.Equals, true)
+
+	// Case: the append cannot be permitted by the MaxAppendRate prior to the
+	// client's deadline. Expect it's rolled back with status RATE_LIMITED.
+	var deadlineCtx, cancel = context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	stream, _ = broker.MustClient().Append(deadlineCtx)
+	c.Check(stream.Send(&pb.AppendRequest{Journal: "a/journal"}), gc.IsNil)
+	c.Check(stream.Send(&pb.AppendRequest{Content: chunk}), gc.IsNil)
+
+	expectProposal(20, pb.SHA1SumOf(string(chunk[:20]))) // Rollback.
+
+	resp, err = stream.CloseAndRecv()
+	c.Check(err, gc.IsNil)
+	c.Check(resp, gc.DeepEquals, &pb.AppendResponse{Status: pb.Status_RATE_LIMITED, Header: &res.Header})
+}
+
 func (s *AppendSuite) TestAppendOffsetReset(c *gc.C) {
 	var tf, cleanup = newTestFixture(c)
 	defer cleanup()
@@ -298,6 +384,80 @@ func (s *AppendSuite) TestAppendOffsetReset(c *gc.C) {
 	})
 }
 
+func (s *AppendSuite) TestAppendOffsetOfEmptyJournal(c *gc.C) {
+	var tf, cleanup = newTestFixture(c)
+	defer cleanup()
+
+	var broker = newTestBroker(c, tf, pb.ProcessSpec_ID{Zone: "local", Suffix: "broker"}, newReadyReplica)
+	var peer = newMockBroker(c, tf, pb.ProcessSpec_ID{Zone: "peer", Suffix: "broker"})
+
+	newTestJournal(c, tf, pb.JournalSpec{Name: "a/journal", Replication: 2}, broker.id, peer.id)
+	var res, _ = broker.resolve(resolveArgs{ctx: tf.ctx, journal: "a/journal"})
+
+	// Part 1: The journal has no content. Expect a request offset rolls it forward.
+	var stream, _ = broker.MustClient().Append(pb.WithDispatchDefault(tf.ctx))
+	c.Check(stream.Send(&pb.AppendRequest{Journal: "a/journal", Offset: 1024}), gc.IsNil)
+	expectPipelineSync(c, peer, res.Header)
+
+	c.Check(<-peer.ReplReqCh, gc.DeepEquals, &pb.ReplicateRequest{
+		Proposal: &pb.Fragment{
+			Journal:          "a/journal",
+			Begin:            1024,
+			End:              1024,
+			CompressionCodec: pb.CompressionCodec_NONE,
+		},
+	})
+	c.Check(<-peer.ReplReqCh, gc.DeepEquals, &pb.ReplicateRequest{
+		Proposal: &pb.Fragment{
+			Journal:          "a/journal",
+			Begin:            1024,
+			End:              1024,
+			CompressionCodec: pb.CompressionCodec_SNAPPY,
+		},
+	})
+
+	c.Check(stream.Send(&pb.AppendRequest{Content: []byte("foobar")}), gc.IsNil)
+	c.Check(<-peer.ReplReqCh, gc.DeepEquals, &pb.ReplicateRequest{Content: []byte("foobar"), ContentDelta: 0})
+	c.Check(stream.Send(&pb.AppendRequest{}), gc.IsNil)
+	c.Check(stream.CloseSend(), gc.IsNil)
+
+	var expectedFragment = &pb.Fragment{
+		Journal:          "a/journal",
+		Begin:            1024,
+		End:              1030,
+		Sum:              pb.SHA1SumOf("foobar"),
+		CompressionCodec: pb.CompressionCodec_SNAPPY,
+	}
+	c.Check(<-peer.ReplReqCh, gc.DeepEquals, &pb.ReplicateRequest{
+		Proposal:    expectedFragment,
+		Acknowledge: true,
+	})
+	peer.ReplRespCh <- &pb.ReplicateResponse{Status: pb.Status_OK} // Acknowledge.
+
+	var resp, err = stream.CloseAndRecv()
+	c.Check(err, gc.IsNil)
+	c.Check(resp, gc.DeepEquals, &pb.AppendResponse{
+		Status: pb.Status_OK,
+		Header: &res.Header,
+		Commit: expectedFragment,
+	})
+
+	// Part 2: The journal now has content. Expect mismatched offsets are
+	// refused, whether beyond or behind the written offset.
+	for _, offset := range []int64{2048, 1024, 512} {
+		stream, _ = broker.MustClient().Append(pb.WithDispatchDefault(tf.ctx))
+		c.Check(stream.Send(&pb.AppendRequest{Journal: "a/journal", Offset: offset}), gc.IsNil)
+		c.Check(stream.Send(&pb.AppendRequest{}), gc.IsNil)
+
+		resp, err = stream.CloseAndRecv()
+		c.Check(err, gc.IsNil)
+		c.Check(resp, gc.DeepEquals, &pb.AppendResponse{
+			Status: pb.Status_WRONG_APPEND_OFFSET,
+			Header: &res.Header,
+		})
+	}
+}
+
 func (s *AppendSuite) TestRequestErrorCases(c *gc.C) {
 	var tf, cleanup = newTestFixture(c)
 	defer cleanup()
@@ -372,6 +532,19 @@ func (s *AppendSuite) TestRequestErrorCases(c *gc.C) {
 	resp, err = stream.CloseAndRecv()
 	c.Check(err, gc.IsNil)
 	c.Check(resp, gc.DeepEquals, &pb.AppendResponse{Status: pb.Status_WRONG_APPEND_OFFSET, Header: &res.Header})
+
+	// Case: Journal which the caller may read, but not append to.
+	broker.svc.Authenticator = auth.NewKeyedAuthenticator([]byte("secret"))
+	token, _ := auth.NewToken(auth.Claims{Grants: []auth.Grant{
+		{Capabilities: []auth.Capability{auth.Read}},
+	}}, []byte("secret"))
+
+	stream, _ = broker.MustClient().Append(auth.WithBearerToken(ctx, token))
+	c.Check(stream.Send(&pb.AppendRequest{Journal: "valid/journal"}), gc.IsNil)
+
+	resp, err = stream.CloseAndRecv()
+	c.Check(err, gc.IsNil)
+	c.Check(resp, gc.DeepEquals, &pb.AppendResponse{Status: pb.Status_NOT_ALLOWED, Header: &res.Header})
 }
 
 func (s *AppendSuite) TestProxyCases(c *gc.C) {
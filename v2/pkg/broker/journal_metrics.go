@@ -0,0 +1,218 @@
+package broker
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/LiveRamp/gazette/v2/pkg/fragment"
+	"github.com/LiveRamp/gazette/v2/pkg/metrics"
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+)
+
+// journalMetrics maps JournalSpecs to the partitions under which their
+// metrics are collected, bounding the number of distinct partitions.
+type journalMetrics struct {
+	label         string // "name", or a JournalSpec label name.
+	maxPartitions int
+
+	mu         sync.Mutex
+	partitions map[string]struct{}
+}
+
+// partition returns the metrics partition of the JournalSpec, or empty
+// if journal metrics are not enabled.
+func (m *journalMetrics) partition(spec *pb.JournalSpec) string {
+	if m == nil {
+		return ""
+	}
+	var p = m.key(spec)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.partitions[p]; ok {
+		return p
+	} else if len(m.partitions) < m.maxPartitions {
+		m.partitions[p] = struct{}{}
+		return p
+	}
+	return otherJournalPartition
+}
+
+// retain evicts partitions other than those of |specs| (the JournalSpecs of
+// local replicas), and deletes their collected metrics. Evicted partitions no
+// longer count towards |maxPartitions|.
+func (m *journalMetrics) retain(specs []*pb.JournalSpec) {
+	if m == nil {
+		return
+	}
+	var keep = make(map[string]struct{}, len(specs))
+	for _, spec := range specs {
+		keep[m.key(spec)] = struct{}{}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for p := range m.partitions {
+		if _, ok := keep[p]; ok {
+			continue
+		}
+		delete(m.partitions, p)
+
+		metrics.JournalActiveReads.DeleteLabelValues(p)
+		metrics.JournalAppendBytesTotal.DeleteLabelValues(p)
+		metrics.JournalAppendsTotal.DeleteLabelValues(p)
+		metrics.JournalPersistDurationSeconds.DeleteLabelValues(p)
+		metrics.JournalReadBytesTotal.DeleteLabelValues(p)
+		metrics.JournalSpoolBytes.DeleteLabelValues(p)
+	}
+}
+
+// has returns whether partition |p| is currently collected.
+func (m *journalMetrics) has(p string) bool {
+	if m == nil {
+		return false
+	} else if p == otherJournalPartition {
+		return true
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var _, ok = m.partitions[p]
+	return ok
+}
+
+// key returns the partition of the JournalSpec, ignoring |maxPartitions|.
+func (m *journalMetrics) key(spec *pb.JournalSpec) string {
+	if m.label == "name" {
+		return spec.Name.String()
+	} else if v := spec.LabelSet.ValuesOf(m.label); len(v) != 0 && v[0] != "" {
+		return v[0]
+	}
+	return unlabeledJournalPartition
+}
+
+// replicaObserver observes the Spool, Appends, and Reads of a replica. It
+// indexes and persists the replica Spool, tracks its last committed Fragment,
+// and records metrics of the replica under the partition of its current
+// JournalSpec.
+type replicaObserver struct {
+	index     *fragment.Index
+	persister *fragment.Persister
+
+	partition atomic.Value // Partition of the replica's JournalSpec.
+	spool     atomic.Value // pb.Fragment of the Spool, as of its last commit.
+
+	// Spool content length and partition, as last recorded.
+	// Guarded by ownership of the Spool.
+	spoolBytes     int64
+	spoolPartition string
+}
+
+func newReplicaObserver(journal pb.Journal, index *fragment.Index, persister *fragment.Persister) *replicaObserver {
+	var o = &replicaObserver{index: index, persister: persister}
+	o.partition.Store("")
+	o.spool.Store(pb.Fragment{Journal: journal, CompressionCodec: pb.CompressionCodec_NONE})
+	return o
+}
+
+// committedSpool returns the Fragment of the replica Spool, as of its last commit.
+func (o *replicaObserver) committedSpool() pb.Fragment { return o.spool.Load().(pb.Fragment) }
+
+// setSpec updates the replica's metrics partition from its JournalSpec.
+func (o *replicaObserver) setSpec(spec *pb.JournalSpec) {
+	o.partition.Store(sharedJournalMetrics.partition(spec))
+}
+
+// appended records a committed Append of |bytes| to the replica.
+func (o *replicaObserver) appended(bytes int64) {
+	if p := o.partition.Load().(string); p != "" {
+		metrics.JournalAppendsTotal.WithLabelValues(p).Inc()
+		metrics.JournalAppendBytesTotal.WithLabelValues(p).Add(float64(bytes))
+	}
+}
+
+// beginRead records the start of a Read of the replica. It returns a closure
+// which records content bytes sent by the Read, and another which must be
+// called when the Read completes.
+func (o *replicaObserver) beginRead() (sent func(bytes int), end func()) {
+	var p = o.partition.Load().(string)
+	if p == "" {
+		return func(int) {}, func() {}
+	}
+	var readBytes = metrics.JournalReadBytesTotal.WithLabelValues(p)
+	metrics.JournalActiveReads.WithLabelValues(p).Inc()
+
+	return func(bytes int) { readBytes.Add(float64(bytes)) },
+		func() {
+			// Don't re-create the gauge of a partition evicted during the Read.
+			if sharedJournalMetrics.has(p) {
+				metrics.JournalActiveReads.WithLabelValues(p).Dec()
+			}
+		}
+}
+
+// SpoolCommit indexes the committed Fragment, and records the Spool
+// content length. fragment.SpoolObserver implementation.
+func (o *replicaObserver) SpoolCommit(frag fragment.Fragment) {
+	o.index.SpoolCommit(frag)
+	o.spool.Store(frag.Fragment)
+	o.recordSpoolBytes(frag.ContentLength())
+}
+
+// SpoolComplete persists the completed Spool, and records that the
+// replica no longer has Spool content. fragment.SpoolObserver implementation.
+func (o *replicaObserver) SpoolComplete(spool fragment.Spool, primary bool) {
+	var frag = spool.Fragment.Fragment
+	frag.Begin, frag.Sum = frag.End, pb.SHA1Sum{}
+	o.spool.Store(frag)
+	o.recordSpoolBytes(0)
+	o.persister.SpoolComplete(spool, primary)
+}
+
+// SpoolPersisted records the duration of persisting a Spool.
+// fragment.PersistObserver implementation.
+func (o *replicaObserver) SpoolPersisted(_ fragment.Spool, took time.Duration) {
+	if p := o.partition.Load().(string); p != "" {
+		metrics.JournalPersistDurationSeconds.WithLabelValues(p).Observe(took.Seconds())
+	}
+}
+
+func (o *replicaObserver) recordSpoolBytes(bytes int64) {
+	// Partitions may aggregate many journals, so adjust by our last recorded
+	// length rather than setting the length outright. If the partition has
+	// since been evicted, its gauge was deleted and there's nothing to adjust.
+	if o.spoolPartition != "" && o.spoolBytes != 0 && sharedJournalMetrics.has(o.spoolPartition) {
+		metrics.JournalSpoolBytes.WithLabelValues(o.spoolPartition).Sub(float64(o.spoolBytes))
+	}
+	var p = o.partition.Load().(string)
+	if p != "" && bytes != 0 {
+		metrics.JournalSpoolBytes.WithLabelValues(p).Add(float64(bytes))
+	}
+	o.spoolBytes, o.spoolPartition = bytes, p
+}
+
+var sharedJournalMetrics *journalMetrics
+
+// SetJournalMetrics enables metrics of journals served by the `broker` package.
+// Metrics are partitioned on journal name if |label| is "name", or otherwise
+// on the value of the |label| JournalSpec label (eg, "topic"). At most
+// |maxPartitions| partitions are collected: further partitions are collected
+// together as partition "_other". It must be called before brokers begin
+// serving journals.
+func SetJournalMetrics(label string, maxPartitions int) {
+	sharedJournalMetrics = &journalMetrics{
+		label:         label,
+		maxPartitions: maxPartitions,
+		partitions:    make(map[string]struct{}),
+	}
+}
+
+const (
+	// Partition of journals which don't have the partitioned JournalSpec label.
+	unlabeledJournalPartition = "_none"
+	// Partition of journals beyond the maximum number of partitions.
+	otherJournalPartition = "_other"
+)
@@ -0,0 +1,76 @@
+package broker
+
+import (
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	gc "github.com/go-check/check"
+)
+
+type JournalMetricsSuite struct{}
+
+func (s *JournalMetricsSuite) TestPartitionCases(c *gc.C) {
+	var spec = func(name string, labels ...string) *pb.JournalSpec {
+		return &pb.JournalSpec{Name: pb.Journal(name), LabelSet: pb.MustLabelSet(labels...)}
+	}
+
+	// Case: journal metrics are not enabled.
+	var m *journalMetrics
+	c.Check(m.partition(spec("a/journal")), gc.Equals, "")
+
+	// Case: partitioned on journal name.
+	m = &journalMetrics{label: "name", maxPartitions: 2, partitions: make(map[string]struct{})}
+	c.Check(m.partition(spec("a/journal")), gc.Equals, "a/journal")
+	c.Check(m.partition(spec("b/journal")), gc.Equals, "b/journal")
+	// Expect partitions beyond |maxPartitions| are collected together.
+	c.Check(m.partition(spec("c/journal")), gc.Equals, "_other")
+	// But previously seen partitions are retained.
+	c.Check(m.partition(spec("a/journal")), gc.Equals, "a/journal")
+
+	// Case: partitioned on a JournalSpec label.
+	m = &journalMetrics{label: "topic", maxPartitions: 2, partitions: make(map[string]struct{})}
+	c.Check(m.partition(spec("a/journal", "topic", "clicks")), gc.Equals, "clicks")
+	c.Check(m.partition(spec("b/journal", "topic", "clicks")), gc.Equals, "clicks")
+	c.Check(m.partition(spec("c/journal", "other", "label")), gc.Equals, "_none")
+	c.Check(m.partition(spec("d/journal", "topic", "views")), gc.Equals, "_other")
+}
+
+func (s *JournalMetricsSuite) TestRetainEvictsPartitions(c *gc.C) {
+	var spec = func(name string, labels ...string) *pb.JournalSpec {
+		return &pb.JournalSpec{Name: pb.Journal(name), LabelSet: pb.MustLabelSet(labels...)}
+	}
+	var a, b, c2 = spec("a/journal", "topic", "clicks"), spec("b/journal", "topic", "views"), spec("c/journal", "topic", "buys")
+
+	// Case: journal metrics are not enabled.
+	var m *journalMetrics
+	m.retain([]*pb.JournalSpec{a})
+
+	m = &journalMetrics{label: "topic", maxPartitions: 2, partitions: make(map[string]struct{})}
+	c.Check(m.partition(a), gc.Equals, "clicks")
+	c.Check(m.partition(b), gc.Equals, "views")
+	c.Check(m.partition(c2), gc.Equals, "_other")
+
+	// Journal |b| is no longer local. Expect its partition is evicted,
+	// and that |c2| may now be partitioned.
+	m.retain([]*pb.JournalSpec{a, c2})
+	c.Check(m.has("clicks"), gc.Equals, true)
+	c.Check(m.has("views"), gc.Equals, false)
+	c.Check(m.has("_other"), gc.Equals, true)
+
+	c.Check(m.partition(c2), gc.Equals, "buys")
+	c.Check(m.partition(b), gc.Equals, "_other")
+}
+
+func (s *JournalMetricsSuite) TestObserverPartitionTracksSpec(c *gc.C) {
+	defer func(m *journalMetrics) { sharedJournalMetrics = m }(sharedJournalMetrics)
+
+	var r = newReplica("a/journal")
+	c.Check(r.observer.partition.Load(), gc.Equals, "")
+
+	SetJournalMetrics("topic", 10)
+	r.observer.setSpec(&pb.JournalSpec{Name: "a/journal", LabelSet: pb.MustLabelSet("topic", "clicks")})
+	c.Check(r.observer.partition.Load(), gc.Equals, "clicks")
+
+	r.observer.setSpec(&pb.JournalSpec{Name: "a/journal", LabelSet: pb.MustLabelSet("topic", "views")})
+	c.Check(r.observer.partition.Load(), gc.Equals, "views")
+}
+
+var _ = gc.Suite(&JournalMetricsSuite{})
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/LiveRamp/gazette/v2/pkg/allocator"
+	"github.com/LiveRamp/gazette/v2/pkg/auth"
 	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
 	"github.com/coreos/etcd/clientv3"
 )
@@ -20,6 +21,13 @@ func (srv *Service) List(ctx context.Context, req *pb.ListRequest) (*pb.ListResp
 	if err := req.Validate(); err != nil {
 		return resp, err
 	}
+	var claims, err = auth.Authenticate(srv.Authenticator, ctx)
+	if err != nil {
+		return resp, err
+	} else if !claims.AllowsAny(auth.Read) {
+		resp.Status = pb.Status_NOT_ALLOWED
+		return resp, nil
+	}
 
 	// TODO(johnny): Implement support for PageLimit & PageToken.
 
@@ -44,7 +52,7 @@ func (srv *Service) List(ctx context.Context, req *pb.ListRequest) (*pb.ListResp
 		metaLabels = pb.ExtractJournalSpecMetaLabels(&journal.Spec, metaLabels)
 		allLabels = pb.UnionLabelSets(metaLabels, journal.Spec.LabelSet, allLabels)
 
-		if !req.Selector.Matches(allLabels) {
+		if !req.Selector.Matches(allLabels) || !claims.Allows(auth.Read, allLabels) {
 			continue
 		}
 		journal.ModRevision = s.Items[cur.Left].Raw.ModRevision
@@ -67,6 +75,13 @@ func (srv *Service) Apply(ctx context.Context, req *pb.ApplyRequest) (*pb.ApplyR
 	if err := req.Validate(); err != nil {
 		return resp, err
 	}
+	var claims, err = auth.Authenticate(srv.Authenticator, ctx)
+	if err != nil {
+		return resp, err
+	} else if !applyAllowed(claims, s, req) {
+		resp.Status = pb.Status_NOT_ALLOWED
+		return resp, nil
+	}
 
 	var cmp []clientv3.Cmp
 	var ops []clientv3.Op
@@ -84,6 +99,9 @@ func (srv *Service) Apply(ctx context.Context, req *pb.ApplyRequest) (*pb.ApplyR
 		cmp = append(cmp, clientv3.Compare(clientv3.ModRevision(key), "=", change.ExpectModRevision))
 	}
 
+	// The caller's credentials are not Etcd credentials.
+	ctx = auth.StripCredentials(ctx)
+
 	if txnResp, err := srv.etcd.Do(ctx, clientv3.OpTxn(cmp, ops, nil)); err != nil {
 		return resp, err
 	} else if !txnResp.Txn().Succeeded {
@@ -96,3 +114,31 @@ func (srv *Service) Apply(ctx context.Context, req *pb.ApplyRequest) (*pb.ApplyR
 	}
 	return resp, nil
 }
+
+// applyAllowed returns whether the Claims allow all changes of the ApplyRequest.
+// Both the current JournalSpec (if any) and its upserted replacement must be
+// allowed, so that callers may neither modify journals outside of their grants
+// nor re-label journals into or out of them.
+func applyAllowed(claims *auth.Claims, s *allocator.State, req *pb.ApplyRequest) bool {
+	defer s.KS.Mu.RUnlock()
+	s.KS.Mu.RLock()
+
+	for _, change := range req.Changes {
+		var name = change.Delete
+
+		if change.Upsert != nil {
+			if !claims.Allows(auth.Apply, journalLabels(change.Upsert)) {
+				return false
+			}
+			name = change.Upsert.Name
+		}
+		if ind, ok := s.Items.Search(allocator.ItemKey(s.KS, name.String())); ok {
+			var spec = s.Items[ind].Decoded.(allocator.Item).ItemValue.(*pb.JournalSpec)
+
+			if !claims.Allows(auth.Apply, journalLabels(spec)) {
+				return false
+			}
+		}
+	}
+	return true
+}
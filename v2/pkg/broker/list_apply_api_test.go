@@ -1,9 +1,11 @@
 package broker
 
 import (
+	"context"
 	"time"
 
 	"github.com/LiveRamp/gazette/v2/pkg/allocator"
+	"github.com/LiveRamp/gazette/v2/pkg/auth"
 	"github.com/LiveRamp/gazette/v2/pkg/etcdtest"
 	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
 	gc "github.com/go-check/check"
@@ -208,4 +210,112 @@ func (s *ListApplySuite) TestApplyCases(c *gc.C) {
 	etcdtest.Cleanup() // We wrote keys outside of |bk|'s lease, and must manually cleanup.
 }
 
+func (s *ListApplySuite) TestAuthorizationCases(c *gc.C) {
+	var tf, cleanup = newTestFixture(c)
+	defer cleanup()
+
+	var fragSpec = pb.JournalSpec_Fragment{
+		Length:           1024,
+		RefreshInterval:  time.Second,
+		CompressionCodec: pb.CompressionCodec_SNAPPY,
+	}
+	var specA = pb.JournalSpec{
+		Name:        "team/a/journal",
+		LabelSet:    pb.MustLabelSet("team", "alpha"),
+		Replication: 1,
+		Fragment:    fragSpec,
+	}
+	var specB = pb.JournalSpec{
+		Name:        "team/b/journal",
+		LabelSet:    pb.MustLabelSet("team", "beta"),
+		Replication: 1,
+		Fragment:    fragSpec,
+	}
+
+	var key = []byte("shared secret")
+	var bk = newTestBroker(c, tf, pb.ProcessSpec_ID{Zone: "local", Suffix: "broker"}, newReplica)
+	bk.svc.Authenticator = auth.NewKeyedAuthenticator(key)
+	var jc = bk.MustClient()
+
+	var withGrants = func(grants ...auth.Grant) context.Context {
+		var token, err = auth.NewToken(auth.Claims{Grants: grants}, key)
+		c.Assert(err, gc.IsNil)
+		return auth.WithBearerToken(pb.WithDispatchDefault(tf.ctx), token)
+	}
+	var teamA = pb.LabelSelector{Include: pb.MustLabelSet("team", "alpha")}
+	var adminCtx = withGrants(auth.Grant{Capabilities: []auth.Capability{auth.Read, auth.Apply}})
+	var teamACtx = withGrants(auth.Grant{Selector: teamA, Capabilities: []auth.Capability{auth.Read, auth.Apply}})
+	var appendCtx = withGrants(auth.Grant{Capabilities: []auth.Capability{auth.Append}})
+
+	// Case: requests without a token are not authenticated.
+	var _, err = jc.List(pb.WithDispatchDefault(tf.ctx), &pb.ListRequest{})
+	c.Check(err, gc.ErrorMatches, `rpc error: code = Unauthenticated desc = expected one authorization metadata value \(got 0\)`)
+
+	// Case: tokens signed with another key are not authenticated.
+	token, _ := auth.NewToken(auth.Claims{}, []byte("other secret"))
+	_, err = jc.List(auth.WithBearerToken(pb.WithDispatchDefault(tf.ctx), token), &pb.ListRequest{})
+	c.Check(err, gc.ErrorMatches, `rpc error: code = Unauthenticated desc = invalid token: signature is invalid`)
+
+	// Case: an unrestricted grant may apply journals of both teams.
+	applyResp, err := jc.Apply(adminCtx, &pb.ApplyRequest{
+		Changes: []pb.ApplyRequest_Change{{Upsert: &specA}, {Upsert: &specB}},
+	})
+	c.Assert(err, gc.IsNil)
+	c.Check(applyResp.Status, gc.Equals, pb.Status_OK)
+
+	// Case: listing returns only journals selected by the caller's grants.
+	listResp, err := jc.List(teamACtx, &pb.ListRequest{})
+	c.Assert(err, gc.IsNil)
+	c.Check(listResp.Status, gc.Equals, pb.Status_OK)
+	c.Assert(listResp.Journals, gc.HasLen, 1)
+	c.Check(listResp.Journals[0].Spec, gc.DeepEquals, specA)
+	var revA = listResp.Journals[0].ModRevision
+
+	// Case: a caller without any read grant may not list.
+	listResp, err = jc.List(appendCtx, &pb.ListRequest{})
+	c.Assert(err, gc.IsNil)
+	c.Check(listResp.Status, gc.Equals, pb.Status_NOT_ALLOWED)
+	c.Check(listResp.Journals, gc.IsNil)
+
+	// Case: team A may update its own journal.
+	var updatedA = specA
+	updatedA.Replication = 2
+
+	applyResp, err = jc.Apply(teamACtx, &pb.ApplyRequest{
+		Changes: []pb.ApplyRequest_Change{
+			{Upsert: &updatedA, ExpectModRevision: revA},
+		},
+	})
+	c.Assert(err, gc.IsNil)
+	c.Check(applyResp.Status, gc.Equals, pb.Status_OK)
+
+	// Case: team A may not delete journals of team B.
+	applyResp, err = jc.Apply(teamACtx, &pb.ApplyRequest{
+		Changes: []pb.ApplyRequest_Change{{Delete: specB.Name, ExpectModRevision: 1}},
+	})
+	c.Assert(err, gc.IsNil)
+	c.Check(applyResp.Status, gc.Equals, pb.Status_NOT_ALLOWED)
+
+	// Case: team A may not re-label its journal out of its grants.
+	updatedA.LabelSet = pb.MustLabelSet("team", "beta")
+
+	applyResp, err = jc.Apply(teamACtx, &pb.ApplyRequest{
+		Changes: []pb.ApplyRequest_Change{{Upsert: &updatedA, ExpectModRevision: 1}},
+	})
+	c.Assert(err, gc.IsNil)
+	c.Check(applyResp.Status, gc.Equals, pb.Status_NOT_ALLOWED)
+
+	// Case: nor may it re-label a journal of team B into its grants.
+	var updatedB = specB
+	updatedB.LabelSet = pb.MustLabelSet("team", "alpha")
+
+	applyResp, err = jc.Apply(teamACtx, &pb.ApplyRequest{
+		Changes: []pb.ApplyRequest_Change{{Upsert: &updatedB, ExpectModRevision: 1}},
+	})
+	c.Assert(err, gc.IsNil)
+	c.Check(applyResp.Status, gc.Equals, pb.Status_NOT_ALLOWED)
+
+	etcdtest.Cleanup() // We wrote keys outside of |bk|'s lease, and must manually cleanup.
+}
+
 var _ = gc.Suite(&ListApplySuite{})
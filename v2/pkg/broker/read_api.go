@@ -5,9 +5,11 @@ import (
 	"io"
 	"io/ioutil"
 
+	"github.com/LiveRamp/gazette/v2/pkg/auth"
 	"github.com/LiveRamp/gazette/v2/pkg/client"
 	"github.com/LiveRamp/gazette/v2/pkg/fragment"
 	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	"github.com/LiveRamp/gazette/v2/pkg/tracing"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 )
@@ -17,8 +19,12 @@ func (svc *Service) Read(req *pb.ReadRequest, stream pb.Journal_ReadServer) erro
 	if err := req.Validate(); err != nil {
 		return err
 	}
+	var claims, err = auth.Authenticate(svc.Authenticator, stream.Context())
+	if err != nil {
+		return err
+	}
 
-	var res, err = svc.resolver.resolve(resolveArgs{
+	res, err := svc.resolver.resolve(resolveArgs{
 		ctx:                   stream.Context(),
 		journal:               req.Journal,
 		mayProxy:              !req.DoNotProxy,
@@ -31,14 +37,18 @@ func (svc *Service) Read(req *pb.ReadRequest, stream pb.Journal_ReadServer) erro
 		return err
 	} else if res.status != pb.Status_OK {
 		return stream.Send(&pb.ReadResponse{Status: res.status, Header: &res.Header})
-	} else if !res.journalSpec.Flags.MayRead() {
+	} else if !res.journalSpec.Flags.MayRead() || !claims.Allows(auth.Read, journalLabels(res.journalSpec)) {
 		return stream.Send(&pb.ReadResponse{Status: pb.Status_NOT_ALLOWED, Header: &res.Header})
 	} else if res.replica == nil {
 		req.Header = &res.Header // Attach resolved Header to |req|, which we'll forward.
 		return proxyRead(stream, req, svc.jc)
 	}
 
-	if err = serveRead(stream, req, &res.Header, res.replica.index); err == context.Canceled {
+	var sent, end = res.replica.observer.beginRead()
+	err = serveRead(stream, req, &res.Header, res.replica.index, sent)
+	end()
+
+	if err == context.Canceled {
 		err = nil // Gracefully terminate RPC.
 	} else if err != nil {
 		log.WithFields(log.Fields{"err": err, "req": req}).Warn("failed to serve Read")
@@ -46,11 +56,16 @@ func (svc *Service) Read(req *pb.ReadRequest, stream pb.Journal_ReadServer) erro
 	return err
 }
 
-// proxyRead forwards a ReadRequest to a resolved peer broker.
-func proxyRead(stream grpc.ServerStream, req *pb.ReadRequest, jc pb.JournalClient) error {
-	var ctx = pb.WithDispatchRoute(stream.Context(), req.Header.Route, req.Header.ProcessId)
+// proxyRead forwards a ReadRequest to a resolved peer broker. The proxied RPC
+// is traced as a child of the client's RPC.
+func proxyRead(stream grpc.ServerStream, req *pb.ReadRequest, jc pb.JournalClient) (err error) {
+	var ctx, span = tracing.StartSpan(stream.Context(), "proxyRead", tracing.SpanKindInternal)
+	span.SetAttribute("journal", req.Journal)
+	defer func() { span.Finish(err) }()
+
+	ctx = pb.WithDispatchRoute(auth.ForwardCredentials(ctx), req.Header.Route, req.Header.ProcessId)
 
-	var client, err = jc.Read(ctx, req)
+	client, err := jc.Read(ctx, req)
 	if err != nil {
 		return err
 	} else if err = client.CloseSend(); err != nil {
@@ -71,7 +86,8 @@ func proxyRead(stream grpc.ServerStream, req *pb.ReadRequest, jc pb.JournalClien
 }
 
 // serveRead evaluates a client's Read RPC against the local replica index.
-func serveRead(stream grpc.ServerStream, req *pb.ReadRequest, hdr *pb.Header, index *fragment.Index) error {
+// |sent| is called with the length of each chunk of content sent to the client.
+func serveRead(stream grpc.ServerStream, req *pb.ReadRequest, hdr *pb.Header, index *fragment.Index, sent func(int)) error {
 	var buffer = make([]byte, chunkSize)
 	var reader io.ReadCloser
 
@@ -126,6 +142,7 @@ func serveRead(stream grpc.ServerStream, req *pb.ReadRequest, hdr *pb.Header, in
 				return err
 			}
 			req.Offset += int64(n)
+			sent(n)
 		}
 
 		if readErr != io.EOF {
@@ -8,9 +8,11 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/LiveRamp/gazette/v2/pkg/auth"
 	"github.com/LiveRamp/gazette/v2/pkg/codecs"
 	"github.com/LiveRamp/gazette/v2/pkg/fragment"
 	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	"github.com/LiveRamp/gazette/v2/pkg/tracing"
 	gc "github.com/go-check/check"
 )
 
@@ -247,6 +249,61 @@ func (s *ReadSuite) TestProxyCases(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, `rpc error: code = Unknown desc = some kind of error`)
 }
 
+func (s *ReadSuite) TestProxyPropagatesTraceContext(c *gc.C) {
+	var tf, cleanup = newTestFixture(c)
+	defer cleanup()
+
+	var exporter = make(spanExporter, 16)
+	tracing.SetExporter(exporter)
+	defer tracing.SetExporter(nil)
+
+	var broker = newTestBroker(c, tf, pb.ProcessSpec_ID{Zone: "local", Suffix: "broker"}, newReplica)
+	var peer = newMockBroker(c, tf, pb.ProcessSpec_ID{Zone: "peer", Suffix: "broker"})
+	newTestJournal(c, tf, pb.JournalSpec{Name: "a/journal", Replication: 1}, peer.id)
+
+	var ctx, caller = tracing.StartSpan(pb.WithDispatchDefault(tf.ctx), "caller", tracing.SpanKindInternal)
+	var stream, _ = broker.MustClient().Read(ctx, &pb.ReadRequest{Journal: "a/journal"})
+
+	<-peer.ReadReqCh
+	peer.ReadRespCh <- &pb.ReadResponse{Offset: 1234}
+	peer.ErrCh <- nil
+
+	expectReadResponse(c, stream, pb.ReadResponse{Offset: 1234})
+	var _, err = stream.Recv()
+	c.Check(err, gc.Equals, io.EOF)
+
+	// Expect Spans of the client and broker RPCs, the proxy, and the peer RPC.
+	// Each is a child of the last, and all share the caller's trace.
+	var spans []*tracing.Span
+	for i := 0; i != 5; i++ {
+		spans = append(spans, <-exporter)
+	}
+	var parent = caller
+	for _, expect := range []struct {
+		name string
+		kind tracing.SpanKind
+	}{
+		{"/protocol.Journal/Read", tracing.SpanKindClient},
+		{"/protocol.Journal/Read", tracing.SpanKindServer},
+		{"proxyRead", tracing.SpanKindInternal},
+		{"/protocol.Journal/Read", tracing.SpanKindClient},
+		{"/protocol.Journal/Read", tracing.SpanKindServer},
+	} {
+		var span = findChild(spans, parent)
+		c.Assert(span, gc.NotNil)
+
+		c.Check(span.Name, gc.Equals, expect.name)
+		c.Check(span.Kind, gc.Equals, expect.kind)
+		c.Check(span.TraceID, gc.Equals, caller.TraceID)
+		c.Check(span.Err(), gc.IsNil)
+
+		if span.Name == "proxyRead" {
+			c.Check(span.Attributes(), gc.DeepEquals, map[string]string{"journal": "a/journal"})
+		}
+		parent = span
+	}
+}
+
 func (s *ReadSuite) TestRemoteFragmentCases(c *gc.C) {
 	var tf, cleanup = newTestFixture(c)
 	defer cleanup()
@@ -347,6 +404,30 @@ func (s *ReadSuite) TestRequestErrorCases(c *gc.C) {
 	resp, err := stream.Recv()
 	c.Assert(err, gc.IsNil)
 	c.Check(resp, gc.DeepEquals, &pb.ReadResponse{Status: pb.Status_NOT_ALLOWED, Header: &res.Header})
+
+	// Case: Read of a journal which the caller isn't granted.
+	broker.svc.Authenticator = auth.NewKeyedAuthenticator([]byte("secret"))
+	token, _ := auth.NewToken(auth.Claims{Grants: []auth.Grant{{
+		Selector:     pb.LabelSelector{Include: pb.MustLabelSet("prefix", "granted/")},
+		Capabilities: []auth.Capability{auth.Read},
+	}}}, []byte("secret"))
+
+	newTestJournal(c, tf, pb.JournalSpec{Name: "not/granted", Replication: 1}, broker.id)
+	res, _ = broker.resolve(resolveArgs{ctx: tf.ctx, journal: "not/granted"})
+
+	stream, err = broker.MustClient().Read(auth.WithBearerToken(ctx, token), &pb.ReadRequest{Journal: "not/granted"})
+	c.Assert(err, gc.IsNil)
+
+	resp, err = stream.Recv()
+	c.Assert(err, gc.IsNil)
+	c.Check(resp, gc.DeepEquals, &pb.ReadResponse{Status: pb.Status_NOT_ALLOWED, Header: &res.Header})
+
+	// Case: Read without a token.
+	stream, err = broker.MustClient().Read(ctx, &pb.ReadRequest{Journal: "not/granted"})
+	c.Assert(err, gc.IsNil)
+
+	_, err = stream.Recv()
+	c.Check(err, gc.ErrorMatches, `rpc error: code = Unauthenticated desc = .*`)
 }
 
 func buildRemoteFragmentFixture(c *gc.C) (frag pb.Fragment, dir string) {
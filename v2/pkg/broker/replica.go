@@ -2,13 +2,16 @@ package broker
 
 import (
 	"context"
+	"time"
 
 	"github.com/LiveRamp/gazette/v2/pkg/allocator"
+	"github.com/LiveRamp/gazette/v2/pkg/auth"
 	"github.com/LiveRamp/gazette/v2/pkg/fragment"
 	"github.com/LiveRamp/gazette/v2/pkg/keyspace"
 	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
 	"github.com/coreos/etcd/clientv3"
 	"github.com/pkg/errors"
+	"golang.org/x/time/rate"
 )
 
 // replica is a runtime instance of a journal which is assigned to this broker.
@@ -20,6 +23,9 @@ type replica struct {
 	cancel context.CancelFunc
 	// Index of all known Fragments of the replica.
 	index *fragment.Index
+	// observer of the replica, which indexes and persists its Spool
+	// and records its metrics.
+	observer *replicaObserver
 	// spoolCh synchronizes access to the single Spool of the replica.
 	spoolCh chan fragment.Spool
 	// pipelineCh synchronizes access to the single pipeline of the replica.
@@ -31,6 +37,14 @@ type replica struct {
 	//  * resolver closes when the replica is no longer routed to this broker,
 	//    and should be gracefully terminated.
 	maintenanceCh chan struct{}
+	// appendLimiter enforces the JournalSpec MaxAppendRate of Appends served
+	// by the replica. Access is guarded by ownership of the replica pipeline.
+	appendLimiter *rate.Limiter
+	// peerToken is presented to peers in Replicate RPCs of replica pipelines.
+	// If empty, no token is presented.
+	peerToken string
+	// created is the time at which the replica was assigned to this broker.
+	created time.Time
 }
 
 func newReplica(journal pb.Journal) *replica {
@@ -44,18 +58,39 @@ func newReplica(journal pb.Journal) *replica {
 		spoolCh:       make(chan fragment.Spool, 1),
 		pipelineCh:    make(chan *pipeline, 1),
 		maintenanceCh: make(chan struct{}, 1),
+		created:       time.Now(),
 	}
 
-	r.spoolCh <- fragment.NewSpool(journal, struct {
-		*fragment.Index
-		*fragment.Persister
-	}{r.index, sharedPersister})
+	r.observer = newReplicaObserver(journal, r.index, sharedPersister)
+	r.spoolCh <- fragment.NewSpool(journal, r.observer)
 
 	r.pipelineCh <- nil
 
 	return r
 }
 
+// appendRateLimiter returns a rate.Limiter of |bytesPerSec| for the replica,
+// or nil if the rate is unlimited. The Limiter permits bursts of up to one
+// second of content, and is retained across calls unless the rate changes.
+// The caller must own the replica pipeline.
+func (r *replica) appendRateLimiter(bytesPerSec int64) *rate.Limiter {
+	if bytesPerSec == 0 {
+		r.appendLimiter = nil
+	} else if r.appendLimiter == nil || r.appendLimiter.Limit() != rate.Limit(bytesPerSec) {
+		r.appendLimiter = rate.NewLimiter(rate.Limit(bytesPerSec), int(bytesPerSec))
+	}
+	return r.appendLimiter
+}
+
+// pipelineContext returns the Context of a new replica pipeline, which is
+// the replica Context having its peerToken (if any) attached.
+func (r *replica) pipelineContext() context.Context {
+	if r.peerToken == "" {
+		return r.ctx
+	}
+	return auth.WithBearerToken(r.ctx, r.peerToken)
+}
+
 // acquireSpool performs a blocking acquisition of the replica's single Spool.
 func acquireSpool(ctx context.Context, r *replica) (spool fragment.Spool, err error) {
 	select {
@@ -112,7 +147,7 @@ func acquirePipeline(ctx context.Context, r *replica, hdr pb.Header, jc pb.Journ
 		spool, err = acquireSpool(ctx, r)
 
 		if err == nil {
-			pln = newPipeline(r.ctx, hdr, spool, r.spoolCh, jc)
+			pln = newPipeline(r.pipelineContext(), hdr, spool, r.spoolCh, jc)
 			err = pln.synchronize()
 		}
 		addTrace(ctx, "newPipeline() => %s, err: %v", pln, err)
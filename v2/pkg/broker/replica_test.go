@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/LiveRamp/gazette/v2/pkg/allocator"
+	"github.com/LiveRamp/gazette/v2/pkg/auth"
 	"github.com/LiveRamp/gazette/v2/pkg/fragment"
 	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
 	"github.com/coreos/etcd/clientv3"
@@ -254,4 +255,39 @@ func (s *ReplicaSuite) TestBasicHealthCheck(c *gc.C) {
 	}
 }
 
+func (s *ReplicaSuite) TestPipelinePresentsPeerToken(c *gc.C) {
+	var tf, cleanup = newTestFixture(c)
+	defer cleanup()
+
+	var key = []byte("secret")
+	var token, _ = auth.NewToken(auth.Claims{Grants: []auth.Grant{
+		{Capabilities: []auth.Capability{auth.Append}},
+	}}, key)
+
+	// Replicas of "a/journal" present |token|, but replicas of "b/journal" don't.
+	var broker = newTestBroker(c, tf, pb.ProcessSpec_ID{Zone: "local", Suffix: "broker"},
+		func(journal pb.Journal) *replica {
+			var r = newReadyReplica(journal)
+			if journal == "a/journal" {
+				r.peerToken = token
+			}
+			return r
+		})
+	var peer = newTestBroker(c, tf, pb.ProcessSpec_ID{Zone: "peer", Suffix: "broker"}, newReplica)
+	peer.svc.Authenticator = auth.NewKeyedAuthenticator(key)
+
+	newTestJournal(c, tf, pb.JournalSpec{Name: "a/journal", Replication: 2}, broker.id, peer.id)
+	newTestJournal(c, tf, pb.JournalSpec{Name: "b/journal", Replication: 2}, broker.id, peer.id)
+
+	var res, err = broker.resolve(resolveArgs{ctx: tf.ctx, journal: "a/journal"})
+	c.Check(err, gc.IsNil)
+	_, err = checkHealth(res, broker.MustClient(), tf.etcd)
+	c.Check(err, gc.IsNil)
+
+	res, err = broker.resolve(resolveArgs{ctx: tf.ctx, journal: "b/journal"})
+	c.Check(err, gc.IsNil)
+	_, err = checkHealth(res, broker.MustClient(), tf.etcd)
+	c.Check(err, gc.ErrorMatches, `acquiringPipeline: recv from .*: rpc error: code = Unauthenticated desc = expected one authorization metadata value \(got 0\)`)
+}
+
 var _ = gc.Suite(&ReplicaSuite{})
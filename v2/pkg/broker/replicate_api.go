@@ -4,12 +4,15 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/LiveRamp/gazette/v2/pkg/auth"
 	"github.com/LiveRamp/gazette/v2/pkg/fragment"
 	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
 	log "github.com/sirupsen/logrus"
 )
 
-// Replicate dispatches the JournalServer.Replicate API.
+// Replicate dispatches the JournalServer.Replicate API. Replication writes
+// journal content, and callers (typically, peer brokers presenting their
+// PeerToken) must be granted Append over the journal.
 func (srv *Service) Replicate(stream pb.Journal_ReplicateServer) error {
 	var req, err = stream.Recv()
 	if err != nil {
@@ -17,6 +20,10 @@ func (srv *Service) Replicate(stream pb.Journal_ReplicateServer) error {
 	} else if err = req.Validate(); err != nil {
 		return err
 	}
+	claims, err := auth.Authenticate(srv.Authenticator, stream.Context())
+	if err != nil {
+		return err
+	}
 
 	var res resolution
 	res, err = srv.resolver.resolve(resolveArgs{
@@ -34,6 +41,8 @@ func (srv *Service) Replicate(stream pb.Journal_ReplicateServer) error {
 	} else if !res.Header.Route.Equivalent(&req.Header.Route) {
 		// Require that the request Route is equivalent to the Route we resolved to.
 		return stream.Send(&pb.ReplicateResponse{Status: pb.Status_WRONG_ROUTE, Header: &res.Header})
+	} else if !claims.Allows(auth.Append, journalLabels(res.journalSpec)) {
+		return stream.Send(&pb.ReplicateResponse{Status: pb.Status_NOT_ALLOWED})
 	}
 
 	var spool fragment.Spool
@@ -3,6 +3,7 @@ package broker
 import (
 	"io"
 
+	"github.com/LiveRamp/gazette/v2/pkg/auth"
 	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
 	gc "github.com/go-check/check"
 )
@@ -120,6 +121,40 @@ func (s *ReplicateSuite) TestErrorCases(c *gc.C) {
 	_, err = stream.Recv()
 	c.Check(err, gc.Equals, io.EOF)
 
+	// Case: caller isn't granted Append over the journal.
+	broker.svc.Authenticator = auth.NewKeyedAuthenticator([]byte("secret"))
+	token, _ := auth.NewToken(auth.Claims{Grants: []auth.Grant{{
+		Selector:     pb.LabelSelector{Include: pb.MustLabelSet("prefix", "other/")},
+		Capabilities: []auth.Capability{auth.Append},
+	}}}, []byte("secret"))
+
+	var req = &pb.ReplicateRequest{
+		Journal: "a/journal",
+		Header:  &res.Header,
+		Proposal: &pb.Fragment{
+			Journal:          "a/journal",
+			CompressionCodec: pb.CompressionCodec_NONE,
+		},
+		Acknowledge: true,
+	}
+	stream, _ = broker.MustClient().Replicate(auth.WithBearerToken(ctx, token))
+	c.Check(stream.Send(req), gc.IsNil)
+
+	expectReplResponse(c, stream, &pb.ReplicateResponse{Status: pb.Status_NOT_ALLOWED})
+
+	// Expect broker closes.
+	_, err = stream.Recv()
+	c.Check(err, gc.Equals, io.EOF)
+
+	// Case: caller presents no token.
+	stream, _ = broker.MustClient().Replicate(ctx)
+	c.Check(stream.Send(req), gc.IsNil)
+
+	_, err = stream.Recv()
+	c.Check(err, gc.ErrorMatches, `rpc error: code = Unauthenticated desc = expected one authorization metadata value \(got 0\)`)
+
+	broker.svc.Authenticator = nil
+
 	// Case: acknowledged proposal doesn't match.
 	stream, _ = broker.MustClient().Replicate(ctx)
 
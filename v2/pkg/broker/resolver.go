@@ -173,6 +173,14 @@ func (r *resolver) resolve(args resolveArgs) (res resolution, err error) {
 // KeySpace.Mu Lock is held.
 func (r *resolver) updateResolutions() {
 	var next = make(map[pb.Journal]*replica, len(r.state.LocalItems))
+	var specs = make([]*pb.JournalSpec, 0, len(r.state.LocalItems))
+
+	for _, li := range r.state.LocalItems {
+		specs = append(specs, li.Item.Decoded.(allocator.Item).ItemValue.(*pb.JournalSpec))
+	}
+	// Evict metrics partitions which no longer have a local replica, before
+	// partitioning current replicas.
+	sharedJournalMetrics.retain(specs)
 
 	for _, li := range r.state.LocalItems {
 		var item = li.Item.Decoded.(allocator.Item)
@@ -189,6 +197,7 @@ func (r *resolver) updateResolutions() {
 			rep = r.newReplica(name)
 			next[name] = rep
 		}
+		rep.observer.setSpec(item.ItemValue.(*pb.JournalSpec))
 
 		if assignment.Slot == 0 && !item.IsConsistent(keyspace.KeyValue{}, li.Assignments) {
 			// Attempt to signal maintenanceLoop that the journal should be pulsed.
@@ -2,11 +2,14 @@ package broker
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/LiveRamp/gazette/v2/pkg/allocator"
+	"github.com/LiveRamp/gazette/v2/pkg/auth"
 	"github.com/LiveRamp/gazette/v2/pkg/fragment"
 	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	"github.com/LiveRamp/gazette/v2/pkg/tracing"
 	"github.com/coreos/etcd/clientv3"
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/net/trace"
@@ -16,6 +19,14 @@ import (
 // drives local journal handling in response to allocator.State, powers
 // journal resolution, and is also an implementation of protocol.JournalServer.
 type Service struct {
+	// Authenticator of RPC callers. If nil, callers are not authenticated
+	// and all List, Read, Append, Replicate and Apply requests are allowed.
+	Authenticator auth.Authenticator
+	// PeerToken is a bearer token presented by the broker in Replicate RPCs
+	// of its peers. It must grant Append over all journals. If empty, no
+	// token is presented.
+	PeerToken string
+
 	jc       pb.JournalClient
 	etcd     clientv3.KV
 	resolver *resolver
@@ -27,6 +38,7 @@ func NewService(state *allocator.State, jc pb.JournalClient, etcd clientv3.KV) *
 
 	svc.resolver = newResolver(state, func(journal pb.Journal) *replica {
 		var rep = newReplica(journal)
+		rep.peerToken = svc.PeerToken
 		go svc.maintenanceLoop(rep)
 		return rep
 	})
@@ -57,6 +69,45 @@ func (svc *Service) UpdateRoute(string, *pb.Route) {} // No-op.
 // IsNoopRouter returns false.
 func (svc *Service) IsNoopRouter() bool { return false }
 
+// Ready returns nil if every journal assigned to the broker has completed
+// its first refresh of remote fragments, or an error otherwise. Until it has,
+// reads of the journal may block and appends are refused. Ready is intended
+// for use as a readiness check of the broker process.
+//
+// A journal which hasn't completed a refresh within readinessGracePeriod of
+// its assignment (eg, because its fragment store is unreachable) is logged,
+// but doesn't fail readiness: it would otherwise hold the broker NotReady, and
+// unable to serve any of its other journals, indefinitely.
+func (svc *Service) Ready() error {
+	var ks = svc.resolver.state.KS
+	defer ks.Mu.RUnlock()
+	ks.Mu.RLock()
+
+	var pending, overdue int
+	var example, overdueExample pb.Journal
+
+	for journal, rep := range svc.resolver.replicas {
+		if rep.index.FirstRemoteRefreshed() {
+			continue
+		} else if time.Since(rep.created) < readinessGracePeriod {
+			pending, example = pending+1, journal
+		} else {
+			overdue, overdueExample = overdue+1, journal
+		}
+	}
+	if overdue != 0 {
+		log.WithFields(log.Fields{
+			"journals": overdue,
+			"example":  overdueExample,
+			"grace":    readinessGracePeriod,
+		}).Warn("journals have not completed a first remote fragment refresh (is the fragment store reachable?)")
+	}
+	if pending != 0 {
+		return fmt.Errorf("%d journals await a first remote fragment refresh (eg, %s)", pending, example)
+	}
+	return nil
+}
+
 // maintenanceLoop performs periodic tasks over a replica:
 //  - Refreshing its remote fragment listings from configured stores.
 //  - Pinging the journal pipeline to ensure its live-ness, and the
@@ -143,10 +194,20 @@ func (svc *Service) maintenanceLoop(r *replica) {
 	}
 }
 
+// journalLabels returns the labels of the JournalSpec, including its meta-labels.
+func journalLabels(spec *pb.JournalSpec) pb.LabelSet {
+	return pb.UnionLabelSets(pb.ExtractJournalSpecMetaLabels(spec, pb.LabelSet{}), spec.LabelSet, pb.LabelSet{})
+}
+
 func addTrace(ctx context.Context, format string, args ...interface{}) {
 	if tr, ok := trace.FromContext(ctx); ok {
 		tr.LazyPrintf(format, args...)
 	}
+	tracing.FromContext(ctx).AddEvent(format, args...)
 }
 
 var healthCheckInterval = time.Minute
+
+// readinessGracePeriod bounds the time after assignment of a journal for
+// which the broker's readiness awaits its first remote fragment refresh.
+var readinessGracePeriod = time.Minute * 5
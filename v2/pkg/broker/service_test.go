@@ -0,0 +1,39 @@
+package broker
+
+import (
+	"time"
+
+	"github.com/LiveRamp/gazette/v2/pkg/fragment"
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	gc "github.com/go-check/check"
+)
+
+type ServiceSuite struct{}
+
+func (s *ServiceSuite) TestReadiness(c *gc.C) {
+	var tf, cleanup = newTestFixture(c)
+	defer cleanup()
+
+	var broker = newTestBroker(c, tf, pb.ProcessSpec_ID{Zone: "local", Suffix: "broker"}, newReplica)
+	c.Check(broker.svc.Ready(), gc.IsNil)
+
+	newTestJournal(c, tf, pb.JournalSpec{Name: "a/journal", Replication: 1}, broker.id)
+
+	// Expect the broker isn't ready until the journal fragment index is loaded.
+	c.Check(broker.svc.Ready(), gc.ErrorMatches,
+		`1 journals await a first remote fragment refresh \(eg, a/journal\)`)
+
+	broker.resolver.replicas["a/journal"].index.ReplaceRemote(fragment.CoverSet{})
+	c.Check(broker.svc.Ready(), gc.IsNil)
+
+	// A journal which hasn't completed a refresh within the grace period
+	// of its assignment doesn't fail readiness.
+	newTestJournal(c, tf, pb.JournalSpec{Name: "b/journal", Replication: 1}, broker.id)
+	c.Check(broker.svc.Ready(), gc.ErrorMatches,
+		`1 journals await a first remote fragment refresh \(eg, b/journal\)`)
+
+	broker.resolver.replicas["b/journal"].created = time.Now().Add(-readinessGracePeriod)
+	c.Check(broker.svc.Ready(), gc.IsNil)
+}
+
+var _ = gc.Suite(&ServiceSuite{})
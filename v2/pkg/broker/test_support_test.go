@@ -10,6 +10,7 @@ import (
 	"github.com/LiveRamp/gazette/v2/pkg/fragment"
 	"github.com/LiveRamp/gazette/v2/pkg/keyspace"
 	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	"github.com/LiveRamp/gazette/v2/pkg/tracing"
 	"github.com/coreos/etcd/clientv3"
 	gc "github.com/go-check/check"
 )
@@ -64,6 +65,8 @@ type testBroker struct {
 
 	*teststub.Broker // nil if not built with newMockBroker.
 	*resolver        // nil if not built with newTestBroker.
+
+	svc *Service // nil if not built with newTestBroker.
 }
 
 // newTestBroker returns a local testBroker of |id|. |newReplicaFn| should be
@@ -90,6 +93,7 @@ func newTestBroker(c *gc.C, tf testFixture, id pb.ProcessSpec_ID,
 		id:       id,
 		Server:   srv,
 		resolver: res,
+		svc:      svc,
 	}
 }
 
@@ -166,3 +170,18 @@ func mustKeyValues(c *gc.C, tf testFixture, kvs map[string]string) {
 	c.Assert(tf.ks.WaitForRevision(tf.ctx, resp.Header.Revision), gc.IsNil)
 	tf.ks.Mu.RUnlock()
 }
+
+// spanExporter is a tracing.Exporter which sends exported Spans to the channel.
+type spanExporter chan *tracing.Span
+
+func (e spanExporter) ExportSpan(span *tracing.Span) { e <- span }
+
+// findChild returns the Span of |spans| which is a child of |parent|, or nil.
+func findChild(spans []*tracing.Span, parent *tracing.Span) *tracing.Span {
+	for _, span := range spans {
+		if span.Parent == parent.SpanID {
+			return span
+		}
+	}
+	return nil
+}
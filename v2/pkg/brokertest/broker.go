@@ -10,9 +10,15 @@ import (
 	"github.com/LiveRamp/gazette/v2/pkg/broker/teststub"
 	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
 	"github.com/coreos/etcd/clientv3"
-	gc "github.com/go-check/check"
 )
 
+// T is the subset of *testing.T used by brokertest. It's also implemented by
+// go-check's *check.C, allowing use from either plain Go tests or go-check suites.
+type T interface {
+	Errorf(format string, args ...interface{})
+	Fatalf(format string, args ...interface{})
+}
+
 // Broker is a lightweight, embedded Gazette broker suitable for testing client
 // functionality which depends on the availability of the Gazette service.
 type Broker struct {
@@ -27,12 +33,14 @@ type Broker struct {
 
 // NewBroker returns a ready Broker with the given Context. Note that journals
 // must still be created with CreateJournal before use.
-func NewBroker(c *gc.C, etcd *clientv3.Client, zone, suffix string) *Broker {
+func NewBroker(t T, etcd *clientv3.Client, zone, suffix string) *Broker {
 	var ctx, cancel = context.WithCancel(context.Background())
 
 	// Grant lease with 1m timeout. Test must complete within this time.
 	var grant, err = etcd.Grant(ctx, 60)
-	c.Assert(err, gc.IsNil)
+	if err != nil {
+		t.Fatalf("failed to grant lease: %s", err)
+	}
 
 	var ks = broker.NewKeySpace("/brokertest")
 	ks.WatchApplyDelay = 0 // Speed test execution.
@@ -60,12 +68,18 @@ func NewBroker(c *gc.C, etcd *clientv3.Client, zone, suffix string) *Broker {
 		Then(clientv3.OpPut(key, spec.MarshalString(), clientv3.WithLease(grant.ID))).
 		Commit()
 
-	c.Assert(err, gc.IsNil)
-	c.Assert(resp.Succeeded, gc.Equals, true)
-	c.Assert(ks.Load(ctx, etcd, resp.Header.Revision), gc.IsNil)
+	if err != nil {
+		t.Fatalf("failed to announce broker: %s", err)
+	} else if !resp.Succeeded {
+		t.Fatalf("failed to announce broker: key %s exists", key)
+	} else if err = ks.Load(ctx, etcd, resp.Header.Revision); err != nil {
+		t.Fatalf("failed to load KeySpace: %s", err)
+	}
 
 	go func() {
-		c.Assert(ks.Watch(ctx, etcd), gc.Equals, context.Canceled)
+		if err := ks.Watch(ctx, etcd); err != context.Canceled {
+			t.Errorf("unexpected KeySpace.Watch error: %v", err)
+		}
 	}()
 	go func() {
 		// We signal |idleCh| on the first idle TestHook callback which follows
@@ -106,7 +120,7 @@ func (b *Broker) AllocateIdleCh() <-chan struct{} { return b.idleCh }
 
 // ZeroJournalLimit of the Broker. The test Broker will eventually exit,
 // assuming other Broker(s) are available to take over the assignments.
-func (b *Broker) ZeroJournalLimit(c *gc.C) {
+func (b *Broker) ZeroJournalLimit(t T) {
 	b.state.KS.Mu.RLock()
 	var kv = b.state.Members[b.state.LocalMemberInd]
 	b.state.KS.Mu.RUnlock()
@@ -119,14 +133,18 @@ func (b *Broker) ZeroJournalLimit(c *gc.C) {
 		Then(clientv3.OpPut(string(kv.Raw.Key), spec.MarshalString(), clientv3.WithIgnoreLease())).
 		Commit()
 
-	c.Assert(err, gc.IsNil)
-	c.Assert(resp.Succeeded, gc.Equals, true)
+	if err != nil {
+		t.Fatalf("failed to update broker spec: %s", err)
+	} else if !resp.Succeeded {
+		t.Fatalf("failed to update broker spec: key %s was modified", kv.Raw.Key)
+	}
 }
 
 // RevokeLease of the Broker, allowing its Allocate loop to immediately exit.
-func (b *Broker) RevokeLease(c *gc.C) {
-	var _, err = b.etcd.Revoke(context.Background(), b.lease)
-	c.Assert(err, gc.IsNil)
+func (b *Broker) RevokeLease(t T) {
+	if _, err := b.etcd.Revoke(context.Background(), b.lease); err != nil {
+		t.Fatalf("failed to revoke lease: %s", err)
+	}
 }
 
 // WaitForExit of the test Broker Allocate loop, and complete its teardown.
@@ -156,15 +174,18 @@ func Journal(spec pb.JournalSpec) *pb.JournalSpec {
 }
 
 // CreateJournals using the Broker Apply API, and wait for them to be allocated.
-func CreateJournals(c *gc.C, bk *Broker, specs ...*pb.JournalSpec) {
+func CreateJournals(t T, bk *Broker, specs ...*pb.JournalSpec) {
 	var req = new(pb.ApplyRequest)
 	for _, spec := range specs {
 		req.Changes = append(req.Changes, pb.ApplyRequest_Change{Upsert: spec})
 	}
 
 	var resp, err = bk.Client().Apply(pb.WithDispatchDefault(context.Background()), req)
-	c.Assert(err, gc.IsNil)
-	c.Assert(resp.Status, gc.Equals, pb.Status_OK)
+	if err != nil {
+		t.Fatalf("failed to apply journals: %s", err)
+	} else if resp.Status != pb.Status_OK {
+		t.Fatalf("failed to apply journals: %s", resp.Status)
+	}
 
 	// Wait for journal assignments to update.
 	<-bk.AllocateIdleCh()
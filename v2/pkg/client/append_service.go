@@ -10,6 +10,7 @@ import (
 	"time"
 
 	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	"github.com/LiveRamp/gazette/v2/pkg/tracing"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -23,9 +24,10 @@ import (
 // interface.
 type AppendService struct {
 	pb.RoutedJournalClient
-	ctx     context.Context
-	appends map[pb.Journal]*AsyncAppend
-	mu      sync.Mutex
+	ctx      context.Context
+	appends  map[pb.Journal]*AsyncAppend
+	maxSizes map[pb.Journal]int64 // Known MaxAppendSize of journals.
+	mu       sync.Mutex
 }
 
 // NewAppendService returns an AppendService with the provided Context and BrokerClient.
@@ -34,6 +36,7 @@ func NewAppendService(ctx context.Context, client pb.RoutedJournalClient) *Appen
 		ctx:                 ctx,
 		RoutedJournalClient: client,
 		appends:             make(map[pb.Journal]*AsyncAppend),
+		maxSizes:            make(map[pb.Journal]int64),
 	}
 }
 
@@ -51,9 +54,11 @@ type AsyncJournalClient interface {
 	//
 	// For performance reasons, an Append will often be batched with other Appends
 	// dispatched to this AppendService, and note the Response.Fragment will reflect
-	// the entire batch written to the broker. In all cases, relative order of
-	// Appends is preserved. One or more dependencies may optionally be supplied.
-	// The Append RPC will not begin until all such dependencies have committed.
+	// the entire batch written to the broker (or, if the batch was split to
+	// respect the journal's MaxAppendSize, its final Append RPC). In all cases,
+	// relative order of Appends is preserved. One or more dependencies may
+	// optionally be supplied. The Append RPC will not begin until all such
+	// dependencies have committed, and fails if any dependency fails.
 	// Dependencies must be ordered on applicable Journal name or StartAppend panics.
 	// StartAppend may retain the slice, and it must not be subsequently modified.
 	StartAppend(journal pb.Journal, dependencies ...*AsyncAppend) *AsyncAppend
@@ -113,7 +118,7 @@ func (s *AppendService) StartAppend(name pb.Journal, dependencies ...*AsyncAppen
 		return s.StartAppend(name, dependencies...)
 	}
 
-	if aa.checkpoint > appendBufferCutoff || !isSubset(dependencies, aa.dependencies) {
+	if aa.checkpoint > s.appendCutoff(name) || !isSubset(dependencies, aa.dependencies) {
 		// We must chain a new Append RPC, ordered after this one.
 		aa = s.chainNewAppend(aa, dependencies)
 	}
@@ -151,6 +156,48 @@ func WaitForPendingAppends(pending []*AsyncAppend) {
 	}
 }
 
+// appendCutoff returns the buffered size beyond which a new AsyncAppend of
+// |journal| is chained: appendBufferCutoff, or the journal's MaxAppendSize if
+// known and smaller.
+func (s *AppendService) appendCutoff(journal pb.Journal) int64 {
+	if size := s.maxAppendSize(journal); size != 0 && size < appendBufferCutoff {
+		return size
+	}
+	return appendBufferCutoff
+}
+
+// maxAppendSize returns the known MaxAppendSize of |journal|, or zero if
+// unknown or unlimited.
+func (s *AppendService) maxAppendSize(journal pb.Journal) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.maxSizes[journal]
+}
+
+// fetchMaxAppendSize lists the JournalSpec of |journal|, and updates and
+// returns its known MaxAppendSize. Zero is returned if the JournalSpec cannot
+// be listed.
+func (s *AppendService) fetchMaxAppendSize(journal pb.Journal) int64 {
+	var resp, err = ListAll(s.ctx, s.RoutedJournalClient, pb.ListRequest{
+		Selector: pb.LabelSelector{Include: pb.MustLabelSet("name", journal.String())},
+	})
+	var size int64
+
+	if err != nil {
+		log.WithFields(log.Fields{"err": err, "journal": journal}).
+			Warn("failed to list JournalSpec")
+	} else if len(resp.Journals) == 1 {
+		size = resp.Journals[0].Spec.MaxAppendSize
+	}
+
+	s.mu.Lock()
+	s.maxSizes[journal] = size
+	s.mu.Unlock()
+
+	return size
+}
+
 // chainNewAppend adds and returns a new AsyncAppend, to be ordered after this one.
 func (s *AppendService) chainNewAppend(aa *AsyncAppend, dependencies []*AsyncAppend) *AsyncAppend {
 	// Precondition: aa.mu lock is already held.
@@ -179,7 +226,9 @@ type AsyncAppend struct {
 	commitCh     chan struct{}  // Closed to signal AsyncAppend has committed.
 	fb           *appendBuffer  // Buffer into which writes are queued.
 	checkpoint   int64          // Buffer |fb| offset to append through.
+	writes       []int64        // Buffer |fb| offsets at which released writes end.
 	err          error          // Retained Require error which is != nil.
+	appendErr    error          // Terminal error of the append, set before |commitCh| closes.
 
 	mu   *sync.Mutex  // Shared mutex over all AsyncAppends of the journal.
 	next *AsyncAppend // Next ordered AsyncAppend of the journal.
@@ -223,6 +272,10 @@ func (p *AsyncAppend) Release() error {
 		return err
 	}
 	p.checkpoint = p.fb.offset + int64(p.fb.buf.Buffered())
+
+	if l := len(p.writes); l == 0 || p.writes[l-1] != p.checkpoint {
+		p.writes = append(p.writes, p.checkpoint)
+	}
 	p.mu.Unlock()
 
 	return nil
@@ -246,11 +299,22 @@ func (p *AsyncAppend) Request() pb.AppendRequest { return p.app.Request }
 // only after calling BeginCommit and waiting for the returned channel to select.
 func (p *AsyncAppend) Response() pb.AppendResponse { return p.app.Response }
 
-// Done returns a channel which selects when the AsyncAppend has committed.
+// Done returns a channel which selects when the AsyncAppend has committed,
+// or has failed (see Err).
 func (p *AsyncAppend) Done() <-chan struct{} { return p.commitCh }
 
+// Err returns a non-nil error if the AsyncAppend failed rather than committed.
+// It may be called only after Done selects. The AppendService retries Append
+// RPCs until successful, with the exception of ErrAppendTooLarge, which is
+// returned if a write of the AsyncAppend exceeds the journal's MaxAppendSize.
+// Other writes batched with it may have committed. An AsyncAppend also fails
+// with the error of a failed dependency, in which case none of its writes
+// were committed.
+func (p *AsyncAppend) Err() error { return p.appendErr }
+
 // serveAppends executes Append RPCs specified by a (potentially growing) chain
-// of ordered AsyncAppends. Each RPC is retried until successful. Upon reaching
+// of ordered AsyncAppends. Each RPC is retried until successful, or until it
+// fails with an error which retries cannot resolve. Upon reaching
 // the end of the chain, serveAppends marks its exit with tombstoneAsyncAppend
 // and halts. serveAppends is a var to facilitate testing.
 var serveAppends = func(s *AppendService, aa *AsyncAppend) {
@@ -279,7 +343,9 @@ var serveAppends = func(s *AppendService, aa *AsyncAppend) {
 		aa.mu.Unlock() // Further appends may queue while we dispatch this RPC.
 
 		for _, dep := range aa.dependencies {
-			<-dep.Done()
+			if <-dep.Done(); dep.Err() != nil && aa.appendErr == nil {
+				aa.appendErr = dep.Err()
+			}
 		}
 
 		// If |aa.fb| is nil, then |aa| was never returned by StartAppend and no
@@ -289,17 +355,9 @@ var serveAppends = func(s *AppendService, aa *AsyncAppend) {
 		if aa.fb != nil {
 			retryUntil(aa.fb.flush, "failed to flush appendBuffer")
 
-			retryUntil(func() error {
-				var _, err = io.Copy(&aa.app, io.NewSectionReader(aa.fb.file, 0, aa.checkpoint))
-
-				if err == nil {
-					err = aa.app.Close()
-				}
-				if err != nil {
-					aa.app.Reset() // Reset for next attempt.
-				}
-				return err
-			}, "failed to append to journal")
+			if aa.appendErr == nil {
+				aa.appendErr = s.appendContent(aa)
+			}
 		}
 
 		close(aa.commitCh) // Notify clients & dependent appends of completion.
@@ -313,6 +371,89 @@ var serveAppends = func(s *AppendService, aa *AsyncAppend) {
 	}
 }
 
+// appendContent appends the buffered content of |aa| to its journal. If the
+// content exceeds the known MaxAppendSize of the journal, it's split into
+// multiple Append RPCs along the boundaries of released writes. RPCs are
+// retried until successful, excepting those which fail with ErrAppendTooLarge:
+// in that case, the journal's MaxAppendSize is re-fetched and, if it permits
+// splitting the remaining content further, appendContent continues. Otherwise,
+// ErrAppendTooLarge is returned. Appended content is traced by a Span, of
+// which each Append RPC is a child.
+func (s *AppendService) appendContent(aa *AsyncAppend) (err error) {
+	var journal = aa.Request().Journal
+
+	var ctx, span = tracing.StartSpan(s.ctx, "appendContent", tracing.SpanKindInternal)
+	span.SetAttribute("journal", journal)
+	span.SetAttribute("bytes", aa.checkpoint)
+	defer func() { span.Finish(err) }()
+
+	aa.app.ctx = ctx
+
+	for offset := int64(0); true; {
+		var limit = s.maxAppendSize(journal)
+		var end = splitWrites(aa.writes, offset, aa.checkpoint, limit)
+
+		if end == offset && end != aa.checkpoint {
+			return ErrAppendTooLarge // A single write is larger than |limit|.
+		}
+
+		var tooLarge bool
+		retryUntil(func() error {
+			var _, err = io.Copy(&aa.app, io.NewSectionReader(aa.fb.file, offset, end-offset))
+
+			if err == nil {
+				err = aa.app.Close()
+			}
+			if err != nil {
+				aa.app.Reset() // Reset for next attempt.
+			}
+			if err == ErrAppendTooLarge {
+				tooLarge = true
+				return nil // Not retried.
+			}
+			return err
+		}, "failed to append to journal")
+
+		if tooLarge {
+			span.AddEvent("append of [%d, %d) is too large (limit %d)", offset, end, limit)
+
+			// Retries can succeed only if the journal's MaxAppendSize is now
+			// known to be smaller than the content we attempted to append.
+			if limit = s.fetchMaxAppendSize(journal); limit == 0 || limit >= end-offset {
+				return ErrAppendTooLarge
+			}
+			continue
+		}
+
+		span.AddEvent("appended [%d, %d)", offset, end)
+
+		if offset = end; offset == aa.checkpoint {
+			return nil
+		}
+		aa.app.Reset() // Reset for the next Append RPC.
+	}
+	panic("not reached")
+}
+
+// splitWrites returns the largest offset in (|offset|, |checkpoint|] at which
+// a released write ends, and which is within |limit| bytes of |offset|. If
+// |limit| is zero, |checkpoint| is returned. If the next write is larger than
+// |limit|, |offset| is returned.
+func splitWrites(writes []int64, offset, checkpoint, limit int64) int64 {
+	if limit == 0 || checkpoint-offset <= limit {
+		return checkpoint
+	}
+	var end = offset
+	for _, w := range writes {
+		if w-offset > limit {
+			break
+		} else if w > offset {
+			end = w
+		}
+	}
+	return end
+}
+
 // appendBuffer composes a backing File with a bufio.Writer, and additionally
 // tracks the offset through which the file is written.
 type appendBuffer struct {
@@ -438,7 +579,7 @@ func retryUntil(fn func() error, msg string) {
 			return
 		}
 		log.WithField("err", err).Error(msg + " (will retry)")
-		time.Sleep(backoff(attempt))
+		time.Sleep(appendBackoff(err, attempt))
 	}
 }
 
@@ -147,6 +147,107 @@ func (s *AppendServiceSuite) TestAppendSizeCutoff(c *gc.C) {
 	WaitForPendingAppends(as.PendingExcept(""))
 }
 
+func (s *AppendServiceSuite) TestAppendTooLargeCases(c *gc.C) {
+	var ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	var broker = teststub.NewBroker(c, ctx)
+	var rjc = pb.NewRoutedJournalClient(broker.MustClient(), pb.NoopDispatchRouter{})
+	var as = NewAppendService(ctx, rjc)
+
+	broker.ListFunc = func(_ context.Context, req *pb.ListRequest) (*pb.ListResponse, error) {
+		c.Check(req.Selector.Include, gc.DeepEquals, pb.MustLabelSet("name", "a/journal"))
+
+		var resp = &pb.ListResponse{
+			Header:   *buildHeaderFixture(broker),
+			Journals: buildListResponseFixture("a/journal"),
+		}
+		resp.Journals[0].Spec.MaxAppendSize = 12
+		return resp, nil
+	}
+	var tooLarge = &pb.AppendResponse{
+		Status: pb.Status_APPEND_TOO_LARGE,
+		Header: buildHeaderFixture(broker),
+	}
+
+	// Case: a batch of writes exceeds the journal's (unknown) MaxAppendSize.
+	var serveCh, cleanup = gateServeAppends()
+
+	var aa *AsyncAppend
+	for i := 0; i != 2; i++ {
+		aa = as.StartAppend("a/journal")
+		aa.Writer().WriteString("hello, world")
+		c.Check(aa.Release(), gc.IsNil)
+	}
+	close(serveCh)
+	cleanup()
+
+	// Expect the batch is attempted, and then split into separate RPCs.
+	c.Check(<-broker.AppendReqCh, gc.DeepEquals, &pb.AppendRequest{Journal: "a/journal"})
+	c.Check(<-broker.AppendReqCh, gc.DeepEquals, &pb.AppendRequest{Content: []byte("hello, worldhello, world")})
+	c.Check(<-broker.AppendReqCh, gc.DeepEquals, &pb.AppendRequest{})
+	c.Check(<-broker.AppendReqCh, gc.IsNil)
+	broker.AppendRespCh <- tooLarge
+
+	for i := 0; i != 2; i++ {
+		readHelloWorldAppendRequest(c, broker)
+		broker.AppendRespCh <- buildAppendResponseFixture(broker)
+	}
+	<-aa.Done()
+	c.Check(aa.Err(), gc.IsNil)
+	c.Check(aa.Response(), gc.DeepEquals, *buildAppendResponseFixture(broker))
+	c.Check(as.appendCutoff("a/journal"), gc.Equals, int64(12))
+
+	// Case: a single write exceeds the now-known MaxAppendSize. Expect it fails
+	// without an RPC, as does an append which depends on it.
+	aa = as.StartAppend("a/journal")
+	aa.Writer().WriteString("hello, world!")
+	c.Check(aa.Release(), gc.IsNil)
+
+	var dep = as.StartAppend("other/journal", aa)
+	dep.Writer().WriteString("dependent write")
+	c.Check(dep.Release(), gc.IsNil)
+
+	<-aa.Done()
+	c.Check(aa.Err(), gc.Equals, ErrAppendTooLarge)
+	<-dep.Done()
+	c.Check(dep.Err(), gc.Equals, ErrAppendTooLarge)
+
+	// Case: the MaxAppendSize is lowered, such that a write which was
+	// appendable no longer is. Expect it fails without further retries.
+	aa = as.StartAppend("a/journal")
+	aa.Writer().WriteString("hello, world")
+	c.Check(aa.Release(), gc.IsNil)
+
+	readHelloWorldAppendRequest(c, broker)
+	as.mu.Lock()
+	as.maxSizes["a/journal"] = 0 // Reset to unknown.
+	as.mu.Unlock()
+	broker.AppendRespCh <- tooLarge
+
+	<-aa.Done()
+	c.Check(aa.Err(), gc.Equals, ErrAppendTooLarge)
+
+	WaitForPendingAppends(as.PendingExcept(""))
+}
+
+func (s *AppendServiceSuite) TestSplitWritesCases(c *gc.C) {
+	var writes = []int64{0, 10, 20, 25, 40}
+
+	for _, tc := range []struct {
+		offset, limit, expect int64
+	}{
+		{0, 0, 40},   // Unlimited.
+		{0, 40, 40},  // Within limit.
+		{0, 22, 20},  // Split at last write within limit.
+		{0, 9, 0},    // Next write exceeds limit.
+		{20, 5, 25},  // Split from offset.
+		{25, 15, 40}, // Remainder within limit.
+	} {
+		c.Check(splitWrites(writes, tc.offset, 40, tc.limit), gc.Equals, tc.expect)
+	}
+}
+
 func (s *AppendServiceSuite) TestAppendRacesServiceLoop(c *gc.C) {
 	var ctx, cancel = context.WithCancel(context.Background())
 	defer cancel()
@@ -47,7 +47,13 @@ func (a *Appender) Write(p []byte) (n int, err error) {
 	// Lazy initialization: begin the Append RPC.
 	if err = a.lazyInit(); err != nil {
 		// Pass.
-	} else if err = a.stream.SendMsg(&pb.AppendRequest{Content: p}); err != nil {
+	} else if err = a.stream.SendMsg(&pb.AppendRequest{Content: p}); err == io.EOF {
+		// The broker closed the RPC prior to its commit (eg, because the
+		// Append was RATE_LIMITED). Read its response status or error.
+		if err = a.recvResponse(); err == nil {
+			err = io.ErrUnexpectedEOF // Broker cannot have committed.
+		}
+	} else if err != nil {
 		// Pass.
 	} else {
 		n = len(p)
@@ -62,9 +68,18 @@ func (a *Appender) Close() (err error) {
 	// Send an empty chunk to signal commit of previously written content
 	if err = a.lazyInit(); err != nil {
 		return
-	} else if err = a.stream.SendMsg(new(pb.AppendRequest)); err != nil {
-		// Pass.
-	} else if err = a.stream.CloseSend(); err != nil {
+	} else if err = a.stream.SendMsg(new(pb.AppendRequest)); err != nil && err != io.EOF {
+		// Pass. (io.EOF indicates the broker has already sent its response).
+	} else {
+		err = a.recvResponse()
+	}
+	return
+}
+
+// recvResponse closes the send-side of the Append RPC and reads the broker
+// AppendResponse, mapping a non-OK Status into a returned error.
+func (a *Appender) recvResponse() (err error) {
+	if err = a.stream.CloseSend(); err != nil {
 		// Pass.
 	} else if err = a.stream.RecvMsg(&a.Response); err != nil {
 		// Pass.
@@ -80,6 +95,10 @@ func (a *Appender) Close() (err error) {
 			err = ErrNotJournalPrimaryBroker
 		case pb.Status_WRONG_APPEND_OFFSET:
 			err = ErrWrongAppendOffset
+		case pb.Status_RATE_LIMITED:
+			err = ErrRateLimited
+		case pb.Status_APPEND_TOO_LARGE:
+			err = ErrAppendTooLarge
 		default:
 			err = errors.New(a.Response.Status.String())
 		}
@@ -140,6 +159,8 @@ func Append(ctx context.Context, rjc pb.RoutedJournalClient, req pb.AppendReques
 			// Fallthrough to retry
 		} else if err == ErrNotJournalPrimaryBroker {
 			// Fallthrough.
+		} else if err == ErrRateLimited {
+			// Fallthrough.
 		} else {
 			return a.Response, err
 		}
@@ -147,8 +168,21 @@ func Append(ctx context.Context, rjc pb.RoutedJournalClient, req pb.AppendReques
 		select {
 		case <-ctx.Done():
 			return a.Response, ctx.Err()
-		case <-time.After(backoff(attempt)):
+		case <-time.After(appendBackoff(err, attempt)):
 		}
 	}
 	panic("not reached")
 }
+
+// appendBackoff returns the duration to wait before retrying an Append
+// attempt which failed with |err|. Appends which were RATE_LIMITED wait for
+// at least rateLimitedBackoff, allowing the broker's token bucket to refill.
+func appendBackoff(err error, attempt int) time.Duration {
+	var d = backoff(attempt)
+	if err == ErrRateLimited && d < rateLimitedBackoff {
+		d = rateLimitedBackoff
+	}
+	return d
+}
+
+var rateLimitedBackoff = 250 * time.Millisecond
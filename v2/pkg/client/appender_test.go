@@ -85,12 +85,44 @@ func (s *AppenderSuite) TestBrokerWriteError(c *gc.C) {
 		time.Sleep(time.Millisecond)
 		n, err = a.Write([]byte("x"))
 	}
-	// NOTE(johnny): For some reason, gRPC translates the remote error into an
-	// EOF returned by an attempted SendMsg.
-	c.Check(err, gc.Equals, io.EOF)
+	// gRPC surfaces the remote error as an EOF returned by an attempted
+	// SendMsg, after which Appender reads the actual RPC error.
+	c.Check(err, gc.ErrorMatches, `rpc error: code = Unknown desc = an error`)
 	c.Check(n, gc.Equals, 0)
 }
 
+func (s *AppenderSuite) TestBrokerRateLimited(c *gc.C) {
+	var ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	var broker = teststub.NewBroker(c, ctx)
+	var rjc = pb.NewRoutedJournalClient(broker.MustClient(), pb.NoopDispatchRouter{})
+	var a = NewAppender(ctx, rjc, pb.AppendRequest{Journal: "a/journal"})
+
+	go func() {
+		c.Check(<-broker.AppendReqCh, gc.DeepEquals, &pb.AppendRequest{Journal: "a/journal"})
+		c.Check(<-broker.AppendReqCh, gc.DeepEquals, &pb.AppendRequest{Content: []byte("foo")})
+
+		// Broker rejects the Append prior to its commit.
+		broker.AppendRespCh <- &pb.AppendResponse{
+			Status: pb.Status_RATE_LIMITED,
+			Header: buildHeaderFixture(broker),
+		}
+	}()
+
+	var n, err = a.Write([]byte("foo"))
+	c.Check(err, gc.IsNil)
+	c.Check(n, gc.Equals, 3)
+
+	for err == nil {
+		time.Sleep(time.Millisecond)
+		n, err = a.Write([]byte("x"))
+	}
+	c.Check(err, gc.Equals, ErrRateLimited)
+	c.Check(n, gc.Equals, 0)
+	c.Check(a.Response.Status, gc.Equals, pb.Status_RATE_LIMITED)
+}
+
 func (s *AppenderSuite) TestBrokerCommitError(c *gc.C) {
 	var ctx, cancel = context.WithCancel(context.Background())
 	defer cancel()
@@ -143,6 +175,28 @@ func (s *AppenderSuite) TestBrokerCommitError(c *gc.C) {
 			errVal:      ErrWrongAppendOffset,
 			cachedRoute: 1,
 		},
+		// Case: known error status (rate limited).
+		{
+			finish: func() {
+				broker.AppendRespCh <- &pb.AppendResponse{
+					Status: pb.Status_RATE_LIMITED,
+					Header: buildHeaderFixture(broker),
+				}
+			},
+			errVal:      ErrRateLimited,
+			cachedRoute: 1,
+		},
+		// Case: known error status (append too large).
+		{
+			finish: func() {
+				broker.AppendRespCh <- &pb.AppendResponse{
+					Status: pb.Status_APPEND_TOO_LARGE,
+					Header: buildHeaderFixture(broker),
+				}
+			},
+			errVal:      ErrAppendTooLarge,
+			cachedRoute: 1,
+		},
 		// Case: other error status.
 		{
 			finish: func() {
@@ -208,8 +262,13 @@ func (s *AppenderSuite) TestAppendCases(c *gc.C) {
 			// Case 1: Append retries on routing error.
 			{status: pb.Status_NOT_JOURNAL_PRIMARY_BROKER},
 			{status: pb.Status_OK},
+			// Case 2: Append backs off and retries when rate limited.
+			{status: pb.Status_RATE_LIMITED},
+			{status: pb.Status_OK},
 			// Case 2: Unexpected status is surfaced.
 			{status: pb.Status_INSUFFICIENT_JOURNAL_BROKERS},
+			// Case 2: As is an Append which is too large.
+			{status: pb.Status_APPEND_TOO_LARGE},
 			// Case 3: As are errors.
 			{err: errors.New("an error")},
 		}
@@ -256,10 +315,22 @@ func (s *AppenderSuite) TestAppendCases(c *gc.C) {
 	c.Check(err, gc.IsNil)
 	c.Check(resp.Commit, gc.NotNil)
 
+	// Case 2: Rate limited Append is retried after a back-off, and then succeeds.
+	defer func(d time.Duration) { rateLimitedBackoff = d }(rateLimitedBackoff)
+	rateLimitedBackoff = time.Millisecond
+
+	resp, err = Append(ctx, rjc, pb.AppendRequest{Journal: "a/journal"}, con, tent)
+	c.Check(err, gc.IsNil)
+	c.Check(resp.Commit, gc.NotNil)
+
 	// Case 2: Unexpected status is surfaced.
 	_, err = Append(ctx, rjc, pb.AppendRequest{Journal: "a/journal"}, con, tent)
 	c.Check(err, gc.ErrorMatches, "INSUFFICIENT_JOURNAL_BROKERS")
 
+	// Case 2: As is an Append which is too large.
+	_, err = Append(ctx, rjc, pb.AppendRequest{Journal: "a/journal"}, con, tent)
+	c.Check(err, gc.Equals, ErrAppendTooLarge)
+
 	// Case 3: As are errors.
 	_, err = Append(ctx, rjc, pb.AppendRequest{Journal: "a/journal"}, con, tent)
 	c.Check(err, gc.ErrorMatches, "rpc error: code = Unknown desc = an error")
@@ -289,6 +289,8 @@ var (
 	ErrNotJournalPrimaryBroker = errors.New(pb.Status_NOT_JOURNAL_PRIMARY_BROKER.String())
 	ErrOffsetNotYetAvailable   = errors.New(pb.Status_OFFSET_NOT_YET_AVAILABLE.String())
 	ErrWrongAppendOffset       = errors.New(pb.Status_WRONG_APPEND_OFFSET.String())
+	ErrRateLimited             = errors.New(pb.Status_RATE_LIMITED.String())
+	ErrAppendTooLarge          = errors.New(pb.Status_APPEND_TOO_LARGE.String())
 
 	ErrOffsetJump            = errors.New("offset jump")
 	ErrSeekRequiresNewReader = errors.New("seek offset requires new Reader")
@@ -52,6 +52,8 @@ const (
 	// expect_mod_revision of the UpdateRequest differs from the current
 	// ModRevision of the ShardSpec within the store.
 	Status_ETCD_TRANSACTION_FAILED Status = 4
+	// The request is not allowed by the authorization policy of the caller.
+	Status_NOT_ALLOWED Status = 5
 )
 
 var Status_name = map[int32]string{
@@ -60,6 +62,7 @@ var Status_name = map[int32]string{
 	2: "NO_SHARD_PRIMARY",
 	3: "NOT_SHARD_PRIMARY",
 	4: "ETCD_TRANSACTION_FAILED",
+	5: "NOT_ALLOWED",
 }
 var Status_value = map[string]int32{
 	"OK":                      0,
@@ -67,13 +70,14 @@ var Status_value = map[string]int32{
 	"NO_SHARD_PRIMARY":        2,
 	"NOT_SHARD_PRIMARY":       3,
 	"ETCD_TRANSACTION_FAILED": 4,
+	"NOT_ALLOWED":             5,
 }
 
 func (x Status) String() string {
 	return proto.EnumName(Status_name, int32(x))
 }
 func (Status) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_consumer_b9f9f6f5c7e0a948, []int{0}
+	return fileDescriptor_consumer_a2b4ed1092406922, []int{0}
 }
 
 type ReplicaStatus_Code int32
@@ -111,7 +115,7 @@ func (x ReplicaStatus_Code) String() string {
 	return proto.EnumName(ReplicaStatus_Code_name, int32(x))
 }
 func (ReplicaStatus_Code) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_consumer_b9f9f6f5c7e0a948, []int{2, 0}
+	return fileDescriptor_consumer_a2b4ed1092406922, []int{2, 0}
 }
 
 // ShardSpec describes a shard and its configuration. Shards represent the
@@ -182,13 +186,45 @@ type ShardSpec struct {
 	// User-defined Labels of this ShardSpec. The label "id" is reserved and may
 	// not be used with a ShardSpec's labels.
 	protocol.LabelSet `protobuf:"bytes,9,opt,name=labels,embedded=labels" json:"labels" yaml:",omitempty,inline"`
+	// Selector over the Labels of consumer processes to which the Shard may be
+	// assigned. Eg, "disk=ssd" pins the Shard to consumers having label "disk"
+	// of value "ssd". If empty, the Shard may be assigned to any consumer.
+	ConsumerSelector github_com_LiveRamp_gazette_v2_pkg_protocol.MemberSelector `protobuf:"bytes,10,opt,name=consumer_selector,json=consumerSelector,customtype=github.com/LiveRamp/gazette/v2/pkg/protocol.MemberSelector" json:"consumer_selector" yaml:"consumer_selector,omitempty"`
+	// Interval at which the primary checkpoints the Shard's Store into the
+	// fragment store of its recovery log. A checkpoint is referenced by FSMHints
+	// subsequently written to hint_keys, and allows a player to restore Store
+	// files directly from the fragment store and to replay only the portion of
+	// the log which follows the checkpoint. Checkpoints require that the Store
+	// implement the Checkpointer interface, and that the recovery log has a
+	// configured fragment store. If zero, checkpoints are not taken.
+	CheckpointInterval time.Duration `protobuf:"bytes,11,opt,name=checkpoint_interval,json=checkpointInterval,stdduration" json:"checkpoint_interval" yaml:"checkpoint_interval,omitempty"`
+	// Maximum number of automatic restarts of a FAILED replica of the shard.
+	// A restarted replica is torn down, and is then re-played from its recovery
+	// log by the same consumer process. Restarts are counted by the ReplicaStatus
+	// of the assignment, and once |max_restarts| is reached, the replica remains
+	// FAILED until restarted manually (eg, via "gazctl shards restart") or
+	// re-assigned. Restarts are reset once a restarted replica has remained
+	// PRIMARY or TAILING for ten minutes. If zero, FAILED replicas are not
+	// restarted automatically.
+	MaxRestarts uint32 `protobuf:"varint,12,opt,name=max_restarts,json=maxRestarts,proto3" json:"max_restarts,omitempty" yaml:"max_restarts,omitempty"`
+	// Delay prior to the first automatic restart of a FAILED replica. The delay
+	// doubles with each subsequent restart, up to a maximum of five minutes.
+	// If zero, a default of one second is used.
+	RestartBackoff time.Duration `protobuf:"bytes,13,opt,name=restart_backoff,json=restartBackoff,stdduration" json:"restart_backoff" yaml:"restart_backoff,omitempty"`
+	// Maximum number of bytes of the recovery log, preceding its write head,
+	// which may be referenced by FSMHints of long-lived Store files. The primary
+	// periodically rebases files having hinted log Segments which begin before
+	// this span, re-recording their content at the head of the log. This bounds
+	// the portion of the log which must be retained, and which is read by a
+	// recovering replica. If zero, files are not rebased.
+	RebaseLogBytes int64 `protobuf:"varint,14,opt,name=rebase_log_bytes,json=rebaseLogBytes,proto3" json:"rebase_log_bytes,omitempty" yaml:"rebase_log_bytes,omitempty"`
 }
 
 func (m *ShardSpec) Reset()         { *m = ShardSpec{} }
 func (m *ShardSpec) String() string { return proto.CompactTextString(m) }
 func (*ShardSpec) ProtoMessage()    {}
 func (*ShardSpec) Descriptor() ([]byte, []int) {
-	return fileDescriptor_consumer_b9f9f6f5c7e0a948, []int{0}
+	return fileDescriptor_consumer_a2b4ed1092406922, []int{0}
 }
 func (m *ShardSpec) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -241,7 +277,7 @@ func (m *ShardSpec_Source) Reset()         { *m = ShardSpec_Source{} }
 func (m *ShardSpec_Source) String() string { return proto.CompactTextString(m) }
 func (*ShardSpec_Source) ProtoMessage()    {}
 func (*ShardSpec_Source) Descriptor() ([]byte, []int) {
-	return fileDescriptor_consumer_b9f9f6f5c7e0a948, []int{0, 0}
+	return fileDescriptor_consumer_a2b4ed1092406922, []int{0, 0}
 }
 func (m *ShardSpec_Source) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -277,13 +313,17 @@ type ConsumerSpec struct {
 	protocol.ProcessSpec `protobuf:"bytes,1,opt,name=process_spec,json=processSpec,embedded=process_spec" json:"process_spec" yaml:",inline"`
 	// Maximum number of assigned Shards.
 	ShardLimit uint32 `protobuf:"varint,2,opt,name=shard_limit,json=shardLimit,proto3" json:"shard_limit,omitempty"`
+	// Maximum total weight of assigned Shard replicas, where the weight of
+	// each Shard is given by its "weight" label (or one, if not set). If zero,
+	// the consumer's weight limit is its shard_limit.
+	ShardWeightLimit uint32 `protobuf:"varint,3,opt,name=shard_weight_limit,json=shardWeightLimit,proto3" json:"shard_weight_limit,omitempty"`
 }
 
 func (m *ConsumerSpec) Reset()         { *m = ConsumerSpec{} }
 func (m *ConsumerSpec) String() string { return proto.CompactTextString(m) }
 func (*ConsumerSpec) ProtoMessage()    {}
 func (*ConsumerSpec) Descriptor() ([]byte, []int) {
-	return fileDescriptor_consumer_b9f9f6f5c7e0a948, []int{1}
+	return fileDescriptor_consumer_a2b4ed1092406922, []int{1}
 }
 func (m *ConsumerSpec) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -314,7 +354,7 @@ var xxx_messageInfo_ConsumerSpec proto.InternalMessageInfo
 
 // ReplicaStatus is the status of a ShardSpec assigned to a ConsumerSpec.
 // It serves as an allocator AssignmentValue. ReplicaStatus is reduced by taking
-// the maximum enum value amoung statuses. Eg, if a primary is PRIMARY, one
+// the maximum enum value among statuses. Eg, if a primary is PRIMARY, one
 // replica is BACKFILL and the other TAILING, then the status is PRIMARY. If one
 // of the replicas transitioned to FAILED, than the status is FAILED. This
 // reduction behavior is used to summarize status across all replicas.
@@ -322,13 +362,16 @@ type ReplicaStatus struct {
 	Code ReplicaStatus_Code `protobuf:"varint,1,opt,name=code,proto3,enum=consumer.ReplicaStatus_Code" json:"code,omitempty"`
 	// Errors encountered during replica processing. Set iff |code| is FAILED.
 	Errors []string `protobuf:"bytes,2,rep,name=errors" json:"errors,omitempty"`
+	// Number of times the replica has been restarted after having FAILED,
+	// either automatically (see ShardSpec.max_restarts) or manually.
+	Restarts uint32 `protobuf:"varint,3,opt,name=restarts,proto3" json:"restarts,omitempty"`
 }
 
 func (m *ReplicaStatus) Reset()         { *m = ReplicaStatus{} }
 func (m *ReplicaStatus) String() string { return proto.CompactTextString(m) }
 func (*ReplicaStatus) ProtoMessage()    {}
 func (*ReplicaStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_consumer_b9f9f6f5c7e0a948, []int{2}
+	return fileDescriptor_consumer_a2b4ed1092406922, []int{2}
 }
 func (m *ReplicaStatus) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -370,7 +413,7 @@ func (m *ListRequest) Reset()         { *m = ListRequest{} }
 func (m *ListRequest) String() string { return proto.CompactTextString(m) }
 func (*ListRequest) ProtoMessage()    {}
 func (*ListRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_consumer_b9f9f6f5c7e0a948, []int{3}
+	return fileDescriptor_consumer_a2b4ed1092406922, []int{3}
 }
 func (m *ListRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -411,7 +454,7 @@ func (m *ListResponse) Reset()         { *m = ListResponse{} }
 func (m *ListResponse) String() string { return proto.CompactTextString(m) }
 func (*ListResponse) ProtoMessage()    {}
 func (*ListResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_consumer_b9f9f6f5c7e0a948, []int{4}
+	return fileDescriptor_consumer_a2b4ed1092406922, []int{4}
 }
 func (m *ListResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -455,7 +498,7 @@ func (m *ListResponse_Shard) Reset()         { *m = ListResponse_Shard{} }
 func (m *ListResponse_Shard) String() string { return proto.CompactTextString(m) }
 func (*ListResponse_Shard) ProtoMessage()    {}
 func (*ListResponse_Shard) Descriptor() ([]byte, []int) {
-	return fileDescriptor_consumer_b9f9f6f5c7e0a948, []int{4, 0}
+	return fileDescriptor_consumer_a2b4ed1092406922, []int{4, 0}
 }
 func (m *ListResponse_Shard) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -492,7 +535,7 @@ func (m *ApplyRequest) Reset()         { *m = ApplyRequest{} }
 func (m *ApplyRequest) String() string { return proto.CompactTextString(m) }
 func (*ApplyRequest) ProtoMessage()    {}
 func (*ApplyRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_consumer_b9f9f6f5c7e0a948, []int{5}
+	return fileDescriptor_consumer_a2b4ed1092406922, []int{5}
 }
 func (m *ApplyRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -538,7 +581,7 @@ func (m *ApplyRequest_Change) Reset()         { *m = ApplyRequest_Change{} }
 func (m *ApplyRequest_Change) String() string { return proto.CompactTextString(m) }
 func (*ApplyRequest_Change) ProtoMessage()    {}
 func (*ApplyRequest_Change) Descriptor() ([]byte, []int) {
-	return fileDescriptor_consumer_b9f9f6f5c7e0a948, []int{5, 0}
+	return fileDescriptor_consumer_a2b4ed1092406922, []int{5, 0}
 }
 func (m *ApplyRequest_Change) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -578,7 +621,7 @@ func (m *ApplyResponse) Reset()         { *m = ApplyResponse{} }
 func (m *ApplyResponse) String() string { return proto.CompactTextString(m) }
 func (*ApplyResponse) ProtoMessage()    {}
 func (*ApplyResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_consumer_b9f9f6f5c7e0a948, []int{6}
+	return fileDescriptor_consumer_a2b4ed1092406922, []int{6}
 }
 func (m *ApplyResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -824,6 +867,40 @@ func (m *ShardSpec) MarshalTo(dAtA []byte) (int, error) {
 		return 0, err
 	}
 	i += n3
+	dAtA[i] = 0x52
+	i++
+	i = encodeVarintConsumer(dAtA, i, uint64(m.ConsumerSelector.ProtoSize()))
+	n4, err := m.ConsumerSelector.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n4
+	dAtA[i] = 0x5a
+	i++
+	i = encodeVarintConsumer(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdDuration(m.CheckpointInterval)))
+	n5, err := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.CheckpointInterval, dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n5
+	if m.MaxRestarts != 0 {
+		dAtA[i] = 0x60
+		i++
+		i = encodeVarintConsumer(dAtA, i, uint64(m.MaxRestarts))
+	}
+	dAtA[i] = 0x6a
+	i++
+	i = encodeVarintConsumer(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdDuration(m.RestartBackoff)))
+	n6, err := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.RestartBackoff, dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n6
+	if m.RebaseLogBytes != 0 {
+		dAtA[i] = 0x70
+		i++
+		i = encodeVarintConsumer(dAtA, i, uint64(m.RebaseLogBytes))
+	}
 	return i, nil
 }
 
@@ -874,16 +951,21 @@ func (m *ConsumerSpec) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0xa
 	i++
 	i = encodeVarintConsumer(dAtA, i, uint64(m.ProcessSpec.ProtoSize()))
-	n4, err := m.ProcessSpec.MarshalTo(dAtA[i:])
+	n7, err := m.ProcessSpec.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n4
+	i += n7
 	if m.ShardLimit != 0 {
 		dAtA[i] = 0x10
 		i++
 		i = encodeVarintConsumer(dAtA, i, uint64(m.ShardLimit))
 	}
+	if m.ShardWeightLimit != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintConsumer(dAtA, i, uint64(m.ShardWeightLimit))
+	}
 	return i, nil
 }
 
@@ -922,6 +1004,11 @@ func (m *ReplicaStatus) MarshalTo(dAtA []byte) (int, error) {
 			i += copy(dAtA[i:], s)
 		}
 	}
+	if m.Restarts != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintConsumer(dAtA, i, uint64(m.Restarts))
+	}
 	return i, nil
 }
 
@@ -943,11 +1030,11 @@ func (m *ListRequest) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0xa
 	i++
 	i = encodeVarintConsumer(dAtA, i, uint64(m.Selector.ProtoSize()))
-	n5, err := m.Selector.MarshalTo(dAtA[i:])
+	n8, err := m.Selector.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n5
+	i += n8
 	return i, nil
 }
 
@@ -974,11 +1061,11 @@ func (m *ListResponse) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x12
 	i++
 	i = encodeVarintConsumer(dAtA, i, uint64(m.Header.ProtoSize()))
-	n6, err := m.Header.MarshalTo(dAtA[i:])
+	n9, err := m.Header.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n6
+	i += n9
 	if len(m.Shards) > 0 {
 		for _, msg := range m.Shards {
 			dAtA[i] = 0x1a
@@ -1012,11 +1099,11 @@ func (m *ListResponse_Shard) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0xa
 	i++
 	i = encodeVarintConsumer(dAtA, i, uint64(m.Spec.ProtoSize()))
-	n7, err := m.Spec.MarshalTo(dAtA[i:])
+	n10, err := m.Spec.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n7
+	i += n10
 	if m.ModRevision != 0 {
 		dAtA[i] = 0x10
 		i++
@@ -1025,11 +1112,11 @@ func (m *ListResponse_Shard) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x1a
 	i++
 	i = encodeVarintConsumer(dAtA, i, uint64(m.Route.ProtoSize()))
-	n8, err := m.Route.MarshalTo(dAtA[i:])
+	n11, err := m.Route.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n8
+	i += n11
 	if len(m.Status) > 0 {
 		for _, msg := range m.Status {
 			dAtA[i] = 0x22
@@ -1099,11 +1186,11 @@ func (m *ApplyRequest_Change) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintConsumer(dAtA, i, uint64(m.Upsert.ProtoSize()))
-		n9, err := m.Upsert.MarshalTo(dAtA[i:])
+		n12, err := m.Upsert.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n9
+		i += n12
 	}
 	if len(m.Delete) > 0 {
 		dAtA[i] = 0x1a
@@ -1137,11 +1224,11 @@ func (m *ApplyResponse) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x12
 	i++
 	i = encodeVarintConsumer(dAtA, i, uint64(m.Header.ProtoSize()))
-	n10, err := m.Header.MarshalTo(dAtA[i:])
+	n13, err := m.Header.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n10
+	i += n13
 	return i, nil
 }
 
@@ -1192,6 +1279,18 @@ func (m *ShardSpec) ProtoSize() (n int) {
 	}
 	l = m.LabelSet.ProtoSize()
 	n += 1 + l + sovConsumer(uint64(l))
+	l = m.ConsumerSelector.ProtoSize()
+	n += 1 + l + sovConsumer(uint64(l))
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.CheckpointInterval)
+	n += 1 + l + sovConsumer(uint64(l))
+	if m.MaxRestarts != 0 {
+		n += 1 + sovConsumer(uint64(m.MaxRestarts))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.RestartBackoff)
+	n += 1 + l + sovConsumer(uint64(l))
+	if m.RebaseLogBytes != 0 {
+		n += 1 + sovConsumer(uint64(m.RebaseLogBytes))
+	}
 	return n
 }
 
@@ -1222,6 +1321,9 @@ func (m *ConsumerSpec) ProtoSize() (n int) {
 	if m.ShardLimit != 0 {
 		n += 1 + sovConsumer(uint64(m.ShardLimit))
 	}
+	if m.ShardWeightLimit != 0 {
+		n += 1 + sovConsumer(uint64(m.ShardWeightLimit))
+	}
 	return n
 }
 
@@ -1240,6 +1342,9 @@ func (m *ReplicaStatus) ProtoSize() (n int) {
 			n += 1 + l + sovConsumer(uint64(l))
 		}
 	}
+	if m.Restarts != 0 {
+		n += 1 + sovConsumer(uint64(m.Restarts))
+	}
 	return n
 }
 
@@ -1634,6 +1739,134 @@ func (m *ShardSpec) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConsumerSelector", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsumer
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConsumer
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.ConsumerSelector.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CheckpointInterval", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsumer
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConsumer
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.CheckpointInterval, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxRestarts", wireType)
+			}
+			m.MaxRestarts = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsumer
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxRestarts |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RestartBackoff", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsumer
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConsumer
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.RestartBackoff, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 14:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RebaseLogBytes", wireType)
+			}
+			m.RebaseLogBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsumer
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RebaseLogBytes |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipConsumer(dAtA[iNdEx:])
@@ -1831,6 +2064,25 @@ func (m *ConsumerSpec) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardWeightLimit", wireType)
+			}
+			m.ShardWeightLimit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsumer
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ShardWeightLimit |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipConsumer(dAtA[iNdEx:])
@@ -1929,6 +2181,25 @@ func (m *ReplicaStatus) Unmarshal(dAtA []byte) error {
 			}
 			m.Errors = append(m.Errors, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Restarts", wireType)
+			}
+			m.Restarts = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsumer
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Restarts |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipConsumer(dAtA[iNdEx:])
@@ -2736,79 +3007,93 @@ var (
 	ErrIntOverflowConsumer   = fmt.Errorf("proto: integer overflow")
 )
 
-func init() { proto.RegisterFile("consumer.proto", fileDescriptor_consumer_b9f9f6f5c7e0a948) }
-
-var fileDescriptor_consumer_b9f9f6f5c7e0a948 = []byte{
-	// 1128 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x55, 0x3d, 0x93, 0xdb, 0x54,
-	0x17, 0xb6, 0x6c, 0xaf, 0x6c, 0x1f, 0x39, 0x1b, 0xe7, 0x6e, 0x92, 0xd5, 0xeb, 0xe4, 0xb5, 0x36,
-	0x82, 0xc2, 0x03, 0x89, 0x16, 0x9c, 0xc9, 0x00, 0x3b, 0x03, 0x33, 0xf6, 0x3a, 0x1f, 0x26, 0x8e,
-	0x37, 0x5c, 0x9b, 0x82, 0x4a, 0xa3, 0x95, 0x6e, 0x6c, 0x11, 0x49, 0x57, 0xd1, 0x95, 0x77, 0xd6,
-	0xf4, 0x34, 0x54, 0x29, 0x99, 0xa1, 0x61, 0xa8, 0xf9, 0x01, 0x34, 0xf4, 0x5b, 0xa6, 0x64, 0x28,
-	0xcc, 0x90, 0xe5, 0x17, 0x6c, 0x99, 0x8a, 0xd1, 0xd5, 0x95, 0xed, 0xfd, 0xa0, 0x08, 0x33, 0x74,
-	0x3a, 0xe7, 0x3c, 0xe7, 0xeb, 0x39, 0xe7, 0x5c, 0xc1, 0xba, 0x4d, 0x03, 0x36, 0xf5, 0x49, 0x64,
-	0x84, 0x11, 0x8d, 0x29, 0x2a, 0x67, 0x72, 0x7d, 0x67, 0xec, 0xc6, 0x93, 0xe9, 0xbe, 0x61, 0x53,
-	0x7f, 0xbb, 0xef, 0x1e, 0x10, 0x6c, 0xf9, 0xe1, 0xf6, 0xd8, 0xfa, 0x86, 0xc4, 0x31, 0xd9, 0x3e,
-	0x68, 0x6d, 0x87, 0xcf, 0xc7, 0xdb, 0xdc, 0xc7, 0xa6, 0xde, 0xe2, 0x23, 0x8d, 0x52, 0xbf, 0xb3,
-	0xe2, 0x3b, 0xa6, 0x63, 0x9a, 0xda, 0xf7, 0xa7, 0xcf, 0xb8, 0xc4, 0x05, 0xfe, 0x25, 0xe0, 0x8d,
-	0x31, 0xa5, 0x63, 0x8f, 0x2c, 0x51, 0xce, 0x34, 0xb2, 0x62, 0x97, 0x06, 0xa9, 0x5d, 0xff, 0x45,
-	0x86, 0xca, 0x70, 0x62, 0x45, 0xce, 0x30, 0x24, 0x36, 0xba, 0x01, 0x79, 0xd7, 0x51, 0xa5, 0x2d,
-	0xa9, 0x59, 0xe9, 0x28, 0x6f, 0xe6, 0x5a, 0x89, 0x9b, 0x7a, 0x5d, 0x9c, 0x77, 0x1d, 0xb4, 0x03,
-	0x25, 0x46, 0xa7, 0x91, 0x4d, 0x98, 0x9a, 0xdf, 0x2a, 0x34, 0x95, 0x56, 0xdd, 0x58, 0x74, 0xb8,
-	0x08, 0x61, 0x0c, 0x39, 0xa4, 0x53, 0x3c, 0x9a, 0x6b, 0x39, 0x9c, 0x39, 0xa0, 0x17, 0x50, 0x8d,
-	0x88, 0x4d, 0x0f, 0x48, 0x34, 0x33, 0x3d, 0x3a, 0x56, 0x0b, 0x3c, 0xc5, 0xe0, 0x64, 0xae, 0x6d,
-	0xcc, 0x2c, 0xdf, 0xdb, 0xd1, 0x57, 0xad, 0xfa, 0x9b, 0xb9, 0x76, 0xf7, 0x2d, 0x28, 0x32, 0x3e,
-	0xa7, 0xd3, 0x28, 0xb0, 0x3c, 0xac, 0x64, 0x51, 0xfa, 0x74, 0x8c, 0x3e, 0x84, 0xca, 0xc4, 0x0d,
-	0x62, 0xf3, 0x39, 0x99, 0x31, 0xb5, 0xb8, 0x55, 0x68, 0x56, 0x3a, 0x57, 0x4f, 0xe6, 0x5a, 0x2d,
-	0xcd, 0xb7, 0x30, 0xe9, 0xb8, 0x9c, 0x7c, 0x3f, 0x26, 0x33, 0x86, 0x22, 0xa8, 0xf9, 0xd6, 0xa1,
-	0x19, 0x1f, 0x06, 0x66, 0x46, 0x93, 0xba, 0xb6, 0x25, 0x35, 0x95, 0xd6, 0xff, 0x8c, 0x94, 0x47,
-	0x23, 0xe3, 0xd1, 0xe8, 0x0a, 0x40, 0xe7, 0x4e, 0xd2, 0xe9, 0xc9, 0x5c, 0xbb, 0x95, 0x06, 0x3e,
-	0x1b, 0xe0, 0x36, 0xf5, 0xdd, 0x98, 0xf8, 0x61, 0x3c, 0xd3, 0xbf, 0xff, 0x43, 0x93, 0xf0, 0xba,
-	0x6f, 0x1d, 0x8e, 0x0e, 0x83, 0xcc, 0x9d, 0xe7, 0x74, 0x83, 0xd3, 0x39, 0xe5, 0xb7, 0xcd, 0x79,
-	0x26, 0xc0, 0xf9, 0x9c, 0x6e, 0xb0, 0x9a, 0x53, 0x85, 0x92, 0xe3, 0x32, 0x6b, 0xdf, 0x23, 0x6a,
-	0x69, 0x4b, 0x6a, 0x96, 0x71, 0x26, 0xa2, 0x1d, 0xa8, 0x4e, 0x68, 0x6c, 0xb2, 0xd8, 0x0a, 0x9c,
-	0xfd, 0x19, 0x53, 0xcb, 0x5b, 0x52, 0xf3, 0x52, 0x67, 0x73, 0x39, 0xa7, 0x55, 0xab, 0x8e, 0x95,
-	0x09, 0x8d, 0x87, 0x42, 0x42, 0x4f, 0x41, 0xf6, 0xac, 0x7d, 0xe2, 0x31, 0xb5, 0xc2, 0xeb, 0x47,
-	0xc6, 0x62, 0x40, 0xfd, 0x44, 0x3f, 0x24, 0x71, 0xe7, 0xdd, 0xa4, 0xf0, 0x57, 0x73, 0x4d, 0x3a,
-	0x99, 0x6b, 0x6a, 0x1a, 0x71, 0x59, 0xec, 0x6d, 0x37, 0xf0, 0xdc, 0x80, 0xe8, 0x58, 0xc4, 0xa9,
-	0xff, 0x20, 0x81, 0x9c, 0xee, 0x13, 0xfa, 0x02, 0x4a, 0x5f, 0xa7, 0x53, 0x16, 0xeb, 0xf9, 0xd1,
-	0xbf, 0x5d, 0x92, 0x2c, 0x0e, 0xfa, 0x0c, 0x20, 0x21, 0x8e, 0x3e, 0x7b, 0xc6, 0x48, 0xcc, 0x37,
-	0xb2, 0xd0, 0xd1, 0x4e, 0xe6, 0xda, 0x8d, 0x25, 0xa9, 0xa9, 0x6d, 0x85, 0x4e, 0x5c, 0xf1, 0xdd,
-	0x60, 0x8f, 0x6b, 0xf5, 0x6f, 0x25, 0xa8, 0xee, 0x8a, 0x03, 0xe0, 0xd7, 0x33, 0x82, 0x6a, 0x18,
-	0x51, 0x9b, 0x30, 0x66, 0xb2, 0x90, 0xd8, 0xbc, 0x50, 0xa5, 0x75, 0x6d, 0x49, 0xc3, 0xd3, 0xd4,
-	0x9a, 0x80, 0x3b, 0xf5, 0x15, 0x26, 0xd6, 0x05, 0x13, 0x59, 0xff, 0x4a, 0xb8, 0x04, 0x22, 0x0d,
-	0x14, 0x96, 0x5c, 0x97, 0xe9, 0xb9, 0xbe, 0x1b, 0xab, 0xf9, 0x64, 0x22, 0x18, 0xb8, 0xaa, 0x9f,
-	0x68, 0xf4, 0x9f, 0x24, 0xb8, 0x84, 0x49, 0xe8, 0xb9, 0xb6, 0x35, 0x8c, 0xad, 0x78, 0xca, 0xd0,
-	0x07, 0x50, 0xb4, 0xa9, 0x43, 0x78, 0x01, 0xeb, 0xad, 0x9b, 0xcb, 0x33, 0x3d, 0x05, 0x33, 0x76,
-	0xa9, 0x43, 0x30, 0x47, 0xa2, 0xeb, 0x20, 0x93, 0x28, 0xa2, 0x51, 0x7a, 0xda, 0x15, 0x2c, 0x24,
-	0xfd, 0x21, 0x14, 0x13, 0x14, 0x2a, 0x43, 0xb1, 0xd7, 0xed, 0xdf, 0xaf, 0xe5, 0x50, 0x15, 0xca,
-	0x9d, 0xf6, 0xee, 0xe3, 0x07, 0xbd, 0x7e, 0xbf, 0xe6, 0xa0, 0x2a, 0x94, 0x46, 0xed, 0x5e, 0xbf,
-	0x37, 0x78, 0x58, 0x3b, 0x92, 0x12, 0xe9, 0x29, 0xee, 0x3d, 0x69, 0xe3, 0xaf, 0x6a, 0x3f, 0xe7,
-	0x91, 0x02, 0xf2, 0x83, 0x76, 0xaf, 0x7f, 0xbf, 0x5b, 0x7b, 0x59, 0xd0, 0x1f, 0x81, 0xd2, 0x77,
-	0x59, 0x8c, 0xc9, 0x8b, 0x29, 0x61, 0x31, 0xfa, 0x04, 0xca, 0x8c, 0x78, 0xc4, 0x8e, 0x69, 0x24,
-	0x68, 0xda, 0x3c, 0xb7, 0x2d, 0xa9, 0x59, 0xbc, 0x24, 0x0b, 0xb8, 0xfe, 0x57, 0x1e, 0xaa, 0x69,
-	0x28, 0x16, 0xd2, 0x80, 0x11, 0xd4, 0x04, 0x99, 0xf1, 0x86, 0x44, 0xbf, 0xb5, 0x95, 0x67, 0x89,
-	0xeb, 0xb1, 0xb0, 0x23, 0x03, 0xe4, 0x09, 0xb1, 0x1c, 0x12, 0x71, 0x16, 0x95, 0x56, 0x6d, 0x99,
-	0xf3, 0x11, 0xd7, 0x8b, 0x64, 0x02, 0x85, 0x76, 0x40, 0xe6, 0x3c, 0x33, 0xb5, 0xc0, 0x1f, 0xbc,
-	0x15, 0x26, 0x57, 0x2b, 0x48, 0x5f, 0xbf, 0xcc, 0x37, 0xf5, 0xa8, 0xff, 0x2a, 0xc1, 0x1a, 0xd7,
-	0xa3, 0x3b, 0x50, 0x5c, 0x59, 0x87, 0x8d, 0x0b, 0x1e, 0x4d, 0xe1, 0xca, 0x61, 0xe8, 0x16, 0x54,
-	0x7d, 0xea, 0x98, 0x11, 0x39, 0x70, 0x59, 0xf2, 0x18, 0x24, 0xa5, 0x16, 0xb0, 0xe2, 0x53, 0x07,
-	0x0b, 0x15, 0x7a, 0x1f, 0xd6, 0x22, 0x3a, 0x8d, 0x09, 0x5f, 0x5a, 0xa5, 0x75, 0x79, 0xd9, 0x06,
-	0x4e, 0xd4, 0x22, 0x5c, 0x8a, 0x41, 0xf7, 0x16, 0xf4, 0x14, 0x79, 0x13, 0x9b, 0xff, 0xb0, 0x0e,
-	0x8b, 0xfa, 0xb9, 0xa4, 0xff, 0x2e, 0x41, 0xb5, 0x1d, 0x86, 0xde, 0x2c, 0x1b, 0xd9, 0xa7, 0x50,
-	0xb2, 0x27, 0x56, 0x30, 0x26, 0x09, 0xcf, 0x49, 0xa0, 0xff, 0x2f, 0x03, 0xad, 0x02, 0x8d, 0x5d,
-	0x8e, 0xca, 0xfe, 0x00, 0xc2, 0xa7, 0xfe, 0x9d, 0x04, 0x72, 0x6a, 0x41, 0x06, 0x6c, 0x90, 0xc3,
-	0x90, 0xd8, 0xb1, 0x79, 0xaa, 0x51, 0x89, 0x37, 0x7a, 0x25, 0x35, 0x3d, 0x39, 0xd5, 0xae, 0x3c,
-	0x0d, 0x19, 0x89, 0x62, 0x31, 0xb6, 0x8b, 0x28, 0xc4, 0x02, 0x82, 0xde, 0x01, 0xd9, 0x21, 0x1e,
-	0x11, 0xe4, 0x9c, 0xf9, 0x8d, 0x09, 0x93, 0xee, 0xc2, 0x25, 0x51, 0xf2, 0x7f, 0xbd, 0x43, 0xef,
-	0x51, 0x90, 0xc5, 0x55, 0xca, 0x90, 0xdf, 0x7b, 0x5c, 0xcb, 0xa1, 0x0d, 0xb8, 0x3c, 0x7c, 0xd4,
-	0xc6, 0x5d, 0x73, 0xb0, 0x37, 0x32, 0x1f, 0xec, 0x7d, 0x39, 0xe8, 0xd6, 0x24, 0x74, 0x15, 0x6a,
-	0x83, 0x3d, 0x33, 0xd5, 0x67, 0x37, 0x94, 0x47, 0xd7, 0xe0, 0x4a, 0x02, 0x3a, 0xad, 0x2e, 0xa0,
-	0x1b, 0xb0, 0x79, 0x7f, 0xb4, 0xdb, 0x35, 0x47, 0xb8, 0x3d, 0x18, 0xb6, 0x77, 0x47, 0xbd, 0xbd,
-	0x81, 0x29, 0x4e, 0xad, 0xd8, 0x3a, 0xcc, 0xf6, 0xee, 0x1e, 0x14, 0x93, 0x2d, 0x45, 0xd7, 0xce,
-	0x6e, 0x2d, 0x1f, 0x53, 0xfd, 0xfa, 0xc5, 0xcb, 0x8c, 0x3e, 0x86, 0x35, 0xce, 0x0d, 0xba, 0x7e,
-	0xf1, 0x7c, 0xeb, 0x9b, 0xe7, 0xf4, 0xa9, 0x67, 0xe7, 0xe6, 0xd1, 0x9f, 0x8d, 0xdc, 0xd1, 0xeb,
-	0x86, 0xf4, 0xea, 0x75, 0x43, 0x7a, 0x79, 0xdc, 0xc8, 0xfd, 0x78, 0xdc, 0x90, 0x5e, 0x1d, 0x37,
-	0x72, 0xbf, 0x1d, 0x37, 0x72, 0xfb, 0x32, 0xe7, 0xe9, 0xee, 0xdf, 0x01, 0x00, 0x00, 0xff, 0xff,
-	0x50, 0x19, 0x92, 0x34, 0x17, 0x09, 0x00, 0x00,
+func init() { proto.RegisterFile("consumer.proto", fileDescriptor_consumer_a2b4ed1092406922) }
+
+var fileDescriptor_consumer_a2b4ed1092406922 = []byte{
+	// 1356 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0xbd, 0x6f, 0xdb, 0xd6,
+	0x16, 0x37, 0x25, 0x59, 0x96, 0x0f, 0x65, 0x9b, 0xb9, 0x4e, 0x62, 0x3e, 0x25, 0x11, 0x15, 0xbe,
+	0x3c, 0x40, 0x78, 0x75, 0xe4, 0x54, 0x41, 0xd0, 0xd6, 0x40, 0x0b, 0x48, 0x96, 0x93, 0xa8, 0x51,
+	0xac, 0x94, 0x72, 0x11, 0x74, 0x22, 0x28, 0xea, 0x5a, 0x62, 0x4d, 0xf1, 0x32, 0x24, 0xe5, 0x5a,
+	0x45, 0x87, 0xce, 0x9d, 0x32, 0x16, 0xe8, 0xd2, 0x3f, 0xa0, 0xe8, 0xd2, 0xb9, 0x9d, 0x3d, 0x66,
+	0x2c, 0x32, 0xa8, 0x68, 0xdc, 0x3f, 0xa0, 0xf0, 0x98, 0xa9, 0xb8, 0x1f, 0x94, 0x68, 0x3b, 0x69,
+	0x90, 0x02, 0xdd, 0x78, 0xcf, 0xef, 0x77, 0x3e, 0xee, 0xf9, 0xba, 0x84, 0x65, 0x9b, 0x78, 0xe1,
+	0x68, 0x88, 0x83, 0x8a, 0x1f, 0x90, 0x88, 0xa0, 0x5c, 0x7c, 0x2e, 0x6c, 0xf6, 0x9d, 0x68, 0x30,
+	0xea, 0x56, 0x6c, 0x32, 0xdc, 0x68, 0x39, 0x07, 0xd8, 0xb0, 0x86, 0xfe, 0x46, 0xdf, 0xfa, 0x12,
+	0x47, 0x11, 0xde, 0x38, 0xa8, 0x6e, 0xf8, 0xfb, 0xfd, 0x0d, 0xa6, 0x63, 0x13, 0x77, 0xfa, 0xc1,
+	0xad, 0x14, 0x6e, 0x26, 0x74, 0xfb, 0xa4, 0x4f, 0x38, 0xde, 0x1d, 0xed, 0xb1, 0x13, 0x3b, 0xb0,
+	0x2f, 0x41, 0x2f, 0xf6, 0x09, 0xe9, 0xbb, 0x78, 0xc6, 0xea, 0x8d, 0x02, 0x2b, 0x72, 0x88, 0xc7,
+	0x71, 0xfd, 0x4f, 0x80, 0xc5, 0xce, 0xc0, 0x0a, 0x7a, 0x1d, 0x1f, 0xdb, 0xe8, 0x0a, 0xa4, 0x9c,
+	0x9e, 0x2a, 0x95, 0xa4, 0xf2, 0x62, 0x5d, 0x7e, 0x39, 0xd1, 0x16, 0x18, 0xd4, 0x6c, 0x18, 0x29,
+	0xa7, 0x87, 0x36, 0x61, 0x21, 0x24, 0xa3, 0xc0, 0xc6, 0xa1, 0x9a, 0x2a, 0xa5, 0xcb, 0x72, 0xb5,
+	0x50, 0x99, 0xde, 0x70, 0x6a, 0xa2, 0xd2, 0x61, 0x94, 0x7a, 0xe6, 0x68, 0xa2, 0xcd, 0x19, 0xb1,
+	0x02, 0x7a, 0x02, 0xf9, 0x00, 0xdb, 0xe4, 0x00, 0x07, 0x63, 0xd3, 0x25, 0x7d, 0x35, 0xcd, 0x5c,
+	0xec, 0x9c, 0x4c, 0xb4, 0xd5, 0xb1, 0x35, 0x74, 0x37, 0xf5, 0x24, 0xaa, 0xbf, 0x9c, 0x68, 0xb7,
+	0xdf, 0x22, 0x45, 0x95, 0x8f, 0xc9, 0x28, 0xf0, 0x2c, 0xd7, 0x90, 0x63, 0x2b, 0x2d, 0xd2, 0x47,
+	0xef, 0xc2, 0xe2, 0xc0, 0xf1, 0x22, 0x73, 0x1f, 0x8f, 0x43, 0x35, 0x53, 0x4a, 0x97, 0x17, 0xeb,
+	0x17, 0x4f, 0x26, 0x9a, 0xc2, 0xfd, 0x4d, 0x21, 0xdd, 0xc8, 0xd1, 0xef, 0x07, 0x78, 0x1c, 0xa2,
+	0x00, 0x94, 0xa1, 0x75, 0x68, 0x46, 0x87, 0x9e, 0x19, 0xa7, 0x49, 0x9d, 0x2f, 0x49, 0x65, 0xb9,
+	0xfa, 0x9f, 0x0a, 0xcf, 0x63, 0x25, 0xce, 0x63, 0xa5, 0x21, 0x08, 0xf5, 0x9b, 0xf4, 0xa6, 0x27,
+	0x13, 0xed, 0x3a, 0x37, 0x7c, 0xd6, 0xc0, 0x3a, 0x19, 0x3a, 0x11, 0x1e, 0xfa, 0xd1, 0x58, 0xff,
+	0xf6, 0x37, 0x4d, 0x32, 0x96, 0x87, 0xd6, 0xe1, 0xee, 0xa1, 0x17, 0xab, 0x33, 0x9f, 0x8e, 0x77,
+	0xda, 0x67, 0xf6, 0x6d, 0x7d, 0x3a, 0xde, 0x1b, 0x7c, 0x3a, 0x5e, 0xd2, 0xa7, 0x0a, 0x0b, 0x3d,
+	0x27, 0xb4, 0xba, 0x2e, 0x56, 0x17, 0x4a, 0x52, 0x39, 0x67, 0xc4, 0x47, 0xb4, 0x09, 0xf9, 0x01,
+	0x89, 0xcc, 0x30, 0xb2, 0xbc, 0x5e, 0x77, 0x1c, 0xaa, 0xb9, 0x92, 0x54, 0x5e, 0xaa, 0xaf, 0xcd,
+	0xea, 0x94, 0x44, 0x75, 0x43, 0x1e, 0x90, 0xa8, 0x23, 0x4e, 0xe8, 0x11, 0x64, 0x5d, 0xab, 0x8b,
+	0xdd, 0x50, 0x5d, 0x64, 0xf1, 0xa3, 0xca, 0xb4, 0x40, 0x2d, 0x2a, 0xef, 0xe0, 0xa8, 0x7e, 0x83,
+	0x06, 0xfe, 0x6c, 0xa2, 0x49, 0x27, 0x13, 0x4d, 0xe5, 0x16, 0x67, 0xc1, 0xae, 0x3b, 0x9e, 0xeb,
+	0x78, 0x58, 0x37, 0x84, 0x1d, 0xf4, 0xa3, 0x04, 0x17, 0xe2, 0x16, 0x33, 0x43, 0xec, 0x62, 0x3b,
+	0x22, 0x81, 0x0a, 0xcc, 0xfa, 0xda, 0x39, 0xeb, 0x1c, 0xae, 0x0f, 0xa8, 0x8b, 0xe7, 0x13, 0xed,
+	0x6d, 0x86, 0xac, 0xf2, 0x10, 0x0f, 0xbb, 0x38, 0x88, 0x6d, 0x9c, 0x4c, 0x34, 0x9d, 0x07, 0x77,
+	0xce, 0x7b, 0x22, 0xb5, 0x86, 0x12, 0xa3, 0xb1, 0x1e, 0xfa, 0x0a, 0x56, 0xed, 0x01, 0xb6, 0xf7,
+	0x7d, 0x42, 0xdb, 0xcb, 0xf1, 0x22, 0x1c, 0x1c, 0x58, 0xae, 0x2a, 0xbf, 0xa9, 0x9e, 0xb7, 0x44,
+	0x3d, 0x6f, 0x08, 0xaf, 0xe7, 0x6d, 0x9c, 0x2d, 0x29, 0x9a, 0x71, 0x9a, 0x82, 0x82, 0x1a, 0x90,
+	0xa7, 0xdd, 0x17, 0xe0, 0x30, 0xb2, 0x82, 0x28, 0x54, 0xf3, 0xac, 0x78, 0xd7, 0x4f, 0x26, 0xda,
+	0xb5, 0x59, 0x6f, 0xc6, 0x68, 0xf2, 0x22, 0xf2, 0xd0, 0x3a, 0x34, 0x84, 0x1c, 0xf9, 0xb0, 0x22,
+	0x38, 0x66, 0xd7, 0xb2, 0xf7, 0xc9, 0xde, 0x9e, 0xba, 0xf4, 0xa6, 0xf8, 0xd7, 0x45, 0xfc, 0xa5,
+	0x78, 0x98, 0x4f, 0xe9, 0x9f, 0x6b, 0x47, 0x81, 0xd7, 0x39, 0x8c, 0xda, 0xa0, 0x04, 0xb8, 0x6b,
+	0x85, 0x98, 0x0e, 0xbf, 0xd9, 0x1d, 0x47, 0x38, 0x54, 0x97, 0x4b, 0x52, 0x39, 0x5d, 0xff, 0xdf,
+	0xac, 0xc7, 0xcf, 0x32, 0x92, 0xf1, 0x2f, 0x73, 0xb0, 0x45, 0xfa, 0x75, 0x0a, 0x15, 0xbe, 0x93,
+	0x20, 0xcb, 0xf7, 0x10, 0xfa, 0x04, 0x16, 0x3e, 0xe7, 0xdb, 0x41, 0xac, 0xb5, 0xf7, 0xfe, 0xe9,
+	0x72, 0x89, 0xed, 0xa0, 0x8f, 0x00, 0xe8, 0xc0, 0x91, 0xbd, 0xbd, 0x10, 0x47, 0x6c, 0x93, 0xa5,
+	0xeb, 0xda, 0xc9, 0x44, 0xbb, 0x32, 0x1b, 0x46, 0x8e, 0x25, 0x43, 0x5c, 0x1c, 0x3a, 0x5e, 0x9b,
+	0x49, 0xf5, 0x9f, 0x24, 0xc8, 0x6f, 0xc5, 0x9d, 0x43, 0xb7, 0xee, 0x2e, 0xe4, 0xfd, 0x80, 0xd8,
+	0x38, 0x0c, 0xcd, 0xd0, 0xc7, 0x36, 0x0b, 0x54, 0xae, 0x5e, 0x9a, 0x35, 0xf8, 0x23, 0x8e, 0x52,
+	0x72, 0xbd, 0x90, 0x98, 0xa0, 0x65, 0x31, 0x41, 0xf1, 0xdc, 0xc8, 0xfe, 0x8c, 0x88, 0x34, 0x90,
+	0x43, 0xba, 0x95, 0x4d, 0xd7, 0x19, 0x3a, 0x91, 0x9a, 0xa2, 0xcd, 0x60, 0x00, 0x13, 0xb5, 0xa8,
+	0x04, 0xad, 0x03, 0xe2, 0x84, 0x2f, 0xb0, 0xd3, 0x1f, 0x44, 0x82, 0x97, 0x66, 0x3c, 0x85, 0x21,
+	0x8f, 0x19, 0xc0, 0xd8, 0xfa, 0x2f, 0x12, 0x2c, 0x19, 0xd8, 0x77, 0x1d, 0xdb, 0xea, 0x44, 0x56,
+	0x34, 0x0a, 0xd1, 0x2d, 0xc8, 0xd8, 0xa4, 0x87, 0x59, 0xb8, 0xcb, 0xd5, 0xab, 0xb3, 0xc7, 0xe0,
+	0x14, 0xad, 0xb2, 0x45, 0x7a, 0xd8, 0x60, 0x4c, 0x74, 0x19, 0xb2, 0x38, 0x08, 0x48, 0xc0, 0x1f,
+	0x90, 0x45, 0x43, 0x9c, 0x50, 0x01, 0x72, 0xd3, 0xa6, 0xe5, 0xfe, 0xa7, 0x67, 0xfd, 0x1e, 0x64,
+	0xa8, 0x05, 0x94, 0x83, 0x4c, 0xb3, 0xd1, 0xda, 0x56, 0xe6, 0x50, 0x1e, 0x72, 0xf5, 0xda, 0xd6,
+	0x83, 0xbb, 0xcd, 0x56, 0x4b, 0xe9, 0xa1, 0x3c, 0x2c, 0xec, 0xd6, 0x9a, 0xad, 0xe6, 0xce, 0x3d,
+	0xe5, 0x48, 0xa2, 0xa7, 0x47, 0x46, 0xf3, 0x61, 0xcd, 0xf8, 0x4c, 0xf9, 0x21, 0x85, 0x64, 0xc8,
+	0xde, 0xad, 0x35, 0x5b, 0xdb, 0x0d, 0xe5, 0x69, 0x5a, 0xbf, 0x0f, 0x72, 0xcb, 0x09, 0x23, 0x03,
+	0x3f, 0x19, 0xe1, 0x30, 0x42, 0x1f, 0x40, 0x6e, 0xba, 0x51, 0xa4, 0xbf, 0xdf, 0x28, 0xfc, 0x2d,
+	0x9b, 0xd2, 0xf5, 0x3f, 0x52, 0x90, 0xe7, 0xa6, 0x42, 0x9f, 0x78, 0x21, 0x46, 0x65, 0xc8, 0x86,
+	0xec, 0xb2, 0x22, 0x17, 0x4a, 0xe2, 0x61, 0x64, 0x72, 0x43, 0xe0, 0xa8, 0x02, 0xd9, 0x01, 0xb6,
+	0x7a, 0x38, 0x60, 0xf5, 0x90, 0xab, 0xca, 0xcc, 0xe7, 0x7d, 0x26, 0x17, 0xce, 0x04, 0x0b, 0x6d,
+	0x42, 0x96, 0x55, 0x82, 0xe6, 0x85, 0x3e, 0xb9, 0x89, 0x2c, 0x27, 0x23, 0xe0, 0xef, 0x6f, 0xac,
+	0xcb, 0x35, 0x0a, 0x3f, 0x4b, 0x30, 0xcf, 0xe4, 0xe8, 0x26, 0x64, 0x12, 0x8d, 0xb5, 0xfa, 0x8a,
+	0x67, 0x5b, 0xa8, 0x32, 0x1a, 0xba, 0x0e, 0xf9, 0x21, 0xe9, 0x99, 0x01, 0x3e, 0x70, 0x42, 0xfa,
+	0x1c, 0xd1, 0x50, 0xd3, 0x86, 0x3c, 0x24, 0x3d, 0x43, 0x88, 0xd0, 0x3b, 0x30, 0x1f, 0x90, 0x51,
+	0x84, 0x59, 0xb9, 0xe4, 0xea, 0xca, 0xec, 0x1a, 0x06, 0x15, 0x0b, 0x73, 0x9c, 0x83, 0xee, 0x4c,
+	0xd3, 0x93, 0x61, 0x97, 0x58, 0x7b, 0x4d, 0xab, 0x4c, 0xe3, 0x67, 0x27, 0xfd, 0xb9, 0x04, 0xf9,
+	0x9a, 0xef, 0xbb, 0xe3, 0xb8, 0x64, 0x1f, 0xc2, 0x82, 0x3d, 0xb0, 0xbc, 0x3e, 0xa6, 0x79, 0xa6,
+	0x86, 0xae, 0xcd, 0x0c, 0x25, 0x89, 0x95, 0x2d, 0xc6, 0x8a, 0xff, 0x41, 0x84, 0x4e, 0xe1, 0x1b,
+	0x09, 0xb2, 0x1c, 0x41, 0x15, 0x58, 0xc5, 0x87, 0x3e, 0xb6, 0x23, 0xf3, 0xd4, 0x45, 0x25, 0x76,
+	0xd1, 0x0b, 0x1c, 0x7a, 0x78, 0xea, 0xba, 0xd9, 0x91, 0x1f, 0xe2, 0x20, 0x52, 0x53, 0xaf, 0x4d,
+	0xa1, 0x21, 0x28, 0xe8, 0xbf, 0x90, 0xed, 0x61, 0x17, 0x8b, 0xe4, 0x9c, 0xf9, 0x91, 0x12, 0x90,
+	0xee, 0xc0, 0x92, 0x08, 0xf9, 0xdf, 0xee, 0xa1, 0xff, 0x7f, 0x4d, 0xb7, 0x21, 0x57, 0xcd, 0x42,
+	0xaa, 0xfd, 0x40, 0x99, 0x43, 0xab, 0xb0, 0xd2, 0xb9, 0x5f, 0x33, 0x1a, 0xe6, 0x4e, 0x7b, 0xd7,
+	0xbc, 0xdb, 0xfe, 0x74, 0xa7, 0xa1, 0x48, 0xe8, 0x22, 0x28, 0x3b, 0x6d, 0x93, 0xcb, 0xe3, 0x21,
+	0x4a, 0xa1, 0x4b, 0x70, 0x81, 0x92, 0x4e, 0x8b, 0xd3, 0xe8, 0x0a, 0xac, 0x6d, 0xef, 0x6e, 0x35,
+	0xcc, 0x5d, 0xa3, 0xb6, 0xd3, 0xa9, 0x6d, 0xed, 0x36, 0xdb, 0x3b, 0xa6, 0x98, 0xb5, 0x0c, 0x5a,
+	0x01, 0x99, 0xea, 0xd4, 0x5a, 0xad, 0xf6, 0xe3, 0xed, 0x86, 0x32, 0x5f, 0x3d, 0x8c, 0x3b, 0xf1,
+	0x0e, 0x64, 0x68, 0xdf, 0xa2, 0x4b, 0x67, 0xfb, 0x98, 0x15, 0xae, 0x70, 0xf9, 0xd5, 0xed, 0x8d,
+	0xde, 0x87, 0x79, 0x96, 0x2d, 0x74, 0xf9, 0xd5, 0x15, 0x2f, 0xac, 0x9d, 0x93, 0x73, 0xcd, 0xfa,
+	0xd5, 0xa3, 0xdf, 0x8b, 0x73, 0x47, 0x2f, 0x8a, 0xd2, 0xb3, 0x17, 0x45, 0xe9, 0xe9, 0x71, 0x71,
+	0xee, 0xfb, 0xe3, 0xa2, 0xf4, 0xec, 0xb8, 0x38, 0xf7, 0xeb, 0x71, 0x71, 0xae, 0x9b, 0x65, 0x99,
+	0xbb, 0xfd, 0xd7, 0x00, 0x21, 0xc0, 0x1d, 0x4f, 0xab, 0x0b, 0x00, 0x00,
 }
@@ -47,6 +47,22 @@ type Store interface {
 	Destroy()
 }
 
+// Checkpointer is an optional interface of Store which is able to checkpoint
+// its live files into a fragment store. Checkpoints allow a recovering Shard to
+// restore Store files directly from the fragment store, and to replay only the
+// portion of its recovery log which follows the checkpoint.
+type Checkpointer interface {
+	// CaptureCheckpoint of the Store, for persistence into the FragmentStore.
+	// It's called between consumer transactions, after the Store has been
+	// Flushed and its recorded writes have committed. The returned checkpoint
+	// is then persisted concurrently with further transactions, and Store files
+	// may not be re-written in place (see recoverylog.Recorder's
+	// CaptureCheckpoint). Typically it ensures Store files aren't removed while
+	// live files are captured, and then delegates to the Recorder. Stores which
+	// remove files only within Flush need not do anything further.
+	CaptureCheckpoint(store pb.FragmentStore) (*recoverylog.CapturedCheckpoint, error)
+}
+
 // Application is the interface provided by domain applications
 // running as Gazette consumers. Only unrecoverable errors should be
 // returned by Application. A returned error will abort processing of an
@@ -119,3 +135,23 @@ type BeginFinisher interface {
 	// journal writes of the transaction may still be ongoing
 	FinishTxn(Shard, Store)
 }
+
+// ViewOpener is an optional interface of Application which is able to open
+// read-only views of a Store. Views allow hot standby Replicas, which are
+// tailing the shard recovery log, to serve queries rather than the primary.
+// See ResolveArgs.MayResolveStandby.
+type ViewOpener interface {
+	// OpenView opens a read-only view of the Store of |shard|, from local
+	// directory |dir| into which the standby's recovered files have been
+	// linked. When linked, the files of |dir| reflect all Store writes which
+	// were committed to the recovery log prior to the view's request. They're
+	// not a snapshot, however: files share content with the standby, and
+	// reflect further writes which the standby applies to them as it tails the
+	// log (see recoverylog.Player.LinkView). An implementation should read
+	// only content which isn't re-written (such as immutable files), or should
+	// read its files once while opening the view. The returned Store is used
+	// only for reads: its Recorder may be nil, and it will not be Flushed.
+	// Files of |dir| must not be modified, though new files may be created.
+	// Destroy is called when the view is no longer used.
+	OpenView(shard Shard, dir string) (Store, error)
+}
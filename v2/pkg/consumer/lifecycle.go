@@ -6,6 +6,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"net/url"
+	"path/filepath"
 	"time"
 
 	"github.com/LiveRamp/gazette/v2/pkg/client"
@@ -13,16 +15,27 @@ import (
 	"github.com/LiveRamp/gazette/v2/pkg/metrics"
 	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
 	"github.com/LiveRamp/gazette/v2/pkg/recoverylog"
+	"github.com/LiveRamp/gazette/v2/pkg/tracing"
 	"github.com/coreos/etcd/clientv3"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 )
 
-// playLog fetches current shard hints and plays them back into a temporary directory using the Player.
-func playLog(shard Shard, pl *recoverylog.Player, etcd *clientv3.Client) error {
-	if dir, err := ioutil.TempDir("", shard.Spec().Id.String()+"-"); err != nil {
+// playLog fetches current shard hints and plays them back into a working
+// directory using the Player. If |localDir| is non-empty, the working directory
+// is a stable directory of the shard under |localDir|, and the Player retains
+// (and later resumes from) its local state. Otherwise, a temporary directory is used.
+func playLog(shard Shard, pl *recoverylog.Player, etcd *clientv3.Client, localDir string) error {
+	var dir string
+	var err error
+
+	if localDir != "" {
+		dir, pl.RetainLocalState = filepath.Join(localDir, url.PathEscape(shard.Spec().Id.String())), true
+	} else if dir, err = ioutil.TempDir("", shard.Spec().Id.String()+"-"); err != nil {
 		return extendErr(err, "creating shard working directory")
-	} else if hints, _, err := fetchHints(shard.Context(), shard.Spec(), etcd); err != nil {
+	}
+
+	if hints, _, err := fetchHints(shard.Context(), shard.Spec(), etcd); err != nil {
 		return extendErr(err, "fetching FSM hints")
 	} else if err = pl.Play(shard.Context(), hints, dir, shard.JournalClient()); err != nil {
 		return extendErr(err, "playing log %s", hints.Log)
@@ -34,7 +47,7 @@ func playLog(shard Shard, pl *recoverylog.Player, etcd *clientv3.Client) error {
 // stores recovered hints, initializes an Application Store, and returns
 // offsets at which journal consumption should continue.
 func completePlayback(shard Shard, app Application, pl *recoverylog.Player,
-	etcd *clientv3.Client) (Store, map[pb.Journal]int64, error) {
+	etcd *clientv3.Client, hc hintsConfig) (Store, map[pb.Journal]int64, error) {
 
 	var author, err = recoverylog.NewRandomAuthorID()
 	if err != nil {
@@ -58,7 +71,7 @@ func completePlayback(shard Shard, app Application, pl *recoverylog.Player,
 
 	// We've completed log playback, and we're likely the most recent shard
 	// primary to do so. Store our recovered hints.
-	if err = storeRecoveredHints(shard, pl.FSM.BuildHints(), etcd); err != nil {
+	if err = storeRecoveredHints(shard, pl.FSM.BuildHints(), etcd, hc); err != nil {
 		return nil, nil, extendErr(err, "storingRecoveredHints")
 	}
 	// Initialize the store.
@@ -132,8 +145,10 @@ func pumpMessages(shard Shard, app Application, journal pb.Journal, offset int64
 
 // consumeMessages runs consumer transactions, consuming from the provided
 // |msgCh| and, when notified by |hintsCh|, occasionally stores recorded FSMHints.
-func consumeMessages(shard Shard, store Store, app Application, etcd *clientv3.Client,
-	msgCh <-chan message.Envelope, hintsCh <-chan time.Time) (err error) {
+// When notified by |checkpointCh|, a checkpoint of the Store is captured
+// between transactions and persisted in the background (see checkpointStore).
+func consumeMessages(shard Shard, store Store, app Application, etcd *clientv3.Client, hc hintsConfig,
+	msgCh <-chan message.Envelope, hintsCh, checkpointCh <-chan time.Time) (err error) {
 
 	// Supply an idle timer for txnStep's use in timing transaction durations.
 	var realTimer = time.NewTimer(0)
@@ -147,17 +162,52 @@ func consumeMessages(shard Shard, store Store, app Application, etcd *clientv3.C
 	}
 	var txn, prior transaction
 
+	// |checkpointDoneCh| is non-nil while a checkpoint is being persisted.
+	var checkpointDue bool
+	var checkpointDoneCh <-chan error
+
+	defer func() {
+		if checkpointDoneCh != nil {
+			<-checkpointDoneCh // Don't return while the Store is being checkpointed.
+		}
+	}()
+
 	for {
 		select {
 		case <-hintsCh:
-			if err = storeRecordedHints(shard, store.Recorder().BuildHints(), etcd); err != nil {
+			var hints recoverylog.FSMHints
+
+			// A pending checkpoint stores FSMHints upon its completion. Don't
+			// race it with hints built prior to the checkpoint being applied.
+			if checkpointDoneCh != nil {
+				// Pass.
+			} else if hints, err = buildRecordedHints(store.Recorder()); err != nil {
+				return
+			} else if err = storeRecordedHints(shard, hints, etcd, hc); err != nil {
 				err = extendErr(err, "storeRecordedHints")
 				return
 			}
+		case <-checkpointCh:
+			checkpointDue = true
+		case err = <-checkpointDoneCh:
+			if checkpointDoneCh = nil; err != nil {
+				err = extendErr(err, "checkpointStore")
+				return
+			}
 		default:
 			// Pass.
 		}
 
+		// We're at a transaction boundary. Begin a due checkpoint, unless the
+		// prior one is still being persisted.
+		if checkpointDue && checkpointDoneCh == nil {
+			if checkpointDoneCh, err = checkpointStore(shard, store, etcd, hc); err != nil {
+				err = extendErr(err, "checkpointStore")
+				return
+			}
+			checkpointDue = false
+		}
+
 		var spec = shard.Spec()
 		txn.minDur, txn.maxDur = spec.MinTxnDuration, spec.MaxTxnDuration
 		txn.msgCh = msgCh
@@ -170,6 +220,10 @@ func consumeMessages(shard Shard, store Store, app Application, etcd *clientv3.C
 			ba.FinishTxn(shard, store)
 		}
 		if err != nil {
+			// Finish Spans of transactions which won't otherwise complete.
+			txn.span.Finish(err)
+			prior.span.Finish(err)
+
 			err = extendErr(err, "txnStep")
 			return
 		}
@@ -179,6 +233,137 @@ func consumeMessages(shard Shard, store Store, app Application, etcd *clientv3.C
 	}
 }
 
+// checkpointStore captures a checkpoint of the Store once all writes pending
+// at the current transaction boundary have committed. The captured checkpoint
+// is then persisted in the background into the first fragment store of the
+// recovery log, after which recorded FSMHints which reference it are stored,
+// and checkpoint files no longer referenced by any HintKeys are pruned. The
+// returned channel is signaled with the outcome of the background persistence.
+func checkpointStore(shard Shard, store Store, etcd *clientv3.Client, hc hintsConfig) (<-chan error, error) {
+	var cp, ok = store.(Checkpointer)
+	if !ok {
+		return nil, errors.Errorf("store does not implement Checkpointer")
+	}
+	var spec, err = fetchJournalSpec(shard.Context(), shard.Spec().RecoveryLog, shard.JournalClient())
+	if err != nil {
+		return nil, extendErr(err, "fetching JournalSpec")
+	} else if len(spec.Fragment.Stores) == 0 {
+		return nil, errors.Errorf("recovery log has no fragment stores (%s)", spec.Name)
+	}
+	var fs = spec.Fragment.Stores[0]
+
+	// Checkpoint only recorded writes which are known to have committed.
+	var barrier = store.Recorder().StrongBarrier()
+	select {
+	case <-barrier.Done():
+		if err = barrier.Err(); err != nil {
+			return nil, extendErr(err, "awaiting StrongBarrier")
+		} else if err = store.Recorder().Err(); err != nil {
+			return nil, extendErr(err, "recovery log")
+		}
+	case <-shard.Context().Done():
+		return nil, shard.Context().Err()
+	}
+
+	captured, err := cp.CaptureCheckpoint(fs)
+	if err != nil {
+		return nil, extendErr(err, "capturing checkpoint")
+	}
+
+	var doneCh = make(chan error, 1)
+	go func() {
+		var hints recoverylog.FSMHints
+
+		if err := captured.Persist(shard.Context()); err != nil {
+			doneCh <- extendErr(err, "persisting checkpoint")
+		} else if hints, err = buildRecordedHints(store.Recorder()); err != nil {
+			doneCh <- err
+		} else if err = storeRecordedHints(shard, hints, etcd, hc); err != nil {
+			doneCh <- extendErr(err, "storeRecordedHints")
+		} else {
+			// Failure to prune is logged, but is not fatal to the Shard.
+			if err = pruneCheckpoints(shard, fs, etcd); err != nil {
+				log.WithFields(log.Fields{"shard": shard.Spec().Id, "err": err}).
+					Warn("failed to prune checkpoint files")
+			}
+			doneCh <- nil
+		}
+	}()
+	return doneCh, nil
+}
+
+// pruneCheckpoints removes persisted checkpoint files of the Shard's recovery
+// log from |fs| which are no longer referenced by FSMHints of any HintKeys.
+func pruneCheckpoints(shard Shard, fs pb.FragmentStore, etcd *clientv3.Client) error {
+	var spec = shard.Spec()
+	var ops []clientv3.Op
+	for _, hk := range spec.HintKeys {
+		ops = append(ops, clientv3.OpGet(hk))
+	}
+	var resp, err = etcd.Txn(shard.Context()).If().Then(ops...).Commit()
+	if err != nil {
+		return extendErr(err, "fetching ShardSpec.HintKeys")
+	}
+
+	var hints []recoverylog.FSMHints
+	for i := range resp.Responses {
+		if kvs := resp.Responses[i].GetResponseRange().Kvs; len(kvs) == 0 {
+			continue
+		} else if h, err := DecodeHints(shard.Context(), kvs[0].Value); err != nil {
+			// We can't know which files undecodable hints reference.
+			return extendErr(err, "decoding %s", spec.HintKeys[i])
+		} else {
+			hints = append(hints, h)
+		}
+	}
+
+	removed, err := recoverylog.PruneCheckpoints(shard.Context(), fs, spec.RecoveryLog, hints...)
+	if removed != 0 {
+		log.WithFields(log.Fields{"shard": spec.Id, "removed": removed}).
+			Info("pruned checkpoint files")
+	}
+	return err
+}
+
+// rebaseStore rebases recorded files of the Store when notified by |rebaseCh|,
+// such that FSMHints reference no more than ShardSpec.RebaseLogBytes of the
+// recovery log which precedes its write head. Rebased files are reflected by
+// FSMHints which are subsequently stored. It runs until an error occurs, or
+// the Shard is cancelled.
+func rebaseStore(shard Shard, rec *recoverylog.Recorder, rebaseCh <-chan time.Time) error {
+	for {
+		select {
+		case <-rebaseCh:
+			// Pass.
+		case <-shard.Context().Done():
+			return shard.Context().Err()
+		}
+		var span = shard.Spec().RebaseLogBytes
+		if span == 0 {
+			continue
+		}
+
+		// The commit of a barrier reflects the current write head of the log.
+		var barrier = rec.WeakBarrier()
+		select {
+		case <-barrier.Done():
+			if err := barrier.Err(); err != nil {
+				return extendErr(err, "awaiting WeakBarrier")
+			} else if err = rec.Err(); err != nil {
+				return extendErr(err, "recovery log")
+			}
+		case <-shard.Context().Done():
+			return shard.Context().Err()
+		}
+
+		if head := barrier.Response().Commit.End; head > span {
+			if err := rec.Rebase(head - span); err != nil {
+				return extendErr(err, "rebasing recorded files")
+			}
+		}
+	}
+}
+
 // fetchJournalSpec retrieves the current JournalSpec.
 func fetchJournalSpec(ctx context.Context, name pb.Journal, journals pb.JournalClient) (spec *pb.JournalSpec, err error) {
 	var lr *pb.ListResponse
@@ -215,8 +400,8 @@ func fetchHints(ctx context.Context, spec *ShardSpec, etcd *clientv3.Client) (hi
 	for i := range resp.Responses {
 		if kvs := resp.Responses[i].GetResponseRange().Kvs; len(kvs) == 0 {
 			continue
-		} else if err = json.Unmarshal(kvs[0].Value, &hints); err != nil {
-			err = extendErr(err, "unmarshal FSMHints")
+		} else if hints, err = DecodeHints(ctx, kvs[0].Value); err != nil {
+			// Pass.
 		} else if _, err = recoverylog.NewFSM(hints); err != nil { // Validate hints.
 			err = extendErr(err, "validating FSMHints")
 		} else if hints.Log != spec.RecoveryLog {
@@ -228,13 +413,30 @@ func fetchHints(ctx context.Context, spec *ShardSpec, etcd *clientv3.Client) (hi
 	return
 }
 
+// buildRecordedHints builds FSMHints of the Recorder. FSMHints reflect all
+// operations recorded thus far, and an error is returned if any of them
+// failed to commit to the recovery log.
+func buildRecordedHints(rec *recoverylog.Recorder) (recoverylog.FSMHints, error) {
+	var hints = rec.BuildHints()
+
+	if err := rec.Err(); err != nil {
+		return recoverylog.FSMHints{}, extendErr(err, "recovery log")
+	}
+	return hints, nil
+}
+
 // storeRecordedHints writes the FSMHints into the first HintKeys of the spec.
-func storeRecordedHints(shard Shard, hints recoverylog.FSMHints, etcd *clientv3.Client) (err error) {
+func storeRecordedHints(shard Shard, hints recoverylog.FSMHints, etcd *clientv3.Client, hc hintsConfig) (err error) {
 	var val []byte
-	if val, err = json.Marshal(hints); err != nil {
-		err = extendErr(err, "marshal FSMHints")
+	var persisted *pb.Fragment
+	if val, persisted, err = encodeHints(shard, hints, hc); err != nil {
 		return
 	}
+	defer func() {
+		if err == nil && persisted != nil {
+			pruneHintsOrWarn(shard, persisted.BackingStore, etcd)
+		}
+	}()
 	var asn = shard.Assignment()
 
 	if _, err = etcd.Txn(shard.Context()).
@@ -251,7 +453,7 @@ func storeRecordedHints(shard Shard, hints recoverylog.FSMHints, etcd *clientv3.
 // storeRecoveredHints writes the FSMHints into the second HintKeys of the spec,
 // rotating hints previously stored under the second HintKeys to the third key,
 // and so on as a single transaction.
-func storeRecoveredHints(shard Shard, hints recoverylog.FSMHints, etcd *clientv3.Client) (err error) {
+func storeRecoveredHints(shard Shard, hints recoverylog.FSMHints, etcd *clientv3.Client, hc hintsConfig) (err error) {
 	var spec = shard.Spec()
 	var asn = shard.Assignment()
 	var resp *clientv3.TxnResponse
@@ -264,9 +466,15 @@ func storeRecoveredHints(shard Shard, hints recoverylog.FSMHints, etcd *clientv3
 	// rotate the current value at HintKeys[1] => HintKeys[2], and so on. We don't
 	// touch HintKeys[0], which holds hints updated by the current primary.
 	var val []byte
-	if val, err = json.Marshal(hints); err != nil {
+	var persisted *pb.Fragment
+	if val, persisted, err = encodeHints(shard, hints, hc); err != nil {
 		return
 	}
+	defer func() {
+		if err == nil && persisted != nil {
+			pruneHintsOrWarn(shard, persisted.BackingStore, etcd)
+		}
+	}()
 
 	var cmp []clientv3.Cmp
 	var ops []clientv3.Op
@@ -295,6 +503,121 @@ func storeRecoveredHints(shard Shard, hints recoverylog.FSMHints, etcd *clientv3
 	return
 }
 
+// DecodeHints decodes FSMHints from a stored HintKeys |value|. If the value is
+// a pointer to FSMHints persisted to a fragment store, the hints are fetched.
+func DecodeHints(ctx context.Context, value []byte) (recoverylog.FSMHints, error) {
+	var stored struct {
+		recoverylog.FSMHints
+		hintsPointer
+	}
+	if err := json.Unmarshal(value, &stored); err != nil {
+		return recoverylog.FSMHints{}, extendErr(err, "unmarshal FSMHints")
+	} else if stored.Persisted == nil {
+		return stored.FSMHints, nil
+	} else if hints, err := recoverylog.FetchPersistedHints(ctx, *stored.Persisted); err != nil {
+		return recoverylog.FSMHints{}, extendErr(err, "fetching persisted FSMHints")
+	} else {
+		return hints, nil
+	}
+}
+
+// encodeHints encodes |hints| as a HintKeys value. If hintsConfig.persistLarge,
+// hints having an encoding larger than hintsConfig.maxStoredSize are instead
+// persisted to the first fragment store of the recovery log (if it has one), and
+// a pointer to the persisted hints is returned along with their Fragment.
+func encodeHints(shard Shard, hints recoverylog.FSMHints, hc hintsConfig) ([]byte, *pb.Fragment, error) {
+	var val, err = json.Marshal(hints)
+	if err != nil {
+		return nil, nil, extendErr(err, "marshal FSMHints")
+	} else if len(val) <= hc.maxStoredSize {
+		return val, nil, nil
+	} else if !hc.persistLarge {
+		log.WithFields(log.Fields{"log": hints.Log, "size": len(val)}).
+			Warn("storing large FSMHints directly, as Service.PersistLargeHints is not set")
+		return val, nil, nil
+	}
+
+	spec, err := fetchJournalSpec(shard.Context(), hints.Log, shard.JournalClient())
+	if err != nil {
+		return nil, nil, extendErr(err, "fetching JournalSpec")
+	} else if len(spec.Fragment.Stores) == 0 {
+		log.WithFields(log.Fields{"log": hints.Log, "size": len(val)}).
+			Warn("storing large FSMHints directly, as recovery log has no fragment stores")
+		return val, nil, nil
+	}
+
+	frag, err := recoverylog.PersistHints(shard.Context(), spec.Fragment.Stores[0], hints)
+	if err != nil {
+		return nil, nil, extendErr(err, "persisting FSMHints")
+	}
+	val, err = json.Marshal(hintsPointer{Persisted: &frag})
+	return val, &frag, err
+}
+
+// pruneHintsOrWarn removes persisted FSMHints of the Shard's recovery log from
+// |fs| which are no longer referenced by any HintKeys. As superseded FSMHints
+// are also pruned by future calls, a failure is logged but not returned.
+func pruneHintsOrWarn(shard Shard, fs pb.FragmentStore, etcd *clientv3.Client) {
+	var spec = shard.Spec()
+	var ops []clientv3.Op
+	for _, hk := range spec.HintKeys {
+		ops = append(ops, clientv3.OpGet(hk))
+	}
+	var resp, err = etcd.Txn(shard.Context()).If().Then(ops...).Commit()
+	if err != nil {
+		err = extendErr(err, "fetching ShardSpec.HintKeys")
+	}
+
+	var referenced []pb.Fragment
+	for i := 0; err == nil && i != len(resp.Responses); i++ {
+		var ptr hintsPointer
+
+		if kvs := resp.Responses[i].GetResponseRange().Kvs; len(kvs) == 0 {
+			continue
+		} else if err = json.Unmarshal(kvs[0].Value, &ptr); err != nil {
+			// We can't know which persisted FSMHints an undecodable value references.
+			err = extendErr(err, "decoding %s", spec.HintKeys[i])
+		} else if ptr.Persisted != nil {
+			referenced = append(referenced, *ptr.Persisted)
+		}
+	}
+
+	var removed int
+	if err == nil {
+		removed, err = recoverylog.PruneHints(shard.Context(), fs, spec.RecoveryLog, referenced...)
+	}
+	if removed != 0 {
+		log.WithFields(log.Fields{"shard": spec.Id, "removed": removed}).
+			Info("pruned persisted FSMHints")
+	}
+	if err != nil {
+		log.WithFields(log.Fields{"shard": spec.Id, "err": err}).
+			Warn("failed to prune persisted FSMHints")
+	}
+}
+
+// hintsPointer is stored as a HintKeys value in place of FSMHints which were
+// persisted to a fragment store.
+type hintsPointer struct {
+	// Fragment of persisted FSMHints.
+	Persisted *pb.Fragment `json:"persisted_hints,omitempty"`
+}
+
+// hintsConfig configures the storage of FSMHints in HintKeys.
+type hintsConfig struct {
+	// persistLarge enables the persistence of large FSMHints to a fragment
+	// store of the recovery log (see Service.PersistLargeHints).
+	persistLarge bool
+	// maxStoredSize is the maximum encoded size of FSMHints which are stored
+	// directly in Etcd.
+	maxStoredSize int
+}
+
+// maxStoredHintsSize is the default hintsConfig.maxStoredSize.
+// storeRecoveredHints may write several HintKeys in a single transaction,
+// which must remain within Etcd's request size limit.
+const maxStoredHintsSize = 1 << 18 // 256KB.
+
 // transaction models state and metrics used in the execution of a consumer transaction.
 type transaction struct {
 	barrier        *client.AsyncAppend     // Write barrier of the txn at commit.
@@ -303,6 +626,7 @@ type transaction struct {
 	msgCount       int                     // Number of messages batched into this transaction.
 	offsets        map[pb.Journal]int64    // End (exclusive) journal offsets of the transaction.
 	doneCh         <-chan struct{}         // DoneCh of prior transaction barrier.
+	span           *tracing.Span           // Traces the transaction from its first message until synced.
 
 	beganAt     time.Time // Time at which transaction began.
 	stalledAt   time.Time // Time at which processing stalled while waiting on IO.
@@ -339,6 +663,9 @@ func txnStep(txn, prior *transaction, shard Shard, store Store, app Application,
 				}
 				txn.beganAt = timeNow()
 				timer.Reset(txn.minDur)
+
+				_, txn.span = tracing.StartSpan(shard.Context(), "consumer transaction", tracing.SpanKindInternal)
+				txn.span.SetAttribute("shard", shard.Spec().Id)
 			}
 			txn.msgCount++
 			txn.offsets[msg.JournalSpec.Name] = msg.NextOffset
@@ -360,12 +687,22 @@ func txnStep(txn, prior *transaction, shard Shard, store Store, app Application,
 				txn.maxDur = -1           // Mark as completed.
 				txn.msgCh = nil           // Stop reading messages.
 				txn.stalledAt = timeNow() // We're stalled waiting for prior txn IO.
+				txn.span.AddEvent("stalled on prior transaction")
 			}
 			return
 
 		case _ = <-txn.doneCh:
 			prior.syncedAt = timeNow()
 			txn.doneCh = nil
+
+			if prior.barrier != nil && prior.barrier.Err() != nil {
+				err = extendErr(prior.barrier.Err(), "prior transaction barrier")
+			} else if rErr := store.Recorder().Err(); rErr != nil {
+				// Operations recorded by the prior transaction were lost.
+				err = extendErr(rErr, "recovery log")
+			}
+			prior.span.AddEvent("synced")
+			prior.span.Finish(err)
 			return
 
 		case _ = <-shard.Context().Done():
@@ -407,6 +744,9 @@ func txnStep(txn, prior *transaction, shard Shard, store Store, app Application,
 	if txn.flushedAt = timeNow(); txn.stalledAt.IsZero() {
 		txn.stalledAt = txn.flushedAt // We spent no time stalled.
 	}
+	txn.span.SetAttribute("messages", txn.msgCount)
+	txn.span.AddEvent("flushing")
+
 	if err = app.FinalizeTxn(shard, store); err != nil {
 		err = extendErr(err, "app.FinalizeTxn")
 		return
@@ -424,6 +764,7 @@ func txnStep(txn, prior *transaction, shard Shard, store Store, app Application,
 	}
 	txn.barrier = store.Recorder().WeakBarrier()
 	txn.committedAt = timeNow()
+	txn.span.AddEvent("committed")
 
 	// If the timer is still running, stop and drain it.
 	if txn.maxDur != -1 && !timer.Stop() {
@@ -4,14 +4,20 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"strconv"
 	"time"
 
 	"github.com/LiveRamp/gazette/v2/pkg/brokertest"
+	"github.com/LiveRamp/gazette/v2/pkg/client"
+	"github.com/LiveRamp/gazette/v2/pkg/fragment"
 	"github.com/LiveRamp/gazette/v2/pkg/keyspace"
 	"github.com/LiveRamp/gazette/v2/pkg/message"
 	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
 	"github.com/LiveRamp/gazette/v2/pkg/recoverylog"
+	"github.com/LiveRamp/gazette/v2/pkg/tracing"
 	"github.com/coreos/etcd/clientv3"
 	gc "github.com/go-check/check"
 )
@@ -22,13 +28,13 @@ func (s *LifecycleSuite) TestRecoveryFromEmptyLog(c *gc.C) {
 	var r, cleanup = newLifecycleTestFixture(c)
 	defer cleanup()
 
-	go func() { c.Assert(playLog(r, r.player, r.etcd), gc.IsNil) }()
+	go func() { c.Assert(playLog(r, r.player, r.etcd, ""), gc.IsNil) }()
 
 	// Precondition: no existing hints in etcd.
 	c.Check(mustGet(c, r.etcd, r.spec.HintKeys[0]).Kvs, gc.HasLen, 0)
 	c.Check(mustGet(c, r.etcd, r.spec.HintKeys[1]).Kvs, gc.HasLen, 0)
 
-	var store, offsets, err = completePlayback(r, r.app, r.player, r.etcd)
+	var store, offsets, err = completePlayback(r, r.app, r.player, r.etcd, r.hints)
 	c.Check(err, gc.IsNil)
 	r.store = store
 
@@ -57,15 +63,15 @@ func (s *LifecycleSuite) TestRecoveryOfNonEmptyLog(c *gc.C) {
 	}
 	c.Check(r.app.FinalizeTxn(r, r.store), gc.IsNil)
 	c.Check(r.store.Flush(map[pb.Journal]int64{sourceA: 123, sourceB: 456}), gc.IsNil)
-	c.Check(storeRecordedHints(r, r.store.Recorder().BuildHints(), r.etcd), gc.IsNil)
+	c.Check(storeRecordedHints(r, r.store.Recorder().BuildHints(), r.etcd, r.hints), gc.IsNil)
 
 	// Reset for the next player.
 	r.store.Destroy()
 	r.player = recoverylog.NewPlayer()
 
-	go func() { c.Assert(playLog(r, r.player, r.etcd), gc.IsNil) }()
+	go func() { c.Assert(playLog(r, r.player, r.etcd, ""), gc.IsNil) }()
 
-	store, offsets, err := completePlayback(r, r.app, r.player, r.etcd)
+	store, offsets, err := completePlayback(r, r.app, r.player, r.etcd, r.hints)
 	c.Check(err, gc.IsNil)
 	c.Check(offsets, gc.DeepEquals, map[pb.Journal]int64{"source/A": 123, "source/B": 456})
 	r.store = store
@@ -77,6 +83,55 @@ func (s *LifecycleSuite) TestRecoveryOfNonEmptyLog(c *gc.C) {
 	})
 }
 
+func (s *LifecycleSuite) TestRecoveryResumesRetainedLocalState(c *gc.C) {
+	var r, cleanup = newLifecycleTestFixture(c)
+	defer cleanup()
+
+	var localDir, err = ioutil.TempDir("", "lifecycle-suite")
+	c.Assert(err, gc.IsNil)
+	defer os.RemoveAll(localDir)
+
+	playAndComplete(c, r)
+
+	c.Check(r.app.ConsumeMessage(r, r.store, message.Envelope{Message: &testMessage{Key: "foo", Value: "1"}}), gc.IsNil)
+	c.Check(r.app.FinalizeTxn(r, r.store), gc.IsNil)
+	c.Check(r.store.Flush(map[pb.Journal]int64{sourceA: 123}), gc.IsNil)
+
+	// Play as a standby under |localDir|, and cancel once tailing the log.
+	var ctx, cancel = context.WithCancel(r.ctx)
+	var pl = recoverylog.NewPlayer()
+	var doneCh = make(chan error)
+
+	go func() { doneCh <- playLog(shardWithContext{Shard: r, ctx: ctx}, pl, r.etcd, localDir) }()
+	<-pl.Tailing()
+	cancel()
+	c.Check(<-doneCh, gc.ErrorMatches, `playing log .*: context canceled`)
+
+	// Expect the stable shard directory was retained.
+	_, err = os.Stat(filepath.Join(localDir, "a-shard"))
+	c.Check(err, gc.IsNil)
+
+	// Record further content, and store hints which follow the standby's.
+	c.Check(r.app.ConsumeMessage(r, r.store, message.Envelope{Message: &testMessage{Key: "bar", Value: "2"}}), gc.IsNil)
+	c.Check(r.app.FinalizeTxn(r, r.store), gc.IsNil)
+	c.Check(r.store.Flush(map[pb.Journal]int64{sourceA: 456}), gc.IsNil)
+	c.Check(storeRecordedHints(r, r.store.Recorder().BuildHints(), r.etcd, r.hints), gc.IsNil)
+
+	r.store.Destroy()
+	r.player = recoverylog.NewPlayer()
+
+	// Expect a new Replica recovers from the retained directory.
+	go func() { c.Assert(playLog(r, r.player, r.etcd, localDir), gc.IsNil) }()
+
+	store, offsets, err := completePlayback(r, r.app, r.player, r.etcd, r.hints)
+	c.Check(err, gc.IsNil)
+	c.Check(offsets, gc.DeepEquals, map[pb.Journal]int64{sourceA: 456})
+	c.Check(r.player.Dir, gc.Equals, filepath.Join(localDir, "a-shard"))
+	r.store = store
+
+	c.Check(r.store.(*JSONFileStore).State, gc.DeepEquals, map[string]string{"foo": "1", "bar": "2"})
+}
+
 func (s *LifecycleSuite) TestRecoveryFailsFromBadHints(c *gc.C) {
 	var r, cleanup = newLifecycleTestFixture(c)
 	defer cleanup()
@@ -85,14 +140,14 @@ func (s *LifecycleSuite) TestRecoveryFailsFromBadHints(c *gc.C) {
 	c.Check(err, gc.IsNil)
 
 	// Expect playLog returns an immediate error.
-	c.Check(playLog(r, r.player, r.etcd), gc.ErrorMatches,
+	c.Check(playLog(r, r.player, r.etcd, ""), gc.ErrorMatches,
 		`fetching FSM hints: unmarshal FSMHints: invalid character .*`)
 
 	// Expect completePlayback blocks waiting for Play completion, but
 	// aborts on context cancellation.
 	time.AfterFunc(10*time.Millisecond, r.cancel)
 
-	_, _, err = completePlayback(r, r.app, r.player, r.etcd)
+	_, _, err = completePlayback(r, r.app, r.player, r.etcd, r.hints)
 	c.Check(err, gc.Equals, context.Canceled)
 }
 
@@ -103,11 +158,11 @@ func (s *LifecycleSuite) TestRecoveryFailsFromPlayError(c *gc.C) {
 	r.spec.RecoveryLog = "does/not/exist"
 
 	// Expect playLog returns an immediate error.
-	c.Check(playLog(r, r.player, r.etcd), gc.ErrorMatches,
+	c.Check(playLog(r, r.player, r.etcd, ""), gc.ErrorMatches,
 		`playing log does/not/exist: determining log head: JOURNAL_NOT_FOUND`)
 
 	// As does completePlayback.
-	var _, _, err = completePlayback(r, r.app, r.player, r.etcd)
+	var _, _, err = completePlayback(r, r.app, r.player, r.etcd, r.hints)
 	c.Check(err, gc.ErrorMatches, `completePlayback aborting due to Play failure`)
 }
 
@@ -513,6 +568,74 @@ func (s *LifecycleSuite) TestTxnCancelledAfterStart(c *gc.C) {
 	c.Check(txn.doneCh, gc.IsNil)
 }
 
+func (s *LifecycleSuite) TestTxnSpans(c *gc.C) {
+	var r, cleanup = newLifecycleTestFixture(c)
+	defer cleanup()
+
+	playAndComplete(c, r)
+	var msgCh = make(chan message.Envelope, 128)
+
+	var timer, restore = newTestTimer()
+	defer restore()
+
+	var exporter = make(spanExporter, 1)
+	tracing.SetExporter(exporter)
+	defer tracing.SetExporter(nil)
+
+	var prior, txn = transaction{}, transaction{
+		minDur:  1 * time.Second,
+		maxDur:  2 * time.Second,
+		msgCh:   msgCh,
+		offsets: make(map[pb.Journal]int64),
+	}
+
+	// Initial message begins the txn Span.
+	sendMsgFixture(msgCh, false, 100)
+	c.Check(mustTxnStep(c, r, &txn, &prior, timer.txnTimer), gc.Equals, false)
+	c.Assert(txn.span, gc.NotNil)
+	c.Check(txn.span.Name, gc.Equals, "consumer transaction")
+
+	timer.timepoint = faketime(1)
+	timer.signal()
+	c.Check(mustTxnStep(c, r, &txn, &prior, timer.txnTimer), gc.Equals, false)
+	c.Check(mustTxnStep(c, r, &txn, &prior, timer.txnTimer), gc.Equals, true)
+
+	// The committed txn isn't yet exported, as it has yet to sync.
+	c.Check(exporter, gc.HasLen, 0)
+	c.Check(txn.span.Attributes(), gc.DeepEquals, map[string]string{
+		"shard":    r.Spec().Id.String(),
+		"messages": "1",
+	})
+
+	// The next txn awaits its prior barrier, which Finishes the prior txn Span.
+	prior, txn = txn, transaction{
+		minDur:  1 * time.Second,
+		maxDur:  2 * time.Second,
+		msgCh:   msgCh,
+		offsets: make(map[pb.Journal]int64),
+		doneCh:  txn.barrier.Done(),
+	}
+	c.Check(mustTxnStep(c, r, &txn, &prior, timer.txnTimer), gc.Equals, false)
+	c.Check(<-exporter, gc.Equals, prior.span)
+	c.Check(prior.span.Err(), gc.IsNil)
+
+	var events []string
+	for _, ev := range prior.span.Events() {
+		events = append(events, ev.Message)
+	}
+	c.Check(events, gc.DeepEquals, []string{"flushing", "committed", "synced"})
+}
+
+// spanExporter is a tracing.Exporter which sends exported transaction Spans
+// to the channel, and ignores others (eg, of recovery log appends).
+type spanExporter chan *tracing.Span
+
+func (e spanExporter) ExportSpan(span *tracing.Span) {
+	if span.Name == "consumer transaction" {
+		e <- span
+	}
+}
+
 func (s *LifecycleSuite) TestConsumeUpdatesRecordedHints(c *gc.C) {
 	var r, cleanup = newLifecycleTestFixture(c)
 	defer cleanup()
@@ -522,7 +645,7 @@ func (s *LifecycleSuite) TestConsumeUpdatesRecordedHints(c *gc.C) {
 	var hintsCh = make(chan time.Time, 1)
 
 	go func() {
-		c.Check(consumeMessages(r, r.store, r.app, r.etcd, msgCh, hintsCh), gc.Equals, context.Canceled)
+		c.Check(consumeMessages(r, r.store, r.app, r.etcd, r.hints, msgCh, hintsCh, nil), gc.Equals, context.Canceled)
 	}()
 	// Precondition: recorded hints are not set.
 	c.Check(mustGet(c, r.etcd, r.spec.HintKeys[0]).Kvs, gc.HasLen, 0)
@@ -550,7 +673,7 @@ func (s *LifecycleSuite) TestConsumeErrorCases(c *gc.C) {
 	app.finalizeErr = errors.New("finalize error")
 
 	sendMsgFixture(msgCh, false, 100)
-	c.Check(consumeMessages(r, r.store, r.app, r.etcd, msgCh, nil),
+	c.Check(consumeMessages(r, r.store, r.app, r.etcd, r.hints, msgCh, nil, nil),
 		gc.ErrorMatches, `txnStep: app.FinalizeTxn: finalize error`)
 
 	<-finishCh // Expect FinishTxn was still called and |finishCh| closed.
@@ -559,15 +682,44 @@ func (s *LifecycleSuite) TestConsumeErrorCases(c *gc.C) {
 	app.consumeErr = errors.New("consume error")
 
 	sendMsgFixture(msgCh, false, 100)
-	c.Check(consumeMessages(r, r.store, r.app, r.etcd, msgCh, nil),
+	c.Check(consumeMessages(r, r.store, r.app, r.etcd, r.hints, msgCh, nil, nil),
 		gc.ErrorMatches, `txnStep: app.ConsumeMessage: consume error`)
 
 	// Case: BeginTxn fails.
 	app.beginErr = errors.New("begin error")
 
 	sendMsgFixture(msgCh, false, 100)
-	c.Check(consumeMessages(r, r.store, r.app, r.etcd, msgCh, nil),
+	c.Check(consumeMessages(r, r.store, r.app, r.etcd, r.hints, msgCh, nil, nil),
 		gc.ErrorMatches, `txnStep: app.BeginTxn: begin error`)
+
+	// Case: Store checkpoint fails, as the recovery log has no fragment stores.
+	var checkpointCh = make(chan time.Time, 1)
+	checkpointCh <- time.Time{}
+
+	c.Check(consumeMessages(r, r.store, r.app, r.etcd, r.hints, msgCh, nil, checkpointCh),
+		gc.ErrorMatches, `checkpointStore: recovery log has no fragment stores \(.*\)`)
+
+	// Case: an append of recorded operations to the recovery log fails.
+	app.beginErr, app.consumeErr, app.finalizeErr = nil, nil, nil
+
+	var lr, err = client.ListAll(r.Context(), r.JournalClient(), pb.ListRequest{
+		Selector: pb.LabelSelector{
+			Include: pb.LabelSet{Labels: []pb.Label{{Name: "name", Value: aRecoveryLog.String()}}},
+		},
+	})
+	c.Assert(err, gc.IsNil)
+
+	var spec = lr.Journals[0].Spec
+	spec.MaxAppendSize = 16
+
+	_, err = client.ApplyJournals(r.Context(), r.JournalClient(), &pb.ApplyRequest{
+		Changes: []pb.ApplyRequest_Change{{Upsert: &spec, ExpectModRevision: lr.Journals[0].ModRevision}},
+	})
+	c.Assert(err, gc.IsNil)
+
+	sendMsgFixture(msgCh, false, 100)
+	c.Check(consumeMessages(r, r.store, r.app, r.etcd, r.hints, msgCh, nil, nil),
+		gc.ErrorMatches, `txnStep: prior transaction barrier: APPEND_TOO_LARGE`)
 }
 
 func (s *LifecycleSuite) TestPumpAndConsume(c *gc.C) {
@@ -583,7 +735,7 @@ func (s *LifecycleSuite) TestPumpAndConsume(c *gc.C) {
 	}()
 
 	go func() {
-		c.Check(consumeMessages(r, r.store, r.app, r.etcd, msgCh, nil), gc.Equals, context.Canceled)
+		c.Check(consumeMessages(r, r.store, r.app, r.etcd, r.hints, msgCh, nil, nil), gc.Equals, context.Canceled)
 	}()
 
 	runSomeTransactions(c, r, r.app.(*testApplication), r.store.(*JSONFileStore))
@@ -646,15 +798,15 @@ func (s *LifecycleSuite) TestStoreAndFetchHints(c *gc.C) {
 	_, err := r.etcd.Put(r.ctx, string(r.assignment.Raw.Key), "")
 	c.Assert(err, gc.IsNil)
 
-	c.Check(storeRecoveredHints(r, mkHints(111), r.etcd), gc.IsNil)
+	c.Check(storeRecoveredHints(r, mkHints(111), r.etcd, r.hints), gc.IsNil)
 	verifyHints(111, 0, 111, 0)
-	c.Check(storeRecordedHints(r, mkHints(222), r.etcd), gc.IsNil)
+	c.Check(storeRecordedHints(r, mkHints(222), r.etcd, r.hints), gc.IsNil)
 	verifyHints(222, 222, 111, 0)
-	c.Check(storeRecoveredHints(r, mkHints(333), r.etcd), gc.IsNil)
+	c.Check(storeRecoveredHints(r, mkHints(333), r.etcd, r.hints), gc.IsNil)
 	verifyHints(222, 222, 333, 111)
-	c.Check(storeRecordedHints(r, mkHints(444), r.etcd), gc.IsNil)
+	c.Check(storeRecordedHints(r, mkHints(444), r.etcd, r.hints), gc.IsNil)
 	verifyHints(444, 444, 333, 111)
-	c.Check(storeRecoveredHints(r, mkHints(555), r.etcd), gc.IsNil)
+	c.Check(storeRecoveredHints(r, mkHints(555), r.etcd, r.hints), gc.IsNil)
 	verifyHints(444, 444, 555, 333)
 
 	// Delete hints in key priority order. Expect older hints are used instead.
@@ -670,6 +822,202 @@ func (s *LifecycleSuite) TestStoreAndFetchHints(c *gc.C) {
 	c.Check(hints, gc.DeepEquals, recoverylog.FSMHints{Log: aRecoveryLog})
 }
 
+func (s *LifecycleSuite) TestStoreAndFetchPersistedHints(c *gc.C) {
+	var r, cleanup = newLifecycleTestFixture(c)
+	defer cleanup()
+
+	var storeRoot, err = ioutil.TempDir("", "lifecycle-suite")
+	c.Assert(err, gc.IsNil)
+	defer os.RemoveAll(storeRoot)
+
+	defer func(prior string) { fragment.FileSystemStoreRoot = prior }(fragment.FileSystemStoreRoot)
+	fragment.FileSystemStoreRoot = storeRoot
+
+	// Expect all hints are persisted, rather than stored directly.
+	r.hints.maxStoredSize = 0
+
+	var mkHints = func(id int64) recoverylog.FSMHints {
+		return recoverylog.FSMHints{
+			Log: aRecoveryLog,
+			LiveNodes: []recoverylog.FnodeSegments{{
+				Fnode: recoverylog.Fnode(id),
+				Segments: []recoverylog.Segment{
+					{Author: 0x1234, FirstSeqNo: id, LastSeqNo: id},
+				},
+			}},
+		}
+	}
+	var expectHints = func(id int64) {
+		var hints, _, err = fetchHints(r.ctx, r.Spec(), r.etcd)
+		c.Check(err, gc.IsNil)
+		c.Check(hints, gc.DeepEquals, mkHints(id))
+	}
+
+	// The recovery log has no fragment stores. Expect hints are stored directly.
+	c.Check(storeRecordedHints(r, mkHints(111), r.etcd, r.hints), gc.IsNil)
+	expectHints(111)
+
+	addRecoveryLogStore(c, r)
+
+	// persistLarge is not set. Expect hints are still stored directly.
+	c.Check(storeRecordedHints(r, mkHints(111), r.etcd, r.hints), gc.IsNil)
+	expectStoredDirectly(c, r, r.spec.HintKeys[0])
+
+	r.hints.persistLarge = true
+
+	c.Check(storeRecordedHints(r, mkHints(222), r.etcd, r.hints), gc.IsNil)
+	c.Check(storeRecoveredHints(r, mkHints(333), r.etcd, r.hints), gc.IsNil)
+
+	// Expect HintKeys hold pointers to hints persisted under the recovery log store.
+	resp, err := r.etcd.Get(r.ctx, r.spec.HintKeys[0])
+	c.Assert(err, gc.IsNil)
+
+	var ptr hintsPointer
+	c.Check(json.Unmarshal(resp.Kvs[0].Value, &ptr), gc.IsNil)
+	c.Assert(ptr.Persisted, gc.NotNil)
+	c.Check(ptr.Persisted.Journal, gc.Equals, aRecoveryLog+".hints")
+	c.Check(ptr.Persisted.BackingStore, gc.Equals, pb.FragmentStore("file:///"))
+
+	expectHints(222)
+	r.etcd.Delete(r.ctx, r.spec.HintKeys[0])
+	expectHints(333)
+
+	// Record further hints. Expect the superseded, persisted hints 222 are
+	// pruned, while the still-referenced hints 333 are not.
+	c.Check(storeRecordedHints(r, mkHints(444), r.etcd, r.hints), gc.IsNil)
+	expectHints(444)
+
+	_, err = os.Stat(filepath.Join(storeRoot, ptr.Persisted.ContentPath()))
+	c.Check(os.IsNotExist(err), gc.Equals, true)
+
+	r.etcd.Delete(r.ctx, r.spec.HintKeys[0])
+	expectHints(333)
+
+	// Expect a missing persisted Fragment is an error.
+	c.Check(os.RemoveAll(filepath.Join(storeRoot, ptr.Persisted.Journal.String())), gc.IsNil)
+	_, _, err = fetchHints(r.ctx, r.Spec(), r.etcd)
+	c.Check(err, gc.ErrorMatches, `fetching persisted FSMHints: opening .*`)
+}
+
+func expectStoredDirectly(c *gc.C, r *Replica, key string) {
+	var resp, err = r.etcd.Get(r.ctx, key)
+	c.Assert(err, gc.IsNil)
+
+	var ptr hintsPointer
+	c.Check(json.Unmarshal(resp.Kvs[0].Value, &ptr), gc.IsNil)
+	c.Check(ptr.Persisted, gc.IsNil)
+}
+
+func (s *LifecycleSuite) TestConsumeCheckpointsStore(c *gc.C) {
+	var r, cleanup = newLifecycleTestFixture(c)
+	defer cleanup()
+
+	var storeRoot, err = ioutil.TempDir("", "lifecycle-suite")
+	c.Assert(err, gc.IsNil)
+	defer os.RemoveAll(storeRoot)
+
+	defer func(prior string) { fragment.FileSystemStoreRoot = prior }(fragment.FileSystemStoreRoot)
+	fragment.FileSystemStoreRoot = storeRoot
+
+	playAndComplete(c, r)
+	addRecoveryLogStore(c, r)
+
+	var msgCh = make(chan message.Envelope)
+	var checkpointCh = make(chan time.Time, 1)
+	var doneCh = make(chan struct{})
+
+	go func() {
+		c.Check(consumeMessages(r, r.store, r.app, r.etcd, r.hints, msgCh, nil, checkpointCh), gc.Equals, context.Canceled)
+		close(doneCh)
+	}()
+	// Run a transaction which writes Store state, and then signal a checkpoint.
+	sendMsgAndWait(r.app.(*testApplication), msgCh)
+	checkpointCh <- time.Time{}
+
+	// Run transactions until recorded hints which reference a checkpoint are
+	// stored. The checkpoint is persisted concurrently with transactions.
+	var hints recoverylog.FSMHints
+	for i := 0; hints.Checkpoint == nil; i++ {
+		c.Assert(i < 100, gc.Equals, true)
+		sendMsgAndWait(r.app.(*testApplication), msgCh)
+
+		hints, _, err = fetchHints(r.ctx, r.Spec(), r.etcd)
+		c.Assert(err, gc.IsNil)
+	}
+	c.Check(hints.Checkpoint.Files, gc.Not(gc.HasLen), 0)
+
+	// Await the exit of consumeMessages, which awaits pruning of the
+	// persisted checkpoint's superseded files.
+	r.cancel()
+	<-doneCh
+
+	persisted, err := filepath.Glob(filepath.Join(storeRoot, aRecoveryLog.String()+".checkpoints", "*", "*"))
+	c.Check(err, gc.IsNil)
+	c.Check(persisted, gc.Not(gc.HasLen), 0)
+}
+
+func (s *LifecycleSuite) TestRebaseStore(c *gc.C) {
+	var r, cleanup = newLifecycleTestFixture(c)
+	defer cleanup()
+
+	playAndComplete(c, r)
+	c.Check(r.store.Flush(map[pb.Journal]int64{sourceA: 123}), gc.IsNil) // Record Store files.
+
+	var rec = r.store.Recorder()
+	var before = rec.BuildHints()
+	c.Assert(before.LiveNodes, gc.Not(gc.HasLen), 0)
+
+	var rebaseCh = make(chan time.Time)
+	var doneCh = make(chan struct{})
+
+	go func() {
+		c.Check(rebaseStore(r, rec, rebaseCh), gc.Equals, context.Canceled)
+		close(doneCh)
+	}()
+
+	// RebaseLogBytes is zero. Expect no files are rebased.
+	rebaseCh <- time.Time{}
+	rebaseCh <- time.Time{} // Wait for the first tick to be processed.
+	c.Check(rec.BuildHints(), gc.DeepEquals, before)
+
+	// Expect files are rebased, such that hints reference only recent log content.
+	// Install an updated ShardSpec (as resolver would) rather than mutating the
+	// current one, which rebaseStore concurrently reads.
+	var spec = *r.Spec()
+	spec.RebaseLogBytes = 1
+
+	r.ks.Mu.Lock()
+	transition(r, &spec, r.assignment)
+	r.ks.Mu.Unlock()
+
+	rebaseCh <- time.Time{}
+	rebaseCh <- time.Time{}
+
+	var after = rec.BuildHints()
+	c.Assert(after.LiveNodes, gc.HasLen, len(before.LiveNodes))
+	for i := range after.LiveNodes {
+		c.Check(after.LiveNodes[i].Fnode > before.LiveNodes[len(before.LiveNodes)-1].Fnode, gc.Equals, true)
+	}
+
+	r.cancel()
+	<-doneCh
+}
+
+// addRecoveryLogStore adds a "file:///" fragment store to the recovery log.
+func addRecoveryLogStore(c *gc.C, r *Replica) {
+	var lr, err = client.ListAll(r.ctx, r.JournalClient(), pb.ListRequest{
+		Selector: pb.LabelSelector{Include: pb.MustLabelSet("name", aRecoveryLog.String())},
+	})
+	c.Assert(err, gc.IsNil)
+	var spec = lr.Journals[0].Spec
+	spec.Fragment.Stores = []pb.FragmentStore{"file:///"}
+
+	_, err = client.ApplyJournals(r.ctx, r.JournalClient(), &pb.ApplyRequest{
+		Changes: []pb.ApplyRequest_Change{{Upsert: &spec, ExpectModRevision: lr.Journals[0].ModRevision}},
+	})
+	c.Assert(err, gc.IsNil)
+}
+
 // newLifecycleTestFixture extends newTestFixture by stubbing out |transition|
 // and allocating an assigned local shard.
 func newLifecycleTestFixture(c *gc.C) (*Replica, func()) {
@@ -716,10 +1064,18 @@ func newTestTimer() (*testTimer, func()) {
 
 func (t testTimer) signal() { t.ch <- t.timepoint }
 
+// shardWithContext overrides the Context of a Shard.
+type shardWithContext struct {
+	Shard
+	ctx context.Context
+}
+
+func (s shardWithContext) Context() context.Context { return s.ctx }
+
 func playAndComplete(c *gc.C, r *Replica) {
-	go func() { c.Assert(playLog(r, r.player, r.etcd), gc.IsNil) }()
+	go func() { c.Assert(playLog(r, r.player, r.etcd, ""), gc.IsNil) }()
 
-	var store, _, err = completePlayback(r, r.app, r.player, r.etcd)
+	var store, _, err = completePlayback(r, r.app, r.player, r.etcd, r.hints)
 	c.Check(err, gc.IsNil)
 	r.store = store
 }
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/LiveRamp/gazette/v2/pkg/allocator"
+	"github.com/LiveRamp/gazette/v2/pkg/auth"
 	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
 	"github.com/coreos/etcd/clientv3"
 )
@@ -21,6 +22,13 @@ func (srv *Service) List(ctx context.Context, req *ListRequest) (*ListResponse,
 	if err := req.Validate(); err != nil {
 		return resp, err
 	}
+	var claims, err = auth.Authenticate(srv.Authenticator, ctx)
+	if err != nil {
+		return resp, err
+	} else if !claims.AllowsAny(auth.Read) {
+		resp.Status = Status_NOT_ALLOWED
+		return resp, nil
+	}
 
 	defer s.KS.Mu.RUnlock()
 	s.KS.Mu.RLock()
@@ -43,7 +51,7 @@ func (srv *Service) List(ctx context.Context, req *ListRequest) (*ListResponse,
 		metaLabels = ExtractShardSpecMetaLabels(&shard.Spec, metaLabels)
 		allLabels = pb.UnionLabelSets(metaLabels, shard.Spec.LabelSet, allLabels)
 
-		if !req.Selector.Matches(allLabels) {
+		if !req.Selector.Matches(allLabels) || !claims.Allows(auth.Read, allLabels) {
 			continue
 		}
 		shard.ModRevision = s.Items[cur.Left].Raw.ModRevision
@@ -71,6 +79,13 @@ func (srv *Service) Apply(ctx context.Context, req *ApplyRequest) (*ApplyRespons
 	if err := req.Validate(); err != nil {
 		return resp, err
 	}
+	var claims, err = auth.Authenticate(srv.Authenticator, ctx)
+	if err != nil {
+		return resp, err
+	} else if !applyAllowed(claims, s, req) {
+		resp.Status = Status_NOT_ALLOWED
+		return resp, nil
+	}
 
 	var cmp []clientv3.Cmp
 	var ops []clientv3.Op
@@ -88,6 +103,9 @@ func (srv *Service) Apply(ctx context.Context, req *ApplyRequest) (*ApplyRespons
 		cmp = append(cmp, clientv3.Compare(clientv3.ModRevision(key), "=", changes.ExpectModRevision))
 	}
 
+	// The caller's credentials are not Etcd credentials.
+	ctx = auth.StripCredentials(ctx)
+
 	if txnResp, err := srv.etcd.Do(ctx, clientv3.OpTxn(cmp, ops, nil)); err != nil {
 		return resp, err
 	} else if !txnResp.Txn().Succeeded {
@@ -102,6 +120,39 @@ func (srv *Service) Apply(ctx context.Context, req *ApplyRequest) (*ApplyRespons
 	return resp, nil
 }
 
+// applyAllowed returns whether the Claims allow all changes of the ApplyRequest.
+// Both the current ShardSpec (if any) and its upserted replacement must be
+// allowed, so that callers may neither modify shards outside of their grants
+// nor re-label shards into or out of them.
+func applyAllowed(claims *auth.Claims, s *allocator.State, req *ApplyRequest) bool {
+	defer s.KS.Mu.RUnlock()
+	s.KS.Mu.RLock()
+
+	for _, change := range req.Changes {
+		var id = change.Delete
+
+		if change.Upsert != nil {
+			if !claims.Allows(auth.Apply, shardLabels(change.Upsert)) {
+				return false
+			}
+			id = change.Upsert.Id
+		}
+		if ind, ok := s.Items.Search(allocator.ItemKey(s.KS, id.String())); ok {
+			var spec = s.Items[ind].Decoded.(allocator.Item).ItemValue.(*ShardSpec)
+
+			if !claims.Allows(auth.Apply, shardLabels(spec)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// shardLabels returns the labels of the ShardSpec, including its meta-labels.
+func shardLabels(spec *ShardSpec) pb.LabelSet {
+	return pb.UnionLabelSets(ExtractShardSpecMetaLabels(spec, pb.LabelSet{}), spec.LabelSet, pb.LabelSet{})
+}
+
 // ListShards invokes the List RPC, and maps a validation or !OK status to an error.
 func ListShards(ctx context.Context, sc ShardClient, req *ListRequest) (*ListResponse, error) {
 	if r, err := sc.List(pb.WithDispatchDefault(ctx), req); err != nil {
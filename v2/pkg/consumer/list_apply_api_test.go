@@ -1,8 +1,12 @@
 package consumer
 
 import (
+	"context"
+
+	"github.com/LiveRamp/gazette/v2/pkg/auth"
 	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
 	gc "github.com/go-check/check"
+	"google.golang.org/grpc/metadata"
 )
 
 type ListApplySuite struct{}
@@ -142,4 +146,65 @@ func (s *ListApplySuite) TestApplyCases(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, `Changes\[0\].Delete: not a valid token \(invalid shard id\)`)
 }
 
+func (s *ListApplySuite) TestAuthorizationCases(c *gc.C) {
+	var tf, cleanup = newTestFixture(c)
+	defer cleanup()
+
+	var specA = makeShard("shard-a")
+	specA.Labels = append(specA.Labels, pb.Label{Name: "team", Value: "alpha"})
+	var specB = makeShard("shard-b")
+	specB.Labels = append(specB.Labels, pb.Label{Name: "team", Value: "beta"})
+
+	var key = []byte("shared secret")
+	tf.service.Authenticator = auth.NewKeyedAuthenticator(key)
+
+	var withGrants = func(grants ...auth.Grant) context.Context {
+		var token, err = auth.NewToken(auth.Claims{Grants: grants}, key)
+		c.Assert(err, gc.IsNil)
+		return metadata.NewIncomingContext(tf.ctx, metadata.Pairs("authorization", "Bearer "+token))
+	}
+	var teamA = pb.LabelSelector{Include: pb.MustLabelSet("team", "alpha")}
+	var adminCtx = withGrants(auth.Grant{Capabilities: []auth.Capability{auth.Read, auth.Apply}})
+	var teamACtx = withGrants(auth.Grant{Selector: teamA, Capabilities: []auth.Capability{auth.Read, auth.Apply}})
+	var readCtx = withGrants(auth.Grant{Capabilities: []auth.Capability{auth.Read}})
+
+	// Case: requests without a token are not authenticated.
+	var _, err = tf.service.List(tf.ctx, &ListRequest{})
+	c.Check(err, gc.ErrorMatches, `rpc error: code = Unauthenticated desc = .*`)
+
+	// Case: an unrestricted grant may apply shards of both teams.
+	applyResp, err := tf.service.Apply(adminCtx, &ApplyRequest{
+		Changes: []ApplyRequest_Change{{Upsert: specA}, {Upsert: specB}},
+	})
+	c.Assert(err, gc.IsNil)
+	c.Check(applyResp.Status, gc.Equals, Status_OK)
+
+	// Case: listing returns only shards selected by the caller's grants.
+	listResp, err := tf.service.List(teamACtx, &ListRequest{})
+	c.Assert(err, gc.IsNil)
+	c.Assert(listResp.Shards, gc.HasLen, 1)
+	c.Check(listResp.Shards[0].Spec, gc.DeepEquals, *specA)
+
+	// Case: a caller without an apply grant may not apply.
+	applyResp, err = tf.service.Apply(readCtx, &ApplyRequest{
+		Changes: []ApplyRequest_Change{{Delete: "shard-a", ExpectModRevision: listResp.Shards[0].ModRevision}},
+	})
+	c.Assert(err, gc.IsNil)
+	c.Check(applyResp.Status, gc.Equals, Status_NOT_ALLOWED)
+
+	// Case: team A may not delete shards of team B.
+	applyResp, err = tf.service.Apply(teamACtx, &ApplyRequest{
+		Changes: []ApplyRequest_Change{{Delete: "shard-b", ExpectModRevision: 1}},
+	})
+	c.Assert(err, gc.IsNil)
+	c.Check(applyResp.Status, gc.Equals, Status_NOT_ALLOWED)
+
+	// Case: team A may delete its own shard.
+	applyResp, err = tf.service.Apply(teamACtx, &ApplyRequest{
+		Changes: []ApplyRequest_Change{{Delete: "shard-a", ExpectModRevision: listResp.Shards[0].ModRevision}},
+	})
+	c.Assert(err, gc.IsNil)
+	c.Check(applyResp.Status, gc.Equals, Status_OK)
+}
+
 var _ = gc.Suite(&ListApplySuite{})
@@ -3,6 +3,7 @@ package consumer
 import (
 	"context"
 	"fmt"
+	"os"
 	"sync"
 	"time"
 
@@ -20,12 +21,21 @@ import (
 const (
 	// Frequency with which current FSM hints are written to Etcd.
 	storeHintsInterval = 5 * time.Minute
+	// Frequency with which recorded Store files are considered for rebasing.
+	rebaseInterval = 5 * time.Minute
 	// Size of the channel used between message decode & consumption. Needs to
 	// be rather large, to minimize processing stalls. The current value will
 	// tolerate a data delay of up to 82ms @ 100K messages / sec without stalling.
 	messageBufferSize = 1 << 13 // 8192.
+	// Default and maximum delays before automatic restart of a FAILED Replica.
+	defaultRestartBackoff = time.Second
+	maxRestartBackoff     = 5 * time.Minute
 )
 
+// Default duration for which a restarted Replica must remain PRIMARY or TAILING
+// before its count of Restarts (and thus its restart backoff) is reset.
+var defaultRestartsResetDelay = 2 * maxRestartBackoff
+
 // Replica of a shard which is processed locally.
 type Replica struct {
 	// Context tied to processing lifetime of this shard replica by this
@@ -40,12 +50,42 @@ type Replica struct {
 	store        Store
 	storeReadyCh chan struct{} // Closed when |store| is ready.
 	player       *recoverylog.Player
+	// Directory under which the shard is played back. See Service.LocalDir.
+	localDir string
+	// Storage of the shard's FSMHints. See Service.PersistLargeHints.
+	hints hintsConfig
 	// Clients retained for Replica's use during processing.
 	ks            *keyspace.KeySpace
 	etcd          *clientv3.Client
 	journalClient client.AsyncJournalClient
 	// Synchronizes over goroutines referencing the Replica.
 	wg sync.WaitGroup
+	// Read-only view of the standby's played-back Store, if one is open.
+	viewMu sync.Mutex
+	view   *standbyView
+	// Closed when a prior Replica of the shard (which this Replica restarts, or
+	// which was cancelled before the shard was re-assigned) has been torn down
+	// and may no longer access the shard's local directory. Playback, and thus
+	// completion of playback as primary, doesn't begin until it's closed. Nil
+	// if there is no such prior Replica.
+	priorTornDownCh <-chan struct{}
+	// Whether the Resolver has observed the Replica as FAILED, and whether it
+	// has scheduled its automatic restart. Guarded by the KeySpace lock.
+	failed, restarting bool
+	// Duration for which the Replica must remain PRIMARY or TAILING before
+	// its count of Restarts is reset.
+	restartsResetDelay time.Duration
+}
+
+// standbyView is a read-only Store view, opened by a standby Replica from a
+// directory linked by its tailing Player. A standbyView is reference-counted:
+// once released by the Replica and all Resolutions using it, the Store is
+// destroyed and its directory removed.
+type standbyView struct {
+	store Store
+	dir   string
+	asOf  time.Time // Time at which the view was known to be current.
+	wg    sync.WaitGroup
 }
 
 // NewReplica returns a Replica in its initial state. The Replica must be
@@ -54,14 +94,16 @@ func NewReplica(app Application, ks *keyspace.KeySpace, etcd *clientv3.Client, r
 	var ctx, cancel = context.WithCancel(context.Background())
 
 	var r = &Replica{
-		ctx:           ctx,
-		cancel:        cancel,
-		app:           app,
-		storeReadyCh:  make(chan struct{}),
-		player:        recoverylog.NewPlayer(),
-		ks:            ks,
-		etcd:          etcd,
-		journalClient: client.NewAppendService(context.Background(), rjc),
+		ctx:                ctx,
+		cancel:             cancel,
+		app:                app,
+		storeReadyCh:       make(chan struct{}),
+		player:             recoverylog.NewPlayer(),
+		ks:                 ks,
+		etcd:               etcd,
+		journalClient:      client.NewAppendService(context.Background(), rjc),
+		hints:              hintsConfig{maxStoredSize: maxStoredHintsSize},
+		restartsResetDelay: defaultRestartsResetDelay,
 	}
 	return r
 }
@@ -115,6 +157,15 @@ var transition = func(r *Replica, spec *ShardSpec, assignment keyspace.KeyValue)
 func (r *Replica) serveStandby() {
 	defer r.wg.Done()
 
+	if r.priorTornDownCh != nil {
+		select {
+		case <-r.priorTornDownCh:
+			// Pass.
+		case <-r.Context().Done():
+			return
+		}
+	}
+
 	go func() {
 		tryUpdateStatus(r, r.ks, r.etcd, ReplicaStatus{Code: ReplicaStatus_BACKFILL})
 
@@ -124,10 +175,11 @@ func (r *Replica) serveStandby() {
 			return
 		case <-r.player.Tailing():
 			tryUpdateStatus(r, r.ks, r.etcd, ReplicaStatus{Code: ReplicaStatus_TAILING})
+			r.resetRestartsAfter(r.restartsResetDelay)
 		}
 	}()
 
-	if err := playLog(r, r.player, r.etcd); err != nil {
+	if err := playLog(r, r.player, r.etcd, r.localDir); err != nil {
 		err = extendErr(err, "playLog")
 		tryUpdateStatus(r, r.ks, r.etcd, newErrorStatus(err))
 	}
@@ -138,7 +190,7 @@ func (r *Replica) serveStandby() {
 func (r *Replica) servePrimary() {
 	defer r.wg.Done()
 
-	var store, offsets, err = completePlayback(r, r.app, r.player, r.etcd)
+	var store, offsets, err = completePlayback(r, r.app, r.player, r.etcd, r.hints)
 	if err != nil {
 		err = extendErr(err, "completePlayback")
 		tryUpdateStatus(r, r.ks, r.etcd, newErrorStatus(err))
@@ -147,7 +199,9 @@ func (r *Replica) servePrimary() {
 
 	r.store = store
 	close(r.storeReadyCh)
+	r.releaseView() // Further resolutions are served by |store|.
 	tryUpdateStatus(r, r.ks, r.etcd, ReplicaStatus{Code: ReplicaStatus_PRIMARY})
+	go r.resetRestartsAfter(r.restartsResetDelay)
 
 	// Spawn service loops to read & decode messages.
 	var msgCh = make(chan message.Envelope, messageBufferSize)
@@ -163,11 +217,39 @@ func (r *Replica) servePrimary() {
 		}(src.Journal, offsets[src.Journal])
 	}
 
+	// Periodically rebase recorded files of the Store, if configured.
+	if r.Spec().RebaseLogBytes != 0 {
+		var ticker = time.NewTicker(rebaseInterval)
+		defer ticker.Stop()
+
+		r.wg.Add(1)
+		go func() {
+			if err := rebaseStore(r, r.store.Recorder(), ticker.C); err != nil {
+				err = extendErr(err, "rebaseStore")
+				tryUpdateStatus(r, r.ks, r.etcd, newErrorStatus(err))
+			}
+			r.wg.Done()
+		}()
+	}
+
 	// Consume messages from |msgCh| until an error occurs (such as context.Cancelled).
 	var hintsTimer = time.NewTimer(storeHintsInterval)
 	defer hintsTimer.Stop()
 
-	if err := consumeMessages(r, r.store, r.app, r.etcd, msgCh, hintsTimer.C); err != nil {
+	// Periodically checkpoint the Store, if configured and supported.
+	var checkpointCh <-chan time.Time
+	if d := r.Spec().CheckpointInterval; d != 0 {
+		if _, ok := r.store.(Checkpointer); ok {
+			var ticker = time.NewTicker(d)
+			defer ticker.Stop()
+			checkpointCh = ticker.C
+		} else {
+			log.WithField("shard", r.Spec().Id).
+				Warn("CheckpointInterval is set, but Store does not implement Checkpointer")
+		}
+	}
+
+	if err := consumeMessages(r, r.store, r.app, r.etcd, r.hints, msgCh, hintsTimer.C, checkpointCh); err != nil {
 		err = extendErr(err, "consumeMessages")
 		tryUpdateStatus(r, r.ks, r.etcd, newErrorStatus(err))
 	}
@@ -178,12 +260,206 @@ func (r *Replica) servePrimary() {
 // the store.
 func (r *Replica) WaitAndTearDown() {
 	r.wg.Wait()
+	r.releaseView()
 
 	if r.store != nil {
 		r.store.Destroy()
 	}
 }
 
+// restartAfter clears the FAILED Assignment of the Replica after |delay|,
+// unless the Replica is first cancelled. The Resolver observes the cleared
+// status, and restarts the Replica. The current Assignment is read with each
+// attempt, and a failed attempt (eg, because the Assignment was since updated)
+// is retried after |delay|.
+func (r *Replica) restartAfter(delay time.Duration) {
+	for {
+		select {
+		case <-time.After(delay):
+			// Pass.
+		case <-r.Context().Done():
+			return
+		}
+		var asn = r.Assignment()
+		var status = asn.Decoded.(allocator.Assignment).AssignmentValue.(*ReplicaStatus)
+
+		if status.Code != ReplicaStatus_FAILED {
+			return // Already cleared. The Resolver will restart the Replica.
+		} else if err := ClearFailedStatus(r.Context(), r.etcd, asn); err == nil {
+			return
+		} else {
+			log.WithFields(log.Fields{"err": err, "key": string(asn.Raw.Key)}).
+				Warn("failed to clear FAILED status for restart (will retry)")
+		}
+	}
+}
+
+// resetRestartsAfter resets the count of Restarts of the Replica's Assignment
+// after |delay|, if the Replica is then PRIMARY or TAILING and is not first
+// cancelled. A Replica which recovers from a failure and then runs without
+// further failures is thus again allowed ShardSpec.MaxRestarts restarts.
+func (r *Replica) resetRestartsAfter(delay time.Duration) {
+	select {
+	case <-time.After(delay):
+		// Pass.
+	case <-r.Context().Done():
+		return
+	}
+	var asn = r.Assignment()
+	var status = asn.Decoded.(allocator.Assignment).AssignmentValue.(*ReplicaStatus)
+
+	if status.Restarts == 0 ||
+		(status.Code != ReplicaStatus_PRIMARY && status.Code != ReplicaStatus_TAILING) {
+		return
+	}
+	var key = string(asn.Raw.Key)
+	var val = (&ReplicaStatus{Code: status.Code}).MarshalString()
+
+	// ReplicaStatus.Reduce retains the largest Restarts, so update directly.
+	var resp, err = r.etcd.Txn(r.Context()).
+		If(clientv3.Compare(clientv3.ModRevision(key), "=", asn.Raw.ModRevision)).
+		Then(clientv3.OpPut(key, val, clientv3.WithIgnoreLease())).
+		Commit()
+
+	if err == nil && !resp.Succeeded {
+		err = errors.Errorf("transaction failed")
+	}
+	if err != nil {
+		log.WithFields(log.Fields{"err": err, "key": key}).
+			Warn("failed to reset replica restarts")
+	} else {
+		log.WithFields(log.Fields{"key": key, "restarts": status.Restarts}).
+			Info("reset restarts of recovered replica")
+	}
+}
+
+// ClearFailedStatus clears the FAILED ReplicaStatus of shard Assignment |asn|,
+// and increments its count of Restarts. The consumer process of the Assignment
+// observes the cleared status, and tears down and restarts its Replica. The
+// update is conditioned on |asn| being unmodified since it was read.
+func ClearFailedStatus(ctx context.Context, etcd clientv3.KV, asn keyspace.KeyValue) error {
+	var status = asn.Decoded.(allocator.Assignment).AssignmentValue.(*ReplicaStatus)
+	if status.Code != ReplicaStatus_FAILED {
+		return errors.Errorf("status is not FAILED (%s)", status.Code)
+	}
+
+	var key = string(asn.Raw.Key)
+	var val = (&ReplicaStatus{Code: ReplicaStatus_IDLE, Restarts: status.Restarts + 1}).MarshalString()
+
+	var resp, err = etcd.Txn(ctx).
+		If(clientv3.Compare(clientv3.ModRevision(key), "=", asn.Raw.ModRevision)).
+		Then(clientv3.OpPut(key, val, clientv3.WithIgnoreLease())).
+		Commit()
+
+	if err == nil && !resp.Succeeded {
+		err = errors.Errorf("transaction failed")
+	}
+	return err
+}
+
+// restartBackoff returns the delay before automatic restart of a FAILED
+// Replica of |spec|, which has been restarted |restarts| times already.
+func restartBackoff(spec *ShardSpec, restarts uint32) time.Duration {
+	var d = spec.RestartBackoff
+	if d == 0 {
+		d = defaultRestartBackoff
+	}
+	for ; restarts != 0 && d < maxRestartBackoff; restarts-- {
+		d *= 2
+	}
+	if d > maxRestartBackoff {
+		d = maxRestartBackoff
+	}
+	return d
+}
+
+// acquireView returns a standbyView of the Replica's tailing Player, which
+// reflects all recovery log operations committed no later than |maxStaleness|
+// prior to the call. A current view is re-used if it's fresh enough, and
+// otherwise a new view is opened. The caller must call wg.Done of the
+// returned view once it's no longer used. An error is returned if the Replica
+// is cancelled, and the caller must hold a reference of the Replica's
+// WaitGroup such that it's not torn down during the call.
+func (r *Replica) acquireView(ctx context.Context, maxStaleness time.Duration) (*standbyView, error) {
+	r.viewMu.Lock()
+	defer r.viewMu.Unlock()
+
+	if err := r.Context().Err(); err != nil {
+		return nil, err
+	}
+	// Abort blocking operations if the Replica is cancelled.
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithCancel(ctx)
+	defer cancel()
+
+	go func() {
+		select {
+		case <-r.Context().Done():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	if v := r.view; v != nil && timeNow().Sub(v.asOf) <= maxStaleness {
+		v.wg.Add(1)
+		return v, nil
+	}
+	var asOf = timeNow()
+
+	// Issue an empty Append to the recovery log as a write barrier, and await
+	// our Player's read-through of its commit. The linked view then reflects
+	// all operations committed to the log prior to |asOf|.
+	var resp, err = client.Append(ctx, r.journalClient, pb.AppendRequest{Journal: r.Spec().RecoveryLog})
+	if err != nil {
+		return nil, extendErr(err, "appending recovery log barrier")
+	} else if err = r.player.ReadThrough(ctx, resp.Commit.End); err != nil {
+		return nil, extendErr(err, "awaiting read-through of offset %d", resp.Commit.End)
+	}
+
+	dir, err := r.player.LinkView(ctx)
+	if err != nil {
+		return nil, extendErr(err, "linking view")
+	}
+	store, err := r.app.(ViewOpener).OpenView(r, dir)
+	if err != nil {
+		if rmErr := os.RemoveAll(dir); rmErr != nil {
+			log.WithFields(log.Fields{"dir": dir, "err": rmErr}).
+				Warn("failed to remove view directory")
+		}
+		return nil, extendErr(err, "OpenView")
+	}
+
+	var v = &standbyView{store: store, dir: dir, asOf: asOf}
+	v.wg.Add(2) // Referenced by both the Replica and the caller.
+
+	go func() {
+		v.wg.Wait()
+		v.store.Destroy()
+
+		if err := os.RemoveAll(v.dir); err != nil {
+			log.WithFields(log.Fields{"dir": v.dir, "err": err}).
+				Warn("failed to remove view directory")
+		}
+	}()
+
+	if r.view != nil {
+		r.view.wg.Done() // Release our reference of the prior view.
+	}
+	r.view = v
+	return v, nil
+}
+
+// releaseView releases the Replica's reference of its current standbyView, if any.
+func (r *Replica) releaseView() {
+	r.viewMu.Lock()
+	defer r.viewMu.Unlock()
+
+	if r.view != nil {
+		r.view.wg.Done()
+		r.view = nil
+	}
+}
+
 // updateStatus publishes |status| under the Shard Assignment key in a checked
 // transaction. An existing ReplicaStatus is reduced into |status| prior to update.
 func updateStatus(shard Shard, ks *keyspace.KeySpace, etcd *clientv3.Client, status ReplicaStatus) error {
@@ -1,8 +1,11 @@
 package consumer
 
 import (
+	"context"
 	"errors"
+	"time"
 
+	"github.com/coreos/etcd/clientv3"
 	gc "github.com/go-check/check"
 )
 
@@ -116,4 +119,179 @@ func (s *ReplicaSuite) TestConsumeMessagesError(c *gc.C) {
 	tf.allocateShard(c, makeShard("a-shard")) // Cleanup.
 }
 
+func (s *ReplicaSuite) TestManualRestartOfFailedReplica(c *gc.C) {
+	var tf, cleanup = newTestFixture(c)
+	defer cleanup()
+
+	tf.app.newStoreErr = errors.New("an error") // Cause NewStore to fail.
+	tf.allocateShard(c, makeShard("a-shard"), localID)
+
+	// Expect the replica FAILED, and (as MaxRestarts is zero) isn't restarted.
+	var status, asn = awaitStatus(tf.state, func(s *ReplicaStatus) bool { return s.Code == ReplicaStatus_FAILED })
+	c.Check(status.Restarts, gc.Equals, uint32(0))
+
+	tf.ks.Mu.RLock()
+	var failed = tf.resolver.replicas["a-shard"]
+	tf.ks.Mu.RUnlock()
+
+	// Clearing a status which isn't current fails.
+	var stale = asn
+	stale.Raw.ModRevision--
+	c.Check(ClearFailedStatus(tf.ctx, tf.etcd, stale), gc.ErrorMatches, `transaction failed`)
+
+	// Clear the FAILED status. Expect the replica is torn down, and restarted.
+	tf.app.newStoreErr = nil
+	c.Check(ClearFailedStatus(tf.ctx, tf.etcd, asn), gc.IsNil)
+
+	status, asn = awaitStatus(tf.state, func(s *ReplicaStatus) bool { return s.Code == ReplicaStatus_PRIMARY })
+	c.Check(status.Restarts, gc.Equals, uint32(1))
+	<-failed.Context().Done()
+
+	// A status which isn't FAILED cannot be cleared.
+	c.Check(ClearFailedStatus(tf.ctx, tf.etcd, asn), gc.ErrorMatches, `status is not FAILED \(PRIMARY\)`)
+
+	// Verify message pump and consumer loops of the restarted replica.
+	tf.ks.Mu.RLock()
+	var r = tf.resolver.replicas["a-shard"]
+	tf.ks.Mu.RUnlock()
+
+	c.Check(r != failed, gc.Equals, true)
+	runSomeTransactions(c, r, r.app.(*testApplication), r.store.(*JSONFileStore))
+
+	tf.allocateShard(c, makeShard("a-shard")) // Cleanup.
+}
+
+func (s *ReplicaSuite) TestAutomaticRestartsOfFailedReplica(c *gc.C) {
+	var tf, cleanup = newTestFixture(c)
+	defer cleanup()
+
+	var shard = makeShard("a-shard")
+	shard.MaxRestarts = 2
+	shard.RestartBackoff = time.Millisecond
+
+	tf.app.newStoreErr = errors.New("an error") // Cause NewStore to fail.
+	tf.allocateShard(c, shard, localID)
+
+	// Expect the replica is restarted until MaxRestarts is reached.
+	var status, _ = awaitStatus(tf.state, func(s *ReplicaStatus) bool {
+		return s.Code == ReplicaStatus_FAILED && s.Restarts == 2
+	})
+	c.Check(status.Errors[0], gc.Matches, `completePlayback: initializing store: an error`)
+
+	// Expect it then remains FAILED.
+	var ctx, cancel = context.WithTimeout(tf.ctx, 50*time.Millisecond)
+	defer cancel()
+
+	tf.ks.Mu.RLock()
+	c.Check(tf.ks.WaitForRevision(ctx, tf.ks.Header.Revision+1), gc.Equals, context.DeadlineExceeded)
+	tf.ks.Mu.RUnlock()
+
+	tf.allocateShard(c, makeShard("a-shard")) // Cleanup.
+}
+
+func (s *ReplicaSuite) TestRestartOfUpdatedFailedReplica(c *gc.C) {
+	var tf, cleanup = newTestFixture(c)
+	defer cleanup()
+
+	var shard = makeShard("a-shard")
+	shard.MaxRestarts = 1
+	shard.RestartBackoff = 100 * time.Millisecond
+
+	tf.app.newStoreErr = errors.New("an error") // Cause NewStore to fail.
+	tf.allocateShard(c, shard, localID)
+
+	var _, asn = awaitStatus(tf.state, func(s *ReplicaStatus) bool { return s.Code == ReplicaStatus_FAILED })
+	tf.app.newStoreErr = nil // Allow the restarted replica to succeed.
+
+	// Update the FAILED Assignment before its scheduled restart. Expect the
+	// replica is nonetheless restarted.
+	var _, err = tf.etcd.Put(tf.ctx, string(asn.Raw.Key), string(asn.Raw.Value), clientv3.WithIgnoreLease())
+	c.Assert(err, gc.IsNil)
+
+	var status, _ = awaitStatus(tf.state, func(s *ReplicaStatus) bool { return s.Code == ReplicaStatus_PRIMARY })
+	c.Check(status.Restarts, gc.Equals, uint32(1))
+
+	tf.allocateShard(c, makeShard("a-shard")) // Cleanup.
+}
+
+func (s *ReplicaSuite) TestRaisedMaxRestartsRestartsFailedReplica(c *gc.C) {
+	var tf, cleanup = newTestFixture(c)
+	defer cleanup()
+
+	var shard = makeShard("a-shard")
+	shard.RestartBackoff = time.Millisecond
+
+	tf.app.newStoreErr = errors.New("an error") // Cause NewStore to fail.
+	tf.allocateShard(c, shard, localID)
+
+	// Expect the replica FAILED, and (as MaxRestarts is zero) isn't restarted.
+	var status, _ = awaitStatus(tf.state, func(s *ReplicaStatus) bool { return s.Code == ReplicaStatus_FAILED })
+	c.Check(status.Restarts, gc.Equals, uint32(0))
+
+	// Raise MaxRestarts. Expect the FAILED replica is now restarted.
+	shard.MaxRestarts = 1
+	tf.allocateShard(c, shard, localID)
+
+	status, _ = awaitStatus(tf.state, func(s *ReplicaStatus) bool {
+		return s.Code == ReplicaStatus_FAILED && s.Restarts == 1
+	})
+	c.Check(status.Errors[0], gc.Matches, `completePlayback: initializing store: an error`)
+
+	tf.allocateShard(c, makeShard("a-shard")) // Cleanup.
+}
+
+func (s *ReplicaSuite) TestRestartsAreResetOnceRecovered(c *gc.C) {
+	var tf, cleanup = newTestFixture(c)
+	defer cleanup()
+
+	// Shorten the restarts reset delay of Replicas created by the Resolver.
+	tf.ks.Mu.Lock()
+	var newReplica = tf.resolver.newReplica
+	tf.resolver.newReplica = func() *Replica {
+		var r = newReplica()
+		r.restartsResetDelay = 10 * time.Millisecond
+		return r
+	}
+	tf.ks.Mu.Unlock()
+
+	var shard = makeShard("a-shard")
+	shard.MaxRestarts = 1
+	shard.RestartBackoff = 50 * time.Millisecond
+
+	tf.app.newStoreErr = errors.New("an error") // Cause NewStore to fail.
+	tf.allocateShard(c, shard, localID)
+
+	awaitStatus(tf.state, func(s *ReplicaStatus) bool { return s.Code == ReplicaStatus_FAILED })
+	tf.app.newStoreErr = nil // Allow the restarted replica to succeed.
+
+	tf.ks.Mu.RLock()
+	var failed = tf.resolver.replicas["a-shard"]
+	tf.ks.Mu.RUnlock()
+
+	// Expect the replica is restarted, and its Restarts are then reset. A
+	// PRIMARY status with zero Restarts is reached only through both (the
+	// intermediate status having one Restart may not be observed).
+	awaitStatus(tf.state, func(s *ReplicaStatus) bool {
+		return s.Code == ReplicaStatus_PRIMARY && s.Restarts == 0
+	})
+
+	tf.ks.Mu.RLock()
+	c.Check(tf.resolver.replicas["a-shard"] != failed, gc.Equals, true)
+	tf.ks.Mu.RUnlock()
+
+	tf.allocateShard(c, makeShard("a-shard")) // Cleanup.
+}
+
+func (s *ReplicaSuite) TestRestartBackoff(c *gc.C) {
+	var spec = makeShard("a-shard")
+
+	c.Check(restartBackoff(spec, 0), gc.Equals, time.Second)
+	c.Check(restartBackoff(spec, 3), gc.Equals, 8*time.Second)
+	c.Check(restartBackoff(spec, 100), gc.Equals, 5*time.Minute)
+
+	spec.RestartBackoff = 10 * time.Millisecond
+	c.Check(restartBackoff(spec, 0), gc.Equals, 10*time.Millisecond)
+	c.Check(restartBackoff(spec, 2), gc.Equals, 40*time.Millisecond)
+}
+
 var _ = gc.Suite(&ReplicaSuite{})
@@ -3,9 +3,17 @@ package consumer
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/LiveRamp/gazette/v2/pkg/allocator"
 	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	"github.com/LiveRamp/gazette/v2/pkg/recoverylog"
+	log "github.com/sirupsen/logrus"
 )
 
 // Resolver maps shards to responsible consumer processes, and manages the set
@@ -14,6 +22,9 @@ type Resolver struct {
 	state      *allocator.State
 	newReplica func() *Replica
 	replicas   map[ShardID]*Replica
+	// Channels of shards which are not assigned, closed once a cancelled
+	// Replica of the shard is torn down, or its local directory is removed.
+	tearingDown map[ShardID]<-chan struct{}
 }
 
 // NewResolver returns a Resolver derived from the allocator.State, which
@@ -21,9 +32,10 @@ type Resolver struct {
 // of the local ConsumerSpec.
 func NewResolver(state *allocator.State, newReplica func() *Replica) *Resolver {
 	var r = &Resolver{
-		state:      state,
-		newReplica: newReplica,
-		replicas:   make(map[ShardID]*Replica),
+		state:       state,
+		newReplica:  newReplica,
+		replicas:    make(map[ShardID]*Replica),
+		tearingDown: make(map[ShardID]<-chan struct{}),
 	}
 	state.KS.Mu.Lock()
 	state.KS.Observers = append(state.KS.Observers, r.updateResolutions)
@@ -40,6 +52,18 @@ type ResolveArgs struct {
 	MayProxy bool
 	// Optional Header attached to the request from a proxy-ing peer.
 	ProxyHeader *pb.Header
+	// Whether we may resolve to a local standby Replica, if this process is
+	// not primary for the ShardID. The standby must be TAILING the shard's
+	// recovery log, and the Application must implement ViewOpener. Resolutions
+	// to a standby are read-only, and must not be used to mutate Store state.
+	MayResolveStandby bool
+	// Maximum staleness of a standby Resolution's Store view. The view reflects
+	// all Store state which was committed to the recovery log at least
+	// MaxStandbyStaleness prior to the Resolve call. If zero, the view reflects
+	// all Store state committed prior to the Resolve call. State which a
+	// primary has recorded but not yet committed (eg, of a pending
+	// transaction) isn't reflected.
+	MaxStandbyStaleness time.Duration
 }
 
 // Resolution result of a ShardID.
@@ -53,7 +77,10 @@ type Resolution struct {
 	// Shard processing context, or nil if this process is not primary for the ShardID.
 	Shard Shard
 	// Store of the Shard, or nil if this process is not primary for the ShardID.
+	// If Standby, Store is a read-only view opened by ViewOpener.
 	Store Store
+	// Standby is true iff the ShardID resolved to a local standby Replica.
+	Standby bool
 	// Done releases Shard & Store, and must be called when no longer needed.
 	// Iff Shard & Store are nil, so is Done.
 	Done func()
@@ -135,6 +162,40 @@ func (r *Resolver) Resolve(args ResolveArgs) (res Resolution, err error) {
 		res.Status = Status_OK
 	}
 
+	// If we're not primary but may resolve to a tailing local standby, do so.
+	if res.Spec != nil && args.MayResolveStandby && res.Header.ProcessId != localID {
+		if replica, ok := r.replicas[args.ShardID]; ok && mayServeStandby(replica) {
+			res.Status = Status_OK
+			res.Header.ProcessId = localID
+
+			// Reference |replica| while |ks| is still locked, and it cannot yet
+			// have been cancelled and begun to tear down.
+			replica.wg.Add(1)
+			ks.Mu.RUnlock() // We no longer require |ks|; don't hold the lock while we open a view.
+			ks = nil
+
+			var view *standbyView
+			if view, err = replica.acquireView(args.Context, args.MaxStandbyStaleness); err != nil {
+				replica.wg.Done()
+				err = extendErr(err, "resolving standby of %s", args.ShardID)
+				return
+			}
+			addTrace(args.Context, "acquireView() => %s", view.dir)
+
+			res.Shard = replica
+			res.Store = view.store
+			res.Standby = true
+			res.Done = func() {
+				view.wg.Done()
+				replica.wg.Done()
+			}
+
+			addTrace(args.Context, "resolve(%s) => %s, header: %s, standby: %t",
+				args.ShardID, res.Status, &res.Header, res.Standby)
+			return
+		}
+	}
+
 	if res.Status != Status_OK {
 		// If we're returning an error, the effective ProcessId is ourselves
 		// (since we authored the error response).
@@ -180,6 +241,118 @@ func (r *Resolver) Resolve(args ResolveArgs) (res Resolution, err error) {
 	return
 }
 
+// mayServeStandby returns true iff the Replica's Application implements
+// ViewOpener, and its Player is tailing the recovery log.
+func mayServeStandby(r *Replica) bool {
+	if _, ok := r.app.(ViewOpener); !ok {
+		return false
+	}
+	select {
+	case <-r.player.Tailing():
+		return true
+	default:
+		return false
+	}
+}
+
+// restartReplica cancels the |prior| Replica and returns |next|, which will
+// begin processing only after |prior| is fully torn down. This ensures |next|
+// doesn't race |prior| over its local state (eg, of a shared LocalDir).
+func (r *Resolver) restartReplica(prior, next *Replica) *Replica {
+	next.priorTornDownCh = r.tearDown(prior, false)
+	return next
+}
+
+// tearDown cancels the |replica| and returns a channel which is closed once
+// it's torn down. If |gc|, local directories of unassigned shards are then
+// removed (see gcLocalDir) before the channel is closed.
+func (r *Resolver) tearDown(replica *Replica, gc bool) <-chan struct{} {
+	var ch = make(chan struct{})
+
+	replica.cancel()
+	go func() {
+		replica.WaitAndTearDown()
+
+		if gc && replica.localDir != "" {
+			r.gcLocalDir(replica.localDir, ch)
+		}
+		close(ch)
+	}()
+	return ch
+}
+
+// gcLocalDir removes shard directories of |localDir| which aren't used by a
+// Replica of this Resolver. Directories of shards having a cancelled Replica
+// which is still being torn down (other than that of |self|) are skipped. So is collection altogether if
+// the local ConsumerSpec is exiting, as its local directories are to be
+// re-used by a future process.
+func (r *Resolver) gcLocalDir(localDir string, self <-chan struct{}) {
+	var entries, err = ioutil.ReadDir(localDir)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err, "dir": localDir}).
+			Warn("failed to list local directory")
+		return
+	}
+	var ks = r.state.KS
+	var ch = make(chan struct{})
+	var remove []string
+
+	// Determine directories to remove, and mark their shards as tearing down
+	// so that a newly assigned Replica awaits their removal.
+	ks.Mu.Lock()
+	if !r.localMemberExiting() {
+		for _, entry := range entries {
+			// Views of a shard are linked into sibling directories of its playback
+			// directory (see recoverylog.Player.LinkView).
+			var name = entry.Name()
+			if ind := strings.LastIndex(name, recoverylog.ViewDirSuffix); ind != -1 {
+				name = name[:ind]
+			}
+			var id, err = url.PathUnescape(name)
+			if err != nil {
+				continue // Not a shard directory.
+			} else if _, ok := r.replicas[ShardID(id)]; ok {
+				continue
+			} else if prior, ok := r.tearingDown[ShardID(id)]; ok && prior != self && prior != ch && !isClosed(prior) {
+				continue
+			}
+			r.tearingDown[ShardID(id)] = ch
+			remove = append(remove, filepath.Join(localDir, entry.Name()))
+		}
+	}
+	ks.Mu.Unlock()
+
+	for _, dir := range remove {
+		if err := os.RemoveAll(dir); err != nil {
+			log.WithFields(log.Fields{"err": err, "dir": dir}).
+				Warn("failed to remove local directory of unassigned shard")
+		} else {
+			log.WithField("dir", dir).Info("removed local directory of unassigned shard")
+		}
+	}
+	close(ch)
+}
+
+// localMemberExiting returns true if the local ConsumerSpec is missing or has
+// a zero shard limit, as is the case during graceful shutdown. The KeySpace
+// lock must be held.
+func (r *Resolver) localMemberExiting() bool {
+	if r.state.LocalMemberInd == -1 {
+		return true
+	}
+	return r.state.Members[r.state.LocalMemberInd].
+		Decoded.(allocator.Member).MemberValue.(*ConsumerSpec).ItemLimit() == 0
+}
+
+func isClosed(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
 // updateResolutions updates |replicas| to match LocalItems, creating,
 // transitioning, and cancelling Replicas as needed. The KeySpace
 // lock must be held.
@@ -189,25 +362,62 @@ func (r *Resolver) updateResolutions() {
 	for _, li := range r.state.LocalItems {
 		var item = li.Item.Decoded.(allocator.Item)
 		var assignment = li.Assignments[li.Index]
+		var spec = item.ItemValue.(*ShardSpec)
+		var status = assignment.Decoded.(allocator.Assignment).AssignmentValue.(*ReplicaStatus)
 		var id = ShardID(item.ID)
 
 		var replica, ok = r.replicas[id]
 		if !ok {
 			replica = r.newReplica() // Newly assigned shard.
+
+			// If a prior Replica of the shard is still being torn down, await it.
+			if ch, ok := r.tearingDown[id]; ok {
+				replica.priorTornDownCh = ch
+				delete(r.tearingDown, id)
+			}
 		} else {
 			delete(r.replicas, id) // Move from |r.replicas| to |next|.
+
+			if replica.failed && status.Code != ReplicaStatus_FAILED {
+				// The FAILED status of |replica| has since been cleared. Tear it
+				// down, and restart with a new Replica once it's torn down.
+				replica = r.restartReplica(replica, r.newReplica())
+			}
 		}
 		next[id] = replica
-		transition(replica, item.ItemValue.(*ShardSpec), assignment)
+		transition(replica, spec, assignment)
+
+		// Evaluate the restart policy of a FAILED Replica which isn't already
+		// restarting. It's re-evaluated with each update, as a changed ShardSpec
+		// (eg, a raised MaxRestarts) may now permit a restart.
+		if status.Code == ReplicaStatus_FAILED && !replica.restarting {
+			var fields = log.Fields{"shard": id, "errors": status.Errors, "restarts": status.Restarts}
+
+			if status.Restarts < spec.MaxRestarts {
+				var delay = restartBackoff(spec, status.Restarts)
+				fields["delay"] = delay
+
+				log.WithFields(fields).Warn("replica FAILED (will restart)")
+				replica.restarting = true
+				go replica.restartAfter(delay)
+			} else if !replica.failed {
+				log.WithFields(fields).Warn("replica FAILED")
+			}
+			replica.failed = true
+		}
 	}
 
 	var prev = r.replicas
 	r.replicas = next
 
 	// Any remaining Replicas in |prev| were not in LocalItems.
-	for _, replica := range prev {
-		replica.cancel()
-		go replica.WaitAndTearDown()
+	for id, replica := range prev {
+		r.tearingDown[id] = r.tearDown(replica, true)
+	}
+	for id, ch := range r.tearingDown {
+		if isClosed(ch) {
+			delete(r.tearingDown, id)
+		}
 	}
 	return
 }
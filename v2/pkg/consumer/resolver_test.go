@@ -3,9 +3,16 @@ package consumer
 import (
 	"context"
 	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"time"
 
+	"github.com/LiveRamp/gazette/v2/pkg/allocator"
+	"github.com/LiveRamp/gazette/v2/pkg/client"
 	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	"github.com/LiveRamp/gazette/v2/pkg/recoverylog"
+	"github.com/coreos/etcd/clientv3"
 	gc "github.com/go-check/check"
 )
 
@@ -251,4 +258,190 @@ func (s *ResolverSuite) TestReplicaTransitions(c *gc.C) {
 	tf.allocateShard(c, makeShard("shard-a")) // Cleanup.
 }
 
+func (s *ResolverSuite) TestLocalDirCollection(c *gc.C) {
+	var tf, cleanup = newTestFixture(c)
+	defer cleanup()
+
+	var localDir, err = ioutil.TempDir("", "resolver-suite")
+	c.Assert(err, gc.IsNil)
+	defer os.RemoveAll(localDir)
+	tf.service.LocalDir = localDir
+
+	// Fixture: directories of a shard which isn't assigned, and of its view.
+	c.Assert(os.Mkdir(filepath.Join(localDir, "stale"), 0700), gc.IsNil)
+	c.Assert(os.Mkdir(filepath.Join(localDir, "stale"+recoverylog.ViewDirSuffix+"123"), 0700), gc.IsNil)
+
+	tf.allocateShard(c, makeShard("shard-a"), remoteID, localID)
+	tf.allocateShard(c, makeShard("shard-b"), remoteID, localID)
+
+	tf.ks.Mu.RLock()
+	var repA, repB = tf.resolver.replicas["shard-a"], tf.resolver.replicas["shard-b"]
+	tf.ks.Mu.RUnlock()
+
+	<-repA.player.Tailing()
+	<-repB.player.Tailing()
+	expectDirs(c, localDir, "shard-a", "shard-b", "stale", "stale.view-123")
+
+	// Remove "shard-a". Once torn down, expect its directory and those of
+	// other unassigned shards are removed.
+	tf.allocateShard(c, makeShard("shard-a"))
+
+	tf.ks.Mu.RLock()
+	var tornDownCh = tf.resolver.tearingDown["shard-a"]
+	tf.ks.Mu.RUnlock()
+
+	c.Assert(tornDownCh, gc.NotNil)
+	<-tornDownCh
+	expectDirs(c, localDir, "shard-b")
+
+	// Re-assign "shard-a", and expect it's played into a new directory.
+	tf.allocateShard(c, makeShard("shard-a"), remoteID, localID)
+
+	tf.ks.Mu.RLock()
+	repA = tf.resolver.replicas["shard-a"]
+	tf.ks.Mu.RUnlock()
+	<-repA.player.Tailing()
+
+	expectDirs(c, localDir, "shard-a", "shard-b")
+
+	// The local ConsumerSpec begins to exit, and its shards are removed.
+	// Expect their directories are retained.
+	var spec = makeConsumer(localID)
+	spec.ZeroLimit()
+
+	resp, err := tf.etcd.Txn(tf.ctx).If().Then(
+		clientv3.OpPut(allocator.MemberKey(tf.ks, localID.Zone, localID.Suffix), spec.MarshalString()),
+		clientv3.OpDelete(allocator.ItemAssignmentsPrefix(tf.ks, "shard-a"), clientv3.WithPrefix()),
+		clientv3.OpDelete(allocator.ItemAssignmentsPrefix(tf.ks, "shard-b"), clientv3.WithPrefix()),
+	).Commit()
+	c.Assert(err, gc.IsNil)
+
+	tf.ks.Mu.RLock()
+	c.Check(tf.ks.WaitForRevision(tf.ctx, resp.Header.Revision), gc.IsNil)
+
+	var tornDownChs = []<-chan struct{}{tf.resolver.tearingDown["shard-a"], tf.resolver.tearingDown["shard-b"]}
+	tf.ks.Mu.RUnlock()
+
+	for _, ch := range tornDownChs {
+		<-ch
+	}
+	expectDirs(c, localDir, "shard-a", "shard-b")
+}
+
+func (s *ResolverSuite) TestStandbyResolution(c *gc.C) {
+	var tf, cleanup = newTestFixture(c)
+	defer cleanup()
+
+	var shardID ShardID = "a-shard"
+	tf.allocateShard(c, makeShard(shardID), remoteID, localID)
+	expectStatusCode(c, tf.state, ReplicaStatus_TAILING)
+
+	// Play the part of the remote primary, by recording a JSONFileStore
+	// into the shard recovery log.
+	var dir, err = ioutil.TempDir("", "resolver-suite")
+	c.Assert(err, gc.IsNil)
+	defer os.RemoveAll(dir)
+
+	fsm, err := recoverylog.NewFSM(recoverylog.FSMHints{Log: aRecoveryLog})
+	c.Assert(err, gc.IsNil)
+	author, err := recoverylog.NewRandomAuthorID()
+	c.Assert(err, gc.IsNil)
+
+	var ajc = client.NewAppendService(tf.ctx,
+		pb.NewRoutedJournalClient(tf.broker.Client(), pb.NoopDispatchRouter{}))
+	var rec = recoverylog.NewRecorder(fsm, author, dir, ajc)
+
+	var state = map[string]string{"foo": "bar"}
+	primary, err := NewJSONFileStore(rec, dir, &state)
+	c.Assert(err, gc.IsNil)
+	c.Check(primary.Flush(map[pb.Journal]int64{sourceA: 123}), gc.IsNil)
+	<-rec.StrongBarrier().Done() // Views reflect only committed log writes.
+
+	var resolveStandby = func(maxStaleness time.Duration) Resolution {
+		var r, err = tf.resolver.Resolve(ResolveArgs{
+			Context:             tf.ctx,
+			ShardID:             shardID,
+			MayResolveStandby:   true,
+			MaxStandbyStaleness: maxStaleness,
+		})
+		c.Assert(err, gc.IsNil)
+		return r
+	}
+
+	// Case: Expect we resolve to our local standby, and its view reflects
+	// Store state recorded by the primary.
+	var r1 = resolveStandby(0)
+	var rShard, rStore, rDone = r1.Shard, r1.Store, r1.Done
+	r1.Shard, r1.Store, r1.Done = nil, nil, nil
+
+	c.Check(r1, gc.DeepEquals, Resolution{
+		Status: Status_OK,
+		Header: pb.Header{
+			ProcessId: localID,
+			Route: pb.Route{
+				Members:   []pb.ProcessSpec_ID{localID, remoteID},
+				Primary:   1,
+				Endpoints: []pb.Endpoint{"http://local/endpoint", "http://remote/endpoint"},
+			},
+			Etcd: r1.Header.Etcd,
+		},
+		Spec:    makeShard(shardID),
+		Standby: true,
+	})
+	c.Check(rShard.Spec(), gc.DeepEquals, makeShard(shardID))
+	c.Check(rStore.(*JSONFileStore).State, gc.DeepEquals, map[string]string{"foo": "bar"})
+	rDone()
+
+	var offsets, _ = rStore.FetchJournalOffsets()
+	c.Check(offsets, gc.DeepEquals, map[pb.Journal]int64{sourceA: 123})
+
+	// Primary records a further update.
+	primary.State.(map[string]string)["foo"] = "baz"
+	c.Check(primary.Flush(nil), gc.IsNil)
+	<-rec.StrongBarrier().Done()
+
+	// Case: A view which is fresh enough is re-used.
+	var r2 = resolveStandby(time.Hour)
+	c.Check(r2.Store, gc.Equals, rStore)
+	c.Check(r2.Store.(*JSONFileStore).State, gc.DeepEquals, map[string]string{"foo": "bar"})
+	r2.Done()
+
+	// Case: Otherwise, a new and current view is opened.
+	var r3 = resolveStandby(0)
+	c.Check(r3.Store, gc.Not(gc.Equals), rStore)
+	c.Check(r3.Store.(*JSONFileStore).State, gc.DeepEquals, map[string]string{"foo": "baz"})
+	r3.Done()
+
+	// Expect the prior view was destroyed, having been released by all references.
+	for {
+		if _, err = os.Stat(rStore.(*JSONFileStore).dir); os.IsNotExist(err) {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// Case: Standby resolution is not permitted.
+	var r4, _ = tf.resolver.Resolve(ResolveArgs{Context: tf.ctx, ShardID: shardID})
+	c.Check(r4.Status, gc.Equals, Status_NOT_SHARD_PRIMARY)
+
+	// Case: The standby is cancelled. Expect a view cannot be acquired.
+	rShard.(*Replica).cancel()
+	_, err = rShard.(*Replica).acquireView(tf.ctx, 0)
+	c.Check(err, gc.Equals, context.Canceled)
+
+	<-rec.WeakBarrier().Done()
+	tf.allocateShard(c, makeShard(shardID)) // Cleanup.
+}
+
+func expectDirs(c *gc.C, dir string, expect ...string) {
+	var entries, err = ioutil.ReadDir(dir)
+	c.Assert(err, gc.IsNil)
+
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	c.Check(names, gc.DeepEquals, expect)
+}
+
 var _ = gc.Suite(&ResolverSuite{})
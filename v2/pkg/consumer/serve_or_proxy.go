@@ -0,0 +1,52 @@
+package consumer
+
+import (
+	"context"
+
+	"github.com/LiveRamp/gazette/v2/pkg/auth"
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+)
+
+// ServeOrProxy resolves the ShardID of an application-defined RPC request, and
+// either serves the request locally or proxies it to the shard's primary peer.
+// It's intended for custom gRPC services of consumer applications which
+// require access to a shard Store, and which are routed on ShardID.
+//
+// The request may be proxied only if it hasn't been already: MayProxy of |args|
+// is ignored, and is instead set iff args.ProxyHeader is nil. Requests which
+// have been proxied must carry the Header with which |proxy| was invoked, as
+// args.ProxyHeader. Like broker proxying, this ensures the peer synchronizes
+// to at least the Etcd revision of the Header, and that the request reached its
+// intended recipient, before resolving the request itself.
+//
+// If the shard resolves to the local process, ServeOrProxy blocks until the
+// shard Store is ready, invokes |serve| with the Resolution, and releases the
+// Resolution on return. Else if the shard resolves to a peer, |proxy| is
+// invoked with a Context which dispatches to the resolved peer (suitable for
+// use with Service.Loopback) and forwards the caller's credentials, and with
+// the resolved Header which must be attached to the proxied request.
+//
+// The Status and Header of the resolution are returned. If the Status is not
+// OK, neither |serve| nor |proxy| is invoked, and the caller should generally
+// return the Status to its client.
+func (svc *Service) ServeOrProxy(args ResolveArgs,
+	serve func(Resolution) error,
+	proxy func(ctx context.Context, hdr pb.Header) error,
+) (Status, pb.Header, error) {
+
+	args.MayProxy = args.ProxyHeader == nil
+
+	var res, err = svc.Resolver.Resolve(args)
+	if err != nil {
+		return res.Status, res.Header, err
+	} else if res.Status != Status_OK {
+		return res.Status, res.Header, nil
+	} else if res.Store == nil {
+		var ctx = pb.WithDispatchRoute(auth.ForwardCredentials(args.Context),
+			res.Header.Route, res.Header.ProcessId)
+		return res.Status, res.Header, proxy(ctx, res.Header)
+	}
+
+	defer res.Done()
+	return res.Status, res.Header, serve(res)
+}
@@ -0,0 +1,94 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	gc "github.com/go-check/check"
+)
+
+type ServeOrProxySuite struct{}
+
+func (s *ServeOrProxySuite) TestServeAndProxyCases(c *gc.C) {
+	var tf, cleanup = newTestFixture(c)
+	defer cleanup()
+
+	var shardID ShardID = "a-shard"
+	var served, proxied []pb.Header
+
+	var serveOrProxy = func(args ResolveArgs, serveErr error) (Status, pb.Header, error) {
+		return tf.service.ServeOrProxy(args, func(res Resolution) error {
+			c.Check(res.Store, gc.NotNil)
+			c.Check(res.Shard.Spec().Id, gc.Equals, shardID)
+			served = append(served, res.Header)
+			return serveErr
+		}, func(ctx context.Context, hdr pb.Header) error {
+			proxied = append(proxied, hdr)
+			return nil
+		})
+	}
+
+	// Case: Shard does not exist. Neither |serve| nor |proxy| is invoked.
+	var status, hdr, err = serveOrProxy(ResolveArgs{Context: tf.ctx, ShardID: shardID}, nil)
+	c.Check(err, gc.IsNil)
+	c.Check(status, gc.Equals, Status_SHARD_NOT_FOUND)
+	c.Check(served, gc.HasLen, 0)
+	c.Check(proxied, gc.HasLen, 0)
+
+	// Case: Shard has a remote primary. Expect the request is proxied with the
+	// resolved Header, even if MayProxy is not set.
+	tf.allocateShard(c, makeShard(shardID), remoteID)
+
+	status, hdr, err = serveOrProxy(ResolveArgs{Context: tf.ctx, ShardID: shardID}, nil)
+	c.Check(err, gc.IsNil)
+	c.Check(status, gc.Equals, Status_OK)
+	c.Check(hdr.ProcessId, gc.Equals, remoteID)
+	c.Check(proxied, gc.DeepEquals, []pb.Header{hdr})
+	c.Check(served, gc.HasLen, 0)
+
+	// Case: A request which was already proxied is not proxied again.
+	status, hdr, err = serveOrProxy(ResolveArgs{
+		Context:  tf.ctx,
+		ShardID:  shardID,
+		MayProxy: true,
+		ProxyHeader: &pb.Header{
+			ProcessId: localID,
+			Etcd:      hdr.Etcd,
+		},
+	}, nil)
+	c.Check(err, gc.IsNil)
+	c.Check(status, gc.Equals, Status_NOT_SHARD_PRIMARY)
+	c.Check(hdr.ProcessId, gc.Equals, localID)
+	c.Check(proxied, gc.HasLen, 1)
+
+	// Case: Shard is local. Expect the request is served, and that an error
+	// returned by |serve| is passed through.
+	tf.allocateShard(c, makeShard(shardID), localID)
+
+	status, hdr, err = serveOrProxy(ResolveArgs{Context: tf.ctx, ShardID: shardID}, nil)
+	c.Check(err, gc.IsNil)
+	c.Check(status, gc.Equals, Status_OK)
+	c.Check(hdr.ProcessId, gc.Equals, localID)
+	c.Check(served, gc.DeepEquals, []pb.Header{hdr})
+
+	_, _, err = serveOrProxy(ResolveArgs{Context: tf.ctx, ShardID: shardID}, errors.New("serve error"))
+	c.Check(err, gc.ErrorMatches, "serve error")
+	c.Check(served, gc.HasLen, 2)
+	c.Check(proxied, gc.HasLen, 1)
+
+	// Case: Resolution errors are returned.
+	_, _, err = serveOrProxy(ResolveArgs{
+		Context: tf.ctx,
+		ShardID: shardID,
+		ProxyHeader: &pb.Header{
+			ProcessId: pb.ProcessSpec_ID{Zone: "wrong", Suffix: "ID"},
+			Etcd:      hdr.Etcd,
+		},
+	}, nil)
+	c.Check(err, gc.ErrorMatches, `proxied request ProcessId doesn't match our own .*`)
+
+	tf.allocateShard(c, makeShard(shardID)) // Cleanup.
+}
+
+var _ = gc.Suite(&ServeOrProxySuite{})
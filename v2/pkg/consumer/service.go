@@ -2,9 +2,12 @@ package consumer
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/LiveRamp/gazette/v2/pkg/allocator"
+	"github.com/LiveRamp/gazette/v2/pkg/auth"
 	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	"github.com/LiveRamp/gazette/v2/pkg/tracing"
 	"github.com/coreos/etcd/clientv3"
 	"golang.org/x/net/trace"
 	"google.golang.org/grpc"
@@ -23,18 +26,42 @@ type Service struct {
 	Loopback *grpc.ClientConn
 	// Journal client for use by consumer applications.
 	Journals pb.RoutedJournalClient
+	// Authenticator of RPC callers. If nil, callers are not authenticated
+	// and all List and Apply requests are allowed.
+	Authenticator auth.Authenticator
+	// LocalDir, if set, is a directory under which shard recovery logs are
+	// played back (into a stable sub-directory of each shard). A standby
+	// Replica which is torn down while tailing its recovery log retains its
+	// local state, and a later Replica of the shard on this host resumes from
+	// it rather than playing back the log from scratch. Directories of shards
+	// which are no longer assigned to this process are removed, unless the
+	// process is exiting. If empty, temporary directories are used, and are
+	// always removed.
+	LocalDir string
+	// PersistLargeHints enables the persistence of large FSMHints to a fragment
+	// store of the recovery log, with only a pointer to them stored in HintKeys.
+	// Pointers may be read only by processes which understand them (see
+	// DecodeHints), so it should be set only once all consumer processes of the
+	// application (and any other readers of HintKeys) have been updated.
+	PersistLargeHints bool
 
 	etcd clientv3.KV
 }
 
 // NewService constructs a new Service of the Application, driven by allocator.State.
 func NewService(app Application, state *allocator.State, rjc pb.RoutedJournalClient, lo *grpc.ClientConn, etcd *clientv3.Client) *Service {
-	return &Service{
-		Resolver: NewResolver(state, func() *Replica { return NewReplica(app, state.KS, etcd, rjc) }),
+	var svc = &Service{
 		Loopback: lo,
 		Journals: rjc,
 		etcd:     etcd,
 	}
+	svc.Resolver = NewResolver(state, func() *Replica {
+		var r = NewReplica(app, state.KS, etcd, rjc)
+		r.localDir = svc.LocalDir
+		r.hints.persistLarge = svc.PersistLargeHints
+		return r
+	})
+	return svc
 }
 
 // Specs returns the current collection of ShardSpecs.
@@ -48,8 +75,35 @@ func (svc *Service) Specs() (out []*ShardSpec) {
 	return
 }
 
+// Ready returns nil if every shard assigned to the consumer has completed
+// playback of its recovery log, or an error otherwise. Ready is intended for
+// use as a readiness check of the consumer process, which fails while shards
+// are back-filling (for example, after a rolling restart).
+func (svc *Service) Ready() error {
+	var state = svc.Resolver.state
+	defer state.KS.Mu.RUnlock()
+	state.KS.Mu.RLock()
+
+	var pending int
+	var example string
+
+	for _, li := range state.LocalItems {
+		var assignment = li.Assignments[li.Index].Decoded.(allocator.Assignment)
+
+		switch assignment.AssignmentValue.(*ReplicaStatus).Code {
+		case ReplicaStatus_IDLE, ReplicaStatus_BACKFILL:
+			pending, example = pending+1, assignment.ItemID
+		}
+	}
+	if pending != 0 {
+		return fmt.Errorf("%d shards are recovering (eg, %s)", pending, example)
+	}
+	return nil
+}
+
 func addTrace(ctx context.Context, format string, args ...interface{}) {
 	if tr, ok := trace.FromContext(ctx); ok {
 		tr.LazyPrintf(format, args...)
 	}
+	tracing.FromContext(ctx).AddEvent(format, args...)
 }
@@ -0,0 +1,53 @@
+package consumer
+
+import (
+	"github.com/LiveRamp/gazette/v2/pkg/allocator"
+	epb "github.com/coreos/etcd/etcdserver/etcdserverpb"
+	"github.com/coreos/etcd/mvcc/mvccpb"
+	gc "github.com/go-check/check"
+)
+
+type ServiceSuite struct{}
+
+func (s *ServiceSuite) TestReadiness(c *gc.C) {
+	var ks = NewKeySpace("/consumertest")
+	var state = allocator.NewObservedState(ks, allocator.MemberKey(ks, localID.Zone, localID.Suffix))
+	var svc = &Service{Resolver: &Resolver{state: state}}
+
+	// loadStatuses loads a KeySpace fixture in which shard-a is assigned to
+	// the local consumer (as primary), and shard-b to the local consumer (as
+	// standby) and the remote consumer (as primary), having respective |codes|.
+	var loadStatuses = func(rev int64, codes ...ReplicaStatus_Code) {
+		var kvs = []*mvccpb.KeyValue{
+			{Key: []byte(allocator.MemberKey(ks, localID.Zone, localID.Suffix)), Value: []byte(makeConsumer(localID).MarshalString())},
+			{Key: []byte(allocator.MemberKey(ks, remoteID.Zone, remoteID.Suffix)), Value: []byte(makeConsumer(remoteID).MarshalString())},
+			{Key: []byte(allocator.ItemKey(ks, "shard-a")), Value: []byte(makeShard("shard-a").MarshalString())},
+			{Key: []byte(allocator.ItemKey(ks, "shard-b")), Value: []byte(makeShard("shard-b").MarshalString())},
+		}
+		for i, asn := range []allocator.Assignment{
+			{ItemID: "shard-a", MemberZone: localID.Zone, MemberSuffix: localID.Suffix, Slot: 0},
+			{ItemID: "shard-b", MemberZone: localID.Zone, MemberSuffix: localID.Suffix, Slot: 1},
+			{ItemID: "shard-b", MemberZone: remoteID.Zone, MemberSuffix: remoteID.Suffix, Slot: 0},
+		} {
+			kvs = append(kvs, &mvccpb.KeyValue{
+				Key:   []byte(allocator.AssignmentKey(ks, asn)),
+				Value: []byte((&ReplicaStatus{Code: codes[i]}).MarshalString()),
+			})
+		}
+		c.Assert(ks.LoadSnapshot(epb.ResponseHeader{Revision: rev}, kvs), gc.IsNil)
+	}
+
+	// Expect the consumer isn't ready while local replicas are IDLE or in BACKFILL.
+	loadStatuses(1, ReplicaStatus_IDLE, ReplicaStatus_BACKFILL, ReplicaStatus_PRIMARY)
+	c.Check(svc.Ready(), gc.ErrorMatches, `2 shards are recovering \(eg, shard-[ab]\)`)
+
+	loadStatuses(2, ReplicaStatus_PRIMARY, ReplicaStatus_BACKFILL, ReplicaStatus_PRIMARY)
+	c.Check(svc.Ready(), gc.ErrorMatches, `1 shards are recovering \(eg, shard-b\)`)
+
+	// Once the primary and standby have recovered, the consumer is ready.
+	// The status of the remote replica is not considered.
+	loadStatuses(3, ReplicaStatus_PRIMARY, ReplicaStatus_TAILING, ReplicaStatus_BACKFILL)
+	c.Check(svc.Ready(), gc.IsNil)
+}
+
+var _ = gc.Suite(&ServiceSuite{})
@@ -54,6 +54,16 @@ func (m *ShardSpec) Validate() error {
 		return pb.ExtendContext(err, "LabelSet")
 	} else if len(m.LabelSet.ValuesOf("id")) != 0 {
 		return pb.NewValidationError(`Labels cannot include label "id"`)
+	} else if _, err = pb.ParseWeightLabel(m.LabelSet); err != nil {
+		return err
+	} else if err = m.ConsumerSelector.Validate(); err != nil {
+		return pb.ExtendContext(err, "ConsumerSelector")
+	} else if m.CheckpointInterval < 0 {
+		return pb.NewValidationError("invalid CheckpointInterval (%d; expected >= 0)", m.CheckpointInterval)
+	} else if m.RestartBackoff < 0 {
+		return pb.NewValidationError("invalid RestartBackoff (%d; expected >= 0)", m.RestartBackoff)
+	} else if m.RebaseLogBytes < 0 {
+		return pb.NewValidationError("invalid RebaseLogBytes (%d; expected >= 0)", m.RebaseLogBytes)
 	}
 
 	for i := range m.Sources {
@@ -70,7 +80,7 @@ func (m *ShardSpec) Validate() error {
 		}
 	}
 
-	// Disable and HotStandbys require no extra validation.
+	// Disable, HotStandbys, and MaxRestarts require no extra validation.
 
 	return nil
 }
@@ -102,6 +112,23 @@ func (m *ShardSpec) DesiredReplication() int {
 	return 1 + int(m.HotStandbys)
 }
 
+// ItemWeight is the weight of the ShardSpec "weight" label, or one if the
+// label isn't set. allocator.WeightedItem implementation.
+func (m *ShardSpec) ItemWeight() int {
+	var w, _ = pb.ParseWeightLabel(m.LabelSet) // Validated by ShardSpec.Validate.
+	return w
+}
+
+// MemberSelector is the canonical string of the ShardSpec ConsumerSelector.
+// allocator.SelectiveItem implementation.
+func (m *ShardSpec) MemberSelector() string { return m.ConsumerSelector.String() }
+
+// SelectsMember returns whether the ConsumerSelector matches the Labels of
+// the ConsumerSpec |member|. allocator.SelectiveItem implementation.
+func (m *ShardSpec) SelectsMember(member allocator.MemberValue) bool {
+	return m.ConsumerSelector.Matches(member.(*ConsumerSpec).Labels)
+}
+
 // IsConsistent is whether the shard assignment is consistent. allocator.ItemValue implementation.
 func (m *ShardSpec) IsConsistent(assignment keyspace.KeyValue, _ keyspace.KeyValues) bool {
 	switch assignment.Decoded.(allocator.Assignment).AssignmentValue.(*ReplicaStatus).Code {
@@ -136,12 +163,21 @@ func (m *ConsumerSpec) MarshalString() string {
 	return string(d)
 }
 
-// ZeroLimit zeros the ConsumerSpec ShardLimit.
-func (m *ConsumerSpec) ZeroLimit() { m.ShardLimit = 0 }
+// ZeroLimit zeros the ConsumerSpec ShardLimit and ShardWeightLimit.
+func (m *ConsumerSpec) ZeroLimit() { m.ShardLimit, m.ShardWeightLimit = 0, 0 }
 
 // ItemLimit is the maximum number of shards this consumer may process. allocator.MemberValue implementation.
 func (m *ConsumerSpec) ItemLimit() int { return int(m.ShardLimit) }
 
+// ItemWeightLimit is the ShardWeightLimit of the ConsumerSpec, or its
+// ShardLimit if ShardWeightLimit is zero. allocator.WeightedMember implementation.
+func (m *ConsumerSpec) ItemWeightLimit() int {
+	if m.ShardWeightLimit == 0 {
+		return int(m.ShardLimit)
+	}
+	return int(m.ShardWeightLimit)
+}
+
 // Reduce folds another ReplicaStatus into this one.
 func (m *ReplicaStatus) Reduce(other *ReplicaStatus) {
 	if other.Code > m.Code {
@@ -150,6 +186,9 @@ func (m *ReplicaStatus) Reduce(other *ReplicaStatus) {
 	for _, e := range other.Errors {
 		m.Errors = append(m.Errors, e)
 	}
+	if other.Restarts > m.Restarts {
+		m.Restarts = other.Restarts
+	}
 }
 
 // Validate returns an error if the ReplicaStatus is not well-formed.
@@ -2,6 +2,7 @@ package consumer
 
 import (
 	"testing"
+	"time"
 
 	"github.com/LiveRamp/gazette/v2/pkg/allocator"
 	"github.com/LiveRamp/gazette/v2/pkg/keyspace"
@@ -57,6 +58,10 @@ func (s *SpecSuite) TestShardSpecValidationCases(c *gc.C) {
 	c.Check(spec.Validate(), gc.ErrorMatches, `invalid MaxTxnDuration \(0; expected > 0\)`)
 	spec.MaxTxnDuration = 1
 	c.Check(spec.Validate(), gc.ErrorMatches, `LabelSet.Labels\[0\].Name: not a valid token \(bad label\)`)
+	spec.Labels[0].Name = "id"
+	c.Check(spec.Validate(), gc.ErrorMatches, `Labels cannot include label "id"`)
+	spec.Labels[0].Name = "weight"
+	c.Check(spec.Validate(), gc.ErrorMatches, `Label "weight" contains an invalid value \(value; .*\)`)
 	spec.Labels[0].Name = "label"
 
 	c.Check(spec.Validate(), gc.ErrorMatches, `Sources\[0\].Journal: not a valid token \(journal 2\)`)
@@ -75,6 +80,22 @@ func (s *SpecSuite) TestShardSpecValidationCases(c *gc.C) {
 	c.Check(spec.Validate(), gc.ErrorMatches, `HintKeys\[0\] is not an absolute, clean, non-directory path \(/rooted//path\)`)
 	spec.HintKeys[0] = "/rooted/path"
 
+	spec.ConsumerSelector.Exclude = pb.LabelSet{Labels: []pb.Label{{Name: "bad label"}}}
+	c.Check(spec.Validate(), gc.ErrorMatches, `ConsumerSelector.Exclude.Labels\[0\].Name: not a valid token \(bad label\)`)
+	spec.ConsumerSelector.Exclude = pb.MustLabelSet("disk", "hdd")
+
+	spec.CheckpointInterval = -1
+	c.Check(spec.Validate(), gc.ErrorMatches, `invalid CheckpointInterval \(-1; expected >= 0\)`)
+	spec.CheckpointInterval = time.Hour
+
+	spec.RestartBackoff = -1
+	c.Check(spec.Validate(), gc.ErrorMatches, `invalid RestartBackoff \(-1; expected >= 0\)`)
+	spec.RestartBackoff = time.Second
+
+	spec.RebaseLogBytes = -1
+	c.Check(spec.Validate(), gc.ErrorMatches, `invalid RebaseLogBytes \(-1; expected >= 0\)`)
+	spec.RebaseLogBytes = 1 << 30
+
 	c.Check(spec.Validate(), gc.IsNil)
 }
 
@@ -90,6 +111,10 @@ func (s *SpecSuite) TestShardSpecRoutines(c *gc.C) {
 	spec.Disable, spec.HotStandbys = false, 0
 	c.Check(spec.DesiredReplication(), gc.Equals, 1)
 
+	c.Check(spec.ItemWeight(), gc.Equals, 1)
+	spec.LabelSet = pb.MustLabelSet("weight", "12")
+	c.Check(spec.ItemWeight(), gc.Equals, 12)
+
 	var status = new(ReplicaStatus)
 	var asn = keyspace.KeyValue{Decoded: allocator.Assignment{AssignmentValue: status}}
 
@@ -99,6 +124,16 @@ func (s *SpecSuite) TestShardSpecRoutines(c *gc.C) {
 
 	c.Check(ExtractShardSpecMetaLabels(&spec, pb.MustLabelSet("label", "buffer")),
 		gc.DeepEquals, pb.MustLabelSet("id", "shard-id"))
+
+	var consumer = &ConsumerSpec{ProcessSpec: pb.ProcessSpec{Labels: pb.MustLabelSet("disk", "hdd")}}
+	c.Check(spec.MemberSelector(), gc.Equals, "")
+
+	spec.ConsumerSelector = pb.MemberSelector{Include: pb.MustLabelSet("disk", "ssd")}
+	c.Check(spec.MemberSelector(), gc.Equals, "disk=ssd,")
+	c.Check(spec.SelectsMember(consumer), gc.Equals, false)
+	consumer.Labels = pb.MustLabelSet("disk", "ssd")
+	c.Check(spec.SelectsMember(consumer), gc.Equals, true)
+	c.Check(consumer.MemberLabels(), gc.Equals, "disk=ssd,")
 }
 
 func (s *SpecSuite) TestConsumerSpecValidationCases(c *gc.C) {
@@ -114,6 +149,9 @@ func (s *SpecSuite) TestConsumerSpecValidationCases(c *gc.C) {
 
 	c.Check(spec.Validate(), gc.IsNil)
 	c.Check(spec.ItemLimit(), gc.Equals, 5)
+	c.Check(spec.ItemWeightLimit(), gc.Equals, 5)
+	spec.ShardWeightLimit = 50
+	c.Check(spec.ItemWeightLimit(), gc.Equals, 50)
 }
 
 func (s *SpecSuite) TestReplicaStatusValidationCases(c *gc.C) {
@@ -145,6 +183,13 @@ func (s *SpecSuite) TestReplicaStatusReduction(c *gc.C) {
 	status.Reduce(&ReplicaStatus{Code: ReplicaStatus_FAILED, Errors: []string{"err-1"}})
 	status.Reduce(&ReplicaStatus{Code: ReplicaStatus_FAILED, Errors: []string{"err-2"}})
 	c.Check(status, gc.DeepEquals, &ReplicaStatus{Code: ReplicaStatus_FAILED, Errors: []string{"err-1", "err-2"}})
+
+	// Restarts is the maximum of reduced statuses.
+	status = &ReplicaStatus{Code: ReplicaStatus_BACKFILL}
+	status.Reduce(&ReplicaStatus{Code: ReplicaStatus_IDLE, Restarts: 3})
+	c.Check(status, gc.DeepEquals, &ReplicaStatus{Code: ReplicaStatus_BACKFILL, Restarts: 3})
+	status.Reduce(&ReplicaStatus{Code: ReplicaStatus_TAILING, Restarts: 2})
+	c.Check(status, gc.DeepEquals, &ReplicaStatus{Code: ReplicaStatus_TAILING, Restarts: 3})
 }
 
 func (s *SpecSuite) TestListRequestValidationCases(c *gc.C) {
@@ -96,6 +96,11 @@ func (s *JSONFileStore) Flush(offsets map[pb.Journal]int64) error {
 	return nil
 }
 
+// CaptureCheckpoint of the JSONFileStore.
+func (s *JSONFileStore) CaptureCheckpoint(store pb.FragmentStore) (*recoverylog.CapturedCheckpoint, error) {
+	return s.recorder.CaptureCheckpoint(store)
+}
+
 // Destroy the JSONFileStore directory and state file.
 func (s *JSONFileStore) Destroy() {
 	if err := os.RemoveAll(s.dir); err != nil {
@@ -0,0 +1,896 @@
+package consumer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	"github.com/LiveRamp/gazette/v2/pkg/recoverylog"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+)
+
+// KVStore is a Store of ordered keys and values which is implemented in pure
+// Go. It's an alternative to RocksDBStore for consumers built without cgo.
+//
+// KVStore is log-structured. Flush appends the Batch and journal offsets to a
+// write-ahead log as a single checksummed record, and applies them to an
+// in-memory table. Once the in-memory table reaches Options.MemTableSize, it's
+// written to an immutable and sorted table file, and a new write-ahead log is
+// begun. Once more than Options.MaxTables accumulate, the most recent table
+// files of similar size are compacted together (a size-tiered compaction), so
+// that each entry is re-written a number of times which is logarithmic in the
+// size of the store. A MANIFEST, which is replaced by rename, names the current
+// log and table files and is the commit point of each such change.
+//
+// All files are written through a recoverylog.RecordedAferoFS, and are only
+// ever created, appended to, renamed, or removed. KVStore is not safe for
+// concurrent use.
+type KVStore struct {
+	// Options of the KVStore, which may be modified prior to Open.
+	Options KVStoreOptions
+	// Batch of Puts and Deletes, which are atomically applied by the next Flush.
+	Batch KVBatch
+
+	// Cache is a convenient mechanism for consumers to associate shard-specific,
+	// in-memory state with a KVStore (see RocksDBStore.Cache). It's not directly
+	// used by KVStore.
+	Cache interface{}
+
+	rec *recoverylog.Recorder
+	dir string
+	fs  afero.Fs
+
+	manifest kvManifest           // Current MANIFEST.
+	offsets  map[pb.Journal]int64 // Offsets of all applied log records.
+	wal      afero.File           // Current write-ahead log.
+	mem      map[string]kvValue   // Entries applied since the last table was written.
+	memSize  int                  // Approximate size of |mem| entries.
+	tables   []*kvTable           // Current tables, ordered newest to oldest.
+}
+
+// KVStoreOptions are options of a KVStore.
+type KVStoreOptions struct {
+	// Approximate size of entries held by the in-memory table before they're
+	// written to a table file. The write-ahead log is of similar size.
+	MemTableSize int
+	// Number of table files which may accumulate before the most recent
+	// tables of similar size are compacted into a single table.
+	MaxTables int
+	// Approximate size of table blocks, which are the unit of table reads.
+	BlockSize int
+}
+
+// NewKVStore builds a KVStore which is prepared to open its database, but
+// has not yet done so. The caller may wish to further tweak Options, and
+// should then call Open to open the database.
+func NewKVStore(rec *recoverylog.Recorder, dir string) *KVStore {
+	return &KVStore{
+		Options: KVStoreOptions{
+			MemTableSize: 1 << 22, // 4MB.
+			MaxTables:    8,
+			BlockSize:    1 << 12, // 4KB.
+		},
+		rec: rec,
+		dir: dir,
+		fs:  recoverylog.RecordedAferoFS{Recorder: rec, Fs: afero.NewOsFs()},
+	}
+}
+
+// Open the KVStore, initializing it if its directory is empty. Open recovers
+// entries of the current write-ahead log, which are written to a new table
+// (as recorded files may not be re-opened for append).
+func (s *KVStore) Open() error {
+	if err := s.fs.MkdirAll(s.dir, 0700); err != nil {
+		return extendErr(err, "creating store directory")
+	}
+	// MANIFEST.next exists only if we failed while writing it.
+	if _, err := s.fs.Stat(s.path(kvManifestNextName)); err == nil {
+		if err = s.fs.Remove(s.path(kvManifestNextName)); err != nil {
+			return extendErr(err, "removing partial MANIFEST")
+		}
+	}
+
+	if f, err := s.fs.Open(s.path(kvManifestName)); os.IsNotExist(err) {
+		s.manifest = kvManifest{NextFile: 1}
+	} else if err != nil {
+		return extendErr(err, "opening MANIFEST")
+	} else if err = json.NewDecoder(f).Decode(&s.manifest); err != nil {
+		return extendErr(err, "decoding MANIFEST")
+	} else if err = f.Close(); err != nil {
+		return extendErr(err, "closing MANIFEST")
+	}
+
+	s.offsets = make(map[pb.Journal]int64, len(s.manifest.Offsets))
+	for journal, offset := range s.manifest.Offsets {
+		s.offsets[journal] = offset
+	}
+	s.mem = make(map[string]kvValue)
+
+	for _, name := range s.manifest.Tables {
+		var t, err = openKVTable(s.fs, s.path(name), name)
+		if err != nil {
+			return extendErr(err, "opening table %s", name)
+		}
+		s.tables = append(s.tables, t)
+	}
+	if s.manifest.Log != "" {
+		if err := s.replayLog(s.manifest.Log); err != nil {
+			return extendErr(err, "replaying log %s", s.manifest.Log)
+		}
+	}
+	if err := s.removeUnreferenced(); err != nil {
+		return err
+	}
+	return s.rotate()
+}
+
+// Recorder of the KVStore.
+func (s *KVStore) Recorder() *recoverylog.Recorder { return s.rec }
+
+// Get returns the value of |key|, and whether it was found. Writes of the
+// Batch which have not yet been Flushed are not reflected.
+func (s *KVStore) Get(key []byte) ([]byte, bool, error) {
+	if v, ok := s.mem[string(key)]; ok {
+		return v.value, !v.deleted, nil
+	}
+	for _, t := range s.tables {
+		if e, ok, err := t.get(key); err != nil {
+			return nil, false, extendErr(err, "reading table %s", t.name)
+		} else if ok {
+			return e.value, !e.deleted, nil
+		}
+	}
+	return nil, false, nil
+}
+
+// NewIterator returns a KVIterator over the keys and values of the KVStore.
+// The KVIterator must not be used after a subsequent Flush.
+func (s *KVStore) NewIterator() *KVIterator {
+	var sources = []kvSource{newKVMemSource(s.mem)}
+	for _, t := range s.tables {
+		sources = append(sources, &kvTableSource{table: t})
+	}
+	return &KVIterator{merged: kvMergedSource{sources: sources}}
+}
+
+// FetchJournalOffsets returns a map of Journals and offsets captured by the KVStore.
+func (s *KVStore) FetchJournalOffsets() (map[pb.Journal]int64, error) {
+	var offsets = make(map[pb.Journal]int64, len(s.offsets))
+	for journal, offset := range s.offsets {
+		offsets[journal] = offset
+	}
+	return offsets, nil
+}
+
+// Flush |offsets| and the Batch, as a single record of the write-ahead log.
+func (s *KVStore) Flush(offsets map[pb.Journal]int64) error {
+	var payload = s.Batch.rep
+	for journal, offset := range offsets {
+		var tmp [binary.MaxVarintLen64]byte
+		payload = appendKVEntry(payload, kvOffset, []byte(journal),
+			tmp[:binary.PutVarint(tmp[:], offset)])
+	}
+
+	if _, err := s.wal.Write(frameKVRecord(payload)); err != nil {
+		return extendErr(err, "writing log")
+	} else if err = s.apply(payload); err != nil {
+		return extendErr(err, "applying log record")
+	}
+	s.Batch.Clear()
+
+	if s.memSize >= s.Options.MemTableSize {
+		return s.rotate()
+	}
+	return nil
+}
+
+// CaptureCheckpoint of the KVStore. Tables and logs of the KVStore are
+// removed only by Flush (see Checkpointer).
+func (s *KVStore) CaptureCheckpoint(store pb.FragmentStore) (*recoverylog.CapturedCheckpoint, error) {
+	return s.rec.CaptureCheckpoint(store)
+}
+
+// Destroy the KVStore.
+func (s *KVStore) Destroy() {
+	if s.wal != nil {
+		s.wal.Close()
+	}
+	for _, t := range s.tables {
+		t.file.Close()
+	}
+	if err := os.RemoveAll(s.dir); err != nil {
+		log.WithFields(log.Fields{
+			"dir": s.dir,
+			"err": err,
+		}).Error("failed to remove KVStore directory")
+	}
+}
+
+// KVBatch is a batch of Puts and Deletes, to be applied to a KVStore.
+type KVBatch struct {
+	rep   []byte
+	count int
+}
+
+// Put |key| to |value|.
+func (b *KVBatch) Put(key, value []byte) {
+	b.rep = appendKVEntry(b.rep, kvPut, key, value)
+	b.count++
+}
+
+// Delete |key|.
+func (b *KVBatch) Delete(key []byte) {
+	b.rep = appendKVEntry(b.rep, kvDelete, key, nil)
+	b.count++
+}
+
+// Count returns the number of Puts and Deletes of the KVBatch.
+func (b *KVBatch) Count() int { return b.count }
+
+// Clear the KVBatch.
+func (b *KVBatch) Clear() { b.rep, b.count = b.rep[:0], 0 }
+
+// KVIterator iterates over keys and values of a KVStore, in ascending key order.
+type KVIterator struct {
+	merged kvMergedSource
+	err    error
+}
+
+// Seek to the first key which is equal to or greater than |key|.
+func (it *KVIterator) Seek(key []byte) {
+	if it.err = it.merged.seek(key); it.err == nil {
+		it.skipDeleted()
+	}
+}
+
+// SeekToFirst seeks to the first key of the KVStore.
+func (it *KVIterator) SeekToFirst() { it.Seek(nil) }
+
+// Valid returns true if the KVIterator is positioned at a key.
+func (it *KVIterator) Valid() bool { return it.err == nil && it.merged.valid() }
+
+// Next advances to the next key.
+func (it *KVIterator) Next() {
+	if it.err = it.merged.next(); it.err == nil {
+		it.skipDeleted()
+	}
+}
+
+// Key of the current position.
+func (it *KVIterator) Key() []byte { return it.merged.entry().key }
+
+// Value of the current position.
+func (it *KVIterator) Value() []byte { return it.merged.entry().value }
+
+// Err returns an error encountered during iteration.
+func (it *KVIterator) Err() error { return it.err }
+
+func (it *KVIterator) skipDeleted() {
+	for it.err == nil && it.merged.valid() && it.merged.entry().deleted {
+		it.err = it.merged.next()
+	}
+}
+
+// kvManifest names the current write-ahead log and tables of the KVStore,
+// and journal offsets reflected by the tables.
+type kvManifest struct {
+	NextFile int64                `json:"nextFile"`
+	Log      string               `json:"log"`
+	Tables   []string             `json:"tables"`
+	Offsets  map[pb.Journal]int64 `json:"offsets"`
+}
+
+// allocate returns a new and unique file name having extension |ext|.
+func (m *kvManifest) allocate(ext string) string {
+	m.NextFile++
+	return fmt.Sprintf("%06d%s", m.NextFile-1, ext)
+}
+
+func (s *KVStore) path(name string) string { return filepath.Join(s.dir, name) }
+
+// apply an encoded log record |payload| to the in-memory table and offsets.
+func (s *KVStore) apply(payload []byte) error {
+	for len(payload) != 0 {
+		var kind, key, value, rem, err = decodeKVEntry(payload)
+		if err != nil {
+			return err
+		}
+		payload = rem
+
+		switch kind {
+		case kvPut:
+			s.mem[string(key)] = kvValue{value: append([]byte(nil), value...)}
+		case kvDelete:
+			s.mem[string(key)] = kvValue{deleted: true}
+		case kvOffset:
+			var offset, n = binary.Varint(value)
+			if n <= 0 {
+				return fmt.Errorf("invalid offset encoding (%x)", value)
+			}
+			s.offsets[pb.Journal(key)] = offset
+			continue
+		}
+		s.memSize += len(key) + len(value) + kvEntryOverhead
+	}
+	return nil
+}
+
+// replayLog applies each complete record of the write-ahead log |name|.
+func (s *KVStore) replayLog(name string) error {
+	var b, err = afero.ReadFile(s.fs, s.path(name))
+	if err != nil {
+		return err
+	}
+	for len(b) != 0 {
+		var payload, rem, ok, err = decodeKVRecord(b)
+		if err != nil {
+			return err
+		} else if !ok {
+			// A record may be only partially written if we failed while writing it.
+			log.WithFields(log.Fields{"log": name, "length": len(b)}).
+				Warn("ignoring partial write-ahead log record")
+			break
+		} else if err = s.apply(payload); err != nil {
+			return err
+		}
+		b = rem
+	}
+	return nil
+}
+
+// removeUnreferenced removes log and table files which aren't referenced by
+// the MANIFEST. They remain if we failed while changing the set of current files.
+func (s *KVStore) removeUnreferenced() error {
+	var infos, err = afero.ReadDir(s.fs, s.dir)
+	if err != nil {
+		return extendErr(err, "reading store directory")
+	}
+	var live = map[string]bool{s.manifest.Log: true}
+	for _, name := range s.manifest.Tables {
+		live[name] = true
+	}
+	for _, info := range infos {
+		var name = info.Name()
+
+		if live[name] || !(strings.HasSuffix(name, kvLogExt) || strings.HasSuffix(name, kvTableExt)) {
+			continue
+		} else if err = s.fs.Remove(s.path(name)); err != nil {
+			return extendErr(err, "removing unreferenced file %s", name)
+		}
+	}
+	return nil
+}
+
+// rotate writes the in-memory table to a new table file, compacts tables if
+// there are too many, and begins a new write-ahead log. Changes are committed
+// by writing a new MANIFEST, after which files no longer referenced are removed.
+func (s *KVStore) rotate() error {
+	var next = kvManifest{
+		NextFile: s.manifest.NextFile,
+		Offsets:  make(map[pb.Journal]int64, len(s.offsets)),
+	}
+	for journal, offset := range s.offsets {
+		next.Offsets[journal] = offset
+	}
+	var tables = s.tables
+	var obsolete []*kvTable
+
+	if len(s.mem) != 0 {
+		// Deletions must be retained, as they may mask keys of older tables.
+		var t, err = s.writeTable(&next, newKVMemSource(s.mem), false)
+		if err != nil {
+			return extendErr(err, "writing table")
+		}
+		tables = append([]*kvTable{t}, tables...)
+	}
+	if len(tables) > s.Options.MaxTables {
+		// Compact a run of the most recent tables. Deletions may be dropped
+		// only if the run includes the oldest table.
+		var n = kvCompactionRun(tables)
+		var sources []kvSource
+		for _, t := range tables[:n] {
+			sources = append(sources, &kvTableSource{table: t})
+		}
+		var t, err = s.writeTable(&next, &kvMergedSource{sources: sources}, n == len(tables))
+		if err != nil {
+			return extendErr(err, "compacting tables")
+		}
+		obsolete, tables = tables[:n], append([]*kvTable{t}, tables[n:]...)
+	}
+
+	next.Log = next.allocate(kvLogExt)
+	var wal, err = s.fs.OpenFile(s.path(next.Log), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return extendErr(err, "creating log")
+	}
+	for _, t := range tables {
+		next.Tables = append(next.Tables, t.name)
+	}
+	if err = s.writeManifest(next); err != nil {
+		return extendErr(err, "writing MANIFEST")
+	}
+
+	// The change is committed. Remove the prior log and compacted tables.
+	var prevLog = s.manifest.Log
+	if s.wal != nil {
+		s.wal.Close()
+	}
+	s.manifest, s.wal, s.tables = next, wal, tables
+	s.mem, s.memSize = make(map[string]kvValue), 0
+
+	if prevLog != "" {
+		if err = s.fs.Remove(s.path(prevLog)); err != nil {
+			return extendErr(err, "removing log %s", prevLog)
+		}
+	}
+	for _, t := range obsolete {
+		t.file.Close()
+
+		if err = s.fs.Remove(s.path(t.name)); err != nil {
+			return extendErr(err, "removing table %s", t.name)
+		}
+	}
+	return nil
+}
+
+// kvCompactionRun returns the number of most recent |tables| to compact,
+// which is at least two. The run is extended to each older table which is no
+// larger than kvCompactionRatio times the total size of the run thus far, so
+// that tables of similar size are compacted together. A compacted table is
+// larger than each of its inputs by a constant factor, and an entry is thus
+// compacted a number of times which is logarithmic in the size of the store.
+func kvCompactionRun(tables []*kvTable) int {
+	var n, size = 1, tables[0].size
+	for n != len(tables) && (n < 2 || tables[n].size <= kvCompactionRatio*size) {
+		size += tables[n].size
+		n++
+	}
+	return n
+}
+
+// writeTable writes the entries of |src| to a new table file.
+func (s *KVStore) writeTable(m *kvManifest, src kvSource, dropDeleted bool) (*kvTable, error) {
+	var name = m.allocate(kvTableExt)
+
+	var f, err = s.fs.OpenFile(s.path(name), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return nil, err
+	}
+	var w = kvTableWriter{bw: bufio.NewWriterSize(f, 1<<16), blockSize: s.Options.BlockSize}
+
+	for err = src.seek(nil); err == nil && src.valid(); err = src.next() {
+		if e := src.entry(); !e.deleted || !dropDeleted {
+			err = w.add(e)
+		}
+		if err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = w.finish()
+	}
+	if err == nil {
+		err = f.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return openKVTable(s.fs, s.path(name), name)
+}
+
+func (s *KVStore) writeManifest(m kvManifest) error {
+	var f, err = s.fs.OpenFile(s.path(kvManifestNextName), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	} else if err = json.NewEncoder(f).Encode(m); err != nil {
+		return err
+	} else if err = f.Close(); err != nil {
+		return err
+	}
+	return s.fs.Rename(s.path(kvManifestNextName), s.path(kvManifestName))
+}
+
+// kvValue is a value of the in-memory table.
+type kvValue struct {
+	value   []byte
+	deleted bool
+}
+
+// kvEntry is a key and its value (or deletion).
+type kvEntry struct {
+	key, value []byte
+	deleted    bool
+}
+
+// kvTable is an immutable table file of sorted entries. Entries are grouped
+// into blocks, and the table is terminated by an index of its blocks and a
+// fixed-length footer:
+//
+//	[block]... [index] [index offset: uint64] [index length: uint32] [index CRC: uint32]
+//
+// Each index entry holds the last key, offset, length, and CRC of a block.
+type kvTable struct {
+	name  string
+	file  afero.File
+	size  int64
+	index []kvBlockHandle
+}
+
+type kvBlockHandle struct {
+	lastKey        []byte
+	offset, length int64
+	sum            uint32
+}
+
+func openKVTable(fs afero.Fs, path, name string) (*kvTable, error) {
+	var f, err = fs.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	var t = &kvTable{name: name, file: f}
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	} else if info.Size() < kvFooterLength {
+		return nil, fmt.Errorf("table is too short (%d bytes)", info.Size())
+	}
+	t.size = info.Size()
+
+	var footer [kvFooterLength]byte
+	if _, err = f.ReadAt(footer[:], info.Size()-kvFooterLength); err != nil {
+		return nil, err
+	}
+	var index = make([]byte, binary.LittleEndian.Uint32(footer[8:12]))
+	if _, err = f.ReadAt(index, int64(binary.LittleEndian.Uint64(footer[0:8]))); err != nil {
+		return nil, err
+	} else if sum := crc32.Checksum(index, kvCRCTable); sum != binary.LittleEndian.Uint32(footer[12:16]) {
+		return nil, fmt.Errorf("index checksum mismatch")
+	}
+
+	for len(index) != 0 {
+		var h kvBlockHandle
+		var n int
+		var l, offset, length uint64
+
+		if l, n = binary.Uvarint(index); n <= 0 || uint64(len(index)-n) < l {
+			return nil, fmt.Errorf("invalid index encoding")
+		}
+		h.lastKey, index = index[n:n+int(l)], index[n+int(l):]
+
+		if offset, n = binary.Uvarint(index); n <= 0 {
+			return nil, fmt.Errorf("invalid index encoding")
+		}
+		index = index[n:]
+
+		if length, n = binary.Uvarint(index); n <= 0 || len(index)-n < 4 {
+			return nil, fmt.Errorf("invalid index encoding")
+		}
+		h.offset, h.length = int64(offset), int64(length)
+		h.sum, index = binary.LittleEndian.Uint32(index[n:]), index[n+4:]
+
+		t.index = append(t.index, h)
+	}
+	return t, nil
+}
+
+// get returns the entry of |key|, and whether it was found.
+func (t *kvTable) get(key []byte) (kvEntry, bool, error) {
+	var ind = t.seekBlock(key)
+	if ind == len(t.index) {
+		return kvEntry{}, false, nil
+	}
+	var entries, err = t.readBlock(ind)
+	if err != nil {
+		return kvEntry{}, false, err
+	}
+	var i = sort.Search(len(entries), func(i int) bool { return bytes.Compare(entries[i].key, key) >= 0 })
+	if i != len(entries) && bytes.Equal(entries[i].key, key) {
+		return entries[i], true, nil
+	}
+	return kvEntry{}, false, nil
+}
+
+// seekBlock returns the index of the first block which may contain |key|.
+func (t *kvTable) seekBlock(key []byte) int {
+	return sort.Search(len(t.index), func(i int) bool { return bytes.Compare(t.index[i].lastKey, key) >= 0 })
+}
+
+// readBlock reads and decodes the entries of the block at |ind|.
+func (t *kvTable) readBlock(ind int) ([]kvEntry, error) {
+	var h = t.index[ind]
+	var b = make([]byte, h.length)
+
+	if _, err := t.file.ReadAt(b, h.offset); err != nil {
+		return nil, err
+	} else if crc32.Checksum(b, kvCRCTable) != h.sum {
+		return nil, fmt.Errorf("block checksum mismatch (offset %d)", h.offset)
+	}
+
+	var entries []kvEntry
+	for len(b) != 0 {
+		var kind, key, value, rem, err = decodeKVEntry(b)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, kvEntry{key: key, value: value, deleted: kind == kvDelete})
+		b = rem
+	}
+	return entries, nil
+}
+
+// kvTableWriter writes sorted entries as a table file.
+type kvTableWriter struct {
+	bw        *bufio.Writer
+	blockSize int
+	block     []byte // Encoding of the current block.
+	offset    int64  // File offset of the current block.
+	index     []byte // Encoding of the block index.
+	lastKey   []byte
+}
+
+func (w *kvTableWriter) add(e kvEntry) error {
+	if e.deleted {
+		w.block = appendKVEntry(w.block, kvDelete, e.key, nil)
+	} else {
+		w.block = appendKVEntry(w.block, kvPut, e.key, e.value)
+	}
+	w.lastKey = e.key
+
+	if len(w.block) >= w.blockSize {
+		return w.flushBlock()
+	}
+	return nil
+}
+
+func (w *kvTableWriter) flushBlock() error {
+	if _, err := w.bw.Write(w.block); err != nil {
+		return err
+	}
+	var tmp [binary.MaxVarintLen64]byte
+	w.index = append(w.index, tmp[:binary.PutUvarint(tmp[:], uint64(len(w.lastKey)))]...)
+	w.index = append(w.index, w.lastKey...)
+	w.index = append(w.index, tmp[:binary.PutUvarint(tmp[:], uint64(w.offset))]...)
+	w.index = append(w.index, tmp[:binary.PutUvarint(tmp[:], uint64(len(w.block)))]...)
+
+	binary.LittleEndian.PutUint32(tmp[:4], crc32.Checksum(w.block, kvCRCTable))
+	w.index = append(w.index, tmp[:4]...)
+
+	w.offset += int64(len(w.block))
+	w.block = w.block[:0]
+	return nil
+}
+
+func (w *kvTableWriter) finish() error {
+	if len(w.block) != 0 {
+		if err := w.flushBlock(); err != nil {
+			return err
+		}
+	}
+	var footer [kvFooterLength]byte
+	binary.LittleEndian.PutUint64(footer[0:8], uint64(w.offset))
+	binary.LittleEndian.PutUint32(footer[8:12], uint32(len(w.index)))
+	binary.LittleEndian.PutUint32(footer[12:16], crc32.Checksum(w.index, kvCRCTable))
+
+	if _, err := w.bw.Write(w.index); err != nil {
+		return err
+	} else if _, err = w.bw.Write(footer[:]); err != nil {
+		return err
+	}
+	return w.bw.Flush()
+}
+
+// kvSource is an ordered source of entries.
+type kvSource interface {
+	// seek to the first entry having a key equal to or greater than |key|.
+	seek(key []byte) error
+	valid() bool
+	entry() kvEntry
+	next() error
+}
+
+// kvMemSource is a kvSource over a sorted snapshot of the in-memory table.
+type kvMemSource struct {
+	entries []kvEntry
+	ind     int
+}
+
+func newKVMemSource(mem map[string]kvValue) *kvMemSource {
+	var src = &kvMemSource{entries: make([]kvEntry, 0, len(mem))}
+	for key, v := range mem {
+		src.entries = append(src.entries, kvEntry{key: []byte(key), value: v.value, deleted: v.deleted})
+	}
+	sort.Slice(src.entries, func(i, j int) bool {
+		return bytes.Compare(src.entries[i].key, src.entries[j].key) < 0
+	})
+	return src
+}
+
+func (s *kvMemSource) seek(key []byte) error {
+	s.ind = sort.Search(len(s.entries), func(i int) bool { return bytes.Compare(s.entries[i].key, key) >= 0 })
+	return nil
+}
+func (s *kvMemSource) valid() bool    { return s.ind < len(s.entries) }
+func (s *kvMemSource) entry() kvEntry { return s.entries[s.ind] }
+func (s *kvMemSource) next() error    { s.ind++; return nil }
+
+// kvTableSource is a kvSource over a kvTable, which reads a block at a time.
+type kvTableSource struct {
+	table   *kvTable
+	block   int
+	entries []kvEntry
+	ind     int
+}
+
+func (s *kvTableSource) seek(key []byte) (err error) {
+	s.block, s.entries = s.table.seekBlock(key), nil
+
+	if s.block != len(s.table.index) {
+		if s.entries, err = s.table.readBlock(s.block); err != nil {
+			return err
+		}
+	}
+	s.ind = sort.Search(len(s.entries), func(i int) bool { return bytes.Compare(s.entries[i].key, key) >= 0 })
+	return nil
+}
+
+func (s *kvTableSource) valid() bool    { return s.ind < len(s.entries) }
+func (s *kvTableSource) entry() kvEntry { return s.entries[s.ind] }
+
+func (s *kvTableSource) next() (err error) {
+	if s.ind++; s.ind < len(s.entries) {
+		return nil
+	} else if s.block++; s.block == len(s.table.index) {
+		return nil // Iteration is complete.
+	}
+	s.entries, s.ind = nil, 0
+	s.entries, err = s.table.readBlock(s.block)
+	return err
+}
+
+// kvMergedSource is a kvSource which merges |sources|, which are ordered on
+// descending recency. Where sources have entries of the same key, only the
+// entry of the most recent source is returned.
+type kvMergedSource struct {
+	sources []kvSource
+	cur     int // Index of the source of the current entry, or -1.
+}
+
+func (s *kvMergedSource) seek(key []byte) error {
+	for _, src := range s.sources {
+		if err := src.seek(key); err != nil {
+			return err
+		}
+	}
+	s.settle()
+	return nil
+}
+
+func (s *kvMergedSource) valid() bool    { return s.cur != -1 }
+func (s *kvMergedSource) entry() kvEntry { return s.sources[s.cur].entry() }
+
+func (s *kvMergedSource) next() error {
+	var key = s.entry().key
+
+	for _, src := range s.sources {
+		if src.valid() && bytes.Equal(src.entry().key, key) {
+			if err := src.next(); err != nil {
+				return err
+			}
+		}
+	}
+	s.settle()
+	return nil
+}
+
+// settle selects the most recent source having the least key.
+func (s *kvMergedSource) settle() {
+	s.cur = -1
+	for i, src := range s.sources {
+		if !src.valid() {
+			continue
+		} else if s.cur == -1 || bytes.Compare(src.entry().key, s.entry().key) < 0 {
+			s.cur = i
+		}
+	}
+}
+
+// appendKVEntry appends the encoding of an entry of |kind| to |b|:
+//
+//	[kind: byte] [key length: uvarint] [key] [value length: uvarint] [value]
+//
+// The value is omitted for entries of kind kvDelete.
+func appendKVEntry(b []byte, kind byte, key, value []byte) []byte {
+	var tmp [binary.MaxVarintLen64]byte
+
+	b = append(b, kind)
+	b = append(b, tmp[:binary.PutUvarint(tmp[:], uint64(len(key)))]...)
+	b = append(b, key...)
+
+	if kind != kvDelete {
+		b = append(b, tmp[:binary.PutUvarint(tmp[:], uint64(len(value)))]...)
+		b = append(b, value...)
+	}
+	return b
+}
+
+// decodeKVEntry decodes an entry encoded by appendKVEntry. Returned |key|
+// and |value| alias |b|.
+func decodeKVEntry(b []byte) (kind byte, key, value, rem []byte, err error) {
+	if len(b) == 0 {
+		return 0, nil, nil, nil, fmt.Errorf("unexpected end of entry")
+	}
+	kind, b = b[0], b[1:]
+
+	if kind != kvPut && kind != kvDelete && kind != kvOffset {
+		return 0, nil, nil, nil, fmt.Errorf("invalid entry kind (%d)", kind)
+	}
+	var l, n = binary.Uvarint(b)
+	if n <= 0 || uint64(len(b)-n) < l {
+		return 0, nil, nil, nil, fmt.Errorf("invalid key encoding")
+	}
+	key, b = b[n:n+int(l)], b[n+int(l):]
+
+	if kind != kvDelete {
+		if l, n = binary.Uvarint(b); n <= 0 || uint64(len(b)-n) < l {
+			return 0, nil, nil, nil, fmt.Errorf("invalid value encoding")
+		}
+		value, b = b[n:n+int(l)], b[n+int(l):]
+	}
+	return kind, key, value, b, nil
+}
+
+// frameKVRecord frames |payload| as a record of the write-ahead log:
+//
+//	[payload length: uint32] [payload CRC: uint32] [payload]
+func frameKVRecord(payload []byte) []byte {
+	var b = make([]byte, 8, 8+len(payload))
+	binary.LittleEndian.PutUint32(b[0:4], uint32(len(payload)))
+	binary.LittleEndian.PutUint32(b[4:8], crc32.Checksum(payload, kvCRCTable))
+	return append(b, payload...)
+}
+
+// decodeKVRecord decodes a record framed by frameKVRecord. If |b| holds only
+// part of a record, |ok| is false.
+func decodeKVRecord(b []byte) (payload, rem []byte, ok bool, err error) {
+	if len(b) < 8 {
+		return nil, nil, false, nil
+	}
+	var l = binary.LittleEndian.Uint32(b[0:4])
+	if uint64(len(b)-8) < uint64(l) {
+		return nil, nil, false, nil
+	}
+	payload, rem = b[8:8+l], b[8+l:]
+
+	if crc32.Checksum(payload, kvCRCTable) != binary.LittleEndian.Uint32(b[4:8]) {
+		return nil, nil, false, fmt.Errorf("record checksum mismatch")
+	}
+	return payload, rem, true, nil
+}
+
+const (
+	// Kinds of encoded entries.
+	kvPut    byte = 1
+	kvDelete byte = 2
+	kvOffset byte = 3
+
+	kvManifestName     = "MANIFEST"
+	kvManifestNextName = "MANIFEST.next"
+	kvLogExt           = ".log"
+	kvTableExt         = ".sst"
+
+	kvFooterLength    = 16
+	kvEntryOverhead   = 32 // Approximate in-memory overhead of each entry.
+	kvCompactionRatio = 2  // Size ratio of tables which are compacted together.
+)
+
+var kvCRCTable = crc32.MakeTable(crc32.Castagnoli)
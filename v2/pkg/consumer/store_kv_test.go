@@ -0,0 +1,260 @@
+package consumer
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	gc "github.com/go-check/check"
+	"github.com/spf13/afero"
+)
+
+type KVStoreSuite struct{}
+
+func (s *KVStoreSuite) TestWriteAndReadKeysAndOffsets(c *gc.C) {
+	var store, cleanup = newTestKVStore(c)
+	defer cleanup()
+
+	store.Batch.Put([]byte("foo"), []byte("bar"))
+	store.Batch.Put([]byte("baz"), []byte("bing"))
+	c.Check(store.Batch.Count(), gc.Equals, 2)
+
+	// Batched writes are not visible until flushed.
+	var _, ok, err = store.Get([]byte("foo"))
+	c.Check(err, gc.IsNil)
+	c.Check(ok, gc.Equals, false)
+
+	c.Check(store.Flush(map[pb.Journal]int64{"journal/A": 1234}), gc.IsNil)
+	c.Check(store.Batch.Count(), gc.Equals, 0)
+
+	value, ok, err := store.Get([]byte("foo"))
+	c.Check(err, gc.IsNil)
+	c.Check(ok, gc.Equals, true)
+	c.Check(value, gc.DeepEquals, []byte("bar"))
+
+	store.Batch.Delete([]byte("foo"))
+	c.Check(store.Flush(map[pb.Journal]int64{"journal/B": 5678}), gc.IsNil)
+
+	_, ok, err = store.Get([]byte("foo"))
+	c.Check(err, gc.IsNil)
+	c.Check(ok, gc.Equals, false)
+
+	offsets, err := store.FetchJournalOffsets()
+	c.Check(err, gc.IsNil)
+	c.Check(offsets, gc.DeepEquals, map[pb.Journal]int64{
+		"journal/A": 1234,
+		"journal/B": 5678,
+	})
+}
+
+func (s *KVStoreSuite) TestTablesAndCompaction(c *gc.C) {
+	var store, cleanup = newTestKVStore(c)
+	defer cleanup()
+
+	store.Options = KVStoreOptions{MemTableSize: 256, MaxTables: 3, BlockSize: 64}
+	var expect = make(map[string]string)
+
+	// Write rounds of overlapping keys, overwriting and deleting some keys
+	// of prior rounds. Each round rotates one or more tables, and compactions
+	// are frequently required.
+	for round := 0; round != 20; round++ {
+		for i := 0; i != 15; i++ {
+			var key = fmt.Sprintf("key-%03d", (round*7+i*3)%50)
+
+			if i%4 == 3 {
+				store.Batch.Delete([]byte(key))
+				delete(expect, key)
+			} else {
+				var value = fmt.Sprintf("value-%d-%d", round, i)
+				store.Batch.Put([]byte(key), []byte(value))
+				expect[key] = value
+			}
+		}
+		c.Check(store.Flush(map[pb.Journal]int64{"a/journal": int64(round)}), gc.IsNil)
+		c.Check(len(store.tables) <= store.Options.MaxTables, gc.Equals, true)
+	}
+	verifyKVStore(c, store, expect)
+
+	// Expect only live files remain in the store directory.
+	var names, err = filepath.Glob(filepath.Join(store.dir, "*"))
+	c.Check(err, gc.IsNil)
+	c.Check(names, gc.HasLen, len(store.tables)+2) // Tables, log, and MANIFEST.
+
+	// Re-open the store. Expect the same content is recovered.
+	var other = NewKVStore(nil, store.dir)
+	other.fs, other.Options = afero.NewOsFs(), store.Options
+	c.Assert(other.Open(), gc.IsNil)
+
+	verifyKVStore(c, other, expect)
+
+	offsets, err := other.FetchJournalOffsets()
+	c.Check(err, gc.IsNil)
+	c.Check(offsets, gc.DeepEquals, map[pb.Journal]int64{"a/journal": 19})
+}
+
+func (s *KVStoreSuite) TestCompactionWritesAreBounded(c *gc.C) {
+	var store, cleanup = newTestKVStore(c)
+	defer cleanup()
+
+	var written int64
+	store.fs = countingFs{Fs: store.fs, n: &written}
+	store.Options = KVStoreOptions{MemTableSize: 1024, MaxTables: 4, BlockSize: 256}
+
+	// Write rounds of distinct keys, such that every entry is live and table
+	// sizes grow without bound. Each round rotates a table.
+	var expect = make(map[string]string)
+	var size int64
+
+	for round := 0; round != 256; round++ {
+		for i := 0; i != 20; i++ {
+			var key, value = fmt.Sprintf("key-%03d-%03d", i, round), fmt.Sprintf("value-%d", round)
+
+			store.Batch.Put([]byte(key), []byte(value))
+			expect[key] = value
+			size += int64(len(key) + len(value))
+		}
+		c.Check(store.Flush(nil), gc.IsNil)
+		c.Check(len(store.tables) <= store.Options.MaxTables, gc.Equals, true)
+	}
+	verifyKVStore(c, store, expect)
+
+	// Compacting all tables would re-write every entry once per MaxTables
+	// rotations. Expect that compacting only tables of similar size instead
+	// writes a logarithmic factor of the entry size.
+	c.Check(written < 12*size, gc.Equals, true)
+}
+
+func (s *KVStoreSuite) TestIteration(c *gc.C) {
+	var store, cleanup = newTestKVStore(c)
+	defer cleanup()
+
+	store.Options.MemTableSize = 1 // Rotate a table on every Flush.
+
+	store.Batch.Put([]byte("a"), []byte("1"))
+	store.Batch.Put([]byte("c"), []byte("3"))
+	store.Batch.Put([]byte("e"), []byte("5"))
+	c.Check(store.Flush(nil), gc.IsNil)
+
+	store.Batch.Put([]byte("b"), []byte("2"))
+	store.Batch.Delete([]byte("c"))
+	c.Check(store.Flush(nil), gc.IsNil)
+
+	store.Options.MemTableSize = 1 << 20 // Remaining writes are in-memory.
+
+	store.Batch.Put([]byte("a"), []byte("one"))
+	store.Batch.Put([]byte("d"), []byte("4"))
+	c.Check(store.Flush(nil), gc.IsNil)
+
+	var collect = func(it *KVIterator) (out []string) {
+		for ; it.Valid(); it.Next() {
+			out = append(out, string(it.Key())+"="+string(it.Value()))
+		}
+		c.Check(it.Err(), gc.IsNil)
+		return
+	}
+
+	var it = store.NewIterator()
+	it.SeekToFirst()
+	c.Check(collect(it), gc.DeepEquals, []string{"a=one", "b=2", "d=4", "e=5"})
+
+	it = store.NewIterator()
+	it.Seek([]byte("bb"))
+	c.Check(collect(it), gc.DeepEquals, []string{"d=4", "e=5"})
+
+	it = store.NewIterator()
+	it.Seek([]byte("f"))
+	c.Check(collect(it), gc.HasLen, 0)
+}
+
+func (s *KVStoreSuite) TestPartialLogRecordIsIgnored(c *gc.C) {
+	var store, cleanup = newTestKVStore(c)
+	defer cleanup()
+
+	store.Batch.Put([]byte("foo"), []byte("bar"))
+	c.Check(store.Flush(map[pb.Journal]int64{"a/journal": 10}), gc.IsNil)
+	store.Batch.Put([]byte("baz"), []byte("bing"))
+	c.Check(store.Flush(map[pb.Journal]int64{"a/journal": 20}), gc.IsNil)
+
+	// Truncate the final record of the log, as if we failed while writing it.
+	var path = store.path(store.manifest.Log)
+	var b, err = ioutil.ReadFile(path)
+	c.Assert(err, gc.IsNil)
+	c.Assert(ioutil.WriteFile(path, b[:len(b)-3], 0600), gc.IsNil)
+
+	var other = NewKVStore(nil, store.dir)
+	other.fs = afero.NewOsFs()
+	c.Assert(other.Open(), gc.IsNil)
+
+	verifyKVStore(c, other, map[string]string{"foo": "bar"})
+
+	offsets, err := other.FetchJournalOffsets()
+	c.Check(err, gc.IsNil)
+	c.Check(offsets, gc.DeepEquals, map[pb.Journal]int64{"a/journal": 10})
+
+	// However, a corrupted record fails Open.
+	b[10] ^= 0xff
+	c.Assert(ioutil.WriteFile(other.path(other.manifest.Log), b, 0600), gc.IsNil)
+
+	other = NewKVStore(nil, store.dir)
+	other.fs = afero.NewOsFs()
+	c.Check(other.Open(), gc.ErrorMatches, `replaying log .*: record checksum mismatch`)
+}
+
+func newTestKVStore(c *gc.C) (*KVStore, func()) {
+	var dir, err = ioutil.TempDir("", "kvstore")
+	c.Assert(err, gc.IsNil)
+
+	// Replace the recorded FS with a regular one.
+	var store = NewKVStore(nil, dir)
+	store.fs = afero.NewOsFs()
+	c.Assert(store.Open(), gc.IsNil)
+
+	return store, func() { os.RemoveAll(dir) }
+}
+
+func verifyKVStore(c *gc.C, store *KVStore, expect map[string]string) {
+	var it = store.NewIterator()
+	var count int
+
+	for it.SeekToFirst(); it.Valid(); it.Next() {
+		c.Check(string(it.Value()), gc.Equals, expect[string(it.Key())])
+		count++
+	}
+	c.Check(it.Err(), gc.IsNil)
+	c.Check(count, gc.Equals, len(expect))
+
+	for key, value := range expect {
+		var v, ok, err = store.Get([]byte(key))
+		c.Check(err, gc.IsNil)
+		c.Check(ok, gc.Equals, true)
+		c.Check(string(v), gc.Equals, value)
+	}
+}
+
+var _ = gc.Suite(&KVStoreSuite{})
+
+// countingFs is an afero.Fs which counts bytes written to its files.
+type countingFs struct {
+	afero.Fs
+	n *int64
+}
+
+func (fs countingFs) OpenFile(name string, flag int, perm os.FileMode) (afero.File, error) {
+	var f, err = fs.Fs.OpenFile(name, flag, perm)
+	if err != nil {
+		return nil, err
+	}
+	return countingFile{File: f, n: fs.n}, nil
+}
+
+type countingFile struct {
+	afero.File
+	n *int64
+}
+
+func (f countingFile) Write(p []byte) (int, error) {
+	*f.n += int64(len(p))
+	return f.File.Write(p)
+}
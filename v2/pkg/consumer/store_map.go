@@ -0,0 +1,347 @@
+package consumer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	"github.com/LiveRamp/gazette/v2/pkg/recoverylog"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+)
+
+// MapStore is a Store of string keys and JSON-encodable values, which are held
+// in memory. Unlike JSONFileStore, which re-writes its entire state with each
+// Flush, MapStore tracks keys which are Put or Deleted and Flushes only those
+// keys, as a delta record appended to a log file. Once the delta log is larger
+// than the current snapshot (and at least MinCompactionSize), MapStore writes
+// a new snapshot of its full state and begins a new delta log. Recovery loads
+// the snapshot and then replays its delta log.
+//
+// Values are retained as-is, and are encoded by the Flush following their Put.
+// A value which is modified in place must be Put again to be captured.
+// MapStore is not safe for concurrent use.
+type MapStore struct {
+	// MinCompactionSize is the size of a delta log below which it's never
+	// compacted into a new snapshot.
+	MinCompactionSize int64
+
+	dir      string
+	fs       afero.Fs
+	recorder *recoverylog.Recorder
+	newValue func() interface{}
+
+	state   map[string]interface{}
+	dirty   map[string]struct{}
+	offsets map[pb.Journal]int64
+
+	snapshotSize int64      // Size of the current snapshot.
+	deltasName   string     // Name of the current delta log.
+	deltas       afero.File // Current delta log.
+	deltasSize   int64      // Size of the current delta log.
+}
+
+// NewMapStore returns a new MapStore, which recovers from the snapshot and
+// delta log of |dir| if present. |newValue| returns a new value instance into
+// which a recovered, JSON-encoded value is decoded.
+func NewMapStore(rec *recoverylog.Recorder, dir string, newValue func() interface{}) (*MapStore, error) {
+	return newMapStore(recoverylog.RecordedAferoFS{Recorder: rec, Fs: afero.NewOsFs()}, rec, dir, newValue)
+}
+
+func newMapStore(fs afero.Fs, rec *recoverylog.Recorder, dir string, newValue func() interface{}) (*MapStore, error) {
+	var s = &MapStore{
+		MinCompactionSize: 1 << 20, // 1MB.
+		dir:               dir,
+		fs:                fs,
+		recorder:          rec,
+		newValue:          newValue,
+		state:             make(map[string]interface{}),
+		dirty:             make(map[string]struct{}),
+		offsets:           make(map[pb.Journal]int64),
+	}
+
+	// A next snapshot exists only if we failed while writing it.
+	if _, err := s.fs.Stat(s.path(mapStoreNextName)); err == nil {
+		if err = s.fs.Remove(s.path(mapStoreNextName)); err != nil {
+			return nil, extendErr(err, "removing partial snapshot")
+		}
+	}
+
+	if b, err := afero.ReadFile(s.fs, s.path(mapStoreSnapshotName)); os.IsNotExist(err) {
+		// Pass.
+	} else if err != nil {
+		return nil, extendErr(err, "reading snapshot")
+	} else if err = s.replay(b, true); err != nil {
+		return nil, extendErr(err, "decoding snapshot")
+	}
+
+	if s.deltasName != "" {
+		if b, err := afero.ReadFile(s.fs, s.path(s.deltasName)); err != nil {
+			return nil, extendErr(err, "reading deltas")
+		} else if err = s.replay(b, false); err != nil {
+			return nil, extendErr(err, "decoding deltas")
+		}
+	}
+
+	// Remove delta logs which aren't referenced by the snapshot. They remain
+	// if we failed while compacting.
+	var infos, err = afero.ReadDir(s.fs, s.dir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, extendErr(err, "reading store directory")
+	}
+	for _, info := range infos {
+		var name = info.Name()
+
+		if name == s.deltasName || !strings.HasPrefix(name, mapStoreDeltasPrefix) {
+			continue
+		} else if err = s.fs.Remove(s.path(name)); err != nil {
+			return nil, extendErr(err, "removing unreferenced deltas %s", name)
+		}
+	}
+
+	// Recorded files may not be re-opened for append. Compact to begin a new delta log.
+	if err = s.compact(); err != nil {
+		return nil, extendErr(err, "compacting")
+	}
+	return s, nil
+}
+
+// Recorder of the MapStore.
+func (s *MapStore) Recorder() *recoverylog.Recorder { return s.recorder }
+
+// Get the value of |key|, and whether it exists.
+func (s *MapStore) Get(key string) (interface{}, bool) {
+	var value, ok = s.state[key]
+	return value, ok
+}
+
+// Put |value| to |key|.
+func (s *MapStore) Put(key string, value interface{}) {
+	s.state[key] = value
+	s.dirty[key] = struct{}{}
+}
+
+// Delete |key|.
+func (s *MapStore) Delete(key string) {
+	delete(s.state, key)
+	s.dirty[key] = struct{}{}
+}
+
+// Len returns the number of keys of the MapStore.
+func (s *MapStore) Len() int { return len(s.state) }
+
+// Range invokes |fn| with each key and value of the MapStore, in unspecified
+// order, until |fn| returns false. |fn| must not Put or Delete keys.
+func (s *MapStore) Range(fn func(key string, value interface{}) bool) {
+	for key, value := range s.state {
+		if !fn(key, value) {
+			return
+		}
+	}
+}
+
+// FetchJournalOffsets returns offsets encoded by the MapStore.
+func (s *MapStore) FetchJournalOffsets() (map[pb.Journal]int64, error) {
+	var offsets = make(map[pb.Journal]int64)
+	for k, o := range s.offsets {
+		offsets[k] = o
+	}
+	return offsets, nil
+}
+
+// Flush |offsets| and keys which were Put or Deleted since the last Flush,
+// as a single record appended to the delta log.
+func (s *MapStore) Flush(offsets map[pb.Journal]int64) error {
+	if len(offsets) == 0 && len(s.dirty) == 0 {
+		return nil
+	}
+	var rec = mapStoreRecord{Offsets: offsets}
+
+	for key := range s.dirty {
+		if value, ok := s.state[key]; !ok {
+			rec.Deletes = append(rec.Deletes, key)
+		} else if b, err := json.Marshal(value); err != nil {
+			return extendErr(err, "encoding value of %q", key)
+		} else {
+			if rec.Puts == nil {
+				rec.Puts = make(map[string]json.RawMessage)
+			}
+			rec.Puts[key] = b
+		}
+	}
+	sort.Strings(rec.Deletes)
+
+	var b, err = json.Marshal(rec)
+	if err != nil {
+		return extendErr(err, "encoding deltas")
+	}
+	// Each record is written with a single Write, and is newline-terminated.
+	if _, err = s.deltas.Write(append(b, '\n')); err != nil {
+		return extendErr(err, "writing deltas")
+	}
+	s.deltasSize += int64(len(b) + 1)
+
+	for k, o := range offsets {
+		s.offsets[k] = o
+	}
+	s.dirty = make(map[string]struct{})
+
+	if s.deltasSize >= s.MinCompactionSize && s.deltasSize >= s.snapshotSize {
+		return s.compact()
+	}
+	return nil
+}
+
+// CaptureCheckpoint of the MapStore. Files of the MapStore are removed only
+// by Flush (see Checkpointer).
+func (s *MapStore) CaptureCheckpoint(store pb.FragmentStore) (*recoverylog.CapturedCheckpoint, error) {
+	return s.recorder.CaptureCheckpoint(store)
+}
+
+// Destroy the MapStore directory and its files.
+func (s *MapStore) Destroy() {
+	if s.deltas != nil {
+		s.deltas.Close()
+	}
+	if err := os.RemoveAll(s.dir); err != nil {
+		log.WithFields(log.Fields{
+			"dir": s.dir,
+			"err": err,
+		}).Error("failed to remove map store directory")
+	}
+}
+
+// mapStoreRecord is a snapshot or delta record of a MapStore.
+type mapStoreRecord struct {
+	// Name of the delta log which follows this snapshot. Set only on snapshots.
+	Deltas  string                     `json:"deltas,omitempty"`
+	Offsets map[pb.Journal]int64       `json:"offsets,omitempty"`
+	Puts    map[string]json.RawMessage `json:"puts,omitempty"`
+	Deletes []string                   `json:"deletes,omitempty"`
+}
+
+// replay newline-terminated records of |b|. A trailing record which isn't
+// newline-terminated is ignored, as we failed while writing it.
+func (s *MapStore) replay(b []byte, isSnapshot bool) error {
+	var br = bufio.NewReader(bytes.NewReader(b))
+
+	for {
+		var line, err = br.ReadBytes('\n')
+		if len(line) != 0 && err != nil {
+			log.WithFields(log.Fields{"dir": s.dir, "length": len(line)}).
+				Warn("ignoring partial map store record")
+			return nil
+		} else if err != nil {
+			return nil // io.EOF.
+		}
+
+		var rec mapStoreRecord
+		if err = json.Unmarshal(line, &rec); err != nil {
+			return err
+		}
+		for key, raw := range rec.Puts {
+			var value = s.newValue()
+			if err = json.Unmarshal(raw, value); err != nil {
+				return extendErr(err, "decoding value of %q", key)
+			}
+			s.state[key] = value
+		}
+		for _, key := range rec.Deletes {
+			delete(s.state, key)
+		}
+		for k, o := range rec.Offsets {
+			s.offsets[k] = o
+		}
+		if isSnapshot {
+			s.deltasName = rec.Deltas
+		}
+	}
+}
+
+// compact writes a snapshot of the full MapStore state which references a
+// new and empty delta log. The snapshot is written to a temporary file which
+// is then renamed to the well-known location, which is the commit point.
+func (s *MapStore) compact() error {
+	var seq int
+	if s.deltasName != "" {
+		if _, err := fmt.Sscanf(s.deltasName, mapStoreDeltasPrefix+"%d", &seq); err != nil {
+			return extendErr(err, "parsing deltas name %q", s.deltasName)
+		}
+	}
+	var rec = mapStoreRecord{
+		Deltas:  fmt.Sprintf("%s%06d", mapStoreDeltasPrefix, seq+1),
+		Offsets: s.offsets,
+		Puts:    make(map[string]json.RawMessage, len(s.state)),
+	}
+	for key, value := range s.state {
+		var b, err = json.Marshal(value)
+		if err != nil {
+			return extendErr(err, "encoding value of %q", key)
+		}
+		rec.Puts[key] = b
+	}
+
+	var deltas, err = s.fs.OpenFile(s.path(rec.Deltas), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return extendErr(err, "creating deltas")
+	}
+
+	f, err := s.fs.OpenFile(s.path(mapStoreNextName), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return extendErr(err, "creating snapshot")
+	}
+	var bw = bufio.NewWriterSize(f, 1<<16)
+	var cw = countingWriter{w: bw}
+
+	if err = json.NewEncoder(&cw).Encode(rec); err != nil {
+		return extendErr(err, "encoding snapshot")
+	} else if err = bw.Flush(); err != nil {
+		return extendErr(err, "writing snapshot")
+	} else if err = f.Close(); err != nil {
+		return extendErr(err, "closing snapshot")
+	} else if err = s.fs.Rename(s.path(mapStoreNextName), s.path(mapStoreSnapshotName)); err != nil {
+		return extendErr(err, "renaming next => snapshot")
+	}
+
+	// The snapshot is committed. Remove the prior delta log.
+	var prevDeltas = s.deltasName
+
+	if s.deltas != nil {
+		s.deltas.Close()
+	}
+	s.snapshotSize, s.deltasName, s.deltas, s.deltasSize = cw.n, rec.Deltas, deltas, 0
+
+	if prevDeltas != "" {
+		if err = s.fs.Remove(s.path(prevDeltas)); err != nil {
+			return extendErr(err, "removing deltas %s", prevDeltas)
+		}
+	}
+	return nil
+}
+
+func (s *MapStore) path(name string) string { return filepath.Join(s.dir, name) }
+
+// countingWriter counts bytes written through it.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	var n, err = w.w.Write(p)
+	w.n += int64(n)
+	return n, err
+}
+
+const (
+	mapStoreSnapshotName = "snapshot.json"
+	mapStoreNextName     = "snapshot.next.json"
+	mapStoreDeltasPrefix = "deltas."
+)
@@ -0,0 +1,135 @@
+package consumer
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	gc "github.com/go-check/check"
+	"github.com/spf13/afero"
+)
+
+type MapStoreSuite struct{}
+
+func (s *MapStoreSuite) TestFlushAndRecoverDeltas(c *gc.C) {
+	var dir, cleanup = newTestMapStoreDir(c)
+	defer cleanup()
+
+	var store = newTestMapStore(c, dir)
+
+	store.Put("foo", &mapStoreTestValue{N: 1})
+	store.Put("bar", &mapStoreTestValue{N: 2})
+	c.Check(store.Flush(map[pb.Journal]int64{"journal/A": 100}), gc.IsNil)
+
+	// Only changed keys are written with each Flush.
+	store.Put("baz", &mapStoreTestValue{N: 3})
+	store.Delete("foo")
+	c.Check(store.Flush(map[pb.Journal]int64{"journal/B": 200}), gc.IsNil)
+
+	var b, err = ioutil.ReadFile(filepath.Join(dir, store.deltasName))
+	c.Check(err, gc.IsNil)
+	c.Check(string(b), gc.Equals,
+		`{"offsets":{"journal/A":100},"puts":{"bar":{"N":2},"foo":{"N":1}}}`+"\n"+
+			`{"offsets":{"journal/B":200},"puts":{"baz":{"N":3}},"deletes":["foo"]}`+"\n")
+
+	// A Flush without changes or offsets writes nothing.
+	c.Check(store.Flush(nil), gc.IsNil)
+	c.Check(store.deltasSize, gc.Equals, int64(len(b)))
+
+	// Recover a new store from the snapshot and deltas.
+	var other = newTestMapStore(c, dir)
+
+	c.Check(other.Len(), gc.Equals, 2)
+	var v, ok = other.Get("bar")
+	c.Check(ok, gc.Equals, true)
+	c.Check(v, gc.DeepEquals, &mapStoreTestValue{N: 2})
+	_, ok = other.Get("foo")
+	c.Check(ok, gc.Equals, false)
+
+	offsets, err := other.FetchJournalOffsets()
+	c.Check(err, gc.IsNil)
+	c.Check(offsets, gc.DeepEquals, map[pb.Journal]int64{"journal/A": 100, "journal/B": 200})
+
+	// Expect recovery compacted into a new snapshot, and removed prior deltas.
+	c.Check(listMapStoreDir(c, dir), gc.DeepEquals, []string{"deltas.000002", "snapshot.json"})
+}
+
+func (s *MapStoreSuite) TestCompaction(c *gc.C) {
+	var dir, cleanup = newTestMapStoreDir(c)
+	defer cleanup()
+
+	var store = newTestMapStore(c, dir)
+	store.MinCompactionSize = 200
+
+	for i := 0; i != 100; i++ {
+		store.Put(strconv.Itoa(i%10), &mapStoreTestValue{N: i})
+		c.Check(store.Flush(map[pb.Journal]int64{"journal/A": int64(i)}), gc.IsNil)
+
+		// Deltas are bounded by the larger of the snapshot and MinCompactionSize.
+		c.Check(store.deltasSize < 200 || store.deltasSize < store.snapshotSize, gc.Equals, true)
+	}
+	c.Check(store.deltasName, gc.Not(gc.Equals), "deltas.000001")
+	c.Check(listMapStoreDir(c, dir), gc.DeepEquals, []string{store.deltasName, "snapshot.json"})
+
+	var other = newTestMapStore(c, dir)
+	c.Check(other.Len(), gc.Equals, 10)
+
+	var v, _ = other.Get("7")
+	c.Check(v, gc.DeepEquals, &mapStoreTestValue{N: 97})
+	c.Check(other.offsets, gc.DeepEquals, map[pb.Journal]int64{"journal/A": 99})
+}
+
+func (s *MapStoreSuite) TestPartialRecordAndStaleFilesAreIgnored(c *gc.C) {
+	var dir, cleanup = newTestMapStoreDir(c)
+	defer cleanup()
+
+	var store = newTestMapStore(c, dir)
+	store.Put("foo", &mapStoreTestValue{N: 1})
+	c.Check(store.Flush(map[pb.Journal]int64{"journal/A": 100}), gc.IsNil)
+
+	// Append a partial record, and leave behind files of a failed compaction.
+	var f, err = os.OpenFile(filepath.Join(dir, store.deltasName), os.O_WRONLY|os.O_APPEND, 0)
+	c.Assert(err, gc.IsNil)
+	_, err = f.WriteString(`{"offsets":{"journal/A":200},"puts":{"fo`)
+	c.Check(err, gc.IsNil)
+	c.Check(f.Close(), gc.IsNil)
+
+	c.Check(ioutil.WriteFile(filepath.Join(dir, "deltas.000099"), nil, 0600), gc.IsNil)
+	c.Check(ioutil.WriteFile(filepath.Join(dir, "snapshot.next.json"), []byte("{"), 0600), gc.IsNil)
+
+	var other = newTestMapStore(c, dir)
+	var v, _ = other.Get("foo")
+	c.Check(v, gc.DeepEquals, &mapStoreTestValue{N: 1})
+	c.Check(other.offsets, gc.DeepEquals, map[pb.Journal]int64{"journal/A": 100})
+	c.Check(listMapStoreDir(c, dir), gc.DeepEquals, []string{"deltas.000002", "snapshot.json"})
+}
+
+type mapStoreTestValue struct{ N int }
+
+func newTestMapStoreDir(c *gc.C) (string, func()) {
+	var dir, err = ioutil.TempDir("", "mapstore")
+	c.Assert(err, gc.IsNil)
+	return dir, func() { os.RemoveAll(dir) }
+}
+
+func newTestMapStore(c *gc.C, dir string) *MapStore {
+	// Use a regular, un-recorded FS.
+	var store, err = newMapStore(afero.NewOsFs(), nil, dir,
+		func() interface{} { return new(mapStoreTestValue) })
+	c.Assert(err, gc.IsNil)
+	return store
+}
+
+func listMapStoreDir(c *gc.C, dir string) (names []string) {
+	var infos, err = ioutil.ReadDir(dir)
+	c.Assert(err, gc.IsNil)
+
+	for _, info := range infos {
+		names = append(names, info.Name())
+	}
+	return
+}
+
+var _ = gc.Suite(&MapStoreSuite{})
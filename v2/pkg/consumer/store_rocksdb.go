@@ -1,4 +1,4 @@
-// +build !norocksdb
+// +build !norocksdb,cgo
 
 package consumer
 
@@ -124,6 +124,20 @@ func (s *RocksDBStore) Flush(offsets map[pb.Journal]int64) error {
 	return nil
 }
 
+// CaptureCheckpoint of the RocksDB. File deletions are disabled while the
+// checkpoint is captured, so that compactions don't remove live files.
+func (s *RocksDBStore) CaptureCheckpoint(store pb.FragmentStore) (*recoverylog.CapturedCheckpoint, error) {
+	if err := s.DB.DisableFileDeletions(); err != nil {
+		return nil, err
+	}
+	var cp, err = s.rec.CaptureCheckpoint(store)
+
+	if err2 := s.DB.EnableFileDeletions(false); err == nil {
+		err = err2
+	}
+	return cp, err
+}
+
 // Destroy the RocksDBStore
 func (s *RocksDBStore) Destroy() {
 	if s.DB != nil {
@@ -2,6 +2,8 @@ package consumer
 
 import (
 	"context"
+	"encoding/json"
+	"os"
 	"time"
 
 	"github.com/LiveRamp/gazette/v2/pkg/allocator"
@@ -56,6 +58,26 @@ func (a *testApplication) NewStore(shard Shard, dir string, rec *recoverylog.Rec
 	return NewJSONFileStore(rec, dir, &state)
 }
 
+func (a *testApplication) OpenView(shard Shard, dir string) (Store, error) {
+	var state = make(map[string]string)
+	var store = &JSONFileStore{State: state, dir: dir, offsets: make(map[pb.Journal]int64)}
+
+	// Decode the view's state file directly. It's read-only, and not recorded.
+	var f, err = os.Open(store.currentPath())
+	if os.IsNotExist(err) {
+		return store, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var dec = json.NewDecoder(f)
+	if err = dec.Decode(&store.offsets); err == nil {
+		err = dec.Decode(&state)
+	}
+	return store, err
+}
+
 func (a *testApplication) NewMessage(*pb.JournalSpec) (message.Message, error) {
 	return new(testMessage), a.newMsgErr
 }
@@ -134,6 +156,21 @@ func newTestFixture(c *gc.C) (*testFixture, func()) {
 			c.Check(err, gc.IsNil)
 			c.Check(resp.Kvs, gc.HasLen, 0)
 
+			// Await the tear-down of local Replicas, so that none outlive the test.
+			var tornDownChs []<-chan struct{}
+
+			ks.Mu.RLock()
+			c.Check(ks.WaitForRevision(ctx, resp.Header.Revision), gc.IsNil)
+			c.Check(svc.Resolver.replicas, gc.HasLen, 0)
+			for _, ch := range svc.Resolver.tearingDown {
+				tornDownChs = append(tornDownChs, ch)
+			}
+			ks.Mu.RUnlock()
+
+			for _, ch := range tornDownChs {
+				<-ch
+			}
+
 			broker.RevokeLease(c)
 			broker.WaitForExit()
 
@@ -248,6 +285,25 @@ func expectStatusCode(c *gc.C, state *allocator.State, code ReplicaStatus_Code)
 	}
 }
 
+// awaitStatus blocks until the ReplicaStatus of the shard Assignment matches
+// |pred|, and returns it with its Assignment KeyValue.
+func awaitStatus(state *allocator.State, pred func(*ReplicaStatus) bool) (*ReplicaStatus, keyspace.KeyValue) {
+	defer state.KS.Mu.RUnlock()
+	state.KS.Mu.RLock()
+
+	for {
+		if len(state.LocalItems) == 1 {
+			var li = state.LocalItems[0]
+			var kv = li.Assignments[li.Index]
+
+			if status := kv.Decoded.(allocator.Assignment).AssignmentValue.(*ReplicaStatus); pred(status) {
+				return status, kv
+			}
+		}
+		state.KS.WaitForRevision(context.Background(), state.KS.Header.Revision+1)
+	}
+}
+
 func runSomeTransactions(c *gc.C, shard Shard, app *testApplication, store *JSONFileStore) {
 	for _, write := range []string{
 		`{"key":"foo","value":"bar"}`,
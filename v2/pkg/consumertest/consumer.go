@@ -0,0 +1,408 @@
+// Package consumertest provides utilities for testing consumer Applications
+// against an embedded Gazette broker, Etcd, and consumer.Service. It may be
+// used from plain Go tests (via *testing.T) or from go-check suites.
+package consumertest
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/LiveRamp/gazette/v2/pkg/allocator"
+	"github.com/LiveRamp/gazette/v2/pkg/brokertest"
+	"github.com/LiveRamp/gazette/v2/pkg/client"
+	"github.com/LiveRamp/gazette/v2/pkg/consumer"
+	"github.com/LiveRamp/gazette/v2/pkg/etcdtest"
+	"github.com/LiveRamp/gazette/v2/pkg/message"
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	"github.com/LiveRamp/gazette/v2/pkg/recoverylog"
+	"github.com/coreos/etcd/clientv3"
+	log "github.com/sirupsen/logrus"
+)
+
+// T is the subset of *testing.T used by consumertest. It's also implemented
+// by go-check's *check.C.
+type T interface {
+	Errorf(format string, args ...interface{})
+	Fatalf(format string, args ...interface{})
+}
+
+// Consumer is an embedded consumer.Service which runs an Application under
+// test. It's backed by an embedded broker and Etcd.
+type Consumer struct {
+	// Service of the Consumer.
+	Service *consumer.Service
+	// Broker is the embedded broker of the Consumer.
+	Broker *brokertest.Broker
+	// Journals is a client of the embedded broker.
+	Journals client.AsyncJournalClient
+
+	t       T
+	etcd    *clientv3.Client
+	app     *trackingApp
+	lease   clientv3.LeaseID
+	idleCh  chan struct{}
+	stopCh  chan struct{}
+	ctx     context.Context
+	cancel  context.CancelFunc
+	specs   []*consumer.ShardSpec
+	timeout time.Duration
+}
+
+// NewConsumer returns a running Consumer of the Application. The Consumer
+// begins without journals or shards: use CreateJournals and CreateShards.
+// Stop must be called at the completion of the test.
+func NewConsumer(t T, app consumer.Application) *Consumer {
+	var etcd = etcdtest.TestClient()
+	var bk = brokertest.NewBroker(t, etcd, "local", "broker")
+	var rjc = pb.NewRoutedJournalClient(bk.Client(), pb.NoopDispatchRouter{})
+	var ctx, cancel = context.WithCancel(context.Background())
+
+	// Grant lease with 1m timeout. Test must complete within this time.
+	var grant, err = etcd.Grant(ctx, 60)
+	if err != nil {
+		t.Fatalf("failed to grant lease: %s", err)
+	}
+
+	var ks = consumer.NewKeySpace("/consumertest")
+	ks.WatchApplyDelay = 0 // Speed test execution.
+	var key = allocator.MemberKey(ks, "local", "consumer")
+	var state = allocator.NewObservedState(ks, key)
+
+	var c = &Consumer{
+		Broker:   bk,
+		Journals: client.NewAppendService(ctx, rjc),
+		t:        t,
+		etcd:     etcd,
+		app:      newTrackingApp(app),
+		lease:    grant.ID,
+		idleCh:   make(chan struct{}, 1),
+		stopCh:   make(chan struct{}),
+		ctx:      ctx,
+		cancel:   cancel,
+		timeout:  defaultTimeout,
+	}
+	c.Service = consumer.NewService(c.app, state, rjc, nil, etcd)
+
+	var spec = consumer.ConsumerSpec{
+		ProcessSpec: pb.ProcessSpec{
+			Id:       pb.ProcessSpec_ID{Zone: "local", Suffix: "consumer"},
+			Endpoint: "http://consumertest/",
+		},
+		ShardLimit: 100,
+	}
+	resp, err := etcd.Txn(ctx).
+		If(clientv3.Compare(clientv3.Version(key), "=", 0)).
+		Then(clientv3.OpPut(key, spec.MarshalString(), clientv3.WithLease(grant.ID))).
+		Commit()
+
+	if err != nil {
+		t.Fatalf("failed to announce consumer: %s", err)
+	} else if !resp.Succeeded {
+		t.Fatalf("failed to announce consumer: key %s exists", key)
+	} else if err = ks.Load(ctx, etcd, resp.Header.Revision); err != nil {
+		t.Fatalf("failed to load KeySpace: %s", err)
+	}
+
+	go func() {
+		if err := ks.Watch(ctx, etcd); err != context.Canceled {
+			t.Errorf("unexpected KeySpace.Watch error: %v", err)
+		}
+	}()
+	go func() {
+		// As with brokertest.Broker, signal |idleCh| on the first idle TestHook
+		// callback which follows at least one non-idle callback.
+		var signalOnIdle bool
+
+		if err := allocator.Allocate(allocator.AllocateArgs{
+			Context: ctx,
+			Etcd:    etcd,
+			State:   state,
+			TestHook: func(_ int, isIdle bool) {
+				if !isIdle {
+					signalOnIdle = true
+				} else if signalOnIdle {
+					c.idleCh <- struct{}{}
+					signalOnIdle = false
+				}
+			},
+		}); err != nil && ctx.Err() == nil {
+			// Allocate fails with a missing member key once Stop revokes
+			// our lease, which is expected.
+			select {
+			case <-c.stopCh:
+			default:
+				t.Errorf("unexpected Allocate error: %v", err)
+			}
+		}
+		close(c.idleCh) // Signal Allocate has exited.
+	}()
+
+	return c
+}
+
+// SetTimeout sets the maximum duration for which the Consumer will wait
+// for an expected condition (eg, in WaitForOffset) before failing the test.
+func (c *Consumer) SetTimeout(d time.Duration) { c.timeout = d }
+
+// CreateJournals creates JournalSpecs on the embedded broker, and waits for
+// them to be assigned. brokertest.Journal is helpful for building JournalSpecs
+// with reasonable test defaults.
+func (c *Consumer) CreateJournals(specs ...*pb.JournalSpec) {
+	brokertest.CreateJournals(c.t, c.Broker, specs...)
+}
+
+// CreateShards creates ShardSpecs, and waits for each to be assigned to the
+// Consumer and for its Store to be recovered and ready. Shard recovery logs
+// and source journals must already exist.
+func (c *Consumer) CreateShards(specs ...*consumer.ShardSpec) {
+	var req = new(consumer.ApplyRequest)
+	for _, spec := range specs {
+		req.Changes = append(req.Changes, consumer.ApplyRequest_Change{Upsert: spec})
+	}
+
+	var resp, err = c.Service.Apply(c.ctx, req)
+	if err != nil {
+		c.t.Fatalf("failed to apply shards: %s", err)
+	} else if resp.Status != consumer.Status_OK {
+		c.t.Fatalf("failed to apply shards: %s", resp.Status)
+	}
+
+	// Wait for shard assignments to update.
+	select {
+	case <-c.idleCh:
+	case <-time.After(c.timeout):
+		c.t.Fatalf("timeout waiting for shards to be assigned")
+	}
+
+	for _, spec := range specs {
+		var res = c.resolve(spec.Id)
+		res.Done()
+	}
+	c.specs = append(c.specs, specs...)
+}
+
+// Publish Messages to the journal, using the Framing of its JournalSpec
+// "framing" label, and wait for them to commit. The journal offset through
+// which Messages were written is returned.
+func (c *Consumer) Publish(journal pb.Journal, msgs ...message.Message) int64 {
+	var resp, err = client.ListAll(c.ctx, c.Journals, pb.ListRequest{
+		Selector: pb.LabelSelector{Include: pb.MustLabelSet("name", journal.String())},
+	})
+	if err != nil {
+		c.t.Fatalf("failed to list journal %s: %s", journal, err)
+	} else if len(resp.Journals) != 1 {
+		c.t.Fatalf("journal %s not found", journal)
+	}
+
+	framing, err := message.JournalFraming(&resp.Journals[0].Spec)
+	if err != nil {
+		c.t.Fatalf("failed to determine framing of %s: %s", journal, err)
+	}
+	var mapping = func(message.Message) (pb.Journal, message.Framing, error) {
+		return journal, framing, nil
+	}
+
+	var aa *client.AsyncAppend
+	for _, msg := range msgs {
+		if aa, err = message.Publish(c.Journals, mapping, msg); err != nil {
+			c.t.Fatalf("failed to publish message: %s", err)
+		}
+	}
+	if aa == nil {
+		c.t.Fatalf("expected at least one message to publish")
+	}
+
+	select {
+	case <-aa.Done():
+	case <-time.After(c.timeout):
+		c.t.Fatalf("timeout waiting for publish to %s to commit", journal)
+	}
+	if err = aa.Err(); err != nil {
+		c.t.Fatalf("failed to publish to %s: %s", journal, err)
+	}
+	return aa.Response().Commit.End
+}
+
+// WaitForOffset waits until the shard has consumed and committed messages
+// of the journal through at least |offset|, as reflected by the offsets
+// of its Store.
+func (c *Consumer) WaitForOffset(shard consumer.ShardID, journal pb.Journal, offset int64) {
+	var ctx, cancel = context.WithTimeout(c.ctx, c.timeout)
+	defer cancel()
+
+	if err := c.app.waitForOffset(ctx, shard, journal, offset); err != nil {
+		c.t.Fatalf("waiting for shard %s to consume %s through %d: %s (at %d)",
+			shard, journal, offset, err, c.app.offset(shard, journal))
+	}
+}
+
+// WithStore invokes |fn| with the Store of the shard. |fn| is called between
+// consumer transactions of the shard, and may safely inspect the Store
+// (and state of the Application) without racing the shard's processing.
+func (c *Consumer) WithStore(shard consumer.ShardID, fn func(consumer.Store)) {
+	var res = c.resolve(shard)
+	defer res.Done()
+
+	var mu = c.app.txnMutex(shard)
+	mu.Lock()
+	defer mu.Unlock()
+
+	fn(res.Store)
+}
+
+// Stop the Consumer and its embedded broker, and clean up Etcd.
+func (c *Consumer) Stop() {
+	// Revoking our lease removes our ConsumerSpec and shard assignments,
+	// which cancels local shards and allows Allocate to exit.
+	close(c.stopCh)
+	if _, err := c.etcd.Revoke(context.Background(), c.lease); err != nil {
+		c.t.Fatalf("failed to revoke lease: %s", err)
+	}
+	for range c.idleCh { // |idleCh| is closed when Allocate completes.
+	}
+	c.app.waitForShardsDone()
+
+	c.cancel()
+	c.Broker.RevokeLease(c.t)
+	c.Broker.WaitForExit()
+
+	etcdtest.Cleanup()
+}
+
+// resolve the shard, which must be assigned to the Consumer as primary.
+func (c *Consumer) resolve(shard consumer.ShardID) consumer.Resolution {
+	var ctx, cancel = context.WithTimeout(c.ctx, c.timeout)
+	defer cancel()
+
+	var res, err = c.Service.Resolver.Resolve(consumer.ResolveArgs{
+		Context: ctx,
+		ShardID: shard,
+	})
+	if err != nil {
+		c.t.Fatalf("failed to resolve shard %s: %s", shard, err)
+	} else if res.Status != consumer.Status_OK {
+		c.t.Fatalf("failed to resolve shard %s: %s", shard, res.Status)
+	}
+	return res
+}
+
+// trackingApp wraps an Application to track the Store offsets of each shard
+// as transactions complete, and to serialize inspection of shard Stores
+// with transaction processing.
+type trackingApp struct {
+	consumer.Application
+
+	mu       sync.Mutex
+	offsets  map[consumer.ShardID]map[pb.Journal]int64
+	txnMu    map[consumer.ShardID]*sync.Mutex
+	shards   []consumer.Shard
+	changeCh chan struct{} // Closed and replaced on each |offsets| update.
+}
+
+func newTrackingApp(app consumer.Application) *trackingApp {
+	return &trackingApp{
+		Application: app,
+		offsets:     make(map[consumer.ShardID]map[pb.Journal]int64),
+		txnMu:       make(map[consumer.ShardID]*sync.Mutex),
+		changeCh:    make(chan struct{}),
+	}
+}
+
+func (a *trackingApp) NewStore(shard consumer.Shard, dir string, rec *recoverylog.Recorder) (consumer.Store, error) {
+	var store, err = a.Application.NewStore(shard, dir, rec)
+	if err == nil {
+		a.mu.Lock()
+		a.shards = append(a.shards, shard)
+		a.mu.Unlock()
+
+		a.track(shard, store)
+	}
+	return store, err
+}
+
+func (a *trackingApp) BeginTxn(shard consumer.Shard, store consumer.Store) error {
+	var mu = a.txnMutex(shard.Spec().Id)
+	mu.Lock()
+
+	if bf, ok := a.Application.(consumer.BeginFinisher); ok {
+		if err := bf.BeginTxn(shard, store); err != nil {
+			mu.Unlock() // FinishTxn is not called if BeginTxn fails.
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *trackingApp) FinishTxn(shard consumer.Shard, store consumer.Store) {
+	if bf, ok := a.Application.(consumer.BeginFinisher); ok {
+		bf.FinishTxn(shard, store)
+	}
+	a.track(shard, store)
+	a.txnMutex(shard.Spec().Id).Unlock()
+}
+
+// track the current offsets of the shard Store, and notify waiters.
+func (a *trackingApp) track(shard consumer.Shard, store consumer.Store) {
+	var offsets, err = store.FetchJournalOffsets()
+	if err != nil {
+		log.WithFields(log.Fields{"shard": shard.Spec().Id, "err": err}).
+			Warn("failed to fetch Store offsets")
+		return
+	}
+
+	a.mu.Lock()
+	a.offsets[shard.Spec().Id] = offsets
+	close(a.changeCh)
+	a.changeCh = make(chan struct{})
+	a.mu.Unlock()
+}
+
+func (a *trackingApp) txnMutex(shard consumer.ShardID) *sync.Mutex {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var mu, ok = a.txnMu[shard]
+	if !ok {
+		mu = new(sync.Mutex)
+		a.txnMu[shard] = mu
+	}
+	return mu
+}
+
+func (a *trackingApp) offset(shard consumer.ShardID, journal pb.Journal) int64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.offsets[shard][journal]
+}
+
+func (a *trackingApp) waitForOffset(ctx context.Context, shard consumer.ShardID, journal pb.Journal, offset int64) error {
+	for {
+		a.mu.Lock()
+		var cur, changeCh = a.offsets[shard][journal], a.changeCh
+		a.mu.Unlock()
+
+		if cur >= offset {
+			return nil
+		}
+		select {
+		case <-changeCh:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// waitForShardsDone waits for the Context of each created shard to be done.
+func (a *trackingApp) waitForShardsDone() {
+	a.mu.Lock()
+	var shards = a.shards
+	a.mu.Unlock()
+
+	for _, shard := range shards {
+		<-shard.Context().Done()
+	}
+}
+
+// defaultTimeout of waited-for conditions.
+const defaultTimeout = 30 * time.Second
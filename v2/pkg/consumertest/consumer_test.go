@@ -0,0 +1,78 @@
+package consumertest
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/LiveRamp/gazette/v2/pkg/brokertest"
+	"github.com/LiveRamp/gazette/v2/pkg/consumer"
+	"github.com/LiveRamp/gazette/v2/pkg/message"
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	"github.com/LiveRamp/gazette/v2/pkg/recoverylog"
+)
+
+func TestConsumeAndInspectStore(t *testing.T) {
+	var c = NewConsumer(t, testApp{})
+	defer c.Stop()
+
+	c.CreateJournals(
+		brokertest.Journal(pb.JournalSpec{Name: "recovery/log"}),
+		brokertest.Journal(pb.JournalSpec{Name: "source/A", LabelSet: pb.MustLabelSet("framing", "json")}),
+	)
+	c.CreateShards(&consumer.ShardSpec{
+		Id:             "a-shard",
+		Sources:        []consumer.ShardSpec_Source{{Journal: "source/A"}},
+		RecoveryLog:    "recovery/log",
+		HintKeys:       []string{"/hints"},
+		MaxTxnDuration: 10 * time.Millisecond,
+	})
+
+	var offset = c.Publish("source/A",
+		&testMessage{Key: "foo", Value: "1"},
+		&testMessage{Key: "bar", Value: "2"},
+		&testMessage{Key: "foo", Value: "3"},
+	)
+	c.WaitForOffset("a-shard", "source/A", offset)
+
+	c.WithStore("a-shard", func(store consumer.Store) {
+		var state = store.(*consumer.JSONFileStore).State.(map[string]string)
+
+		if expect := map[string]string{"foo": "3", "bar": "2"}; !reflect.DeepEqual(state, expect) {
+			t.Errorf("unexpected state: %v (expected %v)", state, expect)
+		}
+	})
+
+	// Publish further messages, and expect they're consumed as well.
+	offset = c.Publish("source/A", &testMessage{Key: "baz", Value: "4"})
+	c.WaitForOffset("a-shard", "source/A", offset)
+
+	c.WithStore("a-shard", func(store consumer.Store) {
+		var state = store.(*consumer.JSONFileStore).State.(map[string]string)
+
+		if state["baz"] != "4" {
+			t.Errorf("unexpected state: %v", state)
+		}
+	})
+}
+
+type testMessage struct {
+	Key, Value string
+}
+
+type testApp struct{}
+
+func (testApp) NewStore(shard consumer.Shard, dir string, rec *recoverylog.Recorder) (consumer.Store, error) {
+	var state = make(map[string]string)
+	return consumer.NewJSONFileStore(rec, dir, &state)
+}
+
+func (testApp) NewMessage(*pb.JournalSpec) (message.Message, error) { return new(testMessage), nil }
+
+func (testApp) ConsumeMessage(shard consumer.Shard, store consumer.Store, env message.Envelope) error {
+	var msg = env.Message.(*testMessage)
+	store.(*consumer.JSONFileStore).State.(map[string]string)[msg.Key] = msg.Value
+	return nil
+}
+
+func (testApp) FinalizeTxn(consumer.Shard, consumer.Store) error { return nil }
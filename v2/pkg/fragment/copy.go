@@ -0,0 +1,118 @@
+package fragment
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/LiveRamp/gazette/v2/pkg/codecs"
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+)
+
+// Copy the remote Fragment to |store|, encoding it with |codec|. Copied
+// content is verified against the SHA1 sum of the Fragment (which is encoded
+// in its content name), and Copy fails without persisting the Fragment if
+// content doesn't match. If the Fragment is already present in |store|,
+// Copy is a no-op. The Fragment as persisted to |store| is returned.
+func Copy(ctx context.Context, fragment pb.Fragment, store pb.FragmentStore, codec pb.CompressionCodec) (pb.Fragment, error) {
+	if fragment.ContentLength() == 0 {
+		return pb.Fragment{}, fmt.Errorf("cannot copy empty fragment %s", fragment.ContentName())
+	}
+	var rc, err = Open(ctx, fragment)
+	if err != nil {
+		return pb.Fragment{}, fmt.Errorf("opening fragment: %s", err)
+	}
+	defer rc.Close()
+
+	// Content of GZIP_OFFLOAD_DECOMPRESSION fragments is read as persisted,
+	// and must be decompressed client-side.
+	var decodeAs = fragment.CompressionCodec
+	if decodeAs == pb.CompressionCodec_GZIP_OFFLOAD_DECOMPRESSION {
+		decodeAs = pb.CompressionCodec_GZIP
+	}
+	decomp, err := codecs.NewCodecReader(rc, decodeAs)
+	if err != nil {
+		return pb.Fragment{}, fmt.Errorf("building decompressor: %s", err)
+	}
+	defer decomp.Close()
+
+	// Roll an empty Spool to the Fragment Begin offset.
+	var spool = NewSpool(fragment.Journal, nopSpoolObserver{})
+	spool.MustApply(&pb.ReplicateRequest{Proposal: &pb.Fragment{
+		Journal:          fragment.Journal,
+		Begin:            fragment.Begin,
+		End:              fragment.Begin,
+		CompressionCodec: codec,
+		BackingStore:     store,
+	}})
+	defer func() {
+		if spool.File != nil {
+			_ = spool.File.Close()
+		}
+		if spool.compressedFile != nil {
+			_ = spool.compressedFile.Close()
+		}
+	}()
+
+	var buf = make([]byte, copyBufferSize)
+	for delta := int64(0); true; {
+		var n int
+		n, err = decomp.Read(buf)
+
+		if n != 0 {
+			if delta+int64(n) > fragment.ContentLength() {
+				return pb.Fragment{}, fmt.Errorf("fragment content is longer than expected (%d)",
+					fragment.ContentLength())
+			} else if _, err := spool.Apply(&pb.ReplicateRequest{
+				Content:      buf[:n],
+				ContentDelta: delta,
+			}, true); err != nil {
+				return pb.Fragment{}, err
+			}
+			delta += int64(n)
+		}
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return pb.Fragment{}, fmt.Errorf("reading fragment: %s", err)
+		}
+	}
+
+	// Verify and commit the copied content.
+	var next = spool.Next()
+	if next.End != fragment.End || next.Sum != fragment.Sum {
+		return pb.Fragment{}, fmt.Errorf("copied content of fragment %s doesn't match (got End %d, Sum %x)",
+			fragment.ContentName(), next.End, next.Sum.ToDigest())
+	}
+	spool.MustApply(&pb.ReplicateRequest{Proposal: &next})
+
+	if err = Persist(ctx, spool); err != nil {
+		return pb.Fragment{}, fmt.Errorf("persisting fragment: %s", err)
+	}
+	return spool.Fragment.Fragment, nil
+}
+
+// Remove the Fragment from its BackingStore. Removing a Fragment which
+// doesn't exist is not an error.
+func Remove(ctx context.Context, fragment pb.Fragment) error {
+	var ep = fragment.BackingStore.URL()
+
+	switch ep.Scheme {
+	case "s3":
+		return s3Remove(ctx, ep, fragment)
+	case "gs":
+		return gcsRemove(ctx, ep, fragment)
+	case "file":
+		return fsRemove(ep, fragment)
+	default:
+		panic("unsupported scheme: " + ep.Scheme)
+	}
+}
+
+// nopSpoolObserver is a SpoolObserver which ignores Spool events.
+type nopSpoolObserver struct{}
+
+func (nopSpoolObserver) SpoolCommit(Fragment)          {}
+func (nopSpoolObserver) SpoolComplete(_ Spool, _ bool) {}
+
+const copyBufferSize = 1 << 15 // 32KB.
@@ -0,0 +1,124 @@
+package fragment
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/LiveRamp/gazette/v2/pkg/codecs"
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	gc "github.com/go-check/check"
+)
+
+type CopySuite struct{}
+
+func (s *CopySuite) TestCopyWithReencodingAndRemove(c *gc.C) {
+	defer setTestStoreRoot(c)()
+	var ctx = context.Background()
+
+	var content = "some fragment content\nwith multiple lines\n"
+	var src = writeTestFragment(c, "file:///source/", pb.CompressionCodec_GZIP, content, content)
+
+	// Case: copy and re-encode the fragment as SNAPPY.
+	var copied, err = Copy(ctx, src, "file:///dest/", pb.CompressionCodec_SNAPPY)
+	c.Check(err, gc.IsNil)
+
+	var expect = src
+	expect.CompressionCodec = pb.CompressionCodec_SNAPPY
+	expect.BackingStore = "file:///dest/"
+	c.Check(copied, gc.DeepEquals, expect)
+	c.Check(readTestFragment(c, copied), gc.Equals, content)
+
+	// Case: copying a fragment which is already present is a no-op.
+	copied, err = Copy(ctx, src, "file:///dest/", pb.CompressionCodec_SNAPPY)
+	c.Check(err, gc.IsNil)
+	c.Check(copied, gc.DeepEquals, expect)
+
+	// Case: remove the source fragment. Expect removing it again is a no-op.
+	c.Check(Remove(ctx, src), gc.IsNil)
+	exists, err := fsExists(src.BackingStore.URL(), src)
+	c.Check(err, gc.IsNil)
+	c.Check(exists, gc.Equals, false)
+	c.Check(Remove(ctx, src), gc.IsNil)
+}
+
+func (s *CopySuite) TestCopyVerifiesContent(c *gc.C) {
+	defer setTestStoreRoot(c)()
+	var ctx = context.Background()
+
+	// Case: content doesn't match the fragment's content name.
+	var src = writeTestFragment(c, "file:///source/", pb.CompressionCodec_NONE,
+		"expected content", "other content!!!")
+
+	var _, err = Copy(ctx, src, "file:///dest/", pb.CompressionCodec_NONE)
+	c.Check(err, gc.ErrorMatches, `copied content of fragment .* doesn't match .*`)
+
+	// Expect the fragment wasn't persisted.
+	var dest = src
+	dest.BackingStore = "file:///dest/"
+	exists, err := fsExists(dest.BackingStore.URL(), dest)
+	c.Check(err, gc.IsNil)
+	c.Check(exists, gc.Equals, false)
+
+	// Case: content is longer than the fragment.
+	src = writeTestFragment(c, "file:///source/", pb.CompressionCodec_NONE,
+		"short", "much longer content")
+
+	_, err = Copy(ctx, src, "file:///dest/", pb.CompressionCodec_NONE)
+	c.Check(err, gc.ErrorMatches, `fragment content is longer than expected \(5\)`)
+}
+
+// setTestStoreRoot sets FileSystemStoreRoot to a temporary directory, and
+// returns a func which restores it and removes the directory.
+func setTestStoreRoot(c *gc.C) func() {
+	var dir, err = ioutil.TempDir("", "fragment-copy")
+	c.Assert(err, gc.IsNil)
+
+	var prior = FileSystemStoreRoot
+	FileSystemStoreRoot = dir
+
+	return func() {
+		FileSystemStoreRoot = prior
+		os.RemoveAll(dir)
+	}
+}
+
+// writeTestFragment writes |actual| content to |store| under the content name
+// of a Fragment having |expect| content, and returns the Fragment.
+func writeTestFragment(c *gc.C, store pb.FragmentStore, codec pb.CompressionCodec, expect, actual string) pb.Fragment {
+	var frag = pb.Fragment{
+		Journal:          "a/journal",
+		Begin:            1000,
+		End:              1000 + int64(len(expect)),
+		Sum:              pb.SHA1SumOf(expect),
+		CompressionCodec: codec,
+		BackingStore:     store,
+	}
+	var buf bytes.Buffer
+	var w, err = codecs.NewCodecWriter(&buf, codec)
+	c.Assert(err, gc.IsNil)
+	_, _ = w.Write([]byte(actual))
+	c.Assert(w.Close(), gc.IsNil)
+
+	var path = filepath.Join(FileSystemStoreRoot, filepath.FromSlash(store.URL().Path+frag.ContentPath()))
+	c.Assert(os.MkdirAll(filepath.Dir(path), 0750), gc.IsNil)
+	c.Assert(ioutil.WriteFile(path, buf.Bytes(), 0640), gc.IsNil)
+
+	return frag
+}
+
+func readTestFragment(c *gc.C, frag pb.Fragment) string {
+	var rc, err = Open(context.Background(), frag)
+	c.Assert(err, gc.IsNil)
+	defer rc.Close()
+
+	dec, err := codecs.NewCodecReader(rc, frag.CompressionCodec)
+	c.Assert(err, gc.IsNil)
+	b, err := ioutil.ReadAll(dec)
+	c.Assert(err, gc.IsNil)
+	return string(b)
+}
+
+var _ = gc.Suite(&CopySuite{})
@@ -5,4 +5,5 @@
 //  * Indexing local and remote Fragments (see Index).
 //  * The construction of new Fragments from a replication stream (see Spool).
 //  * The persisting of constructed Fragments to remote stores (see Persister).
+//  * The copying of Fragments between remote stores (see Copy).
 package fragment
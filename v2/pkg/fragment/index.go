@@ -6,6 +6,7 @@ import (
 	"time"
 
 	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	"github.com/LiveRamp/gazette/v2/pkg/tracing"
 	"golang.org/x/net/trace"
 )
 
@@ -22,7 +23,8 @@ type Index struct {
 	local          CoverSet        // Local Fragments only (having non-nil File).
 	condCh         chan struct{}   // Condition variable; notifies blocked queries on each |set| update.
 	firstRefreshCh chan struct{}   // Closed when the first remote index load has completed.
-	mu             sync.RWMutex    // Guards |set| and |condCh|.
+	refreshTime    time.Time       // Time of the last remote index load.
+	mu             sync.RWMutex    // Guards |set|, |local|, |condCh|, and |refreshTime|.
 }
 
 // NewIndex returns a new, empty Index.
@@ -121,6 +123,19 @@ func (fi *Index) EndOffset() int64 {
 	return fi.set.EndOffset()
 }
 
+// Summary of the Index, returning the number of all Fragments and of local
+// Fragments, the begin and end offsets spanned by all Fragments, and the time
+// of the last remote Fragment refresh (or zero, if none has completed).
+func (fi *Index) Summary() (fragments, local int, begin, end int64, refreshTime time.Time) {
+	defer fi.mu.RUnlock()
+	fi.mu.RLock()
+
+	if len(fi.set) != 0 {
+		begin = fi.set[0].Begin
+	}
+	return len(fi.set), len(fi.local), begin, fi.set.EndOffset(), fi.refreshTime
+}
+
 // SpoolCommit adds local Spool Fragment |frag| to the index.
 func (fi *Index) SpoolCommit(frag Fragment) {
 	defer fi.mu.Unlock()
@@ -155,6 +170,7 @@ func (fi *Index) ReplaceRemote(set CoverSet) {
 	}
 
 	fi.set = set
+	fi.refreshTime = timeNow()
 	fi.wakeBlockedQueries()
 
 	select {
@@ -196,6 +212,17 @@ func (fi *Index) WaitForFirstRemoteRefresh(ctx context.Context) error {
 	}
 }
 
+// FirstRemoteRefreshed returns whether ReplaceRemote has been called at least
+// one time. It's the non-blocking equivalent of WaitForFirstRemoteRefresh.
+func (fi *Index) FirstRemoteRefreshed() bool {
+	select {
+	case <-fi.firstRefreshCh:
+		return true
+	default:
+		return false
+	}
+}
+
 // WalkAllStores enumerates Fragments from each of |stores| into the returned
 // CoverSet, or returns an encountered error.
 func WalkAllStores(ctx context.Context, name pb.Journal, stores []pb.FragmentStore) (CoverSet, error) {
@@ -219,4 +246,5 @@ func addTrace(ctx context.Context, format string, args ...interface{}) {
 	if tr, ok := trace.FromContext(ctx); ok {
 		tr.LazyPrintf(format, args...)
 	}
+	tracing.FromContext(ctx).AddEvent(format, args...)
 }
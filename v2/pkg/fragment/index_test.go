@@ -49,6 +49,11 @@ func (s *IndexSuite) TestRemoteReplacesLocal(c *gc.C) {
 	set[0].File = os.Stdin
 	ind.SpoolCommit(set[0])
 
+	var fragments, local, begin, end, refreshTime = ind.Summary()
+	c.Check([]int{fragments, local}, gc.DeepEquals, []int{1, 1})
+	c.Check([]int64{begin, end}, gc.DeepEquals, []int64{100, 200})
+	c.Check(refreshTime.IsZero(), gc.Equals, true)
+
 	// Precondition: local fragment is queryable.
 	var resp, file, err = ind.Query(context.Background(), &pb.ReadRequest{Offset: 110, Block: true})
 	c.Check(resp, gc.DeepEquals, &pb.ReadResponse{
@@ -63,8 +68,17 @@ func (s *IndexSuite) TestRemoteReplacesLocal(c *gc.C) {
 	// return the longest overlapping fragment, but as we've removed local
 	// fragments covered by remote ones, we should see remote fragments only.
 	set = buildSet(c, 100, 150, 150, 200)
+
+	defer func() { timeNow = time.Now }()
+	timeNow = func() time.Time { return time.Unix(1500000000, 0) }
 	ind.ReplaceRemote(set)
 
+	// Expect the summary reflects remote fragments and the refresh time.
+	fragments, local, begin, end, refreshTime = ind.Summary()
+	c.Check([]int{fragments, local}, gc.DeepEquals, []int{2, 0})
+	c.Check([]int64{begin, end}, gc.DeepEquals, []int64{100, 200})
+	c.Check(refreshTime, gc.Equals, time.Unix(1500000000, 0))
+
 	resp, file, err = ind.Query(context.Background(), &pb.ReadRequest{Offset: 110, Block: true})
 	c.Check(resp, gc.DeepEquals, &pb.ReadResponse{
 		Offset:    110,
@@ -208,6 +222,7 @@ func (s *IndexSuite) TestWalkStoresAndURLSigning(c *gc.C) {
 	var ctx = context.Background()
 	var ind = NewIndex(ctx)
 	var set CoverSet
+	c.Check(ind.FirstRemoteRefreshed(), gc.Equals, false)
 
 	set, err = WalkAllStores(ctx, "a/journal", []pb.FragmentStore{
 		pb.FragmentStore("file:///path/does/not/exist/"),
@@ -224,6 +239,7 @@ func (s *IndexSuite) TestWalkStoresAndURLSigning(c *gc.C) {
 
 	// Expect first remote load has completed.
 	c.Check(ind.WaitForFirstRemoteRefresh(context.Background()), gc.IsNil)
+	c.Check(ind.FirstRemoteRefreshed(), gc.Equals, true)
 
 	c.Check(ind.set, gc.HasLen, 3)
 	c.Check(ind.EndOffset(), gc.Equals, int64(0x255))
@@ -25,7 +25,7 @@ func (p *Persister) SpoolComplete(spool Spool, primary bool) {
 	} else if primary {
 		// Attempt to immediately persist the Spool.
 		go func() {
-			if err := Persist(context.Background(), spool); err != nil {
+			if err := persist(spool); err != nil {
 				log.WithField("err", err).Warn("failed to persist Spool")
 				p.queue(spool)
 			}
@@ -36,6 +36,21 @@ func (p *Persister) SpoolComplete(spool Spool, primary bool) {
 	return
 }
 
+// Backlog returns the number of Spools queued for persistence, and their
+// total content length. Spools being immediately persisted on completion
+// by the primary are not queued, and are not included.
+func (p *Persister) Backlog() (spools int, bytes int64) {
+	defer p.mu.Unlock()
+	p.mu.Lock()
+
+	for _, q := range [][]Spool{p.qA, p.qB, p.qC} {
+		for _, spool := range q {
+			spools, bytes = spools+1, bytes+spool.ContentLength()
+		}
+	}
+	return
+}
+
 func (p *Persister) Finish() {
 	p.doneCh <- struct{}{}
 	<-p.doneCh
@@ -60,7 +75,7 @@ func (p *Persister) Serve() {
 		}
 
 		for _, spool := range p.qA {
-			if err := Persist(context.Background(), spool); err != nil {
+			if err := persist(spool); err != nil {
 				log.WithField("err", err).Warn("failed to persist Spool")
 				p.queue(spool)
 			}
@@ -77,3 +92,23 @@ func (p *Persister) Serve() {
 	}
 	close(p.doneCh)
 }
+
+// PersistObserver is an optional interface of a SpoolObserver, which is
+// notified by the Persister of each Spool it persists.
+type PersistObserver interface {
+	// SpoolPersisted is called with a Spool which was persisted, and the
+	// duration of the successful Persist.
+	SpoolPersisted(_ Spool, took time.Duration)
+}
+
+// persist the Spool, notifying its observer if it's a PersistObserver.
+func persist(spool Spool) error {
+	var start = time.Now()
+
+	if err := Persist(context.Background(), spool); err != nil {
+		return err
+	} else if o, ok := spool.observer.(PersistObserver); ok {
+		o.SpoolPersisted(spool, time.Since(start))
+	}
+	return nil
+}
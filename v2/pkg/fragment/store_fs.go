@@ -37,6 +37,15 @@ func fsOpen(ep *url.URL, fragment pb.Fragment) (io.ReadCloser, error) {
 	return f, err
 }
 
+func fsRemove(ep *url.URL, fragment pb.Fragment) error {
+	var path = filepath.Join(FileSystemStoreRoot, filepath.FromSlash(ep.Path+fragment.ContentPath()))
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
 func fsPersist(ep *url.URL, spool Spool) error {
 	var path = filepath.Join(FileSystemStoreRoot, filepath.FromSlash(ep.Path+spool.ContentPath()))
 
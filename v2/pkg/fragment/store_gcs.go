@@ -54,6 +54,18 @@ func gcsOpen(ctx context.Context, ep *url.URL, fragment pb.Fragment) (io.ReadClo
 	return client.Bucket(cfg.bucket).Object(cfg.prefix + fragment.ContentPath()).NewReader(ctx)
 }
 
+func gcsRemove(ctx context.Context, ep *url.URL, fragment pb.Fragment) error {
+	cfg, client, _, err := gcsClient(ep)
+	if err != nil {
+		return err
+	}
+	err = client.Bucket(cfg.bucket).Object(cfg.prefix + fragment.ContentPath()).Delete(ctx)
+	if err == storage.ErrObjectNotExist {
+		err = nil
+	}
+	return err
+}
+
 func gcsPersist(ctx context.Context, ep *url.URL, spool Spool) error {
 	cfg, client, _, err := gcsClient(ep)
 	if err != nil {
@@ -89,6 +89,19 @@ func s3Open(ctx context.Context, ep *url.URL, fragment pb.Fragment) (io.ReadClos
 	}
 }
 
+func s3Remove(ctx context.Context, ep *url.URL, fragment pb.Fragment) error {
+	var cfg, client, err = s3Client(ep)
+	if err != nil {
+		return err
+	}
+	var deleteObj = s3.DeleteObjectInput{
+		Bucket: aws.String(cfg.bucket),
+		Key:    aws.String(cfg.prefix + fragment.ContentPath()),
+	}
+	_, err = client.DeleteObjectWithContext(ctx, &deleteObj)
+	return err
+}
+
 func s3Persist(ctx context.Context, ep *url.URL, spool Spool) error {
 	var cfg, client, err = s3Client(ep)
 	if err != nil {
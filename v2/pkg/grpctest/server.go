@@ -5,6 +5,7 @@ import (
 	"net"
 
 	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	"github.com/LiveRamp/gazette/v2/pkg/tracing"
 	"google.golang.org/grpc"
 )
 
@@ -24,16 +25,17 @@ func NewServer(ctx context.Context) Server {
 		panic(err)
 	}
 
-	conn, err := grpc.DialContext(ctx, l.Addr().String(),
+	conn, err := grpc.DialContext(ctx, l.Addr().String(), append([]grpc.DialOption{
 		grpc.WithInsecure(),
-		grpc.WithBalancerName(pb.DispatcherGRPCBalancerName))
+		grpc.WithBalancerName(pb.DispatcherGRPCBalancerName),
+	}, tracing.DialOptions()...)...)
 
 	if err != nil {
 		panic(err)
 	}
 
 	var p = Server{
-		Server:   grpc.NewServer(),
+		Server:   grpc.NewServer(tracing.ServerOptions()...),
 		Ctx:      ctx,
 		Conn:     conn,
 		listener: l,
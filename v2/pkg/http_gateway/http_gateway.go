@@ -11,7 +11,9 @@ import (
 	"net/url"
 	"path"
 	"strconv"
+	"strings"
 
+	"github.com/LiveRamp/gazette/v2/pkg/auth"
 	"github.com/LiveRamp/gazette/v2/pkg/client"
 	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
 	"github.com/gogo/protobuf/proto"
@@ -55,7 +57,7 @@ func (h *Gateway) serveRead(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var reader = client.NewReader(r.Context(), h.client, req)
+	var reader = client.NewReader(withAuthorization(r), h.client, req)
 	if _, err = reader.Read(nil); err == client.ErrOffsetJump {
 		// Swallow this error, as the client is notified via the Content-Range
 		// header and we can continue the read. Any future jump after this one
@@ -99,7 +101,7 @@ func (h *Gateway) serveWrite(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var appender = client.NewAppender(r.Context(), h.client, req)
+	var appender = client.NewAppender(withAuthorization(r), h.client, req)
 	if _, err = io.Copy(appender, r.Body); err == nil {
 		err = appender.Close()
 	}
@@ -185,6 +187,8 @@ func writeReadResponse(w http.ResponseWriter, r *http.Request, resp pb.ReadRespo
 		http.Error(w, resp.Status.String(), http.StatusServiceUnavailable) // 503.
 	case pb.Status_OFFSET_NOT_YET_AVAILABLE:
 		http.Error(w, resp.Status.String(), http.StatusRequestedRangeNotSatisfiable) // 416.
+	case pb.Status_NOT_ALLOWED:
+		http.Error(w, resp.Status.String(), http.StatusForbidden) // 403.
 	default:
 		http.Error(w, resp.Status.String(), http.StatusInternalServerError) // 500.
 	}
@@ -227,6 +231,17 @@ func writeHeader(w http.ResponseWriter, r *http.Request, h *pb.Header) {
 	}
 }
 
+// withAuthorization returns the Request Context, with a bearer token of the
+// Request's Authorization header (if any) attached for forwarding to brokers.
+func withAuthorization(r *http.Request) context.Context {
+	var h = r.Header.Get("Authorization")
+
+	if strings.HasPrefix(h, "Bearer ") {
+		return auth.WithBearerToken(r.Context(), h[len("Bearer "):])
+	}
+	return r.Context()
+}
+
 type flushWriter struct{ io.Writer }
 
 func (fw flushWriter) Write(p []byte) (n int, err error) {
@@ -13,6 +13,7 @@ import (
 	"github.com/coreos/etcd/clientv3"
 	"github.com/coreos/etcd/clientv3/mirror"
 	"github.com/coreos/etcd/etcdserver/etcdserverpb"
+	"github.com/coreos/etcd/mvcc/mvccpb"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -117,6 +118,36 @@ func (ks *KeySpace) Load(ctx context.Context, client *clientv3.Client, rev int64
 	return nil
 }
 
+// LoadSnapshot loads the KeySpace from a snapshot of prefixed key/values and
+// their Etcd ResponseHeader, as might be returned by a prefixed Etcd Get and
+// preserved in a file (eg, `etcdctl get --prefix -w json`). It's an alternative
+// to Load for clients which obtain the KeySpace through other means. Keys
+// outside of the KeySpace Root are ignored.
+func (ks *KeySpace) LoadSnapshot(header etcdserverpb.ResponseHeader, kvs []*mvccpb.KeyValue) error {
+	defer ks.Mu.Unlock()
+	ks.Mu.Lock()
+
+	kvs = append([]*mvccpb.KeyValue(nil), kvs...)
+	sort.Slice(kvs, func(i, j int) bool { return bytes.Compare(kvs[i].Key, kvs[j].Key) < 0 })
+
+	ks.Header, ks.KeyValues = header, ks.KeyValues[:0]
+
+	for i, kv := range kvs {
+		if !bytes.HasPrefix(kv.Key, []byte(ks.Root)) {
+			continue
+		} else if i != 0 && bytes.Equal(kvs[i-1].Key, kv.Key) {
+			return fmt.Errorf("duplicate key in snapshot: %s", kv.Key)
+		}
+		var err error
+		if ks.KeyValues, err = appendKeyValue(ks.KeyValues, ks.decode, kv); err != nil {
+			log.WithFields(log.Fields{"key": string(kv.Key), "err": err}).
+				Error("key/value decode failed while loading snapshot")
+		}
+	}
+	ks.onUpdate()
+	return nil
+}
+
 // Watch a loaded KeySpace and apply updates as they are received.
 func (ks *KeySpace) Watch(ctx context.Context, client clientv3.Watcher) error {
 	var watchCh clientv3.WatchChan
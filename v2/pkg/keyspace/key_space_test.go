@@ -8,6 +8,7 @@ import (
 	"github.com/LiveRamp/gazette/v2/pkg/etcdtest"
 	"github.com/coreos/etcd/clientv3"
 	epb "github.com/coreos/etcd/etcdserver/etcdserverpb"
+	"github.com/coreos/etcd/mvcc/mvccpb"
 	gc "github.com/go-check/check"
 )
 
@@ -100,6 +101,32 @@ func (s *KeySpaceSuite) TestHeaderPatching(c *gc.C) {
 		`etcd ClusterID mismatch \(expected 8675309, got 1337\)`)
 }
 
+func (s *KeySpaceSuite) TestLoadSnapshot(c *gc.C) {
+	var ks = NewKeySpace("/root", testDecoder)
+
+	var observed int
+	ks.Observers = append(ks.Observers, func() { observed++ })
+
+	var hdr = epb.ResponseHeader{ClusterId: 9999, Revision: 10}
+	var kvs = []*mvccpb.KeyValue{
+		{Key: []byte("/root/two"), Value: []byte("2"), CreateRevision: 3, ModRevision: 4, Version: 2},
+		{Key: []byte("/other"), Value: []byte("99"), CreateRevision: 5, ModRevision: 5, Version: 1},
+		{Key: []byte("/root/one"), Value: []byte("1"), CreateRevision: 2, ModRevision: 2, Version: 1},
+		{Key: []byte("/root/bad"), Value: []byte("invalid value is logged and skipped")},
+	}
+	c.Check(ks.LoadSnapshot(hdr, kvs), gc.IsNil)
+	c.Check(ks.Header, gc.Equals, hdr)
+	c.Check(observed, gc.Equals, 1)
+	verifyDecodedKeyValues(c, ks.KeyValues, map[string]int{"/root/one": 1, "/root/two": 2})
+
+	// Snapshot ordering of |kvs| is unmodified.
+	c.Check(string(kvs[0].Key), gc.Equals, "/root/two")
+
+	// Duplicated keys are an error.
+	kvs = append(kvs, &mvccpb.KeyValue{Key: []byte("/root/one"), Value: []byte("3")})
+	c.Check(ks.LoadSnapshot(hdr, kvs), gc.ErrorMatches, `duplicate key in snapshot: /root/one`)
+}
+
 func (s *KeySpaceSuite) TestWatchResponseApply(c *gc.C) {
 	var ks = NewKeySpace("/", testDecoder)
 
@@ -2,8 +2,11 @@ package mainboilerplate
 
 import (
 	"context"
+	"crypto/tls"
+	"fmt"
 	"time"
 
+	"github.com/LiveRamp/gazette/v2/pkg/auth"
 	"github.com/LiveRamp/gazette/v2/pkg/client"
 	"github.com/LiveRamp/gazette/v2/pkg/consumer"
 	"github.com/LiveRamp/gazette/v2/pkg/keepalive"
@@ -14,14 +17,37 @@ import (
 // AddressConfig of a remote service.
 type AddressConfig struct {
 	Address pb.Endpoint `long:"address" env:"ADDRESS" default:"http://localhost:8080" description:"Service address endpoint"`
+
+	CertFile      string `long:"cert-file" env:"CERT_FILE" description:"Path to the PEM-encoded client TLS certificate, presented to https:// services requiring mutual TLS"`
+	CertKeyFile   string `long:"cert-key-file" env:"CERT_KEY_FILE" description:"Path to the PEM-encoded private key of the client certificate"`
+	TrustedCAFile string `long:"trusted-ca-file" env:"TRUSTED_CA_FILE" description:"Path to a PEM-encoded bundle of CA certificates trusted to verify https:// services. If not set, system roots are used"`
+
+	AuthToken         string `long:"auth-token" env:"AUTH_TOKEN" description:"Bearer token presented to services which authenticate callers"`
+	AllowInsecureAuth bool   `long:"allow-insecure-auth" env:"ALLOW_INSECURE_AUTH" description:"Allow the AuthToken to be presented to http:// services. Otherwise, it's presented only over TLS"`
 }
 
-// Dial the server address using a protocol.Dispatcher balancer.
+// Dial the server address using a protocol.Dispatcher balancer. If the
+// address is an https:// Endpoint, TLS is used. If an AuthToken is
+// configured, it's presented with each RPC. Dial fails if the AuthToken
+// would be presented without TLS, unless AllowInsecureAuth is set.
 func (c *AddressConfig) Dial(ctx context.Context) *grpc.ClientConn {
-	var cc, err = grpc.DialContext(ctx, c.Address.URL().Host,
-		grpc.WithInsecure(),
-		grpc.WithDialer(keepalive.DialerFunc),
-		grpc.WithBalancerName(pb.DispatcherGRPCBalancerName))
+	var tlsConfig *tls.Config
+	var err error
+
+	if c.Address.URL().Scheme == "https" {
+		tlsConfig, err = BuildTLSConfig(c.CertFile, c.CertKeyFile, c.TrustedCAFile)
+		Must(err, "failed to build client TLS config")
+	}
+	var opts = pb.DispatcherDialOptions(tlsConfig, keepalive.DialerFunc)
+	if c.AuthToken != "" {
+		if tlsConfig == nil && !c.AllowInsecureAuth {
+			Must(fmt.Errorf("AuthToken requires an https:// address, or --allow-insecure-auth"),
+				"refusing to present AuthToken without TLS", "endpoint", c.Address)
+		}
+		opts = append(opts, grpc.WithPerRPCCredentials(
+			auth.NewBearerCredentials(c.AuthToken, c.AllowInsecureAuth)))
+	}
+	cc, err := grpc.DialContext(ctx, c.Address.URL().Host, opts...)
 	Must(err, "failed to dial remote service", "endpoint", c.Address)
 
 	return cc
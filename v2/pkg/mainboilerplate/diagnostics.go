@@ -1,12 +1,16 @@
 package mainboilerplate
 
 import (
+	"context"
 	_ "expvar" // Import for /debug/vars
 	"fmt"
 	"net/http"
 	_ "net/http/pprof" // Import for /debug/pprof
 	"os"
+	"path/filepath"
+	"time"
 
+	"github.com/LiveRamp/gazette/v2/pkg/tracing"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
@@ -14,12 +18,15 @@ import (
 
 // DiagnosticsConfig configures pull-based application metrics, debugging and diagnostics.
 type DiagnosticsConfig struct {
-	// Nothing to see here (yet).
+	OTLPEndpoint string `long:"otlp-endpoint" env:"OTLP_ENDPOINT" description:"Endpoint of an OpenTelemetry collector (eg, http://localhost:4318) to which trace spans are exported using OTLP/HTTP. If empty, spans aren't recorded"`
 }
 
 // InitDiagnosticsAndRecover enables serving of metrics and debugging services
-// registered on the default HTTPMux. It also returns a closure which should be
-// deferred, which recover a panic and attempt to log a K8s termination message.
+// registered on the default HTTPMux, and exports trace spans to a configured
+// OpenTelemetry collector. It also returns a closure which should be
+// deferred, which flushes queued trace spans (waiting up to
+// otlpShutdownTimeout), and recovers a panic and attempts to log a K8s
+// termination message.
 func InitDiagnosticsAndRecover(cfg DiagnosticsConfig) func() {
 	grpc.EnableTracing = true
 
@@ -33,8 +40,30 @@ func InitDiagnosticsAndRecover(cfg DiagnosticsConfig) func() {
 	// Serve Prometheus metrics at /debug/metrics.
 	http.Handle("/debug/metrics", promhttp.Handler())
 
+	// The exporter runs until the returned closure cancels its Context.
+	var ctx, cancel = context.WithCancel(context.Background())
+	var exporter *tracing.OTLPExporter
+
+	if cfg.OTLPEndpoint != "" {
+		exporter = tracing.NewOTLPExporter(ctx, cfg.OTLPEndpoint, filepath.Base(os.Args[0]))
+		tracing.SetExporter(exporter)
+	}
+
 	return func() {
-		if r := recover(); r != nil {
+		var r = recover()
+
+		cancel()
+		if exporter != nil {
+			tracing.SetExporter(nil)
+
+			select {
+			case <-exporter.Done():
+			case <-time.After(otlpShutdownTimeout):
+				log.WithField("timeout", otlpShutdownTimeout).Warn("timed out flushing trace spans")
+			}
+		}
+
+		if r != nil {
 			// Make a best effort attempt to write a termination message.
 			// Bug: https://github.com/kubernetes/kubernetes/issues/31839
 			if f, err := os.OpenFile(k8sTerminationLog, os.O_WRONLY, 0777); err == nil {
@@ -65,4 +94,7 @@ const (
 	//
 	// Link: https://kubernetes.io/docs/tasks/debug-application-cluster/determine-reason-pod-failure/#setting-the-termination-log-file
 	k8sTerminationLog = "/dev/termination-log"
+	// otlpShutdownTimeout bounds the time spent flushing queued trace spans
+	// as the process exits.
+	otlpShutdownTimeout = 5 * time.Second
 )
@@ -0,0 +1,95 @@
+package mainboilerplate
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Health evaluates readiness checks of the process, and serves the result
+// through the standard grpc.health.v1 service and HTTP /healthz (liveness)
+// and /readyz (readiness) endpoints.
+type Health struct {
+	server *health.Server
+
+	mu     sync.Mutex
+	checks []readinessCheck
+}
+
+type readinessCheck struct {
+	name  string
+	check func() error
+}
+
+// NewHealth returns a Health having no readiness checks.
+func NewHealth() *Health {
+	return &Health{server: health.NewServer()}
+}
+
+// AddReadinessCheck adds a named |check| which returns a non-nil error
+// while the process isn't ready.
+func (h *Health) AddReadinessCheck(name string, check func() error) {
+	h.mu.Lock()
+	h.checks = append(h.checks, readinessCheck{name: name, check: check})
+	h.mu.Unlock()
+}
+
+// Ready evaluates readiness checks, returning an error of the first check
+// which fails, or nil if all pass.
+func (h *Health) Ready() error {
+	h.mu.Lock()
+	var checks = h.checks
+	h.mu.Unlock()
+
+	for _, c := range checks {
+		if err := c.check(); err != nil {
+			return fmt.Errorf("%s: %s", c.name, err)
+		}
+	}
+	return nil
+}
+
+// register the Health gRPC service and HTTP endpoints with the ServerContext.
+func (h *Health) register(srv *ServerContext) {
+	healthpb.RegisterHealthServer(srv.GRPCServer, h.server)
+
+	srv.HTTPMux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	srv.HTTPMux.HandleFunc("/readyz", func(w http.ResponseWriter, _ *http.Request) {
+		if err := h.Ready(); err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+// serve periodically evaluates readiness checks, and updates the serving
+// status of the gRPC health service, until the Context is cancelled.
+func (h *Health) serve(ctx context.Context) {
+	var ticker = time.NewTicker(healthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		var status = healthpb.HealthCheckResponse_SERVING
+		if h.Ready() != nil {
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+		h.server.SetServingStatus("", status)
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			h.server.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+			return
+		}
+	}
+}
+
+var healthCheckInterval = time.Second
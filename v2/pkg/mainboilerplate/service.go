@@ -2,17 +2,23 @@ package mainboilerplate
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
+	"io/ioutil"
 	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"sort"
+	"strings"
 	"syscall"
 
 	"github.com/LiveRamp/gazette/v2/pkg/allocator"
+	"github.com/LiveRamp/gazette/v2/pkg/auth"
 	"github.com/LiveRamp/gazette/v2/pkg/keepalive"
 	"github.com/LiveRamp/gazette/v2/pkg/keyspace"
 	"github.com/LiveRamp/gazette/v2/pkg/protocol"
+	"github.com/LiveRamp/gazette/v2/pkg/tracing"
 	log "github.com/sirupsen/logrus"
 	"github.com/soheilhy/cmux"
 	"google.golang.org/grpc"
@@ -23,20 +29,89 @@ type ZoneConfig struct {
 	Zone string `long:"zone" env:"ZONE" default:"local" description:"Availability zone within which this process is running"`
 }
 
+// AllocatorConfig configures the allocator of the process, which is used
+// only while the process is allocator leader.
+type AllocatorConfig struct {
+	MaxPendingMoves int `long:"max-pending-moves" env:"MAX_PENDING_MOVES" default:"0" description:"Maximum number of items which may be moving between members at once, or zero for no limit"`
+}
+
 // ServiceConfig represents identification and addressing configuration of the process.
 type ServiceConfig struct {
 	ZoneConfig
 	ID   string `long:"id" env:"ID" default:"localhost" description:"Unique ID of the process"`
 	Host string `long:"host" env:"HOST" default:"localhost" description:"Addressable, advertised hostname of this process"`
 	Port uint16 `long:"port" env:"PORT" default:"8080" description:"Service port for HTTP and gRPC requests"`
+
+	Labels []string `long:"label" env:"LABELS" env-delim:"," description:"Label of the process as name=value, which may be matched by item selectors to constrain item assignments (may be repeated)"`
+
+	ServerCertFile    string `long:"server-cert-file" env:"SERVER_CERT_FILE" description:"Path to the PEM-encoded TLS certificate of the server. If set, the service is served over TLS and advertised as https://. The certificate is also presented to peers"`
+	ServerCertKeyFile string `long:"server-cert-key-file" env:"SERVER_CERT_KEY_FILE" description:"Path to the PEM-encoded private key of the server certificate"`
+	ServerCAFile      string `long:"server-ca-file" env:"SERVER_CA_FILE" description:"Path to a PEM-encoded bundle of trusted CA certificates. If set, clients and peers must present a certificate verified by it (mutual TLS)"`
+
+	AuthKeyFile       string `long:"auth-key-file" env:"AUTH_KEY_FILE" description:"Path to a key which verifies HS256-signed bearer tokens of callers. If set, callers must present a token, and are authorized by its grants"`
+	AllowInsecureAuth bool   `long:"allow-insecure-auth" env:"ALLOW_INSECURE_AUTH" description:"Allow the process to present its peer token to peers over http://, if no server certificate is configured"`
 }
 
 // ProcessSpec of the ServiceConfig.
 func (cfg ServiceConfig) ProcessSpec() protocol.ProcessSpec {
+	var scheme = "http"
+	if cfg.ServerCertFile != "" {
+		scheme = "https"
+	}
+	var labels protocol.LabelSet
+	for _, l := range cfg.Labels {
+		var ind = strings.IndexByte(l, '=')
+		if ind == -1 {
+			Must(fmt.Errorf("expected name=value"), "failed to parse label", "label", l)
+		}
+		labels.Labels = append(labels.Labels, protocol.Label{Name: l[:ind], Value: l[ind+1:]})
+	}
+	sort.Slice(labels.Labels, func(i, j int) bool {
+		var li, lj = labels.Labels[i], labels.Labels[j]
+		return li.Name < lj.Name || (li.Name == lj.Name && li.Value < lj.Value)
+	})
+	Must(labels.Validate(), "invalid labels", "labels", cfg.Labels)
+
 	return protocol.ProcessSpec{
 		Id:       protocol.ProcessSpec_ID{Zone: cfg.Zone, Suffix: cfg.ID},
-		Endpoint: protocol.Endpoint(fmt.Sprintf("http://%s:%d", cfg.Host, cfg.Port)),
+		Endpoint: protocol.Endpoint(fmt.Sprintf("%s://%s:%d", scheme, cfg.Host, cfg.Port)),
+		Labels:   labels,
+	}
+}
+
+// Authenticator of the ServiceConfig, or nil if callers are not authenticated.
+func (cfg ServiceConfig) Authenticator() auth.Authenticator {
+	if cfg.AuthKeyFile == "" {
+		return nil
 	}
+	return auth.NewKeyedAuthenticator(cfg.authKey())
+}
+
+// PeerToken of the ServiceConfig, which is presented by the process to its
+// peers and grants Append over all items. Peers share the AuthKeyFile, and
+// verify the token as they would any other caller's. If callers are not
+// authenticated, PeerToken is empty. As peers are dialed using TLS only if a
+// ServerCertFile is configured, PeerToken otherwise requires AllowInsecureAuth.
+func (cfg ServiceConfig) PeerToken() string {
+	if cfg.AuthKeyFile == "" {
+		return ""
+	} else if cfg.ServerCertFile == "" && !cfg.AllowInsecureAuth {
+		Must(fmt.Errorf("peer token requires a server certificate, or --allow-insecure-auth"),
+			"refusing to present peer token without TLS")
+	}
+	var token, err = auth.NewToken(auth.Claims{
+		Grants: []auth.Grant{{Capabilities: []auth.Capability{auth.Append}}},
+	}, cfg.authKey())
+	Must(err, "failed to build peer token")
+
+	return token
+}
+
+func (cfg ServiceConfig) authKey() []byte {
+	var key, err = ioutil.ReadFile(cfg.AuthKeyFile)
+	Must(err, "failed to read auth key file", "path", cfg.AuthKeyFile)
+
+	return key
 }
 
 // MemberKey of an allocator implied by the ServiceConfig.
@@ -49,17 +124,25 @@ func (cfg ServiceConfig) MemberKey(ks *keyspace.KeySpace) string {
 type ServerContext struct {
 	HTTPMux    *http.ServeMux
 	GRPCServer *grpc.Server
+	// Health of the server, to which readiness checks may be added.
+	Health *Health
 
 	RawListener  *net.TCPListener
 	CMux         cmux.CMux
 	GRPCListener net.Listener
 	HTTPListener net.Listener
 
+	// Address and TLS configuration with which Loopback dials the server.
+	// |loopbackTLS| is nil if the server is not served over TLS.
+	loopbackAddr string
+	loopbackTLS  *tls.Config
+
 	ctx    context.Context
 	cancel context.CancelFunc
 }
 
 // MustBuildServer builds and returns a ServerContext from the ServiceConfig.
+// If a server certificate is configured, connections are served over TLS.
 func MustBuildServer(cfg ServiceConfig) ServerContext {
 	var raw, err = net.Listen("tcp", fmt.Sprintf(":%d", cfg.Port))
 	Must(err, "failed to bind local service address", "port", cfg.Port)
@@ -67,14 +150,34 @@ func MustBuildServer(cfg ServiceConfig) ServerContext {
 	var ctx, cancel = context.WithCancel(context.Background())
 
 	var sl = ServerContext{
-		HTTPMux:     http.DefaultServeMux,
-		GRPCServer:  grpc.NewServer(),
-		RawListener: raw.(*net.TCPListener),
-		ctx:         ctx,
-		cancel:      cancel,
+		HTTPMux:      http.DefaultServeMux,
+		GRPCServer:   grpc.NewServer(tracing.ServerOptions()...),
+		Health:       NewHealth(),
+		RawListener:  raw.(*net.TCPListener),
+		loopbackAddr: raw.Addr().String(),
+		ctx:          ctx,
+		cancel:       cancel,
 	}
+	sl.Health.register(&sl)
 
-	sl.CMux = cmux.New(keepalive.TCPListener{TCPListener: sl.RawListener})
+	var listener net.Listener = keepalive.TCPListener{TCPListener: sl.RawListener}
+
+	if cfg.ServerCertFile != "" {
+		serverTLS, err := BuildServerTLSConfig(cfg.ServerCertFile, cfg.ServerCertKeyFile, cfg.ServerCAFile)
+		Must(err, "failed to build server TLS config")
+
+		// Loopback and peer connections present the server certificate, and
+		// verify peers against the trusted CA bundle. The loopback dials the
+		// advertised host, which the server certificate must be valid for.
+		sl.loopbackTLS, err = BuildTLSConfig(cfg.ServerCertFile, cfg.ServerCertKeyFile, cfg.ServerCAFile)
+		Must(err, "failed to build peer TLS config")
+		sl.loopbackAddr = fmt.Sprintf("%s:%d", cfg.Host, cfg.Port)
+
+		// Terminate TLS prior to multiplexing gRPC and HTTP.
+		listener = tls.NewListener(listener, serverTLS)
+	}
+
+	sl.CMux = cmux.New(listener)
 	sl.GRPCListener = sl.CMux.Match(cmux.HTTP2HeaderField("content-type", "application/grpc"))
 	sl.HTTPListener = sl.CMux.Match(cmux.HTTP1Fast())
 	return sl
@@ -84,6 +187,7 @@ func MustBuildServer(cfg ServiceConfig) ServerContext {
 // or http.ServeMux return an error without the Context also having
 // been cancelled.
 func (c *ServerContext) Serve() {
+	go c.Health.serve(c.ctx)
 	go func() {
 		if err := c.CMux.Serve(); err != nil && c.ctx.Err() == nil {
 			Must(err, "cmux.Serve failed")
@@ -105,14 +209,13 @@ func (c *ServerContext) GracefulStop() {
 	c.GRPCServer.GracefulStop()
 }
 
-// Loopback dials and returns a connection to the local gRPC server.
+// Loopback dials and returns a connection to the local gRPC server. The
+// connection uses a protocol.Dispatcher balancer, and TLS if the server does.
 func (c *ServerContext) Loopback() *grpc.ClientConn {
-	var addr = c.RawListener.Addr().String()
+	var addr = c.loopbackAddr
 
 	var cc, err = grpc.DialContext(c.ctx, addr,
-		grpc.WithInsecure(),
-		grpc.WithDialer(keepalive.DialerFunc),
-		grpc.WithBalancerName(protocol.DispatcherGRPCBalancerName))
+		protocol.DispatcherDialOptions(c.loopbackTLS, keepalive.DialerFunc)...)
 
 	if err != nil {
 		log.WithFields(log.Fields{"addr": addr, "err": err}).Fatal("failed to dial service loopback")
@@ -122,18 +225,48 @@ func (c *ServerContext) Loopback() *grpc.ClientConn {
 
 type memberSpec interface {
 	MarshalString() string
+	Reset()
+	Unmarshal([]byte) error
 	ZeroLimit()
 	Validate() error
 }
 
 // AnnounceServeAndAllocate will announce the |spec| to |etcd|, begin
 // asynchronously serving the ServerContext, and synchronously run
-// allocator.Allocate. It installs a signal handler which zeros the |spec| item
-// limit and updates the announcement, causing the Allocate to gracefully exit.
+// allocator.Allocate with the AllocatorConfig. It installs a signal handler
+// which zeros the |spec| item limit and updates the announcement, causing the
+// Allocate to gracefully exit, and adds readiness checks of the KeySpace, Etcd
+// lease, and allocator membership to the ServerContext Health.
 // This is the principal service loop of gazette brokers and consumers.
-func AnnounceServeAndAllocate(etcd EtcdContext, srv ServerContext, state *allocator.State, spec memberSpec) {
+//
+// The announced |spec| is attached to the Etcd session lease, and remote
+// updates of it (eg, a cordon or drain applied by gazctl) last only for the
+// lifetime of the process. A restarted process announces |spec| anew.
+func AnnounceServeAndAllocate(etcd EtcdContext, srv ServerContext, state *allocator.State, spec memberSpec, cfg AllocatorConfig) {
 	Must(spec.Validate(), "member specification validation error")
 
+	// The process is ready only while its KeySpace is loaded, its lease is
+	// held, and its member key is present.
+	srv.Health.AddReadinessCheck("keyspace", func() error {
+		defer state.KS.Mu.RUnlock()
+		state.KS.Mu.RLock()
+
+		if state.KS.Header.Revision == 0 {
+			return fmt.Errorf("not loaded")
+		} else if state.LocalMemberInd == -1 {
+			return fmt.Errorf("member key %s not found", state.LocalKey)
+		}
+		return nil
+	})
+	srv.Health.AddReadinessCheck("etcd lease", func() error {
+		select {
+		case <-etcd.Session.Done():
+			return fmt.Errorf("session lease %x expired or was revoked", etcd.Session.Lease())
+		default:
+			return nil
+		}
+	})
+
 	var ann = allocator.Announce(etcd.Etcd, state.LocalKey, spec.MarshalString(), etcd.Session.Lease())
 	Must(state.KS.Load(context.Background(), etcd.Etcd, ann.Revision), "failed to load KeySpace")
 
@@ -147,6 +280,16 @@ func AnnounceServeAndAllocate(etcd EtcdContext, srv ServerContext, state *alloca
 		var sig = <-signalCh
 		log.WithField("signal", sig).Info("caught signal")
 
+		// Our member spec may have been updated remotely (eg, cordoned by gazctl).
+		// Zero the limit of its current value and revision.
+		state.KS.Mu.RLock()
+		if ind, ok := state.KS.Search(state.LocalKey); ok {
+			spec.Reset()
+			Must(spec.Unmarshal(state.KS.KeyValues[ind].Raw.Value), "failed to unmarshal member spec")
+			ann.Revision = state.KS.KeyValues[ind].Raw.ModRevision
+		}
+		state.KS.Mu.RUnlock()
+
 		spec.ZeroLimit()
 		Must(ann.Update(spec.MarshalString()), "failed to update member announcement", "key", state.LocalKey)
 	}()
@@ -156,9 +299,10 @@ func AnnounceServeAndAllocate(etcd EtcdContext, srv ServerContext, state *alloca
 	go func() { Must(state.KS.Watch(context.Background(), etcd.Etcd), "keyspace Watch failed") }()
 
 	Must(allocator.Allocate(allocator.AllocateArgs{
-		Context: context.Background(),
-		Etcd:    etcd.Etcd,
-		State:   state,
+		Context:         context.Background(),
+		Etcd:            etcd.Etcd,
+		State:           state,
+		MaxPendingMoves: cfg.MaxPendingMoves,
 	}), "Allocate failed")
 
 	// Close our session to remove our member key. If we were leader,
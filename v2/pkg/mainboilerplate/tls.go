@@ -0,0 +1,50 @@
+package mainboilerplate
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+)
+
+// BuildTLSConfig returns a tls.Config which presents the certificate of
+// |certFile| & |keyFile| (if set), and which trusts CA certificates of the
+// PEM-encoded bundle |caFile| (if set). If |caFile| is not set, the system
+// roots are trusted.
+func BuildTLSConfig(certFile, keyFile, caFile string) (*tls.Config, error) {
+	var cfg = &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if certFile != "" || keyFile != "" {
+		if cert, err := tls.LoadX509KeyPair(certFile, keyFile); err != nil {
+			return nil, fmt.Errorf("loading certificate key-pair: %s", err)
+		} else {
+			cfg.Certificates = []tls.Certificate{cert}
+		}
+	}
+	if caFile != "" {
+		if pem, err := ioutil.ReadFile(caFile); err != nil {
+			return nil, fmt.Errorf("reading CA file: %s", err)
+		} else if cfg.RootCAs = x509.NewCertPool(); !cfg.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA file %s", caFile)
+		}
+	}
+	return cfg, nil
+}
+
+// BuildServerTLSConfig returns a tls.Config for serving with the certificate
+// of |certFile| & |keyFile|. If |caFile| is set, clients are required to
+// present a certificate verified by its CA bundle (ie, mutual TLS).
+func BuildServerTLSConfig(certFile, keyFile, caFile string) (*tls.Config, error) {
+	var cfg, err = BuildTLSConfig(certFile, keyFile, caFile)
+	if err != nil {
+		return nil, err
+	}
+	// Negotiate HTTP/2 for gRPC, and HTTP/1.1 for other HTTP requests.
+	cfg.NextProtos = []string{"h2", "http/1.1"}
+
+	if caFile != "" {
+		cfg.ClientCAs, cfg.RootCAs = cfg.RootCAs, nil
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return cfg, nil
+}
@@ -207,3 +207,95 @@ func GazetteConsumerCollectors() []prometheus.Collector {
 		GazetteConsumerTxFlushSecondsTotal,
 	}
 }
+
+// Keys for allocator metrics.
+const (
+	AllocatorCompletedMovesTotalKey = "gazette_allocator_completed_moves_total"
+	AllocatorDeferredMovesKey       = "gazette_allocator_deferred_moves"
+	AllocatorPendingMovesKey        = "gazette_allocator_pending_moves"
+	AllocatorStartedMovesTotalKey   = "gazette_allocator_started_moves_total"
+)
+
+// Collectors for allocator metrics. Metrics are updated only by the current
+// allocator leader.
+var (
+	AllocatorCompletedMovesTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: AllocatorCompletedMovesTotalKey,
+		Help: "Cumulative number of item moves between members which have completed.",
+	})
+	AllocatorDeferredMoves = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: AllocatorDeferredMovesKey,
+		Help: "Number of item moves deferred by the last allocator round, due to the limit of pending moves.",
+	})
+	AllocatorPendingMoves = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: AllocatorPendingMovesKey,
+		Help: "Number of item moves between members which are pending (added, but not yet consistent).",
+	})
+	AllocatorStartedMovesTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: AllocatorStartedMovesTotalKey,
+		Help: "Cumulative number of item moves between members which have started.",
+	})
+)
+
+// AllocatorCollectors returns the metrics used by the allocator package.
+func AllocatorCollectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		AllocatorCompletedMovesTotal,
+		AllocatorDeferredMoves,
+		AllocatorPendingMoves,
+		AllocatorStartedMovesTotal,
+	}
+}
+
+// Keys for broker journal metrics.
+const (
+	JournalActiveReadsKey            = "gazette_journal_active_reads"
+	JournalAppendBytesTotalKey       = "gazette_journal_append_bytes_total"
+	JournalAppendsTotalKey           = "gazette_journal_appends_total"
+	JournalPersistDurationSecondsKey = "gazette_journal_persist_duration_seconds"
+	JournalReadBytesTotalKey         = "gazette_journal_read_bytes_total"
+	JournalSpoolBytesKey             = "gazette_journal_spool_bytes"
+)
+
+// Collectors for broker journal metrics. Journal metrics are opt-in, and
+// are partitioned by either journal name or the value of a JournalSpec
+// label (eg, "topic"), as configured on the broker. The partition is
+// reported as the "journal" label.
+var (
+	JournalActiveReads = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: JournalActiveReadsKey,
+		Help: "Number of Read RPCs being served from local journal replicas.",
+	}, []string{"journal"})
+	JournalAppendBytesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: JournalAppendBytesTotalKey,
+		Help: "Cumulative number of bytes appended to journals by Append RPCs.",
+	}, []string{"journal"})
+	JournalAppendsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: JournalAppendsTotalKey,
+		Help: "Cumulative number of Append RPCs committed to journals.",
+	}, []string{"journal"})
+	JournalPersistDurationSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name: JournalPersistDurationSecondsKey,
+		Help: "Duration of persisting completed journal spools to their fragment store.",
+	}, []string{"journal"})
+	JournalReadBytesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: JournalReadBytesTotalKey,
+		Help: "Cumulative number of journal content bytes sent by Read RPCs.",
+	}, []string{"journal"})
+	JournalSpoolBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: JournalSpoolBytesKey,
+		Help: "Content length of current journal spools, which are not yet persisted.",
+	}, []string{"journal"})
+)
+
+// JournalCollectors returns the journal metrics used by the broker package.
+func JournalCollectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		JournalActiveReads,
+		JournalAppendBytesTotal,
+		JournalAppendsTotal,
+		JournalPersistDurationSeconds,
+		JournalReadBytesTotal,
+		JournalSpoolBytes,
+	}
+}
@@ -0,0 +1,56 @@
+package mirror
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+)
+
+// Checkpoint maps each mirrored source journal to the offset through which
+// its content has been mirrored to the destination.
+type Checkpoint map[pb.Journal]int64
+
+// LoadCheckpoint reads the Checkpoint at |path|. If |path| doesn't exist,
+// an empty Checkpoint is returned.
+func LoadCheckpoint(path string) (Checkpoint, error) {
+	var cp = make(Checkpoint)
+
+	if b, err := ioutil.ReadFile(path); os.IsNotExist(err) {
+		return cp, nil
+	} else if err != nil {
+		return nil, err
+	} else if err = json.Unmarshal(b, &cp); err != nil {
+		return nil, err
+	}
+	return cp, nil
+}
+
+// Save the Checkpoint to |path|. The Checkpoint is first written to a
+// temporary file which then replaces |path|, such that a failure while
+// saving cannot corrupt a previously saved Checkpoint.
+func (cp Checkpoint) Save(path string) error {
+	var b, err = json.Marshal(cp)
+	if err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile(filepath.Dir(path), ".partial-"+filepath.Base(path))
+	if err != nil {
+		return err
+	}
+	if _, err = f.Write(b); err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), path)
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+	}
+	return err
+}
@@ -0,0 +1,46 @@
+package mirror
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	gc "github.com/go-check/check"
+)
+
+type CheckpointSuite struct{}
+
+func (s *CheckpointSuite) TestSaveAndLoadRoundTrip(c *gc.C) {
+	var dir, err = ioutil.TempDir("", "mirror-checkpoint")
+	c.Assert(err, gc.IsNil)
+	defer os.RemoveAll(dir)
+
+	var path = filepath.Join(dir, "checkpoint.json")
+
+	// A missing Checkpoint loads as empty.
+	cp, err := LoadCheckpoint(path)
+	c.Check(err, gc.IsNil)
+	c.Check(cp, gc.DeepEquals, Checkpoint{})
+
+	cp = Checkpoint{"a/journal": 1234, "another/journal": 5678}
+	c.Check(cp.Save(path), gc.IsNil)
+
+	// Saving again replaces the prior Checkpoint.
+	cp["a/journal"] = 2345
+	c.Check(cp.Save(path), gc.IsNil)
+
+	loaded, err := LoadCheckpoint(path)
+	c.Check(err, gc.IsNil)
+	c.Check(loaded, gc.DeepEquals, cp)
+
+	// Expect temporary files were cleaned up.
+	files, _ := ioutil.ReadDir(dir)
+	c.Check(files, gc.HasLen, 1)
+
+	// Case: a malformed Checkpoint fails to load.
+	c.Assert(ioutil.WriteFile(path, []byte("{bad"), 0600), gc.IsNil)
+	_, err = LoadCheckpoint(path)
+	c.Check(err, gc.ErrorMatches, `invalid character 'b' .*`)
+}
+
+var _ = gc.Suite(&CheckpointSuite{})
@@ -0,0 +1,409 @@
+// Package mirror implements the mirroring of journals from a source Gazette
+// cluster to a destination cluster.
+//
+// Journals of the source cluster are selected by a LabelSelector. Each is
+// created on the destination with a matching JournalSpec (if it doesn't
+// already exist), and its content is copied such that every byte has the
+// same offset in the destination journal as it does in the source journal.
+// Appends to the destination specify the expected AppendRequest Offset, and
+// an append which is rejected, or which commits at an unexpected offset or
+// with an unexpected SHA1 sum, indicates that the destination has diverged
+// from the source (eg, because another writer appended to it).
+//
+// If the source journal's earliest available offset is greater than zero (eg,
+// because its older fragments were removed by retention), an empty destination
+// journal begins at that offset.
+//
+// Progress is periodically written to a Checkpoint. On resumption, any
+// destination content beyond the Checkpoint (which was committed by the
+// mirror after its last Checkpoint was saved) is verified against the source
+// before mirroring continues from the destination write head.
+package mirror
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/LiveRamp/gazette/v2/pkg/client"
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	log "github.com/sirupsen/logrus"
+)
+
+// Config of a Mirror.
+type Config struct {
+	// Source cluster from which journals are mirrored.
+	Source pb.RoutedJournalClient
+	// Destination cluster to which journals are mirrored.
+	Destination pb.RoutedJournalClient
+	// Selector of source journals to mirror.
+	Selector pb.LabelSelector
+	// Rename optionally maps the name of a source journal to the name of its
+	// destination journal. If nil, journals have the same name in each cluster.
+	Rename func(pb.Journal) pb.Journal
+	// Stores optionally replaces the fragment stores of created destination
+	// JournalSpecs, which otherwise use the stores of the source JournalSpec.
+	Stores []pb.FragmentStore
+	// CheckpointPath is the file path of the mirror's Checkpoint.
+	CheckpointPath string
+	// Interval between refreshes of selected source journals, and of saves of
+	// the Checkpoint.
+	Interval time.Duration
+}
+
+// Mirror journals of a source cluster to a destination cluster.
+type Mirror struct {
+	cfg Config
+
+	mu         sync.Mutex
+	checkpoint Checkpoint
+}
+
+// NewMirror returns a Mirror of the Config, which resumes from its previously
+// saved Checkpoint (if any).
+func NewMirror(cfg Config) (*Mirror, error) {
+	var cp, err = LoadCheckpoint(cfg.CheckpointPath)
+	if err != nil {
+		return nil, fmt.Errorf("loading checkpoint: %s", err)
+	}
+	return &Mirror{cfg: cfg, checkpoint: cp}, nil
+}
+
+// Run the Mirror until |ctx| is cancelled. Run returns an error only if its
+// Checkpoint cannot be saved. Errors encountered in mirroring an individual
+// journal (eg, because it diverged) are logged, and mirroring of that journal
+// is retried with backoff. Mirroring of other journals continues.
+func (m *Mirror) Run(ctx context.Context) error {
+	var running = make(map[pb.Journal]*mirroring)
+	var wg sync.WaitGroup
+
+	// stop the mirroring of all journals, and wait for each to exit.
+	var stop = func() {
+		for _, r := range running {
+			r.cancel()
+		}
+		wg.Wait()
+	}
+
+	var ticker = time.NewTicker(m.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := m.refresh(ctx, running, &wg); err != nil {
+			log.WithField("err", err).Warn("failed to refresh mirrored journals (will retry)")
+		}
+		if err := m.saveCheckpoint(); err != nil {
+			stop()
+			return fmt.Errorf("saving checkpoint: %s", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			stop()
+			return m.saveCheckpoint()
+		case <-ticker.C:
+		}
+	}
+}
+
+// mirroring is the state of a source journal being mirrored.
+type mirroring struct {
+	cancel   context.CancelFunc
+	doneCh   chan struct{} // Closed when mirrorJournal exits.
+	failures int           // Number of consecutive failed attempts.
+	retryAt  time.Time     // Time after which a failed attempt may be retried.
+}
+
+// refresh lists source journals, starting the mirroring of newly selected
+// journals, restarting failed mirrors whose backoff has elapsed, and stopping
+// the mirroring of journals which are no longer selected.
+func (m *Mirror) refresh(ctx context.Context, running map[pb.Journal]*mirroring, wg *sync.WaitGroup) error {
+	var resp, err = client.ListAll(ctx, m.cfg.Source, pb.ListRequest{Selector: m.cfg.Selector})
+	if err != nil {
+		return fmt.Errorf("listing source journals: %s", err)
+	}
+
+	var selected = make(map[pb.Journal]struct{}, len(resp.Journals))
+	for _, j := range resp.Journals {
+		selected[j.Spec.Name] = struct{}{}
+
+		var failures int
+		if r, ok := running[j.Spec.Name]; ok {
+			select {
+			case <-r.doneCh:
+				// |r| failed. Retry if its backoff has elapsed.
+			default:
+				continue // Still mirroring.
+			}
+			if timeNow().Before(r.retryAt) {
+				continue
+			}
+			failures = r.failures
+		}
+		var spec = m.destinationSpec(j.Spec)
+
+		if err = m.createDestination(ctx, spec); err != nil {
+			log.WithFields(log.Fields{"destination": spec.Name, "err": err}).
+				Warn("failed to create destination journal (will retry)")
+			continue
+		}
+
+		var jctx, cancel = context.WithCancel(ctx)
+		var r = &mirroring{cancel: cancel, doneCh: make(chan struct{}), failures: failures}
+		running[j.Spec.Name] = r
+		wg.Add(1)
+
+		go func(src, dst pb.Journal, offset int64) {
+			defer wg.Done()
+			defer close(r.doneCh)
+
+			var err = m.mirrorJournal(jctx, src, dst, offset)
+			if jctx.Err() != nil {
+				return // Cancelled.
+			}
+			// Reset the backoff if the attempt made progress before failing.
+			if m.offset(src) != offset {
+				r.failures = 0
+			}
+			r.failures++
+			r.retryAt = timeNow().Add(retryBackoff(r.failures))
+
+			log.WithFields(log.Fields{
+				"source":      src,
+				"destination": dst,
+				"err":         err,
+				"retryAt":     r.retryAt,
+			}).Error("mirroring of journal failed (will retry)")
+		}(j.Spec.Name, spec.Name, m.offset(j.Spec.Name))
+	}
+
+	for name, r := range running {
+		if _, ok := selected[name]; !ok {
+			log.WithField("source", name).Info("journal is no longer selected; stopping mirror")
+			r.cancel()
+			delete(running, name)
+		}
+	}
+	return nil
+}
+
+// retryBackoff returns the backoff of a journal mirror which has failed
+// |failures| consecutive times.
+func retryBackoff(failures int) time.Duration {
+	var d = minRetryBackoff << uint(failures-1)
+	if d > maxRetryBackoff || d <= 0 {
+		d = maxRetryBackoff
+	}
+	return d
+}
+
+// destinationSpec maps a source JournalSpec to its destination JournalSpec.
+func (m *Mirror) destinationSpec(src pb.JournalSpec) *pb.JournalSpec {
+	var spec = src
+
+	if m.cfg.Rename != nil {
+		spec.Name = m.cfg.Rename(src.Name)
+	}
+	if len(m.cfg.Stores) != 0 {
+		spec.Fragment.Stores = append([]pb.FragmentStore(nil), m.cfg.Stores...)
+	}
+	// The mirror must be able to append to the destination journal.
+	if spec.Flags == pb.JournalSpec_O_RDONLY {
+		spec.Flags = pb.JournalSpec_NOT_SPECIFIED
+	}
+	return &spec
+}
+
+// createDestination creates the JournalSpec on the destination cluster, if
+// a journal of the same name doesn't already exist. An existing destination
+// JournalSpec is not modified.
+func (m *Mirror) createDestination(ctx context.Context, spec *pb.JournalSpec) error {
+	if err := spec.Validate(); err != nil {
+		return err
+	}
+	var resp, err = client.ListAll(ctx, m.cfg.Destination, pb.ListRequest{
+		Selector: pb.LabelSelector{Include: pb.MustLabelSet("name", spec.Name.String())},
+	})
+	if err != nil {
+		return err
+	} else if len(resp.Journals) != 0 {
+		return nil // Already exists.
+	}
+
+	// ExpectModRevision of zero requires that the journal not exist.
+	_, err = client.ApplyJournals(ctx, m.cfg.Destination, &pb.ApplyRequest{
+		Changes: []pb.ApplyRequest_Change{{Upsert: spec, ExpectModRevision: 0}},
+	})
+	if err == nil {
+		log.WithField("journal", spec.Name).Info("created destination journal")
+	}
+	return err
+}
+
+// mirrorJournal copies content of source journal |src| to destination journal
+// |dst|, beginning from checkpointed |offset|, until |ctx| is cancelled or
+// an error is encountered.
+func (m *Mirror) mirrorJournal(ctx context.Context, src, dst pb.Journal, offset int64) error {
+	// An empty Append returns the current write head of the destination.
+	var resp, err = client.Append(ctx, m.cfg.Destination, pb.AppendRequest{Journal: dst})
+	if err != nil {
+		return fmt.Errorf("fetching destination write head: %s", err)
+	}
+	var head = resp.Commit.End
+
+	if head < offset {
+		return fmt.Errorf("destination %s diverged: write head %d is less than checkpoint offset %d",
+			dst, head, offset)
+	} else if head > offset {
+		// Content in [offset, head) was likely mirrored after our last saved
+		// Checkpoint. Verify it matches the source before continuing.
+		if err = m.verifyRange(ctx, src, dst, offset, head); err != nil {
+			return err
+		}
+		offset = head
+		m.setOffset(src, offset)
+	}
+
+	log.WithFields(log.Fields{"source": src, "destination": dst, "offset": offset}).
+		Info("mirroring journal")
+
+	var rr = client.NewRetryReader(ctx, m.cfg.Source, pb.ReadRequest{
+		Journal: src,
+		Offset:  offset,
+		Block:   true,
+	})
+	var buf = make([]byte, chunkSize)
+
+	for {
+		var n, err = rr.Read(buf)
+
+		if err == client.ErrOffsetJump && offset == 0 {
+			// The destination is empty, and the source has no content at offset
+			// zero. Begin the destination at the earliest source offset. Don't
+			// checkpoint |offset| until an append at it commits: the destination
+			// remains empty until then, and would be judged as diverged from a
+			// greater checkpoint offset.
+			log.WithFields(log.Fields{"source": src, "destination": dst, "offset": rr.Offset()}).
+				Info("beginning destination at earliest available source offset")
+			offset = rr.Offset()
+			continue
+		} else if err == client.ErrOffsetJump {
+			return fmt.Errorf("source %s offset jumped from %d to %d; content cannot be mirrored with preserved offsets",
+				src, offset, rr.Offset())
+		} else if err != nil {
+			return err
+		} else if n == 0 {
+			continue
+		}
+
+		resp, err = client.Append(ctx, m.cfg.Destination,
+			pb.AppendRequest{Journal: dst, Offset: offset}, bytes.NewReader(buf[:n]))
+
+		if err == client.ErrWrongAppendOffset {
+			return fmt.Errorf("destination %s diverged: append at offset %d was rejected (%s)",
+				dst, offset, err)
+		} else if err != nil {
+			return err
+		} else if c, sum := resp.Commit, pb.SHA1SumOf(string(buf[:n])); c.Begin != offset ||
+			c.End != offset+int64(n) || c.Sum != sum {
+			return fmt.Errorf("destination %s diverged: unexpected commit %s (expected [%d, %d) with sum %x)",
+				dst, c, offset, offset+int64(n), sum.ToDigest())
+		}
+
+		offset = resp.Commit.End
+		m.setOffset(src, offset)
+	}
+}
+
+// verifyRange returns an error if content of source journal |src| does not
+// match that of destination journal |dst| over offset range [begin, end).
+func (m *Mirror) verifyRange(ctx context.Context, src, dst pb.Journal, begin, end int64) error {
+	var dstSum, dstBegin, err = sumRange(ctx, m.cfg.Destination, dst, begin, end)
+	if err != nil {
+		return fmt.Errorf("verifying destination %s: %s", dst, err)
+	}
+	srcSum, srcBegin, err := sumRange(ctx, m.cfg.Source, src, begin, end)
+	if err == client.ErrOffsetNotYetAvailable {
+		return fmt.Errorf("destination %s diverged: write head %d is beyond that of source %s",
+			dst, end, src)
+	} else if err != nil {
+		return fmt.Errorf("verifying source %s: %s", src, err)
+	}
+	if srcBegin != dstBegin {
+		return fmt.Errorf("destination %s diverged: content begins at %d, but at %d in source %s",
+			dst, dstBegin, srcBegin, src)
+	} else if srcSum != dstSum {
+		return fmt.Errorf("destination %s diverged: content of [%d, %d) doesn't match source %s",
+			dst, begin, end, src)
+	}
+	return nil
+}
+
+// sumRange returns the SHA1Sum of |journal| content over [begin, end). Reads
+// are non-blocking: ErrOffsetNotYetAvailable is returned if |journal| doesn't
+// have content through |end|. If |journal| has no content at |begin|, the sum
+// begins from its next available offset, which is also returned. An offset
+// jump after content is read is an error.
+func sumRange(ctx context.Context, rjc pb.RoutedJournalClient, journal pb.Journal, begin, end int64) (pb.SHA1Sum, int64, error) {
+	var rctx, cancel = context.WithCancel(ctx)
+	defer cancel()
+
+	var rr = client.NewRetryReader(rctx, rjc, pb.ReadRequest{
+		Journal: journal,
+		Offset:  begin,
+	})
+	var summer = sha1.New()
+	var buf = make([]byte, chunkSize)
+
+	for offset := begin; offset < end; {
+		var n = int64(len(buf))
+		if end-offset < n {
+			n = end - offset
+		}
+		var nn, err = rr.Read(buf[:n])
+
+		if err == client.ErrOffsetJump && offset == begin {
+			begin, offset = rr.Offset(), rr.Offset()
+			continue
+		} else if err == client.ErrOffsetJump {
+			return pb.SHA1Sum{}, begin, fmt.Errorf("offset jumped from %d to %d", offset, rr.Offset())
+		} else if err != nil {
+			return pb.SHA1Sum{}, begin, err
+		}
+		_, _ = summer.Write(buf[:nn]) // Cannot fail.
+		offset += int64(nn)
+	}
+	return pb.SHA1SumFromDigest(summer.Sum(nil)), begin, nil
+}
+
+func (m *Mirror) offset(journal pb.Journal) int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.checkpoint[journal]
+}
+
+func (m *Mirror) setOffset(journal pb.Journal, offset int64) {
+	m.mu.Lock()
+	m.checkpoint[journal] = offset
+	m.mu.Unlock()
+}
+
+func (m *Mirror) saveCheckpoint() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.checkpoint.Save(m.cfg.CheckpointPath)
+}
+
+const (
+	// chunkSize is the maximum size of a single mirrored append.
+	chunkSize = 1 << 20 // 1MB.
+	// Minimum and maximum backoff of failed journal mirrors.
+	minRetryBackoff = time.Second
+	maxRetryBackoff = 5 * time.Minute
+)
+
+// timeNow is a var to facilitate testing.
+var timeNow = time.Now
@@ -0,0 +1,363 @@
+package mirror
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/LiveRamp/gazette/v2/pkg/broker/teststub"
+	"github.com/LiveRamp/gazette/v2/pkg/brokertest"
+	"github.com/LiveRamp/gazette/v2/pkg/client"
+	"github.com/LiveRamp/gazette/v2/pkg/etcdtest"
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	gc "github.com/go-check/check"
+)
+
+type MirrorSuite struct{}
+
+func (s *MirrorSuite) TestMirrorPreservesOffsetsAndResumes(c *gc.C) {
+	var etcd = etcdtest.TestClient()
+	defer etcdtest.Cleanup()
+
+	var dir, err = ioutil.TempDir("", "mirror")
+	c.Assert(err, gc.IsNil)
+	defer os.RemoveAll(dir)
+
+	var bk = brokertest.NewBroker(c, etcd, "local", "broker")
+	brokertest.CreateJournals(c, bk,
+		brokertest.Journal(pb.JournalSpec{Name: "src/one"}),
+		brokertest.Journal(pb.JournalSpec{Name: "dst/one"}))
+
+	var ctx = pb.WithDispatchDefault(context.Background())
+	var rjc = pb.NewRoutedJournalClient(bk.Client(), pb.NoopDispatchRouter{})
+	var cfg = testConfig(rjc, filepath.Join(dir, "checkpoint.json"))
+
+	appendString(c, rjc, "src/one", "hello, ")
+
+	var runMirror = func() (*Mirror, func()) {
+		var m, err = NewMirror(cfg)
+		c.Assert(err, gc.IsNil)
+
+		var runCtx, cancel = context.WithCancel(ctx)
+		var doneCh = make(chan struct{})
+
+		go func() {
+			c.Check(m.Run(runCtx), gc.IsNil)
+			close(doneCh)
+		}()
+		return m, func() { cancel(); <-doneCh }
+	}
+
+	// Expect source content is mirrored at the same offsets, including
+	// content which is appended while the mirror is running.
+	var m, stop = runMirror()
+	c.Check(readString(c, rjc, "dst/one", 0, 7), gc.Equals, "hello, ")
+	appendString(c, rjc, "src/one", "world")
+	c.Check(readString(c, rjc, "dst/one", 7, 5), gc.Equals, "world")
+	waitForOffset(c, m, "src/one", 12)
+	stop()
+
+	// Expect a final Checkpoint was saved on exit.
+	cp, err := LoadCheckpoint(cfg.CheckpointPath)
+	c.Check(err, gc.IsNil)
+	c.Check(cp, gc.DeepEquals, Checkpoint{"src/one": 12})
+
+	// Simulate a mirror which exited after appending "world", but before
+	// its Checkpoint was saved. Expect mirrored content beyond the Checkpoint
+	// is verified, and that mirroring resumes from the destination write head.
+	c.Check(Checkpoint{"src/one": 7}.Save(cfg.CheckpointPath), gc.IsNil)
+	appendString(c, rjc, "src/one", "!")
+
+	m, stop = runMirror()
+	c.Check(readString(c, rjc, "dst/one", 12, 1), gc.Equals, "!")
+	waitForOffset(c, m, "src/one", 13)
+	stop()
+
+	cp, err = LoadCheckpoint(cfg.CheckpointPath)
+	c.Check(err, gc.IsNil)
+	c.Check(cp, gc.DeepEquals, Checkpoint{"src/one": 13})
+}
+
+func (s *MirrorSuite) TestMirrorBeginsAtEarliestSourceOffset(c *gc.C) {
+	var etcd = etcdtest.TestClient()
+	defer etcdtest.Cleanup()
+
+	var ctx, cancel = context.WithCancel(pb.WithDispatchDefault(context.Background()))
+	defer cancel()
+
+	// Use a stub source broker, which can serve an offset jump
+	// (as though prior fragments were removed by retention).
+	var stub = teststub.NewBroker(c, ctx)
+	var bk = brokertest.NewBroker(c, etcd, "local", "broker")
+	brokertest.CreateJournals(c, bk, brokertest.Journal(pb.JournalSpec{Name: "dst/one"}))
+
+	var dst = pb.NewRoutedJournalClient(bk.Client(), pb.NoopDispatchRouter{})
+	var cfg = testConfig(dst, "")
+	cfg.Source = pb.NewRoutedJournalClient(stub.MustClient(), pb.NoopDispatchRouter{})
+	var m = &Mirror{cfg: cfg, checkpoint: Checkpoint{}}
+
+	var jumpResp = &pb.ReadResponse{
+		Status: pb.Status_OK,
+		Header: &pb.Header{
+			ProcessId: pb.ProcessSpec_ID{Zone: "a", Suffix: "broker"},
+			Route: pb.Route{
+				Members:   []pb.ProcessSpec_ID{{Zone: "a", Suffix: "broker"}},
+				Endpoints: []pb.Endpoint{stub.Endpoint()},
+				Primary:   0,
+			},
+			Etcd: pb.Header_Etcd{ClusterId: 12, MemberId: 34, Revision: 56, RaftTerm: 78},
+		},
+		Offset:    100,
+		WriteHead: 105,
+		Fragment: &pb.Fragment{
+			Journal:          "src/one",
+			Begin:            100,
+			End:              105,
+			CompressionCodec: pb.CompressionCodec_NONE,
+		},
+	}
+	var serveJump = func() {
+		var req = <-stub.ReadReqCh
+		c.Check(req.Offset, gc.Equals, int64(0))
+
+		stub.ReadRespCh <- jumpResp
+		stub.ReadRespCh <- &pb.ReadResponse{Offset: 100, Content: []byte("hello")}
+	}
+
+	// Case: sumRange begins from the next available offset.
+	go serveJump()
+	var sum, begin, err = sumRange(ctx, cfg.Source, "src/one", 0, 105)
+	c.Check(err, gc.IsNil)
+	c.Check(begin, gc.Equals, int64(100))
+	c.Check(sum, gc.Equals, pb.SHA1SumOf("hello"))
+	stub.ErrCh <- nil // Close the read stream.
+
+	// Case: the mirror observes the jump, but is stopped before its first
+	// append commits. Expect the jumped-to offset isn't checkpointed.
+	var mirrorCtx, mirrorCancel = context.WithCancel(ctx)
+	var doneCh = make(chan error)
+	go func() { doneCh <- m.mirrorJournal(mirrorCtx, "src/one", "dst/one", 0) }()
+
+	c.Check((<-stub.ReadReqCh).Offset, gc.Equals, int64(0))
+	stub.ReadRespCh <- jumpResp
+	stub.ErrCh <- nil // Close the stream before content is sent.
+
+	// The retried read is of the jumped-to offset.
+	c.Check((<-stub.ReadReqCh).Offset, gc.Equals, int64(100))
+	c.Check(m.offset("src/one"), gc.Equals, int64(0))
+
+	mirrorCancel()
+	c.Check(<-doneCh, gc.Equals, context.Canceled)
+	stub.ErrCh <- nil // Close the retried read stream.
+
+	// Case: the empty destination begins at the same offset as the source.
+	// The mirror is restarted from its checkpoint, and doesn't find the
+	// destination to have diverged.
+	go serveJump()
+	go func() { doneCh <- m.mirrorJournal(ctx, "src/one", "dst/one", m.offset("src/one")) }()
+
+	c.Check(readString(c, dst, "dst/one", 100, 5), gc.Equals, "hello")
+	waitForOffset(c, m, "src/one", 105)
+
+	cancel()
+	c.Check(<-doneCh, gc.Equals, context.Canceled)
+}
+
+func (s *MirrorSuite) TestRefreshRetriesFailedMirrors(c *gc.C) {
+	var etcd = etcdtest.TestClient()
+	defer etcdtest.Cleanup()
+
+	var bk = brokertest.NewBroker(c, etcd, "local", "broker")
+	brokertest.CreateJournals(c, bk,
+		brokertest.Journal(pb.JournalSpec{Name: "src/one"}),
+		brokertest.Journal(pb.JournalSpec{Name: "src/two"}),
+		brokertest.Journal(pb.JournalSpec{Name: "dst/one"}))
+
+	var ctx, cancel = context.WithCancel(pb.WithDispatchDefault(context.Background()))
+	var rjc = pb.NewRoutedJournalClient(bk.Client(), pb.NoopDispatchRouter{})
+
+	var cfg = testConfig(rjc, "")
+	cfg.Rename = func(name pb.Journal) pb.Journal {
+		if name == "src/two" {
+			return "invalid name" // Cannot be created.
+		}
+		return "dst/one"
+	}
+	var m = &Mirror{cfg: cfg, checkpoint: Checkpoint{}}
+
+	defer func(f func() time.Time) { timeNow = f }(timeNow)
+	var now = time.Unix(1500000000, 0)
+	timeNow = func() time.Time { return now }
+
+	// Arrange for the mirror of src/one to fail, as its destination diverged.
+	appendString(c, rjc, "src/one", "hello, world")
+	appendString(c, rjc, "dst/one", "hello, there")
+
+	var running = make(map[pb.Journal]*mirroring)
+	var wg sync.WaitGroup
+
+	// Expect the failure to create the destination of src/two is logged,
+	// and doesn't prevent the mirroring of src/one.
+	c.Check(m.refresh(ctx, running, &wg), gc.IsNil)
+	c.Assert(running, gc.HasLen, 1)
+
+	var r = running["src/one"]
+	<-r.doneCh
+	c.Check(r.failures, gc.Equals, 1)
+	c.Check(r.retryAt, gc.Equals, now.Add(minRetryBackoff))
+
+	// Expect the failed mirror isn't restarted until its backoff elapses.
+	c.Check(m.refresh(ctx, running, &wg), gc.IsNil)
+	c.Check(running["src/one"] == r, gc.Equals, true)
+
+	now = now.Add(minRetryBackoff)
+	c.Check(m.refresh(ctx, running, &wg), gc.IsNil)
+	c.Check(running["src/one"] == r, gc.Equals, false)
+
+	r = running["src/one"]
+	<-r.doneCh
+	c.Check(r.failures, gc.Equals, 2)
+	c.Check(r.retryAt, gc.Equals, now.Add(2*minRetryBackoff))
+
+	cancel()
+	wg.Wait()
+}
+
+func (s *MirrorSuite) TestRetryBackoff(c *gc.C) {
+	c.Check(retryBackoff(1), gc.Equals, minRetryBackoff)
+	c.Check(retryBackoff(3), gc.Equals, 4*minRetryBackoff)
+	c.Check(retryBackoff(20), gc.Equals, maxRetryBackoff)
+	c.Check(retryBackoff(200), gc.Equals, maxRetryBackoff)
+}
+
+func (s *MirrorSuite) TestDivergenceCases(c *gc.C) {
+	var etcd = etcdtest.TestClient()
+	defer etcdtest.Cleanup()
+
+	var bk = brokertest.NewBroker(c, etcd, "local", "broker")
+	brokertest.CreateJournals(c, bk,
+		brokertest.Journal(pb.JournalSpec{Name: "src/one"}),
+		brokertest.Journal(pb.JournalSpec{Name: "dst/one"}))
+
+	var ctx = pb.WithDispatchDefault(context.Background())
+	var rjc = pb.NewRoutedJournalClient(bk.Client(), pb.NoopDispatchRouter{})
+	var m = &Mirror{cfg: testConfig(rjc, ""), checkpoint: Checkpoint{}}
+
+	appendString(c, rjc, "src/one", "hello, world")
+	appendString(c, rjc, "dst/one", "hello, there")
+
+	// Case: the destination has content which doesn't match the source.
+	c.Check(m.mirrorJournal(ctx, "src/one", "dst/one", 0), gc.ErrorMatches,
+		`destination dst/one diverged: content of \[0, 12\) doesn't match source src/one`)
+
+	// Case: content of matching ranges is verified.
+	c.Check(m.verifyRange(ctx, "src/one", "dst/one", 0, 7), gc.IsNil)
+
+	// Case: the destination has content beyond the source write head.
+	appendString(c, rjc, "dst/one", "!")
+	c.Check(m.mirrorJournal(ctx, "src/one", "dst/one", 12), gc.ErrorMatches,
+		`destination dst/one diverged: write head 13 is beyond that of source src/one`)
+
+	// Case: the destination write head is behind the checkpoint.
+	c.Check(m.mirrorJournal(ctx, "src/one", "dst/one", 100), gc.ErrorMatches,
+		`destination dst/one diverged: write head 13 is less than checkpoint offset 100`)
+}
+
+func (s *MirrorSuite) TestDestinationSpecCreation(c *gc.C) {
+	var etcd = etcdtest.TestClient()
+	defer etcdtest.Cleanup()
+
+	var bk = brokertest.NewBroker(c, etcd, "local", "broker")
+	var ctx = pb.WithDispatchDefault(context.Background())
+	var rjc = pb.NewRoutedJournalClient(bk.Client(), pb.NoopDispatchRouter{})
+
+	var cfg = testConfig(rjc, "")
+	cfg.Stores = []pb.FragmentStore{"file:///mirror/"}
+	var m = &Mirror{cfg: cfg, checkpoint: Checkpoint{}}
+
+	var src = brokertest.Journal(pb.JournalSpec{
+		Name:     "src/one",
+		LabelSet: pb.MustLabelSet("topic", "events"),
+		Flags:    pb.JournalSpec_O_RDONLY,
+	})
+	src.Fragment.Stores = []pb.FragmentStore{"file:///source/"}
+
+	// Expect the spec is renamed, uses configured Stores, and clears O_RDONLY.
+	var expect = *src
+	expect.Name = "dst/one"
+	expect.Fragment.Stores = cfg.Stores
+	expect.Flags = pb.JournalSpec_NOT_SPECIFIED
+
+	var spec = m.destinationSpec(*src)
+	c.Check(spec, gc.DeepEquals, &expect)
+
+	// Expect the destination journal is created.
+	c.Check(m.createDestination(ctx, spec), gc.IsNil)
+
+	var resp, err = client.ListAll(ctx, rjc, pb.ListRequest{
+		Selector: pb.LabelSelector{Include: pb.MustLabelSet("name", "dst/one")},
+	})
+	c.Assert(err, gc.IsNil)
+	c.Assert(resp.Journals, gc.HasLen, 1)
+	c.Check(resp.Journals[0].Spec, gc.DeepEquals, expect)
+
+	// Expect an existing destination journal is not modified.
+	spec.LabelSet = pb.MustLabelSet("topic", "other")
+	c.Check(m.createDestination(ctx, spec), gc.IsNil)
+
+	resp, err = client.ListAll(ctx, rjc, pb.ListRequest{
+		Selector: pb.LabelSelector{Include: pb.MustLabelSet("name", "dst/one")},
+	})
+	c.Assert(err, gc.IsNil)
+	c.Check(resp.Journals[0].Spec, gc.DeepEquals, expect)
+}
+
+func testConfig(rjc pb.RoutedJournalClient, checkpointPath string) Config {
+	return Config{
+		Source:      rjc,
+		Destination: rjc,
+		Selector:    pb.LabelSelector{Include: pb.MustLabelSet("prefix", "src/")},
+		Rename: func(name pb.Journal) pb.Journal {
+			return pb.Journal("dst/" + strings.TrimPrefix(name.String(), "src/"))
+		},
+		CheckpointPath: checkpointPath,
+		Interval:       10 * time.Millisecond,
+	}
+}
+
+func appendString(c *gc.C, rjc pb.RoutedJournalClient, journal pb.Journal, str string) {
+	var _, err = client.Append(pb.WithDispatchDefault(context.Background()), rjc,
+		pb.AppendRequest{Journal: journal}, strings.NewReader(str))
+	c.Assert(err, gc.IsNil)
+}
+
+func readString(c *gc.C, rjc pb.RoutedJournalClient, journal pb.Journal, offset, length int64) string {
+	var r = client.NewReader(pb.WithDispatchDefault(context.Background()), rjc, pb.ReadRequest{
+		Journal: journal,
+		Offset:  offset,
+		Block:   true,
+	})
+	var b = make([]byte, length)
+	var _, err = io.ReadFull(r, b)
+	c.Assert(err, gc.IsNil)
+	return string(b)
+}
+
+// waitForOffset waits for the Mirror to observe the commit of |journal|
+// through |offset|, which may follow the commit becoming readable.
+func waitForOffset(c *gc.C, m *Mirror, journal pb.Journal, offset int64) {
+	for i := 0; m.offset(journal) != offset; i++ {
+		c.Assert(i < 1000, gc.Equals, true)
+		time.Sleep(time.Millisecond)
+	}
+}
+
+var _ = gc.Suite(&MirrorSuite{})
+
+func Test(t *testing.T) { gc.TestingT(t) }
@@ -25,10 +25,22 @@ func (m *ProcessSpec) Validate() error {
 		return ExtendContext(err, "Id")
 	} else if err = m.Endpoint.Validate(); err != nil {
 		return ExtendContext(err, "Endpoint")
+	} else if err = m.Labels.Validate(); err != nil {
+		return ExtendContext(err, "Labels")
 	}
 	return nil
 }
 
+// MemberLabels is the canonical string of the ProcessSpec Labels.
+// allocator.LabeledMember implementation.
+func (m *ProcessSpec) MemberLabels() string {
+	return LabelSelector{Include: m.Labels}.String()
+}
+
+// IsCordoned returns whether the ProcessSpec is cordoned.
+// allocator.CordonedMember implementation.
+func (m *ProcessSpec) IsCordoned() bool { return m.Cordon }
+
 // Validate returns an error if the BrokerSpec is not well-formed.
 func (m *BrokerSpec) Validate() error {
 	if err := m.ProcessSpec.Validate(); err != nil {
@@ -49,12 +61,22 @@ func (m *BrokerSpec) MarshalString() string {
 	return string(d)
 }
 
-// ZeroLimit zeros the BrokerSpec JournalLimit.
-func (m *BrokerSpec) ZeroLimit() { m.JournalLimit = 0 }
+// ZeroLimit zeros the BrokerSpec JournalLimit and JournalWeightLimit.
+func (m *BrokerSpec) ZeroLimit() { m.JournalLimit, m.JournalWeightLimit = 0, 0 }
 
 // v3_allocator.MemberValue implementation.
 func (m *BrokerSpec) ItemLimit() int { return int(m.JournalLimit) }
 
+// ItemWeightLimit is the JournalWeightLimit of the BrokerSpec, or its
+// JournalLimit if JournalWeightLimit is zero. allocator.WeightedMember
+// implementation.
+func (m *BrokerSpec) ItemWeightLimit() int {
+	if m.JournalWeightLimit == 0 {
+		return int(m.JournalLimit)
+	}
+	return int(m.JournalWeightLimit)
+}
+
 const (
 	minZoneLen            = 1
 	maxZoneLen            = 16
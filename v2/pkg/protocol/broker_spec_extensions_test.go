@@ -37,6 +37,10 @@ func (s *BrokerSpecSuite) TestSpecValidationCases(c *gc.C) {
 	}
 	c.Check(model.Validate(), gc.Equals, nil)
 	c.Check(model.ItemLimit(), gc.Equals, 5)
+	c.Check(model.ItemWeightLimit(), gc.Equals, 5)
+
+	model.JournalWeightLimit = 500
+	c.Check(model.ItemWeightLimit(), gc.Equals, 500)
 
 	model.Id.Zone = ""
 	c.Check(model.Validate(), gc.ErrorMatches, "Id.Zone: invalid length .*")
@@ -46,6 +50,17 @@ func (s *BrokerSpecSuite) TestSpecValidationCases(c *gc.C) {
 	c.Check(model.Validate(), gc.ErrorMatches, "Endpoint: not absolute: .*")
 
 	model.Endpoint = "http://foo"
+	model.Labels = LabelSet{Labels: []Label{{Name: "disk", Value: "ssd"}, {Name: "bad label"}}}
+	c.Check(model.Validate(), gc.ErrorMatches, `Labels.Labels\[1\].Name: not a valid token \(bad label\)`)
+
+	model.Labels = MustLabelSet("disk", "ssd", "rack", "r1")
+	c.Check(model.Validate(), gc.IsNil)
+	c.Check(model.MemberLabels(), gc.Equals, "disk=ssd,rack=r1,")
+
+	c.Check(model.IsCordoned(), gc.Equals, false)
+	model.Cordon = true
+	c.Check(model.IsCordoned(), gc.Equals, true)
+
 	model.JournalLimit = maxBrokerJournalLimit + 1
 	c.Check(model.Validate(), gc.ErrorMatches, `invalid JournalLimit \(\d+; expected 0 <= JournalLimit <= \d+\)`)
 }
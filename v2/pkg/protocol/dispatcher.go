@@ -2,13 +2,19 @@ package protocol
 
 import (
 	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
 	"sync"
 	"time"
 
+	"github.com/LiveRamp/gazette/v2/pkg/tracing"
 	"golang.org/x/net/trace"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/balancer"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/resolver"
 	"google.golang.org/grpc/status"
 )
@@ -21,10 +27,45 @@ const DispatcherGRPCBalancerName = "protocolDispatcher"
 // RegisterGRPCDispatcher registers the dispatcher balancer with gRPC. It should
 // be called once at program startup. The supplied |localZone| is used to prefer
 // intra-zone (over inter-zone) members where able.
+//
+// The dispatcher is aware of the transport security of the ClientConn it's
+// built for: a ClientConn using TLS may only dispatch to members having
+// https:// Endpoints, and an insecure ClientConn only to http:// Endpoints.
+// See DispatcherDialOptions.
 func RegisterGRPCDispatcher(localZone string) {
 	balancer.Register(dispatcherBuilder{zone: localZone})
 }
 
+// DispatcherDialOptions returns grpc.DialOptions which wire a ClientConn for
+// use with the dispatcher balancer, dialing connections with |dialer|. If
+// |tlsConfig| is nil the ClientConn is insecure. Otherwise, connections are
+// secured with TLS and, as dispatched members are dialed at the hosts of their
+// advertised Endpoints (rather than the ClientConn's service address), each
+// member's certificate is verified against the host which was actually dialed.
+// If a client certificate is present in |tlsConfig|, it's presented to servers
+// which require mutual TLS. RPCs of the ClientConn are traced, and propagate
+// the trace context of their caller (see package tracing).
+func DispatcherDialOptions(tlsConfig *tls.Config, dialer func(string, time.Duration) (net.Conn, error)) []grpc.DialOption {
+	if tlsConfig == nil {
+		return append([]grpc.DialOption{
+			grpc.WithInsecure(),
+			grpc.WithDialer(dialer),
+			grpc.WithBalancerName(DispatcherGRPCBalancerName),
+		}, tracing.DialOptions()...)
+	}
+	return append([]grpc.DialOption{
+		grpc.WithTransportCredentials(dispatcherTLS{credentials.NewTLS(tlsConfig)}),
+		grpc.WithDialer(func(addr string, timeout time.Duration) (net.Conn, error) {
+			if conn, err := dialer(addr, timeout); err != nil {
+				return nil, err
+			} else {
+				return dialedConn{Conn: conn, addr: addr}, nil
+			}
+		}),
+		grpc.WithBalancerName(DispatcherGRPCBalancerName),
+	}, tracing.DialOptions()...)
+}
+
 // WithDispatchRoute attaches a Route and optional ProcessSpec_ID to a Context
 // passed to a gRPC RPC call. If ProcessSpec_ID is non-zero valued, the RPC is
 // dispatched to the specified member. Otherwise, the RPC is dispatched to a
@@ -80,8 +121,9 @@ func (NoopDispatchRouter) IsNoopRouter() bool                  { return true }
 // SubConns creation and selection is driven by the Routes and ProcessSpec_IDs
 // attached to RPC call Contexts via WithDispatchRoute or WithDispatchItemRoute.
 type dispatcher struct {
-	cc   balancer.ClientConn
-	zone string
+	cc     balancer.ClientConn
+	zone   string
+	secure bool // Whether |cc| uses TLS transport security.
 
 	idConn    map[ProcessSpec_ID]markedSubConn
 	connID    map[balancer.SubConn]ProcessSpec_ID
@@ -124,10 +166,13 @@ func (d *dispatcher) Pick(ctx context.Context, opts balancer.PickOptions) (balan
 	msc, ok := d.idConn[dispatchID]
 	if !ok {
 		// Initiate a new SubConn to the ProcessSpec_ID.
-		var err error
+		var addr, err = d.idToAddr(dr.route, dispatchID)
+		if err != nil {
+			return nil, nil, err
+		}
 		if msc.subConn, err = d.cc.NewSubConn(
 			[]resolver.Address{{
-				Addr: d.idToAddr(dr.route, dispatchID),
+				Addr: addr,
 				Type: resolver.Backend,
 			}},
 			balancer.NewSubConnOptions{},
@@ -155,6 +200,9 @@ func (d *dispatcher) Pick(ctx context.Context, opts balancer.PickOptions) (balan
 		tr.LazyPrintf("Pick(Route: %s, ID: %s) => %s (%s)",
 			&dr.route, &dr.id, &dispatchID, state)
 	}
+	tracing.FromContext(ctx).AddEvent("Pick(Route: %s, ID: %s) => %s (%s)",
+		&dr.route, &dr.id, &dispatchID, state)
+
 	switch state {
 	case connectivity.Idle, connectivity.Connecting:
 		// gRPC will block until connection becomes ready.
@@ -235,15 +283,24 @@ func (d *dispatcher) less(lhs, rhs ProcessSpec_ID) bool {
 	return lState > rState
 }
 
-// idToAddr returns a suitable address for the ID.
-func (d *dispatcher) idToAddr(rt Route, id ProcessSpec_ID) string {
+// idToAddr returns a suitable address for the ID. An error is returned if the
+// ID's Endpoint scheme is incompatible with the transport security of the
+// dispatcher's ClientConn.
+func (d *dispatcher) idToAddr(rt Route, id ProcessSpec_ID) (string, error) {
 	if id == (ProcessSpec_ID{}) {
-		return d.cc.Target() // Use the default service address.
+		return d.cc.Target(), nil // Use the default service address.
 	}
 	for i := range rt.Members {
-		if rt.Members[i] == id {
-			return rt.Endpoints[i].URL().Host
+		if rt.Members[i] != id {
+			continue
+		}
+		var url = rt.Endpoints[i].URL()
+
+		if (url.Scheme == "https") != d.secure {
+			return "", fmt.Errorf("endpoint %s of %s is incompatible with ClientConn transport security (secure: %t)",
+				rt.Endpoints[i], id, d.secure)
 		}
+		return url.Host, nil
 	}
 	panic("ProcessSpec_ID must be in Route.Members")
 }
@@ -304,8 +361,9 @@ func (db dispatcherBuilder) Name() string { return DispatcherGRPCBalancerName }
 
 func (db dispatcherBuilder) Build(cc balancer.ClientConn, opts balancer.BuildOptions) balancer.Balancer {
 	var d = &dispatcher{
-		cc:   cc,
-		zone: db.zone,
+		cc:     cc,
+		zone:   db.zone,
+		secure: opts.DialCreds != nil && opts.DialCreds.Info().SecurityProtocol == "tls",
 
 		idConn:    make(map[ProcessSpec_ID]markedSubConn),
 		connID:    make(map[balancer.SubConn]ProcessSpec_ID),
@@ -332,4 +390,28 @@ type (
 	dispatchRouteCtxKey struct{}
 )
 
+// dispatcherTLS wraps TLS TransportCredentials to verify server certificates
+// against the address dialed for the connection (as captured by dialedConn),
+// rather than the authority of the ClientConn.
+type dispatcherTLS struct {
+	credentials.TransportCredentials
+}
+
+func (d dispatcherTLS) ClientHandshake(ctx context.Context, authority string, conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	if dc, ok := conn.(dialedConn); ok {
+		authority, conn = dc.addr, dc.Conn
+	}
+	return d.TransportCredentials.ClientHandshake(ctx, authority, conn)
+}
+
+func (d dispatcherTLS) Clone() credentials.TransportCredentials {
+	return dispatcherTLS{d.TransportCredentials.Clone()}
+}
+
+// dialedConn is a net.Conn which retains the address it was dialed with.
+type dialedConn struct {
+	net.Conn
+	addr string
+}
+
 var dispatchSweepInterval = time.Second * 30
@@ -2,12 +2,15 @@ package protocol
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
+	"net"
 
 	gc "github.com/go-check/check"
 	"google.golang.org/grpc/balancer"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/resolver"
 	"google.golang.org/grpc/status"
 )
@@ -234,6 +237,80 @@ func (s *DispatcherSuite) TestDispatchMarkAndSweep(c *gc.C) {
 	c.Check(err, gc.IsNil)
 }
 
+func (s *DispatcherSuite) TestDispatchTransportSecurity(c *gc.C) {
+	var cc mockClientConn
+	var disp = dispatcherBuilder{zone: "local"}.Build(&cc, balancer.BuildOptions{
+		DialCreds: dispatcherTLS{credentials.NewTLS(&tls.Config{})},
+	}).(*dispatcher)
+	close(disp.sweepDoneCh) // Disable async sweeping.
+
+	c.Check(disp.secure, gc.Equals, true)
+
+	// Case: the default service address is always dispatched to.
+	var _, _, err = disp.Pick(WithDispatchDefault(context.Background()), balancer.PickOptions{})
+	c.Check(err, gc.Equals, balancer.ErrNoSubConnAvailable)
+	c.Check(cc.created, gc.DeepEquals, []mockSubConn{"default.addr"})
+	cc.created = nil
+
+	// Case: an http:// member cannot be dispatched to over a secure ClientConn.
+	var rt = buildRouteFixture()
+	var ctx = WithDispatchRoute(context.Background(), rt, rt.Members[0])
+
+	_, _, err = disp.Pick(ctx, balancer.PickOptions{})
+	c.Check(err, gc.ErrorMatches, `endpoint http://remote.addr of .* is incompatible with ClientConn transport security \(secure: true\)`)
+	c.Check(cc.created, gc.IsNil)
+
+	// Case: an https:// member is dispatched to.
+	rt.Endpoints[0] = "https://remote.addr:8443"
+	ctx = WithDispatchRoute(context.Background(), rt, rt.Members[0])
+
+	_, _, err = disp.Pick(ctx, balancer.PickOptions{})
+	c.Check(err, gc.Equals, balancer.ErrNoSubConnAvailable)
+	c.Check(cc.created, gc.DeepEquals, []mockSubConn{"remote.addr:8443"})
+
+	// Conversely, an insecure dispatcher refuses https:// members.
+	disp = dispatcherBuilder{zone: "local"}.Build(&cc, balancer.BuildOptions{}).(*dispatcher)
+	close(disp.sweepDoneCh)
+
+	_, _, err = disp.Pick(ctx, balancer.PickOptions{})
+	c.Check(err, gc.ErrorMatches, `endpoint https://remote.addr:8443 of .* \(secure: false\)`)
+}
+
+func (s *DispatcherSuite) TestTLSHandshakeUsesDialedAddress(c *gc.C) {
+	var mc = new(mockCreds)
+	var creds = dispatcherTLS{mc}
+	var conn, _ = net.Pipe()
+
+	// A dialedConn is unwrapped, and its address is used as the authority.
+	var _, _, err = creds.ClientHandshake(context.Background(), "default.addr:8080",
+		dialedConn{Conn: conn, addr: "peer.addr:8443"})
+	c.Check(err, gc.IsNil)
+	c.Check(mc.authority, gc.Equals, "peer.addr:8443")
+	c.Check(mc.conn, gc.Equals, conn)
+
+	// Other connections use the authority of the ClientConn.
+	_, _, err = creds.ClientHandshake(context.Background(), "default.addr:8080", conn)
+	c.Check(err, gc.IsNil)
+	c.Check(mc.authority, gc.Equals, "default.addr:8080")
+
+	// Clones remain wrapped.
+	_, ok := creds.Clone().(dispatcherTLS)
+	c.Check(ok, gc.Equals, true)
+}
+
+type mockCreds struct {
+	credentials.TransportCredentials // Unimplemented.
+	authority                        string
+	conn                             net.Conn
+}
+
+func (m *mockCreds) ClientHandshake(_ context.Context, authority string, conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	m.authority, m.conn = authority, conn
+	return conn, nil, nil
+}
+
+func (m *mockCreds) Clone() credentials.TransportCredentials { return m }
+
 type mockClientConn struct {
 	err     error
 	created []mockSubConn
@@ -9,6 +9,10 @@ import (
 // and query components. At present, supported schemes are:
 //
 //  * http://host(:port)/path?query
+//  * https://host(:port)/path?query
+//
+// https:// Endpoints are served with TLS, and are dialed using TLS transport
+// security (see DispatcherDialOptions).
 //
 type Endpoint string
 
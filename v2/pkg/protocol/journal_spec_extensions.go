@@ -48,10 +48,18 @@ func (m *JournalSpec) Validate() error {
 		return NewValidationError(`Labels cannot include label "prefix"`)
 	} else if err = validateFramingLabel(m.LabelSet); err != nil {
 		return err
+	} else if _, err = ParseWeightLabel(m.LabelSet); err != nil {
+		return err
 	} else if err = m.Fragment.Validate(); err != nil {
 		return ExtendContext(err, "Fragment")
 	} else if err = m.Flags.Validate(); err != nil {
 		return ExtendContext(err, "Flags")
+	} else if m.MaxAppendSize < 0 {
+		return NewValidationError("invalid MaxAppendSize (%d; expected >= 0)", m.MaxAppendSize)
+	} else if m.MaxAppendRate < 0 {
+		return NewValidationError("invalid MaxAppendRate (%d; expected >= 0)", m.MaxAppendRate)
+	} else if err = m.BrokerSelector.Validate(); err != nil {
+		return ExtendContext(err, "BrokerSelector")
 	}
 
 	return nil
@@ -125,6 +133,23 @@ func (m *JournalSpec) MarshalString() string {
 // v3_allocator.ItemValue implementation.
 func (m *JournalSpec) DesiredReplication() int { return int(m.Replication) }
 
+// ItemWeight is the weight of the JournalSpec "weight" label, or one if the
+// label isn't set. allocator.WeightedItem implementation.
+func (m *JournalSpec) ItemWeight() int {
+	var w, _ = ParseWeightLabel(m.LabelSet) // Validated by JournalSpec.Validate.
+	return w
+}
+
+// MemberSelector is the canonical string of the JournalSpec BrokerSelector.
+// allocator.SelectiveItem implementation.
+func (m *JournalSpec) MemberSelector() string { return m.BrokerSelector.String() }
+
+// SelectsMember returns whether the BrokerSelector matches the Labels of the
+// BrokerSpec |member|. allocator.SelectiveItem implementation.
+func (m *JournalSpec) SelectsMember(member allocator.MemberValue) bool {
+	return m.BrokerSelector.Matches(member.(*BrokerSpec).Labels)
+}
+
 // IsConsistent returns true if the Route stored under each of |assignments|
 // agrees with the Route implied by the |assignments| keys.
 func (m *JournalSpec) IsConsistent(_ keyspace.KeyValue, assignments keyspace.KeyValues) bool {
@@ -166,6 +191,15 @@ func UnionJournalSpecs(a, b JournalSpec) JournalSpec {
 	if a.Flags == JournalSpec_NOT_SPECIFIED {
 		a.Flags = b.Flags
 	}
+	if a.MaxAppendSize == 0 {
+		a.MaxAppendSize = b.MaxAppendSize
+	}
+	if a.MaxAppendRate == 0 {
+		a.MaxAppendRate = b.MaxAppendRate
+	}
+	if a.BrokerSelector.String() == "" {
+		a.BrokerSelector = b.BrokerSelector
+	}
 	return a
 }
 
@@ -195,6 +229,15 @@ func IntersectJournalSpecs(a, b JournalSpec) JournalSpec {
 	if a.Flags != b.Flags {
 		a.Flags = JournalSpec_NOT_SPECIFIED
 	}
+	if a.MaxAppendSize != b.MaxAppendSize {
+		a.MaxAppendSize = 0
+	}
+	if a.MaxAppendRate != b.MaxAppendRate {
+		a.MaxAppendRate = 0
+	}
+	if a.BrokerSelector.String() != b.BrokerSelector.String() {
+		a.BrokerSelector = MemberSelector{}
+	}
 	return a
 }
 
@@ -224,6 +267,15 @@ func SubtractJournalSpecs(a, b JournalSpec) JournalSpec {
 	if a.Flags == b.Flags {
 		a.Flags = JournalSpec_NOT_SPECIFIED
 	}
+	if a.MaxAppendSize == b.MaxAppendSize {
+		a.MaxAppendSize = 0
+	}
+	if a.MaxAppendRate == b.MaxAppendRate {
+		a.MaxAppendRate = 0
+	}
+	if a.BrokerSelector.String() == b.BrokerSelector.String() {
+		a.BrokerSelector = MemberSelector{}
+	}
 	return a
 }
 
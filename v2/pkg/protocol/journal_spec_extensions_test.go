@@ -73,6 +73,13 @@ func (s *JournalSuite) TestSpecValidationCases(c *gc.C) {
 	c.Check(spec.Validate(), gc.ErrorMatches, `Label "framing" cannot have multiple values`)
 	spec.Labels = spec.Labels[:1]
 
+	c.Check(spec.ItemWeight(), gc.Equals, 1)
+	spec.Labels = append(spec.Labels, Label{Name: "weight", Value: "0"})
+	c.Check(spec.Validate(), gc.ErrorMatches, `Label "weight" contains an invalid value \(0; .*\)`)
+	spec.Labels[1].Value = "10"
+	c.Check(spec.ItemWeight(), gc.Equals, 10)
+	spec.Labels = spec.Labels[:1]
+
 	spec.Fragment.Length = 0
 	c.Check(spec.Validate(), gc.ErrorMatches, `Fragment: invalid Length \(0; expected 1024 <= length <= \d+\)`)
 	spec.Fragment.Length = 4096
@@ -97,6 +104,17 @@ func (s *JournalSuite) TestSpecValidationCases(c *gc.C) {
 		c.Check(spec.Validate(), gc.IsNil)
 	}
 
+	spec.MaxAppendSize = -1
+	c.Check(spec.Validate(), gc.ErrorMatches, `invalid MaxAppendSize \(-1; expected >= 0\)`)
+	spec.MaxAppendSize = 1 << 20
+	spec.MaxAppendRate = -1
+	c.Check(spec.Validate(), gc.ErrorMatches, `invalid MaxAppendRate \(-1; expected >= 0\)`)
+	spec.MaxAppendRate = 1 << 16
+	spec.BrokerSelector.Include = LabelSet{Labels: []Label{{Name: "xxx xxx"}}}
+	c.Check(spec.Validate(), gc.ErrorMatches, `BrokerSelector.Include.Labels\[0\].Name: not a valid token \(xxx xxx\)`)
+	spec.BrokerSelector.Include = MustLabelSet("disk", "ssd")
+	c.Check(spec.Validate(), gc.IsNil)
+
 	// Additional tests of JournalSpec_Fragment cases.
 	var f = &spec.Fragment
 
@@ -165,6 +183,25 @@ func (s *JournalSuite) TestConsistencyCases(c *gc.C) {
 	c.Check(spec.IsConsistent(keyspace.KeyValue{}, assignments), gc.Equals, false)
 }
 
+func (s *JournalSuite) TestBrokerSelection(c *gc.C) {
+	var spec = JournalSpec{Name: "a/journal", Replication: 1}
+	var broker = &BrokerSpec{ProcessSpec: ProcessSpec{Labels: MustLabelSet("disk", "ssd", "rack", "r1")}}
+
+	// An empty BrokerSelector selects every broker.
+	c.Check(spec.MemberSelector(), gc.Equals, "")
+
+	spec.BrokerSelector = MemberSelector{Include: MustLabelSet("disk", "ssd")}
+	c.Check(spec.MemberSelector(), gc.Equals, "disk=ssd,")
+	c.Check(spec.SelectsMember(broker), gc.Equals, true)
+
+	spec.BrokerSelector.Exclude = MustLabelSet("rack", "r1")
+	c.Check(spec.MemberSelector(), gc.Equals, "disk=ssd,rack!=r1")
+	c.Check(spec.SelectsMember(broker), gc.Equals, false)
+
+	broker.Labels = MustLabelSet("disk", "ssd", "rack", "r2")
+	c.Check(spec.SelectsMember(broker), gc.Equals, true)
+}
+
 func (s *JournalSuite) TestSetOperations(c *gc.C) {
 	var model = JournalSpec{
 		Replication: 3,
@@ -184,7 +221,13 @@ func (s *JournalSuite) TestSetOperations(c *gc.C) {
 			RefreshInterval: time.Minute,
 			Retention:       time.Hour,
 		},
-		Flags: JournalSpec_O_RDWR,
+		Flags:         JournalSpec_O_RDWR,
+		MaxAppendSize: 1 << 20,
+		MaxAppendRate: 1 << 16,
+		BrokerSelector: MemberSelector{
+			Include: MustLabelSet("disk", "ssd"),
+			Exclude: MustLabelSet("rack", "r1"),
+		},
 	}
 
 	c.Check(UnionJournalSpecs(JournalSpec{}, model), gc.DeepEquals, model)
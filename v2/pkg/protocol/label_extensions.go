@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"regexp"
 	"sort"
+	"strconv"
 )
 
 // Validate returns an error if the Label is not well-formed.
@@ -70,6 +71,28 @@ func (m LabelSet) ValuesOf(name string) (values []string) {
 	return
 }
 
+// ParseWeightLabel returns the positive integer weight of a "weight" label of
+// the LabelSet, or one if the LabelSet has no "weight" label. Weights are used
+// by the allocator to balance the load (rather than the count) of journals or
+// shards assigned to each broker or consumer.
+func ParseWeightLabel(labels LabelSet) (int, error) {
+	var w = labels.ValuesOf("weight")
+
+	switch len(w) {
+	case 0:
+		return 1, nil
+	default:
+		return 0, NewValidationError(`Label "weight" cannot have multiple values`)
+	case 1: // Pass.
+	}
+	if n, err := strconv.Atoi(w[0]); err != nil || n < 1 || n > maxWeightLabel {
+		return 0, NewValidationError(`Label "weight" contains an invalid value (%s; expected 1 <= weight <= %d)`,
+			w[0], maxWeightLabel)
+	} else {
+		return n, nil
+	}
+}
+
 // UnionLabelSets returns the LabelSet having all labels present in either |lhs|
 // or |rhs|. Where both |lhs| and |rhs| have values for a label, those of |lhs|
 // are preferred.
@@ -150,6 +173,57 @@ func (m LabelSelector) Matches(s LabelSet) bool {
 	return true
 }
 
+// MemberSelector is a LabelSelector of allocator Members, used by Items
+// (eg, JournalSpec.BrokerSelector) to constrain the Members to which they may
+// be assigned. Its wire representation is that of LabelSelector, while in YAML
+// it's represented as the canonical LabelSelector string (eg, "disk=ssd").
+type MemberSelector LabelSelector
+
+// Validate returns an error if the MemberSelector is not well-formed.
+func (m MemberSelector) Validate() error { return LabelSelector(m).Validate() }
+
+// Matches returns whether the LabelSet is matched by the MemberSelector.
+func (m MemberSelector) Matches(s LabelSet) bool { return LabelSelector(m).Matches(s) }
+
+// String returns the canonical LabelSelector string of the MemberSelector.
+func (m MemberSelector) String() string { return LabelSelector(m).String() }
+
+// Marshal the MemberSelector as a LabelSelector.
+func (m MemberSelector) Marshal() ([]byte, error) { return (*LabelSelector)(&m).Marshal() }
+
+// MarshalTo marshals the MemberSelector as a LabelSelector.
+func (m *MemberSelector) MarshalTo(dAtA []byte) (int, error) {
+	return (*LabelSelector)(m).MarshalTo(dAtA)
+}
+
+// Unmarshal the MemberSelector from a LabelSelector.
+func (m *MemberSelector) Unmarshal(dAtA []byte) error { return (*LabelSelector)(m).Unmarshal(dAtA) }
+
+// ProtoSize returns the marshalled size of the MemberSelector.
+func (m *MemberSelector) ProtoSize() int { return (*LabelSelector)(m).ProtoSize() }
+
+// Size returns the marshalled size of the MemberSelector.
+func (m *MemberSelector) Size() int { return m.ProtoSize() }
+
+// MarshalYAML marshals the MemberSelector as its canonical string.
+func (m MemberSelector) MarshalYAML() (interface{}, error) {
+	return m.String(), nil
+}
+
+// UnmarshalYAML parses a MemberSelector from its string representation.
+func (m *MemberSelector) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var str string
+
+	if err := unmarshal(&str); err != nil {
+		return err
+	} else if sel, err := ParseLabelSelector(str); err != nil {
+		return err
+	} else {
+		*m = MemberSelector(sel)
+	}
+	return nil
+}
+
 // String returns a canonical string representation of the LabelSelector.
 func (s LabelSelector) String() string {
 	var w = bytes.NewBuffer(nil)
@@ -384,4 +458,5 @@ var (
 const (
 	minLabelLen, maxLabelLen = 2, 64
 	maxLabelValueLen         = 1024
+	maxWeightLabel           = 1 << 16
 )
@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	gc "github.com/go-check/check"
+	"gopkg.in/yaml.v2"
 )
 
 type LabelSuite struct{}
@@ -95,6 +96,23 @@ func (s *LabelSuite) TestValuesOfCases(c *gc.C) {
 	}
 }
 
+func (s *LabelSuite) TestWeightLabelParsing(c *gc.C) {
+	var w, err = ParseWeightLabel(MustLabelSet("foo", "bar"))
+	c.Check(w, gc.Equals, 1)
+	c.Check(err, gc.IsNil)
+
+	w, err = ParseWeightLabel(MustLabelSet("weight", "25"))
+	c.Check(w, gc.Equals, 25)
+	c.Check(err, gc.IsNil)
+
+	for _, tc := range []string{"0", "-1", "1.5", "abc", "65537"} {
+		_, err = ParseWeightLabel(MustLabelSet("weight", tc))
+		c.Check(err, gc.ErrorMatches, `Label "weight" contains an invalid value \(.*; expected 1 <= weight <= 65536\)`)
+	}
+	_, err = ParseWeightLabel(MustLabelSet("weight", "1", "weight", "2"))
+	c.Check(err, gc.ErrorMatches, `Label "weight" cannot have multiple values`)
+}
+
 func (s *LabelSuite) TestUnion(c *gc.C) {
 	c.Check(UnionLabelSets(MustLabelSet(
 		"bbb", "val-1",
@@ -234,6 +252,29 @@ func (s *LabelSuite) TestSelectorMatchingCases(c *gc.C) {
 	c.Check(sel.Matches(MustLabelSet("exc-1", "any", "foo", "bar")), gc.Equals, false)
 }
 
+func (s *LabelSuite) TestMemberSelectorYAMLRoundTrip(c *gc.C) {
+	var doc = struct {
+		Selector MemberSelector `yaml:"selector,omitempty"`
+	}{}
+	c.Check(yaml.Unmarshal([]byte("selector: disk=ssd, rack notin (r1, r2)\n"), &doc), gc.IsNil)
+	c.Check(doc.Selector, gc.DeepEquals, MemberSelector{
+		Include: MustLabelSet("disk", "ssd"),
+		Exclude: MustLabelSet("rack", "r1", "rack", "r2"),
+	})
+
+	var b, err = yaml.Marshal(doc)
+	c.Check(err, gc.IsNil)
+	c.Check(string(b), gc.Equals, "selector: disk=ssd,rack notin (r1,r2)\n")
+
+	// An empty MemberSelector is omitted.
+	doc.Selector = MemberSelector{}
+	b, _ = yaml.Marshal(doc)
+	c.Check(string(b), gc.Equals, "{}\n")
+
+	// Invalid selectors fail to unmarshal.
+	c.Check(yaml.Unmarshal([]byte("selector: foo, foo in (bar)\n"), &doc), gc.NotNil)
+}
+
 func (s *LabelSuite) TestOuterJoin(c *gc.C) {
 	var lhs, rhs = MustLabelSet(
 		"aaa", "l0",
@@ -74,6 +74,13 @@ const (
 	// that journal replication consistency has been lost in the past, due to
 	// too many broker or Etcd failures.
 	Status_INDEX_HAS_GREATER_OFFSET Status = 12
+	// The Append is refused because its content could not be appended at the
+	// sustained append rate permitted by the JournalSpec prior to the client's
+	// deadline. Clients should back off and retry.
+	Status_RATE_LIMITED Status = 13
+	// The Append is refused because its content exceeds the maximum append
+	// size permitted by the JournalSpec. Retries of the Append will also fail.
+	Status_APPEND_TOO_LARGE Status = 14
 )
 
 var Status_name = map[int32]string{
@@ -90,6 +97,8 @@ var Status_name = map[int32]string{
 	10: "NOT_ALLOWED",
 	11: "WRONG_APPEND_OFFSET",
 	12: "INDEX_HAS_GREATER_OFFSET",
+	13: "RATE_LIMITED",
+	14: "APPEND_TOO_LARGE",
 }
 var Status_value = map[string]int32{
 	"OK":                           0,
@@ -105,13 +114,15 @@ var Status_value = map[string]int32{
 	"NOT_ALLOWED":                  10,
 	"WRONG_APPEND_OFFSET":          11,
 	"INDEX_HAS_GREATER_OFFSET":     12,
+	"RATE_LIMITED":                 13,
+	"APPEND_TOO_LARGE":             14,
 }
 
 func (x Status) String() string {
 	return proto.EnumName(Status_name, int32(x))
 }
 func (Status) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_6d2e90632fd7d17f, []int{0}
+	return fileDescriptor_protocol_c5ac8b8be4f4b766, []int{0}
 }
 
 // CompressionCode defines codecs known to Gazette.
@@ -163,7 +174,7 @@ func (x CompressionCodec) String() string {
 	return proto.EnumName(CompressionCodec_name, int32(x))
 }
 func (CompressionCodec) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_6d2e90632fd7d17f, []int{1}
+	return fileDescriptor_protocol_c5ac8b8be4f4b766, []int{1}
 }
 
 // Flags define Journal IO control behaviors. Where possible, flags are named
@@ -200,7 +211,42 @@ func (x JournalSpec_Flag) String() string {
 	return proto.EnumName(JournalSpec_Flag_name, int32(x))
 }
 func (JournalSpec_Flag) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_6d2e90632fd7d17f, []int{3, 0}
+	return fileDescriptor_protocol_c5ac8b8be4f4b766, []int{3, 0}
+}
+
+// State of the replication pipeline of the replica.
+type ReplicasResponse_Replica_PipelineState int32
+
+const (
+	// No pipeline is currently established.
+	ReplicasResponse_Replica_NONE ReplicasResponse_Replica_PipelineState = 0
+	// A pipeline is established and idle.
+	ReplicasResponse_Replica_READY ReplicasResponse_Replica_PipelineState = 1
+	// The pipeline is held by an in-progress Append or health check.
+	ReplicasResponse_Replica_BUSY ReplicasResponse_Replica_PipelineState = 2
+	// The pipeline cannot be established until the broker reads through
+	// |pipeline_read_through_revision|.
+	ReplicasResponse_Replica_AWAITING_REVISION ReplicasResponse_Replica_PipelineState = 3
+)
+
+var ReplicasResponse_Replica_PipelineState_name = map[int32]string{
+	0: "NONE",
+	1: "READY",
+	2: "BUSY",
+	3: "AWAITING_REVISION",
+}
+var ReplicasResponse_Replica_PipelineState_value = map[string]int32{
+	"NONE":              0,
+	"READY":             1,
+	"BUSY":              2,
+	"AWAITING_REVISION": 3,
+}
+
+func (x ReplicasResponse_Replica_PipelineState) String() string {
+	return proto.EnumName(ReplicasResponse_Replica_PipelineState_name, int32(x))
+}
+func (ReplicasResponse_Replica_PipelineState) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_protocol_c5ac8b8be4f4b766, []int{19, 0, 0}
 }
 
 // Label defines a key & value pair which can be attached to entities like
@@ -216,7 +262,7 @@ func (m *Label) Reset()         { *m = Label{} }
 func (m *Label) String() string { return proto.CompactTextString(m) }
 func (*Label) ProtoMessage()    {}
 func (*Label) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_6d2e90632fd7d17f, []int{0}
+	return fileDescriptor_protocol_c5ac8b8be4f4b766, []int{0}
 }
 func (m *Label) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -255,7 +301,7 @@ func (m *LabelSet) Reset()         { *m = LabelSet{} }
 func (m *LabelSet) String() string { return proto.CompactTextString(m) }
 func (*LabelSet) ProtoMessage()    {}
 func (*LabelSet) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_6d2e90632fd7d17f, []int{1}
+	return fileDescriptor_protocol_c5ac8b8be4f4b766, []int{1}
 }
 func (m *LabelSet) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -299,7 +345,7 @@ type LabelSelector struct {
 func (m *LabelSelector) Reset()      { *m = LabelSelector{} }
 func (*LabelSelector) ProtoMessage() {}
 func (*LabelSelector) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_6d2e90632fd7d17f, []int{2}
+	return fileDescriptor_protocol_c5ac8b8be4f4b766, []int{2}
 }
 func (m *LabelSelector) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -343,13 +389,29 @@ type JournalSpec struct {
 	// Flags of the Journal, as a combination of Flag enum values. The Flag enum
 	// not used directly, as protobuf enums do not allow for or'ed bitfields.
 	Flags JournalSpec_Flag `protobuf:"varint,6,opt,name=flags,proto3,casttype=JournalSpec_Flag" json:"flags,omitempty" yaml:",omitempty"`
+	// Maximum number of content bytes which may be written by a single Append
+	// transaction. Appends which exceed this size are rolled back and fail with
+	// APPEND_TOO_LARGE. If zero, the size of Appends is not limited.
+	MaxAppendSize int64 `protobuf:"varint,7,opt,name=max_append_size,json=maxAppendSize,proto3" json:"max_append_size,omitempty" yaml:"max_append_size,omitempty"`
+	// Maximum sustained rate, in bytes per second, at which content may be
+	// appended to the Journal. The rate is enforced by the primary broker as a
+	// token bucket which permits bursts of up to one second of content. Appends
+	// which exceed it are throttled, and fail with RATE_LIMITED only if the
+	// client's deadline would pass before their content is permitted. If zero,
+	// the append rate is not limited.
+	MaxAppendRate int64 `protobuf:"varint,8,opt,name=max_append_rate,json=maxAppendRate,proto3" json:"max_append_rate,omitempty" yaml:"max_append_rate,omitempty"`
+	// Selector over the Labels of brokers to which the Journal may be assigned.
+	// Eg, "disk=ssd" pins the Journal to brokers having label "disk" of value
+	// "ssd", and "rack!=r1" keeps the Journal off of brokers in rack "r1". If
+	// empty, the Journal may be assigned to any broker.
+	BrokerSelector MemberSelector `protobuf:"bytes,9,opt,name=broker_selector,json=brokerSelector,customtype=MemberSelector" json:"broker_selector" yaml:"broker_selector,omitempty"`
 }
 
 func (m *JournalSpec) Reset()         { *m = JournalSpec{} }
 func (m *JournalSpec) String() string { return proto.CompactTextString(m) }
 func (*JournalSpec) ProtoMessage()    {}
 func (*JournalSpec) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_6d2e90632fd7d17f, []int{3}
+	return fileDescriptor_protocol_c5ac8b8be4f4b766, []int{3}
 }
 func (m *JournalSpec) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -422,7 +484,7 @@ func (m *JournalSpec_Fragment) Reset()         { *m = JournalSpec_Fragment{} }
 func (m *JournalSpec_Fragment) String() string { return proto.CompactTextString(m) }
 func (*JournalSpec_Fragment) ProtoMessage()    {}
 func (*JournalSpec_Fragment) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_6d2e90632fd7d17f, []int{3, 0}
+	return fileDescriptor_protocol_c5ac8b8be4f4b766, []int{3, 0}
 }
 func (m *JournalSpec_Fragment) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -456,13 +518,20 @@ type ProcessSpec struct {
 	Id ProcessSpec_ID `protobuf:"bytes,1,opt,name=id" json:"id"`
 	// Advertised URL of the process.
 	Endpoint Endpoint `protobuf:"bytes,2,opt,name=endpoint,proto3,casttype=Endpoint" json:"endpoint,omitempty"`
+	// Labels of the process. Labels are matched by the member selectors of
+	// allocator Items (eg, JournalSpec.broker_selector) to constrain the
+	// processes to which Items may be assigned.
+	Labels LabelSet `protobuf:"bytes,3,opt,name=labels" json:"labels" yaml:",omitempty"`
+	// Cordoned processes are assigned no new Items. Items currently assigned
+	// to a cordoned process remain so, until explicitly drained.
+	Cordon bool `protobuf:"varint,4,opt,name=cordon,proto3" json:"cordon,omitempty" yaml:",omitempty"`
 }
 
 func (m *ProcessSpec) Reset()         { *m = ProcessSpec{} }
 func (m *ProcessSpec) String() string { return proto.CompactTextString(m) }
 func (*ProcessSpec) ProtoMessage()    {}
 func (*ProcessSpec) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_6d2e90632fd7d17f, []int{4}
+	return fileDescriptor_protocol_c5ac8b8be4f4b766, []int{4}
 }
 func (m *ProcessSpec) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -505,6 +574,20 @@ func (m *ProcessSpec) GetEndpoint() Endpoint {
 	return ""
 }
 
+func (m *ProcessSpec) GetLabels() LabelSet {
+	if m != nil {
+		return m.Labels
+	}
+	return LabelSet{}
+}
+
+func (m *ProcessSpec) GetCordon() bool {
+	if m != nil {
+		return m.Cordon
+	}
+	return false
+}
+
 // ID composes a zone and a suffix to uniquely identify a ProcessSpec.
 type ProcessSpec_ID struct {
 	// "Zone" in which the process is running. Zones may be AWS, Azure, or Google
@@ -524,7 +607,7 @@ func (m *ProcessSpec_ID) Reset()         { *m = ProcessSpec_ID{} }
 func (m *ProcessSpec_ID) String() string { return proto.CompactTextString(m) }
 func (*ProcessSpec_ID) ProtoMessage()    {}
 func (*ProcessSpec_ID) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_6d2e90632fd7d17f, []int{4, 0}
+	return fileDescriptor_protocol_c5ac8b8be4f4b766, []int{4, 0}
 }
 func (m *ProcessSpec_ID) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -559,13 +642,17 @@ type BrokerSpec struct {
 	ProcessSpec `protobuf:"bytes,1,opt,name=process_spec,json=processSpec,embedded=process_spec" json:"process_spec" yaml:",inline"`
 	// Maximum number of assigned Journal replicas.
 	JournalLimit uint32 `protobuf:"varint,2,opt,name=journal_limit,json=journalLimit,proto3" json:"journal_limit,omitempty"`
+	// Maximum total weight of assigned Journal replicas, where the weight of
+	// each Journal is given by its "weight" label (or one, if not set). If zero,
+	// the broker's weight limit is its journal_limit.
+	JournalWeightLimit uint32 `protobuf:"varint,3,opt,name=journal_weight_limit,json=journalWeightLimit,proto3" json:"journal_weight_limit,omitempty"`
 }
 
 func (m *BrokerSpec) Reset()         { *m = BrokerSpec{} }
 func (m *BrokerSpec) String() string { return proto.CompactTextString(m) }
 func (*BrokerSpec) ProtoMessage()    {}
 func (*BrokerSpec) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_6d2e90632fd7d17f, []int{5}
+	return fileDescriptor_protocol_c5ac8b8be4f4b766, []int{5}
 }
 func (m *BrokerSpec) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -618,7 +705,7 @@ func (m *Fragment) Reset()         { *m = Fragment{} }
 func (m *Fragment) String() string { return proto.CompactTextString(m) }
 func (*Fragment) ProtoMessage()    {}
 func (*Fragment) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_6d2e90632fd7d17f, []int{6}
+	return fileDescriptor_protocol_c5ac8b8be4f4b766, []int{6}
 }
 func (m *Fragment) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -658,7 +745,7 @@ func (m *SHA1Sum) Reset()         { *m = SHA1Sum{} }
 func (m *SHA1Sum) String() string { return proto.CompactTextString(m) }
 func (*SHA1Sum) ProtoMessage()    {}
 func (*SHA1Sum) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_6d2e90632fd7d17f, []int{7}
+	return fileDescriptor_protocol_c5ac8b8be4f4b766, []int{7}
 }
 func (m *SHA1Sum) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -714,7 +801,7 @@ func (m *ReadRequest) Reset()         { *m = ReadRequest{} }
 func (m *ReadRequest) String() string { return proto.CompactTextString(m) }
 func (*ReadRequest) ProtoMessage()    {}
 func (*ReadRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_6d2e90632fd7d17f, []int{8}
+	return fileDescriptor_protocol_c5ac8b8be4f4b766, []int{8}
 }
 func (m *ReadRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -766,7 +853,7 @@ func (m *ReadResponse) Reset()         { *m = ReadResponse{} }
 func (m *ReadResponse) String() string { return proto.CompactTextString(m) }
 func (*ReadResponse) ProtoMessage()    {}
 func (*ReadResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_6d2e90632fd7d17f, []int{9}
+	return fileDescriptor_protocol_c5ac8b8be4f4b766, []int{9}
 }
 func (m *ReadResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -806,7 +893,9 @@ type AppendRequest struct {
 	// Journal offset at which the append should begin. Most clients should leave
 	// at zero, which uses the broker's tracked offset. The append offset must be
 	// one greater than furthest written offset of the journal, or
-	// WRONG_APPEND_OFFSET is returned.
+	// WRONG_APPEND_OFFSET is returned. As an exception, the append offset of a
+	// journal having no written content may be any value, and the journal will
+	// begin at that offset (eg, to mirror a journal having removed fragments).
 	Offset int64 `protobuf:"varint,5,opt,name=offset,proto3" json:"offset,omitempty"`
 	// Content chunks to be appended. Immediately prior to closing the stream,
 	// the client must send an empty chunk (eg, zero-valued AppendRequest) to
@@ -819,7 +908,7 @@ func (m *AppendRequest) Reset()         { *m = AppendRequest{} }
 func (m *AppendRequest) String() string { return proto.CompactTextString(m) }
 func (*AppendRequest) ProtoMessage()    {}
 func (*AppendRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_6d2e90632fd7d17f, []int{10}
+	return fileDescriptor_protocol_c5ac8b8be4f4b766, []int{10}
 }
 func (m *AppendRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -862,7 +951,7 @@ func (m *AppendResponse) Reset()         { *m = AppendResponse{} }
 func (m *AppendResponse) String() string { return proto.CompactTextString(m) }
 func (*AppendResponse) ProtoMessage()    {}
 func (*AppendResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_6d2e90632fd7d17f, []int{11}
+	return fileDescriptor_protocol_c5ac8b8be4f4b766, []int{11}
 }
 func (m *AppendResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -913,7 +1002,7 @@ func (m *ReplicateRequest) Reset()         { *m = ReplicateRequest{} }
 func (m *ReplicateRequest) String() string { return proto.CompactTextString(m) }
 func (*ReplicateRequest) ProtoMessage()    {}
 func (*ReplicateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_6d2e90632fd7d17f, []int{12}
+	return fileDescriptor_protocol_c5ac8b8be4f4b766, []int{12}
 }
 func (m *ReplicateRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -957,7 +1046,7 @@ func (m *ReplicateResponse) Reset()         { *m = ReplicateResponse{} }
 func (m *ReplicateResponse) String() string { return proto.CompactTextString(m) }
 func (*ReplicateResponse) ProtoMessage()    {}
 func (*ReplicateResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_6d2e90632fd7d17f, []int{13}
+	return fileDescriptor_protocol_c5ac8b8be4f4b766, []int{13}
 }
 func (m *ReplicateResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1007,7 +1096,7 @@ func (m *ListRequest) Reset()         { *m = ListRequest{} }
 func (m *ListRequest) String() string { return proto.CompactTextString(m) }
 func (*ListRequest) ProtoMessage()    {}
 func (*ListRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_6d2e90632fd7d17f, []int{14}
+	return fileDescriptor_protocol_c5ac8b8be4f4b766, []int{14}
 }
 func (m *ListRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1051,7 +1140,7 @@ func (m *ListResponse) Reset()         { *m = ListResponse{} }
 func (m *ListResponse) String() string { return proto.CompactTextString(m) }
 func (*ListResponse) ProtoMessage()    {}
 func (*ListResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_6d2e90632fd7d17f, []int{15}
+	return fileDescriptor_protocol_c5ac8b8be4f4b766, []int{15}
 }
 func (m *ListResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1093,7 +1182,7 @@ func (m *ListResponse_Journal) Reset()         { *m = ListResponse_Journal{} }
 func (m *ListResponse_Journal) String() string { return proto.CompactTextString(m) }
 func (*ListResponse_Journal) ProtoMessage()    {}
 func (*ListResponse_Journal) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_6d2e90632fd7d17f, []int{15, 0}
+	return fileDescriptor_protocol_c5ac8b8be4f4b766, []int{15, 0}
 }
 func (m *ListResponse_Journal) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1130,7 +1219,7 @@ func (m *ApplyRequest) Reset()         { *m = ApplyRequest{} }
 func (m *ApplyRequest) String() string { return proto.CompactTextString(m) }
 func (*ApplyRequest) ProtoMessage()    {}
 func (*ApplyRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_6d2e90632fd7d17f, []int{16}
+	return fileDescriptor_protocol_c5ac8b8be4f4b766, []int{16}
 }
 func (m *ApplyRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1176,7 +1265,7 @@ func (m *ApplyRequest_Change) Reset()         { *m = ApplyRequest_Change{} }
 func (m *ApplyRequest_Change) String() string { return proto.CompactTextString(m) }
 func (*ApplyRequest_Change) ProtoMessage()    {}
 func (*ApplyRequest_Change) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_6d2e90632fd7d17f, []int{16, 0}
+	return fileDescriptor_protocol_c5ac8b8be4f4b766, []int{16, 0}
 }
 func (m *ApplyRequest_Change) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1216,7 +1305,7 @@ func (m *ApplyResponse) Reset()         { *m = ApplyResponse{} }
 func (m *ApplyResponse) String() string { return proto.CompactTextString(m) }
 func (*ApplyResponse) ProtoMessage()    {}
 func (*ApplyResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_6d2e90632fd7d17f, []int{17}
+	return fileDescriptor_protocol_c5ac8b8be4f4b766, []int{17}
 }
 func (m *ApplyResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1245,6 +1334,149 @@ func (m *ApplyResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_ApplyResponse proto.InternalMessageInfo
 
+type ReplicasRequest struct {
+	// Selector optionally refines the set of local replicas which will be
+	// returned. If zero-valued, all replicas of the broker are returned.
+	// Meta-labels "name" and "prefix" are supported, as with ListRequest.
+	Selector LabelSelector `protobuf:"bytes,1,opt,name=selector" json:"selector"`
+}
+
+func (m *ReplicasRequest) Reset()         { *m = ReplicasRequest{} }
+func (m *ReplicasRequest) String() string { return proto.CompactTextString(m) }
+func (*ReplicasRequest) ProtoMessage()    {}
+func (*ReplicasRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_protocol_c5ac8b8be4f4b766, []int{18}
+}
+func (m *ReplicasRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ReplicasRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ReplicasRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *ReplicasRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReplicasRequest.Merge(dst, src)
+}
+func (m *ReplicasRequest) XXX_Size() int {
+	return m.ProtoSize()
+}
+func (m *ReplicasRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReplicasRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReplicasRequest proto.InternalMessageInfo
+
+type ReplicasResponse struct {
+	// Status of the Replicas RPC.
+	Status Status `protobuf:"varint,1,opt,name=status,proto3,enum=protocol.Status" json:"status,omitempty"`
+	// Header of the response.
+	Header   Header                     `protobuf:"bytes,2,opt,name=header" json:"header"`
+	Replicas []ReplicasResponse_Replica `protobuf:"bytes,3,rep,name=replicas" json:"replicas"`
+	// Number of completed Spools queued by the broker Persister for persistence
+	// to their fragment stores, and their total content length.
+	PersisterQueuedSpools int64 `protobuf:"varint,4,opt,name=persister_queued_spools,json=persisterQueuedSpools,proto3" json:"persister_queued_spools,omitempty"`
+	PersisterQueuedBytes  int64 `protobuf:"varint,5,opt,name=persister_queued_bytes,json=persisterQueuedBytes,proto3" json:"persister_queued_bytes,omitempty"`
+}
+
+func (m *ReplicasResponse) Reset()         { *m = ReplicasResponse{} }
+func (m *ReplicasResponse) String() string { return proto.CompactTextString(m) }
+func (*ReplicasResponse) ProtoMessage()    {}
+func (*ReplicasResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_protocol_c5ac8b8be4f4b766, []int{19}
+}
+func (m *ReplicasResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ReplicasResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ReplicasResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *ReplicasResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReplicasResponse.Merge(dst, src)
+}
+func (m *ReplicasResponse) XXX_Size() int {
+	return m.ProtoSize()
+}
+func (m *ReplicasResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReplicasResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReplicasResponse proto.InternalMessageInfo
+
+// Replica is the local runtime state of a journal assigned to the broker.
+type ReplicasResponse_Replica struct {
+	Spec JournalSpec `protobuf:"bytes,1,opt,name=spec" json:"spec"`
+	// Route of the journal, including endpoints.
+	Route Route `protobuf:"bytes,2,opt,name=route" json:"route"`
+	// Fragment of the replica Spool, as of its last commit. The Fragment is
+	// empty if the Spool has no committed content.
+	Spool         Fragment                               `protobuf:"bytes,3,opt,name=spool" json:"spool"`
+	PipelineState ReplicasResponse_Replica_PipelineState `protobuf:"varint,4,opt,name=pipeline_state,json=pipelineState,proto3,enum=protocol.ReplicasResponse_Replica_PipelineState" json:"pipeline_state,omitempty"`
+	// Route of the established pipeline, if READY.
+	PipelineRoute *Route `protobuf:"bytes,5,opt,name=pipeline_route,json=pipelineRoute" json:"pipeline_route,omitempty"`
+	// Etcd revision which must be read through, if AWAITING_REVISION.
+	PipelineReadThroughRevision int64 `protobuf:"varint,6,opt,name=pipeline_read_through_revision,json=pipelineReadThroughRevision,proto3" json:"pipeline_read_through_revision,omitempty"`
+	// Number of Fragments of the replica index, and the number of those
+	// which are local (backed by a Spool file of the broker).
+	IndexFragments      int32 `protobuf:"varint,7,opt,name=index_fragments,json=indexFragments,proto3" json:"index_fragments,omitempty"`
+	IndexLocalFragments int32 `protobuf:"varint,8,opt,name=index_local_fragments,json=indexLocalFragments,proto3" json:"index_local_fragments,omitempty"`
+	// Begin and end offsets of Fragments of the replica index.
+	IndexBeginOffset int64 `protobuf:"varint,9,opt,name=index_begin_offset,json=indexBeginOffset,proto3" json:"index_begin_offset,omitempty"`
+	IndexEndOffset   int64 `protobuf:"varint,10,opt,name=index_end_offset,json=indexEndOffset,proto3" json:"index_end_offset,omitempty"`
+	// Time of the last refresh of remote Fragments of the index, or zero if
+	// the index has not yet been refreshed.
+	IndexRefreshTime time.Time `protobuf:"bytes,11,opt,name=index_refresh_time,json=indexRefreshTime,stdtime" json:"index_refresh_time"`
+}
+
+func (m *ReplicasResponse_Replica) Reset()         { *m = ReplicasResponse_Replica{} }
+func (m *ReplicasResponse_Replica) String() string { return proto.CompactTextString(m) }
+func (*ReplicasResponse_Replica) ProtoMessage()    {}
+func (*ReplicasResponse_Replica) Descriptor() ([]byte, []int) {
+	return fileDescriptor_protocol_c5ac8b8be4f4b766, []int{19, 0}
+}
+func (m *ReplicasResponse_Replica) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ReplicasResponse_Replica) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ReplicasResponse_Replica.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *ReplicasResponse_Replica) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReplicasResponse_Replica.Merge(dst, src)
+}
+func (m *ReplicasResponse_Replica) XXX_Size() int {
+	return m.ProtoSize()
+}
+func (m *ReplicasResponse_Replica) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReplicasResponse_Replica.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReplicasResponse_Replica proto.InternalMessageInfo
+
 // Route captures the current topology of an item and the processes serving it.
 type Route struct {
 	// Members of the Route, ordered on ascending ProcessSpec.ID (zone, suffix).
@@ -1261,7 +1493,7 @@ func (m *Route) Reset()         { *m = Route{} }
 func (m *Route) String() string { return proto.CompactTextString(m) }
 func (*Route) ProtoMessage()    {}
 func (*Route) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_6d2e90632fd7d17f, []int{18}
+	return fileDescriptor_protocol_c5ac8b8be4f4b766, []int{20}
 }
 func (m *Route) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1307,7 +1539,7 @@ func (m *Header) Reset()         { *m = Header{} }
 func (m *Header) String() string { return proto.CompactTextString(m) }
 func (*Header) ProtoMessage()    {}
 func (*Header) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_6d2e90632fd7d17f, []int{19}
+	return fileDescriptor_protocol_c5ac8b8be4f4b766, []int{21}
 }
 func (m *Header) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1358,7 +1590,7 @@ func (m *Header_Etcd) Reset()         { *m = Header_Etcd{} }
 func (m *Header_Etcd) String() string { return proto.CompactTextString(m) }
 func (*Header_Etcd) ProtoMessage()    {}
 func (*Header_Etcd) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_6d2e90632fd7d17f, []int{19, 0}
+	return fileDescriptor_protocol_c5ac8b8be4f4b766, []int{21, 0}
 }
 func (m *Header_Etcd) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1410,12 +1642,16 @@ func init() {
 	proto.RegisterType((*ApplyRequest)(nil), "protocol.ApplyRequest")
 	proto.RegisterType((*ApplyRequest_Change)(nil), "protocol.ApplyRequest.Change")
 	proto.RegisterType((*ApplyResponse)(nil), "protocol.ApplyResponse")
+	proto.RegisterType((*ReplicasRequest)(nil), "protocol.ReplicasRequest")
+	proto.RegisterType((*ReplicasResponse)(nil), "protocol.ReplicasResponse")
+	proto.RegisterType((*ReplicasResponse_Replica)(nil), "protocol.ReplicasResponse.Replica")
 	proto.RegisterType((*Route)(nil), "protocol.Route")
 	proto.RegisterType((*Header)(nil), "protocol.Header")
 	proto.RegisterType((*Header_Etcd)(nil), "protocol.Header.Etcd")
 	proto.RegisterEnum("protocol.Status", Status_name, Status_value)
 	proto.RegisterEnum("protocol.CompressionCodec", CompressionCodec_name, CompressionCodec_value)
 	proto.RegisterEnum("protocol.JournalSpec_Flag", JournalSpec_Flag_name, JournalSpec_Flag_value)
+	proto.RegisterEnum("protocol.ReplicasResponse_Replica_PipelineState", ReplicasResponse_Replica_PipelineState_name, ReplicasResponse_Replica_PipelineState_value)
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1730,6 +1966,74 @@ var _Journal_serviceDesc = grpc.ServiceDesc{
 	Metadata: "protocol.proto",
 }
 
+// AdminClient is the client API for Admin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type AdminClient interface {
+	// Replicas returns the local replicas of the broker, and their Spool,
+	// pipeline, and Fragment index state.
+	Replicas(ctx context.Context, in *ReplicasRequest, opts ...grpc.CallOption) (*ReplicasResponse, error)
+}
+
+type adminClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewAdminClient(cc *grpc.ClientConn) AdminClient {
+	return &adminClient{cc}
+}
+
+func (c *adminClient) Replicas(ctx context.Context, in *ReplicasRequest, opts ...grpc.CallOption) (*ReplicasResponse, error) {
+	out := new(ReplicasResponse)
+	err := c.cc.Invoke(ctx, "/protocol.Admin/Replicas", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServer is the server API for Admin service.
+type AdminServer interface {
+	// Replicas returns the local replicas of the broker, and their Spool,
+	// pipeline, and Fragment index state.
+	Replicas(context.Context, *ReplicasRequest) (*ReplicasResponse, error)
+}
+
+func RegisterAdminServer(s *grpc.Server, srv AdminServer) {
+	s.RegisterService(&_Admin_serviceDesc, srv)
+}
+
+func _Admin_Replicas_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplicasRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).Replicas(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protocol.Admin/Replicas",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).Replicas(ctx, req.(*ReplicasRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Admin_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "protocol.Admin",
+	HandlerType: (*AdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Replicas",
+			Handler:    _Admin_Replicas_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "protocol.proto",
+}
+
 func (m *Label) Marshal() (dAtA []byte, err error) {
 	size := m.ProtoSize()
 	dAtA = make([]byte, size)
@@ -1871,6 +2175,24 @@ func (m *JournalSpec) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintProtocol(dAtA, i, uint64(m.Flags))
 	}
+	if m.MaxAppendSize != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintProtocol(dAtA, i, uint64(m.MaxAppendSize))
+	}
+	if m.MaxAppendRate != 0 {
+		dAtA[i] = 0x40
+		i++
+		i = encodeVarintProtocol(dAtA, i, uint64(m.MaxAppendRate))
+	}
+	dAtA[i] = 0x4a
+	i++
+	i = encodeVarintProtocol(dAtA, i, uint64(m.BrokerSelector.ProtoSize()))
+	n5, err := m.BrokerSelector.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n5
 	return i, nil
 }
 
@@ -1917,19 +2239,19 @@ func (m *JournalSpec_Fragment) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x22
 	i++
 	i = encodeVarintProtocol(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdDuration(m.RefreshInterval)))
-	n5, err := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.RefreshInterval, dAtA[i:])
+	n6, err := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.RefreshInterval, dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n5
+	i += n6
 	dAtA[i] = 0x2a
 	i++
 	i = encodeVarintProtocol(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdDuration(m.Retention)))
-	n6, err := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.Retention, dAtA[i:])
+	n7, err := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.Retention, dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n6
+	i += n7
 	return i, nil
 }
 
@@ -1951,17 +2273,35 @@ func (m *ProcessSpec) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0xa
 	i++
 	i = encodeVarintProtocol(dAtA, i, uint64(m.Id.ProtoSize()))
-	n7, err := m.Id.MarshalTo(dAtA[i:])
+	n8, err := m.Id.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n7
+	i += n8
 	if len(m.Endpoint) > 0 {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Endpoint)))
 		i += copy(dAtA[i:], m.Endpoint)
 	}
+	dAtA[i] = 0x1a
+	i++
+	i = encodeVarintProtocol(dAtA, i, uint64(m.Labels.ProtoSize()))
+	n9, err := m.Labels.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n9
+	if m.Cordon {
+		dAtA[i] = 0x20
+		i++
+		if m.Cordon {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
 	return i, nil
 }
 
@@ -2013,16 +2353,21 @@ func (m *BrokerSpec) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0xa
 	i++
 	i = encodeVarintProtocol(dAtA, i, uint64(m.ProcessSpec.ProtoSize()))
-	n8, err := m.ProcessSpec.MarshalTo(dAtA[i:])
+	n10, err := m.ProcessSpec.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n8
+	i += n10
 	if m.JournalLimit != 0 {
 		dAtA[i] = 0x10
 		i++
 		i = encodeVarintProtocol(dAtA, i, uint64(m.JournalLimit))
 	}
+	if m.JournalWeightLimit != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintProtocol(dAtA, i, uint64(m.JournalWeightLimit))
+	}
 	return i, nil
 }
 
@@ -2060,11 +2405,11 @@ func (m *Fragment) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x22
 	i++
 	i = encodeVarintProtocol(dAtA, i, uint64(m.Sum.ProtoSize()))
-	n9, err := m.Sum.MarshalTo(dAtA[i:])
+	n11, err := m.Sum.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n9
+	i += n11
 	if m.CompressionCodec != 0 {
 		dAtA[i] = 0x28
 		i++
@@ -2079,11 +2424,11 @@ func (m *Fragment) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x3a
 	i++
 	i = encodeVarintProtocol(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdTime(m.ModTime)))
-	n10, err := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.ModTime, dAtA[i:])
+	n12, err := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.ModTime, dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n10
+	i += n12
 	return i, nil
 }
 
@@ -2142,11 +2487,11 @@ func (m *ReadRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintProtocol(dAtA, i, uint64(m.Header.ProtoSize()))
-		n11, err := m.Header.MarshalTo(dAtA[i:])
+		n13, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n11
+		i += n13
 	}
 	if len(m.Journal) > 0 {
 		dAtA[i] = 0x12
@@ -2216,11 +2561,11 @@ func (m *ReadResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintProtocol(dAtA, i, uint64(m.Header.ProtoSize()))
-		n12, err := m.Header.MarshalTo(dAtA[i:])
+		n14, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n12
+		i += n14
 	}
 	if m.Offset != 0 {
 		dAtA[i] = 0x18
@@ -2236,11 +2581,11 @@ func (m *ReadResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x2a
 		i++
 		i = encodeVarintProtocol(dAtA, i, uint64(m.Fragment.ProtoSize()))
-		n13, err := m.Fragment.MarshalTo(dAtA[i:])
+		n15, err := m.Fragment.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n13
+		i += n15
 	}
 	if len(m.FragmentUrl) > 0 {
 		dAtA[i] = 0x32
@@ -2276,11 +2621,11 @@ func (m *AppendRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintProtocol(dAtA, i, uint64(m.Header.ProtoSize()))
-		n14, err := m.Header.MarshalTo(dAtA[i:])
+		n16, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n14
+		i += n16
 	}
 	if len(m.Journal) > 0 {
 		dAtA[i] = 0x12
@@ -2336,21 +2681,21 @@ func (m *AppendResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintProtocol(dAtA, i, uint64(m.Header.ProtoSize()))
-		n15, err := m.Header.MarshalTo(dAtA[i:])
+		n17, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n15
+		i += n17
 	}
 	if m.Commit != nil {
 		dAtA[i] = 0x1a
 		i++
 		i = encodeVarintProtocol(dAtA, i, uint64(m.Commit.ProtoSize()))
-		n16, err := m.Commit.MarshalTo(dAtA[i:])
+		n18, err := m.Commit.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n16
+		i += n18
 	}
 	return i, nil
 }
@@ -2374,11 +2719,11 @@ func (m *ReplicateRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintProtocol(dAtA, i, uint64(m.Header.ProtoSize()))
-		n17, err := m.Header.MarshalTo(dAtA[i:])
+		n19, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n17
+		i += n19
 	}
 	if len(m.Journal) > 0 {
 		dAtA[i] = 0x12
@@ -2390,11 +2735,11 @@ func (m *ReplicateRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x1a
 		i++
 		i = encodeVarintProtocol(dAtA, i, uint64(m.Proposal.ProtoSize()))
-		n18, err := m.Proposal.MarshalTo(dAtA[i:])
+		n20, err := m.Proposal.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n18
+		i += n20
 	}
 	if len(m.Content) > 0 {
 		dAtA[i] = 0x22
@@ -2444,21 +2789,21 @@ func (m *ReplicateResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintProtocol(dAtA, i, uint64(m.Header.ProtoSize()))
-		n19, err := m.Header.MarshalTo(dAtA[i:])
+		n21, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n19
+		i += n21
 	}
 	if m.Fragment != nil {
 		dAtA[i] = 0x1a
 		i++
 		i = encodeVarintProtocol(dAtA, i, uint64(m.Fragment.ProtoSize()))
-		n20, err := m.Fragment.MarshalTo(dAtA[i:])
+		n22, err := m.Fragment.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n20
+		i += n22
 	}
 	return i, nil
 }
@@ -2481,11 +2826,11 @@ func (m *ListRequest) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0xa
 	i++
 	i = encodeVarintProtocol(dAtA, i, uint64(m.Selector.ProtoSize()))
-	n21, err := m.Selector.MarshalTo(dAtA[i:])
+	n23, err := m.Selector.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n21
+	i += n23
 	if m.PageLimit != 0 {
 		dAtA[i] = 0x10
 		i++
@@ -2523,11 +2868,11 @@ func (m *ListResponse) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x12
 	i++
 	i = encodeVarintProtocol(dAtA, i, uint64(m.Header.ProtoSize()))
-	n22, err := m.Header.MarshalTo(dAtA[i:])
+	n24, err := m.Header.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n22
+	i += n24
 	if len(m.Journals) > 0 {
 		for _, msg := range m.Journals {
 			dAtA[i] = 0x1a
@@ -2567,11 +2912,11 @@ func (m *ListResponse_Journal) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0xa
 	i++
 	i = encodeVarintProtocol(dAtA, i, uint64(m.Spec.ProtoSize()))
-	n23, err := m.Spec.MarshalTo(dAtA[i:])
+	n25, err := m.Spec.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n23
+	i += n25
 	if m.ModRevision != 0 {
 		dAtA[i] = 0x10
 		i++
@@ -2580,11 +2925,11 @@ func (m *ListResponse_Journal) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x1a
 	i++
 	i = encodeVarintProtocol(dAtA, i, uint64(m.Route.ProtoSize()))
-	n24, err := m.Route.MarshalTo(dAtA[i:])
+	n26, err := m.Route.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n24
+	i += n26
 	return i, nil
 }
 
@@ -2642,11 +2987,11 @@ func (m *ApplyRequest_Change) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintProtocol(dAtA, i, uint64(m.Upsert.ProtoSize()))
-		n25, err := m.Upsert.MarshalTo(dAtA[i:])
+		n27, err := m.Upsert.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n25
+		i += n27
 	}
 	if len(m.Delete) > 0 {
 		dAtA[i] = 0x1a
@@ -2680,15 +3025,15 @@ func (m *ApplyResponse) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x12
 	i++
 	i = encodeVarintProtocol(dAtA, i, uint64(m.Header.ProtoSize()))
-	n26, err := m.Header.MarshalTo(dAtA[i:])
+	n28, err := m.Header.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n26
+	i += n28
 	return i, nil
 }
 
-func (m *Route) Marshal() (dAtA []byte, err error) {
+func (m *ReplicasRequest) Marshal() (dAtA []byte, err error) {
 	size := m.ProtoSize()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -2698,14 +3043,53 @@ func (m *Route) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Route) MarshalTo(dAtA []byte) (int, error) {
+func (m *ReplicasRequest) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Members) > 0 {
-		for _, msg := range m.Members {
-			dAtA[i] = 0xa
+	dAtA[i] = 0xa
+	i++
+	i = encodeVarintProtocol(dAtA, i, uint64(m.Selector.ProtoSize()))
+	n29, err := m.Selector.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n29
+	return i, nil
+}
+
+func (m *ReplicasResponse) Marshal() (dAtA []byte, err error) {
+	size := m.ProtoSize()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReplicasResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Status != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Status))
+	}
+	dAtA[i] = 0x12
+	i++
+	i = encodeVarintProtocol(dAtA, i, uint64(m.Header.ProtoSize()))
+	n30, err := m.Header.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n30
+	if len(m.Replicas) > 0 {
+		for _, msg := range m.Replicas {
+			dAtA[i] = 0x1a
 			i++
 			i = encodeVarintProtocol(dAtA, i, uint64(msg.ProtoSize()))
 			n, err := msg.MarshalTo(dAtA[i:])
@@ -2715,30 +3099,20 @@ func (m *Route) MarshalTo(dAtA []byte) (int, error) {
 			i += n
 		}
 	}
-	if m.Primary != 0 {
-		dAtA[i] = 0x10
+	if m.PersisterQueuedSpools != 0 {
+		dAtA[i] = 0x20
 		i++
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Primary))
+		i = encodeVarintProtocol(dAtA, i, uint64(m.PersisterQueuedSpools))
 	}
-	if len(m.Endpoints) > 0 {
-		for _, s := range m.Endpoints {
-			dAtA[i] = 0x1a
-			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
-			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
-		}
+	if m.PersisterQueuedBytes != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintProtocol(dAtA, i, uint64(m.PersisterQueuedBytes))
 	}
 	return i, nil
 }
 
-func (m *Header) Marshal() (dAtA []byte, err error) {
+func (m *ReplicasResponse_Replica) Marshal() (dAtA []byte, err error) {
 	size := m.ProtoSize()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -2748,37 +3122,177 @@ func (m *Header) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Header) MarshalTo(dAtA []byte) (int, error) {
+func (m *ReplicasResponse_Replica) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
 	dAtA[i] = 0xa
 	i++
-	i = encodeVarintProtocol(dAtA, i, uint64(m.ProcessId.ProtoSize()))
-	n27, err := m.ProcessId.MarshalTo(dAtA[i:])
+	i = encodeVarintProtocol(dAtA, i, uint64(m.Spec.ProtoSize()))
+	n31, err := m.Spec.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n27
+	i += n31
 	dAtA[i] = 0x12
 	i++
 	i = encodeVarintProtocol(dAtA, i, uint64(m.Route.ProtoSize()))
-	n28, err := m.Route.MarshalTo(dAtA[i:])
+	n32, err := m.Route.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n28
+	i += n32
 	dAtA[i] = 0x1a
 	i++
-	i = encodeVarintProtocol(dAtA, i, uint64(m.Etcd.ProtoSize()))
-	n29, err := m.Etcd.MarshalTo(dAtA[i:])
+	i = encodeVarintProtocol(dAtA, i, uint64(m.Spool.ProtoSize()))
+	n33, err := m.Spool.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n29
-	return i, nil
-}
+	i += n33
+	if m.PipelineState != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintProtocol(dAtA, i, uint64(m.PipelineState))
+	}
+	if m.PipelineRoute != nil {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintProtocol(dAtA, i, uint64(m.PipelineRoute.ProtoSize()))
+		n34, err := m.PipelineRoute.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n34
+	}
+	if m.PipelineReadThroughRevision != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintProtocol(dAtA, i, uint64(m.PipelineReadThroughRevision))
+	}
+	if m.IndexFragments != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintProtocol(dAtA, i, uint64(m.IndexFragments))
+	}
+	if m.IndexLocalFragments != 0 {
+		dAtA[i] = 0x40
+		i++
+		i = encodeVarintProtocol(dAtA, i, uint64(m.IndexLocalFragments))
+	}
+	if m.IndexBeginOffset != 0 {
+		dAtA[i] = 0x48
+		i++
+		i = encodeVarintProtocol(dAtA, i, uint64(m.IndexBeginOffset))
+	}
+	if m.IndexEndOffset != 0 {
+		dAtA[i] = 0x50
+		i++
+		i = encodeVarintProtocol(dAtA, i, uint64(m.IndexEndOffset))
+	}
+	dAtA[i] = 0x5a
+	i++
+	i = encodeVarintProtocol(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdTime(m.IndexRefreshTime)))
+	n35, err := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.IndexRefreshTime, dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n35
+	return i, nil
+}
+
+func (m *Route) Marshal() (dAtA []byte, err error) {
+	size := m.ProtoSize()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Route) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Members) > 0 {
+		for _, msg := range m.Members {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintProtocol(dAtA, i, uint64(msg.ProtoSize()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.Primary != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Primary))
+	}
+	if len(m.Endpoints) > 0 {
+		for _, s := range m.Endpoints {
+			dAtA[i] = 0x1a
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	return i, nil
+}
+
+func (m *Header) Marshal() (dAtA []byte, err error) {
+	size := m.ProtoSize()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Header) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	dAtA[i] = 0xa
+	i++
+	i = encodeVarintProtocol(dAtA, i, uint64(m.ProcessId.ProtoSize()))
+	n36, err := m.ProcessId.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n36
+	dAtA[i] = 0x12
+	i++
+	i = encodeVarintProtocol(dAtA, i, uint64(m.Route.ProtoSize()))
+	n37, err := m.Route.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n37
+	dAtA[i] = 0x1a
+	i++
+	i = encodeVarintProtocol(dAtA, i, uint64(m.Etcd.ProtoSize()))
+	n38, err := m.Etcd.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n38
+	return i, nil
+}
 
 func (m *Header_Etcd) Marshal() (dAtA []byte, err error) {
 	size := m.ProtoSize()
@@ -2892,6 +3406,14 @@ func (m *JournalSpec) ProtoSize() (n int) {
 	if m.Flags != 0 {
 		n += 1 + sovProtocol(uint64(m.Flags))
 	}
+	if m.MaxAppendSize != 0 {
+		n += 1 + sovProtocol(uint64(m.MaxAppendSize))
+	}
+	if m.MaxAppendRate != 0 {
+		n += 1 + sovProtocol(uint64(m.MaxAppendRate))
+	}
+	l = m.BrokerSelector.ProtoSize()
+	n += 1 + l + sovProtocol(uint64(l))
 	return n
 }
 
@@ -2932,6 +3454,11 @@ func (m *ProcessSpec) ProtoSize() (n int) {
 	if l > 0 {
 		n += 1 + l + sovProtocol(uint64(l))
 	}
+	l = m.Labels.ProtoSize()
+	n += 1 + l + sovProtocol(uint64(l))
+	if m.Cordon {
+		n += 2
+	}
 	return n
 }
 
@@ -2963,6 +3490,9 @@ func (m *BrokerSpec) ProtoSize() (n int) {
 	if m.JournalLimit != 0 {
 		n += 1 + sovProtocol(uint64(m.JournalLimit))
 	}
+	if m.JournalWeightLimit != 0 {
+		n += 1 + sovProtocol(uint64(m.JournalWeightLimit))
+	}
 	return n
 }
 
@@ -3282,6 +3812,82 @@ func (m *ApplyResponse) ProtoSize() (n int) {
 	return n
 }
 
+func (m *ReplicasRequest) ProtoSize() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Selector.ProtoSize()
+	n += 1 + l + sovProtocol(uint64(l))
+	return n
+}
+
+func (m *ReplicasResponse) ProtoSize() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Status != 0 {
+		n += 1 + sovProtocol(uint64(m.Status))
+	}
+	l = m.Header.ProtoSize()
+	n += 1 + l + sovProtocol(uint64(l))
+	if len(m.Replicas) > 0 {
+		for _, e := range m.Replicas {
+			l = e.ProtoSize()
+			n += 1 + l + sovProtocol(uint64(l))
+		}
+	}
+	if m.PersisterQueuedSpools != 0 {
+		n += 1 + sovProtocol(uint64(m.PersisterQueuedSpools))
+	}
+	if m.PersisterQueuedBytes != 0 {
+		n += 1 + sovProtocol(uint64(m.PersisterQueuedBytes))
+	}
+	return n
+}
+
+func (m *ReplicasResponse_Replica) ProtoSize() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Spec.ProtoSize()
+	n += 1 + l + sovProtocol(uint64(l))
+	l = m.Route.ProtoSize()
+	n += 1 + l + sovProtocol(uint64(l))
+	l = m.Spool.ProtoSize()
+	n += 1 + l + sovProtocol(uint64(l))
+	if m.PipelineState != 0 {
+		n += 1 + sovProtocol(uint64(m.PipelineState))
+	}
+	if m.PipelineRoute != nil {
+		l = m.PipelineRoute.ProtoSize()
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	if m.PipelineReadThroughRevision != 0 {
+		n += 1 + sovProtocol(uint64(m.PipelineReadThroughRevision))
+	}
+	if m.IndexFragments != 0 {
+		n += 1 + sovProtocol(uint64(m.IndexFragments))
+	}
+	if m.IndexLocalFragments != 0 {
+		n += 1 + sovProtocol(uint64(m.IndexLocalFragments))
+	}
+	if m.IndexBeginOffset != 0 {
+		n += 1 + sovProtocol(uint64(m.IndexBeginOffset))
+	}
+	if m.IndexEndOffset != 0 {
+		n += 1 + sovProtocol(uint64(m.IndexEndOffset))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.IndexRefreshTime)
+	n += 1 + l + sovProtocol(uint64(l))
+	return n
+}
+
 func (m *Route) ProtoSize() (n int) {
 	if m == nil {
 		return 0
@@ -3810,6 +4416,74 @@ func (m *JournalSpec) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxAppendSize", wireType)
+			}
+			m.MaxAppendSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxAppendSize |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxAppendRate", wireType)
+			}
+			m.MaxAppendRate = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxAppendRate |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BrokerSelector", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.BrokerSelector.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProtocol(dAtA[iNdEx:])
@@ -4096,16 +4770,66 @@ func (m *ProcessSpec) Unmarshal(dAtA []byte) error {
 			}
 			m.Endpoint = Endpoint(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipProtocol(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
 			}
-			if skippy < 0 {
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
 				return ErrInvalidLengthProtocol
 			}
-			if (iNdEx + skippy) > l {
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Labels.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Cordon", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Cordon = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
 			iNdEx += skippy
@@ -4303,6 +5027,25 @@ func (m *BrokerSpec) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field JournalWeightLimit", wireType)
+			}
+			m.JournalWeightLimit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.JournalWeightLimit |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProtocol(dAtA[iNdEx:])
@@ -6447,6 +7190,571 @@ func (m *ApplyResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *ReplicasRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReplicasRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReplicasRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Selector", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Selector.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ReplicasResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReplicasResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReplicasResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			m.Status = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Status |= (Status(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Replicas", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Replicas = append(m.Replicas, ReplicasResponse_Replica{})
+			if err := m.Replicas[len(m.Replicas)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PersisterQueuedSpools", wireType)
+			}
+			m.PersisterQueuedSpools = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PersisterQueuedSpools |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PersisterQueuedBytes", wireType)
+			}
+			m.PersisterQueuedBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PersisterQueuedBytes |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ReplicasResponse_Replica) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Replica: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Replica: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Spec", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Spec.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Route", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Route.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Spool", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Spool.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PipelineState", wireType)
+			}
+			m.PipelineState = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PipelineState |= (ReplicasResponse_Replica_PipelineState(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PipelineRoute", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.PipelineRoute == nil {
+				m.PipelineRoute = &Route{}
+			}
+			if err := m.PipelineRoute.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PipelineReadThroughRevision", wireType)
+			}
+			m.PipelineReadThroughRevision = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PipelineReadThroughRevision |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IndexFragments", wireType)
+			}
+			m.IndexFragments = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.IndexFragments |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IndexLocalFragments", wireType)
+			}
+			m.IndexLocalFragments = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.IndexLocalFragments |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IndexBeginOffset", wireType)
+			}
+			m.IndexBeginOffset = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.IndexBeginOffset |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IndexEndOffset", wireType)
+			}
+			m.IndexEndOffset = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.IndexEndOffset |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IndexRefreshTime", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.IndexRefreshTime, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *Route) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -6947,138 +8255,170 @@ var (
 	ErrIntOverflowProtocol   = fmt.Errorf("proto: integer overflow")
 )
 
-func init() { proto.RegisterFile("protocol.proto", fileDescriptor_protocol_6d2e90632fd7d17f) }
-
-var fileDescriptor_protocol_6d2e90632fd7d17f = []byte{
-	// 2072 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x57, 0xcd, 0x6f, 0xdb, 0xc8,
-	0x15, 0x37, 0xf5, 0xad, 0x27, 0x29, 0xa1, 0x67, 0x9b, 0x44, 0x51, 0x12, 0xcb, 0xcb, 0xb4, 0x0b,
-	0x6f, 0xba, 0x51, 0x12, 0xa7, 0xed, 0x6e, 0x03, 0xa4, 0x5b, 0xca, 0xa2, 0x12, 0x6e, 0x64, 0x49,
-	0x18, 0xc9, 0x49, 0xb3, 0x17, 0x82, 0x26, 0xc7, 0x0a, 0x1b, 0x7e, 0x95, 0xa4, 0xb2, 0x71, 0x8b,
-	0x5e, 0xb7, 0x45, 0xd1, 0x43, 0x6e, 0xdd, 0x5b, 0x83, 0xfe, 0x0d, 0x05, 0x7a, 0xe9, 0xb9, 0x08,
-	0xd0, 0x4b, 0x80, 0x5e, 0x7a, 0x28, 0xbc, 0xe8, 0xe6, 0x3f, 0x08, 0x7a, 0xda, 0x53, 0x31, 0xc3,
-	0xa1, 0x44, 0xcb, 0x72, 0xdc, 0x1e, 0x7c, 0xe3, 0xbc, 0xaf, 0x79, 0xef, 0xf7, 0x3e, 0xf8, 0x06,
-	0xce, 0xf8, 0x81, 0x17, 0x79, 0x86, 0x67, 0xb7, 0xd8, 0x07, 0x2a, 0x25, 0xe7, 0xc6, 0xf5, 0x89,
-	0x15, 0x3d, 0x99, 0xee, 0xb6, 0x0c, 0xcf, 0xb9, 0x31, 0xf1, 0x26, 0xde, 0x0d, 0xc6, 0xd9, 0x9d,
-	0xee, 0xb1, 0x13, 0x3b, 0xb0, 0xaf, 0x58, 0xb1, 0xb1, 0x36, 0xf1, 0xbc, 0x89, 0x4d, 0xe6, 0x52,
-	0xe6, 0x34, 0xd0, 0x23, 0xcb, 0x73, 0x39, 0xbf, 0xb9, 0xc8, 0x8f, 0x2c, 0x87, 0x84, 0x91, 0xee,
-	0xf8, 0xb1, 0x80, 0x74, 0x0b, 0xf2, 0x3d, 0x7d, 0x97, 0xd8, 0x08, 0x41, 0xce, 0xd5, 0x1d, 0x52,
-	0x17, 0xd6, 0x85, 0x8d, 0x32, 0x66, 0xdf, 0xe8, 0x3b, 0x90, 0x7f, 0xa6, 0xdb, 0x53, 0x52, 0xcf,
-	0x30, 0x62, 0x7c, 0x90, 0xfa, 0x50, 0x62, 0x2a, 0x23, 0x12, 0xa1, 0x36, 0x14, 0x6c, 0xfa, 0x1d,
-	0xd6, 0x85, 0xf5, 0xec, 0x46, 0x65, 0xf3, 0x6c, 0x6b, 0x16, 0x19, 0x93, 0x69, 0x5f, 0x7c, 0x75,
-	0xd0, 0x5c, 0x79, 0x7b, 0xd0, 0x5c, 0xdd, 0xd7, 0x1d, 0xfb, 0x8e, 0xf4, 0x91, 0xe7, 0x58, 0x11,
-	0x71, 0xfc, 0x68, 0x5f, 0xc2, 0x5c, 0x53, 0xfa, 0x35, 0xd4, 0xb8, 0x3d, 0x9b, 0x18, 0x91, 0x17,
-	0xa0, 0x4d, 0x28, 0x5a, 0xae, 0x61, 0x4f, 0xcd, 0xd8, 0x9b, 0xca, 0x26, 0x5a, 0xb0, 0x3a, 0x22,
-	0x51, 0x3b, 0x47, 0x0d, 0xe3, 0x44, 0x90, 0xea, 0x90, 0xe7, 0xb1, 0x4e, 0xe6, 0x24, 0x1d, 0x2e,
-	0x78, 0x27, 0xf7, 0xd5, 0xcb, 0xe6, 0x8a, 0xf4, 0xa6, 0x00, 0x95, 0xcf, 0xbc, 0x69, 0xe0, 0xea,
-	0xf6, 0xc8, 0x27, 0x06, 0xfa, 0x41, 0x1a, 0x88, 0xf6, 0xfa, 0x52, 0xdf, 0xbf, 0x3d, 0x68, 0x16,
-	0xb9, 0x0e, 0x87, 0xea, 0x63, 0xa8, 0x04, 0xc4, 0xb7, 0x2d, 0x83, 0xa1, 0xcf, 0x7c, 0xc8, 0xb7,
-	0xcf, 0x2d, 0x0f, 0x3c, 0x2d, 0x89, 0x86, 0x33, 0x04, 0xb3, 0xc7, 0xfa, 0xfd, 0x5d, 0xea, 0xf7,
-	0xeb, 0x83, 0xa6, 0xf0, 0xf6, 0xa0, 0x59, 0x5f, 0xb4, 0xf7, 0x91, 0xe5, 0xda, 0x96, 0x4b, 0x66,
-	0x78, 0xa2, 0x1d, 0x28, 0xed, 0x05, 0xfa, 0xc4, 0x21, 0x6e, 0x54, 0xcf, 0x31, 0x9b, 0x6b, 0x73,
-	0x9b, 0xa9, 0x48, 0x5b, 0x5d, 0x2e, 0xf5, 0xae, 0x24, 0xcd, 0x4c, 0xa1, 0x4f, 0x21, 0xbf, 0x67,
-	0xeb, 0x93, 0xb0, 0x5e, 0x58, 0x17, 0x36, 0x6a, 0xed, 0x0f, 0x8f, 0x03, 0x46, 0x4c, 0x5d, 0xa1,
-	0x75, 0x6d, 0x7d, 0x82, 0x63, 0xbd, 0xc6, 0x5f, 0xb2, 0x50, 0x4a, 0xae, 0x44, 0xd7, 0xa1, 0x60,
-	0x13, 0x77, 0x12, 0x3d, 0x61, 0x38, 0x67, 0x8f, 0x83, 0x8a, 0x0b, 0x21, 0x0f, 0x56, 0x0d, 0xcf,
-	0xf1, 0x03, 0x12, 0x86, 0x96, 0xe7, 0x6a, 0x86, 0x67, 0x12, 0x83, 0x81, 0x7c, 0x66, 0xb3, 0x31,
-	0x0f, 0x6e, 0x6b, 0x2e, 0xb2, 0x45, 0x25, 0xda, 0x1f, 0xbc, 0x3d, 0x68, 0x4a, 0xb1, 0xd5, 0x23,
-	0xea, 0xe9, 0x6b, 0x44, 0x63, 0x41, 0x13, 0xfd, 0x04, 0x0a, 0x61, 0xe4, 0x05, 0x84, 0xa6, 0x25,
-	0xbb, 0x51, 0x66, 0x96, 0x96, 0x86, 0x5b, 0x4b, 0x42, 0x1a, 0x51, 0x71, 0xcc, 0xb5, 0x50, 0x08,
-	0x62, 0x40, 0xf6, 0x02, 0x12, 0x3e, 0xd1, 0x2c, 0x37, 0x22, 0xc1, 0x33, 0xdd, 0xe6, 0xc9, 0xb8,
-	0xd8, 0x8a, 0x7b, 0xb2, 0x95, 0xf4, 0x64, 0xab, 0xc3, 0x7b, 0xb6, 0x7d, 0x9d, 0xe7, 0xe1, 0xfd,
-	0xf8, 0xa2, 0x45, 0x03, 0xa9, 0x8b, 0xbf, 0xfa, 0xba, 0x29, 0xe0, 0xb3, 0x5c, 0x40, 0xe5, 0x7c,
-	0xf4, 0x10, 0xca, 0x01, 0x89, 0x88, 0xcb, 0x4a, 0x30, 0x7f, 0xd2, 0x6d, 0x57, 0x8e, 0xcd, 0x3a,
-	0xb3, 0x3e, 0x37, 0x25, 0xc9, 0x90, 0xa3, 0x89, 0x44, 0xab, 0x50, 0xeb, 0x0f, 0xc6, 0xda, 0x68,
-	0xa8, 0x6c, 0xa9, 0x5d, 0x55, 0xe9, 0x88, 0x2b, 0xa8, 0x0a, 0xa5, 0x81, 0x86, 0x3b, 0x83, 0x7e,
-	0xef, 0xb1, 0x28, 0xc4, 0xa7, 0x47, 0x98, 0x9d, 0x32, 0x08, 0xa0, 0x40, 0x79, 0x8f, 0xb0, 0x98,
-	0x93, 0xfe, 0x28, 0x40, 0x65, 0x18, 0x78, 0x06, 0x09, 0x43, 0xd6, 0x65, 0x2d, 0xc8, 0x58, 0x26,
-	0x6f, 0xef, 0xfa, 0x3c, 0x83, 0x29, 0x91, 0x96, 0xda, 0xe1, 0x0d, 0x9b, 0xb1, 0x4c, 0xb4, 0x01,
-	0x25, 0xe2, 0x9a, 0xbe, 0x67, 0xb9, 0x51, 0x3c, 0x8d, 0xda, 0xd5, 0x6f, 0x0f, 0x9a, 0x25, 0x85,
-	0xd3, 0xf0, 0x8c, 0xdb, 0xb8, 0x09, 0x19, 0xb5, 0x43, 0xc7, 0xd9, 0x2f, 0x3d, 0x77, 0x36, 0xce,
-	0xe8, 0x37, 0x3a, 0x0f, 0x85, 0x70, 0xba, 0xb7, 0x67, 0x3d, 0xe7, 0xf3, 0x8c, 0x9f, 0xee, 0xe4,
-	0x7e, 0xfb, 0xb2, 0x29, 0x48, 0xbf, 0x11, 0x00, 0xda, 0x81, 0xf7, 0x94, 0x04, 0xcc, 0xc1, 0x31,
-	0x54, 0xfd, 0xd8, 0x19, 0x2d, 0xf4, 0x89, 0xc1, 0x5d, 0x3d, 0xb7, 0xd4, 0xd5, 0x76, 0x23, 0xd5,
-	0xa0, 0x67, 0x38, 0x9c, 0x49, 0x5b, 0x56, 0xfc, 0x54, 0xd8, 0x57, 0xa1, 0xf6, 0xf3, 0xb8, 0x3d,
-	0x34, 0xdb, 0x72, 0xac, 0x38, 0x96, 0x1a, 0xae, 0x72, 0x62, 0x8f, 0xd2, 0xa4, 0xbf, 0x65, 0x52,
-	0x8d, 0xf2, 0x3d, 0x28, 0x72, 0x26, 0x9f, 0x48, 0x95, 0xf4, 0xf0, 0x49, 0x78, 0x74, 0x54, 0xef,
-	0x92, 0x89, 0x15, 0x4f, 0x9e, 0x2c, 0x8e, 0x0f, 0x48, 0x84, 0x2c, 0x71, 0x4d, 0x36, 0x59, 0xb2,
-	0x98, 0x7e, 0xa2, 0x0f, 0x21, 0x1b, 0x4e, 0x1d, 0x5e, 0x8a, 0xab, 0xf3, 0x68, 0x46, 0xf7, 0xe5,
-	0x5b, 0xa3, 0xa9, 0xc3, 0x11, 0xa7, 0x32, 0xe8, 0xde, 0xb2, 0x9e, 0xcb, 0x9f, 0xd4, 0x73, 0x4b,
-	0x7a, 0xe9, 0x47, 0x50, 0xdb, 0xd5, 0x8d, 0xa7, 0x96, 0x3b, 0xd1, 0x58, 0x77, 0xb0, 0x09, 0x52,
-	0x6e, 0xaf, 0x1e, 0xed, 0x9e, 0x2a, 0x97, 0x63, 0x27, 0xf4, 0x29, 0x94, 0x1c, 0xcf, 0xd4, 0xe8,
-	0x2f, 0xab, 0x5e, 0x64, 0x0e, 0x37, 0x8e, 0x54, 0xf3, 0x38, 0xf9, 0x9f, 0xb5, 0x4b, 0xd4, 0xf3,
-	0x17, 0xb4, 0x72, 0x8b, 0x8e, 0x67, 0x52, 0xba, 0xf4, 0x00, 0x8a, 0x3c, 0x2e, 0x8a, 0x8f, 0xaf,
-	0x07, 0xd1, 0x2d, 0x06, 0x62, 0x01, 0xc7, 0x87, 0x84, 0xba, 0xc9, 0x50, 0xe3, 0xd4, 0xcd, 0x84,
-	0x7a, 0x9b, 0xe1, 0x56, 0x8c, 0xa9, 0xb7, 0xa5, 0x7f, 0x08, 0x50, 0xc1, 0x44, 0x37, 0x31, 0xf9,
-	0xc5, 0x94, 0x84, 0x11, 0xda, 0x80, 0xc2, 0x13, 0xa2, 0x9b, 0x24, 0xe0, 0xa5, 0x21, 0xce, 0x31,
-	0xb9, 0xcf, 0xe8, 0x98, 0xf3, 0xd3, 0x29, 0xcc, 0xbc, 0x23, 0x85, 0xe7, 0xa1, 0xe0, 0xed, 0xed,
-	0x85, 0x24, 0xe2, 0xf9, 0xe2, 0x27, 0x96, 0x5a, 0xdb, 0x33, 0x9e, 0xb2, 0xa4, 0x95, 0x70, 0x7c,
-	0x40, 0xeb, 0x50, 0x35, 0x3d, 0xcd, 0xf5, 0x22, 0xcd, 0x0f, 0xbc, 0xe7, 0xfb, 0x2c, 0x31, 0x25,
-	0x0c, 0xa6, 0xd7, 0xf7, 0xa2, 0x21, 0xa5, 0xd0, 0x5a, 0x73, 0x48, 0xa4, 0x9b, 0x7a, 0xa4, 0x6b,
-	0x9e, 0x6b, 0xef, 0x33, 0xd8, 0x4b, 0xb8, 0x9a, 0x10, 0x07, 0xae, 0xbd, 0x2f, 0x7d, 0x99, 0x81,
-	0x6a, 0x1c, 0x55, 0xe8, 0x7b, 0x6e, 0x48, 0x68, 0x58, 0x61, 0xa4, 0x47, 0xd3, 0x90, 0x85, 0x75,
-	0x26, 0x1d, 0xd6, 0x88, 0xd1, 0x31, 0xe7, 0xa7, 0x00, 0xc8, 0x9c, 0x00, 0xc0, 0x71, 0x91, 0x5d,
-	0x01, 0xf8, 0x22, 0xb0, 0x22, 0xa2, 0x51, 0x39, 0x16, 0x5e, 0x16, 0x97, 0x19, 0x85, 0x1a, 0x40,
-	0xad, 0xd4, 0x8f, 0x2c, 0xbf, 0xf8, 0x73, 0x4c, 0x0a, 0x27, 0xf5, 0x87, 0x7a, 0x1f, 0xaa, 0xc9,
-	0xb7, 0x36, 0x0d, 0xec, 0xb8, 0xcc, 0x70, 0x25, 0xa1, 0xed, 0x04, 0x36, 0xaa, 0x43, 0xd1, 0xf0,
-	0x5c, 0x3a, 0xd7, 0x58, 0x45, 0x55, 0x71, 0x72, 0x94, 0xfe, 0x2c, 0x40, 0x4d, 0xf6, 0x7d, 0xe2,
-	0x9e, 0x5e, 0x82, 0x17, 0x53, 0x96, 0x3d, 0x92, 0xb2, 0x94, 0x7b, 0xb9, 0x43, 0xee, 0xa5, 0x20,
-	0xcc, 0xa7, 0x21, 0x94, 0x5e, 0x08, 0x70, 0x26, 0x71, 0xfb, 0x14, 0x33, 0x78, 0x0d, 0x0a, 0x86,
-	0xe7, 0xd0, 0x81, 0x95, 0x3d, 0x36, 0x11, 0x5c, 0x42, 0xfa, 0x8f, 0x00, 0x22, 0xe6, 0x1b, 0x0e,
-	0x39, 0x35, 0x30, 0x5b, 0x40, 0x97, 0x66, 0xdf, 0x0b, 0x75, 0xfb, 0x1d, 0x3e, 0xcd, 0x64, 0xde,
-	0x01, 0xed, 0x55, 0xa8, 0xf1, 0x4f, 0xcd, 0x24, 0x76, 0xa4, 0x73, 0x84, 0xab, 0x9c, 0xd8, 0xa1,
-	0x34, 0xb4, 0x0e, 0x15, 0xdd, 0x78, 0xea, 0x7a, 0x5f, 0xd8, 0xc4, 0x9c, 0x10, 0xde, 0x4a, 0x69,
-	0x92, 0xf4, 0x07, 0x01, 0x56, 0x53, 0x61, 0x9f, 0x62, 0x32, 0xd2, 0x7d, 0x91, 0x3d, 0xb9, 0x2f,
-	0xa4, 0x2f, 0x05, 0xa8, 0xf4, 0xac, 0x30, 0x4a, 0x72, 0xf1, 0x63, 0x28, 0x85, 0x7c, 0xd7, 0xe6,
-	0xd9, 0xb8, 0x70, 0x64, 0xe9, 0x8c, 0xd9, 0xfc, 0x77, 0x30, 0x13, 0xa7, 0x1d, 0xeb, 0xeb, 0x13,
-	0x72, 0xe8, 0xe7, 0x55, 0xa6, 0x14, 0xf6, 0xe7, 0x9a, 0xb1, 0x23, 0xef, 0x29, 0x71, 0x99, 0x6f,
-	0xe5, 0x98, 0x3d, 0xa6, 0x04, 0xe9, 0xeb, 0x0c, 0x54, 0x63, 0x47, 0xfe, 0x6f, 0x74, 0x5a, 0x27,
-	0xa1, 0xc3, 0x5d, 0x4d, 0x30, 0xfa, 0x29, 0x94, 0x78, 0xa5, 0xc4, 0x1b, 0xdc, 0xa1, 0x25, 0x38,
-	0xed, 0x43, 0xb2, 0x11, 0x27, 0xa1, 0x26, 0x5a, 0xe8, 0x03, 0x38, 0xeb, 0x92, 0xe7, 0x91, 0x96,
-	0x0a, 0x28, 0xc7, 0x02, 0xaa, 0x51, 0xf2, 0x30, 0x09, 0xaa, 0xf1, 0x3b, 0x01, 0x92, 0xea, 0x44,
-	0x37, 0x20, 0xb7, 0x7c, 0x59, 0x48, 0xed, 0xc4, 0xfc, 0x22, 0x26, 0x48, 0x47, 0x16, 0xfd, 0xc5,
-	0x05, 0xe4, 0x99, 0x15, 0x26, 0xef, 0x86, 0x2c, 0xae, 0x38, 0x9e, 0x89, 0x39, 0x09, 0x7d, 0x1f,
-	0xf2, 0x81, 0x37, 0x8d, 0x08, 0x4f, 0x75, 0xea, 0x85, 0x85, 0x29, 0x99, 0x9b, 0x8b, 0x65, 0xa4,
-	0x7f, 0x09, 0x50, 0x95, 0x7d, 0xdf, 0xde, 0x4f, 0x72, 0x7d, 0x17, 0x8a, 0xc6, 0x13, 0xdd, 0x9d,
-	0x90, 0xe4, 0x85, 0x76, 0x65, 0xae, 0x9f, 0x16, 0x6c, 0x6d, 0x31, 0xa9, 0xe4, 0x89, 0xc4, 0x75,
-	0x1a, 0xbf, 0x17, 0xa0, 0x10, 0x73, 0x50, 0x0b, 0xde, 0x23, 0xcf, 0x7d, 0x62, 0x44, 0xda, 0x21,
-	0x8f, 0xd9, 0xfa, 0x8e, 0x57, 0x63, 0xd6, 0x76, 0xca, 0xef, 0xeb, 0x50, 0x98, 0xfa, 0x21, 0x09,
-	0x22, 0x9e, 0xb1, 0xe5, 0x68, 0x60, 0x2e, 0x84, 0xae, 0x42, 0xc1, 0x24, 0x36, 0xe1, 0x71, 0x2e,
-	0x74, 0x3d, 0x67, 0x49, 0x16, 0x9b, 0xd1, 0xd4, 0xe9, 0xd3, 0x2e, 0x20, 0xba, 0x0e, 0xe6, 0x19,
-	0xc0, 0xe8, 0x13, 0x28, 0x3a, 0xc4, 0xd9, 0x25, 0x41, 0x02, 0xe1, 0x49, 0xfb, 0x6a, 0x22, 0x4e,
-	0x67, 0x8e, 0x1f, 0x58, 0x8e, 0x1e, 0xec, 0xc7, 0x0f, 0x42, 0x9c, 0x1c, 0xd1, 0x35, 0x28, 0x27,
-	0x0b, 0x6b, 0xf2, 0xc2, 0x38, 0xbc, 0xcf, 0xce, 0xd9, 0xd2, 0x9f, 0x32, 0x50, 0x88, 0x5d, 0x44,
-	0x77, 0x01, 0x92, 0xa5, 0xf4, 0x7f, 0xde, 0x9e, 0xcb, 0x5c, 0x43, 0x35, 0xe7, 0xa5, 0x94, 0x39,
-	0xb9, 0x94, 0x68, 0x2d, 0x93, 0xc8, 0x30, 0x79, 0xd9, 0x9d, 0x5b, 0x84, 0xab, 0xa5, 0x44, 0x86,
-	0x99, 0xd4, 0x32, 0x15, 0x6c, 0xfc, 0x0a, 0x72, 0x94, 0x46, 0x87, 0x80, 0x61, 0x4f, 0xc3, 0x88,
-	0x04, 0x89, 0x93, 0x39, 0x5c, 0xe6, 0x14, 0xd5, 0x44, 0x97, 0xa0, 0x1c, 0xe3, 0x43, 0xb9, 0x19,
-	0xc6, 0x2d, 0xc5, 0x04, 0xd5, 0x44, 0x0d, 0x28, 0xcd, 0x2a, 0x2b, 0xde, 0x15, 0x66, 0x67, 0xaa,
-	0x18, 0xe8, 0x7b, 0x91, 0x16, 0x91, 0x20, 0x5e, 0x60, 0x73, 0xb8, 0x44, 0x09, 0x63, 0x12, 0x38,
-	0xd7, 0xfe, 0x9e, 0x81, 0x42, 0x9c, 0x71, 0x54, 0x80, 0xcc, 0xe0, 0x81, 0xb8, 0x82, 0xce, 0xc1,
-	0xea, 0x67, 0x83, 0x1d, 0xdc, 0x97, 0x7b, 0x1a, 0x7d, 0xb5, 0x74, 0x07, 0x3b, 0xfd, 0x8e, 0x28,
-	0xa0, 0x2b, 0x70, 0xb1, 0x3f, 0xd0, 0x12, 0xce, 0x10, 0xab, 0xdb, 0x32, 0x7e, 0xac, 0xb5, 0xf1,
-	0xe0, 0x81, 0x82, 0xc5, 0x0c, 0x5a, 0x83, 0x06, 0x95, 0x3e, 0x86, 0x9f, 0x45, 0xe7, 0x01, 0xa5,
-	0xf9, 0x9c, 0x9e, 0x47, 0xeb, 0x70, 0x59, 0xed, 0x8f, 0x76, 0xba, 0x5d, 0x75, 0x4b, 0x55, 0xfa,
-	0x8b, 0x02, 0x23, 0x31, 0x87, 0x2e, 0x43, 0x7d, 0xd0, 0xed, 0x8e, 0x94, 0x31, 0x73, 0xe7, 0xb1,
-	0x32, 0xd6, 0xe4, 0x87, 0xb2, 0xda, 0x93, 0xdb, 0x3d, 0x45, 0x2c, 0xa0, 0xb3, 0x50, 0xa1, 0x0f,
-	0xa7, 0x7b, 0x1a, 0x1e, 0xec, 0x8c, 0x15, 0xb1, 0x48, 0xdd, 0xef, 0x62, 0xf9, 0xde, 0x36, 0x35,
-	0xb6, 0xad, 0x8e, 0xb6, 0xe5, 0xf1, 0xd6, 0x7d, 0xb1, 0x84, 0x2e, 0xc1, 0x05, 0x65, 0xbc, 0xd5,
-	0xd1, 0xc6, 0x58, 0xee, 0x8f, 0xe4, 0xad, 0xb1, 0x3a, 0xe8, 0x6b, 0x5d, 0x59, 0xed, 0x29, 0x1d,
-	0xb1, 0x4c, 0x8d, 0x50, 0xdb, 0x72, 0xaf, 0x37, 0x78, 0xa4, 0x74, 0x44, 0x40, 0x17, 0xe0, 0xbd,
-	0xd8, 0xaa, 0x3c, 0x1c, 0x2a, 0xfd, 0x8e, 0x16, 0x3b, 0x20, 0x56, 0xa8, 0x33, 0x6a, 0xbf, 0xa3,
-	0xfc, 0x4c, 0xbb, 0x2f, 0x8f, 0xb4, 0x7b, 0x58, 0x91, 0xc7, 0x0a, 0x4e, 0xb8, 0xd5, 0x6b, 0x2e,
-	0x88, 0x8b, 0x7b, 0x3d, 0xaa, 0x40, 0x51, 0xed, 0x3f, 0x94, 0x7b, 0x2a, 0x7d, 0xf6, 0x95, 0x20,
-	0xd7, 0x1f, 0xf4, 0x15, 0x51, 0xa0, 0x5f, 0xf7, 0x3e, 0x57, 0x87, 0x62, 0x06, 0xd5, 0xa0, 0xfc,
-	0xf9, 0x68, 0x2c, 0xf7, 0x3b, 0x32, 0xee, 0x88, 0x59, 0xfa, 0xfa, 0x1b, 0xf5, 0xe5, 0xe1, 0xf0,
-	0xb1, 0x98, 0xa3, 0xa0, 0x52, 0x21, 0x7a, 0x41, 0x6f, 0x20, 0x77, 0xb4, 0x8e, 0xb2, 0x35, 0xd8,
-	0x1e, 0x62, 0x65, 0x34, 0x52, 0x07, 0x7d, 0x31, 0xbf, 0xf9, 0xd7, 0xcc, 0x7c, 0x86, 0xfe, 0x10,
-	0x72, 0x74, 0x3e, 0xa3, 0x73, 0x8b, 0xf3, 0x9a, 0xcd, 0xa9, 0xc6, 0xf9, 0xe5, 0x63, 0x1c, 0x7d,
-	0x02, 0x79, 0x36, 0x1a, 0xd0, 0xf9, 0xe5, 0x03, 0xae, 0x71, 0xe1, 0x08, 0x9d, 0x6b, 0x7e, 0x0c,
-	0x39, 0xba, 0x01, 0xa7, 0x2f, 0x4c, 0xed, 0xf9, 0xe9, 0x0b, 0xd3, 0x8b, 0xf2, 0x4d, 0x01, 0xdd,
-	0x85, 0x42, 0xbc, 0x7a, 0xa1, 0xc3, 0xb6, 0xe7, 0x3b, 0x64, 0xa3, 0x7e, 0x94, 0x11, 0xab, 0x6f,
-	0x08, 0xe8, 0x3e, 0x94, 0x67, 0xfb, 0x02, 0x6a, 0xa4, 0x6f, 0x39, 0xbc, 0x3b, 0x35, 0x2e, 0x2d,
-	0xe5, 0x25, 0x76, 0x6e, 0x0a, 0xed, 0xcb, 0xaf, 0xfe, 0xbd, 0xb6, 0xf2, 0xea, 0x9b, 0x35, 0xe1,
-	0xf5, 0x37, 0x6b, 0xc2, 0x8b, 0x37, 0x6b, 0x2b, 0x2f, 0xdf, 0xac, 0x09, 0xaf, 0xdf, 0xac, 0xad,
-	0xfc, 0xf3, 0xcd, 0xda, 0xca, 0x6e, 0x81, 0x69, 0xdf, 0xfe, 0x6f, 0x00, 0x00, 0x00, 0xff, 0xff,
-	0xf5, 0x22, 0x83, 0xa7, 0x75, 0x14, 0x00, 0x00,
+func init() { proto.RegisterFile("protocol.proto", fileDescriptor_protocol_c5ac8b8be4f4b766) }
+
+var fileDescriptor_protocol_c5ac8b8be4f4b766 = []byte{
+	// 2591 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x58, 0xdd, 0x6f, 0xdb, 0xd6,
+	0x15, 0x37, 0xf5, 0xad, 0x23, 0xc9, 0xa6, 0x6f, 0x13, 0x47, 0x51, 0x1a, 0xcb, 0x65, 0xbb, 0xce,
+	0xcd, 0x1a, 0x25, 0x75, 0xbb, 0xb6, 0x0b, 0xd0, 0x75, 0x94, 0x45, 0x3b, 0x6a, 0x65, 0x49, 0xbb,
+	0x92, 0x93, 0xa5, 0x2f, 0x04, 0x2d, 0x5e, 0xcb, 0x5c, 0x28, 0x92, 0x25, 0xa9, 0xd6, 0xee, 0xb6,
+	0xd7, 0x6e, 0x18, 0xf6, 0xd0, 0x97, 0x61, 0x7d, 0x2c, 0xf6, 0x37, 0x0c, 0x18, 0x06, 0x6c, 0xaf,
+	0x43, 0x1f, 0x0b, 0xec, 0x65, 0x18, 0x06, 0x77, 0x6b, 0xfe, 0x83, 0x6c, 0x4f, 0x05, 0x06, 0x0c,
+	0xf7, 0x83, 0x12, 0x2d, 0xcb, 0x76, 0x83, 0x21, 0x7b, 0xe3, 0x3d, 0xe7, 0x77, 0x0e, 0xcf, 0x3d,
+	0x5f, 0xf7, 0x9e, 0x0b, 0x8b, 0x9e, 0xef, 0x86, 0xee, 0xc0, 0xb5, 0x6b, 0xec, 0x03, 0xe5, 0xa2,
+	0x75, 0xe5, 0xe6, 0xd0, 0x0a, 0x0f, 0xc6, 0x7b, 0xb5, 0x81, 0x3b, 0xba, 0x35, 0x74, 0x87, 0xee,
+	0x2d, 0xc6, 0xd9, 0x1b, 0xef, 0xb3, 0x15, 0x5b, 0xb0, 0x2f, 0x2e, 0x58, 0x59, 0x1d, 0xba, 0xee,
+	0xd0, 0x26, 0x53, 0x94, 0x39, 0xf6, 0x8d, 0xd0, 0x72, 0x1d, 0xc1, 0xaf, 0xce, 0xf2, 0x43, 0x6b,
+	0x44, 0x82, 0xd0, 0x18, 0x79, 0x1c, 0xa0, 0xbc, 0x02, 0xe9, 0x96, 0xb1, 0x47, 0x6c, 0x84, 0x20,
+	0xe5, 0x18, 0x23, 0x52, 0x96, 0xd6, 0xa4, 0xf5, 0x3c, 0x66, 0xdf, 0xe8, 0x12, 0xa4, 0x3f, 0x30,
+	0xec, 0x31, 0x29, 0x27, 0x18, 0x91, 0x2f, 0x94, 0x36, 0xe4, 0x98, 0x48, 0x8f, 0x84, 0xa8, 0x0e,
+	0x19, 0x9b, 0x7e, 0x07, 0x65, 0x69, 0x2d, 0xb9, 0x5e, 0xd8, 0x58, 0xaa, 0x4d, 0x76, 0xc6, 0x30,
+	0xf5, 0xab, 0x9f, 0x1f, 0x57, 0x17, 0x1e, 0x1f, 0x57, 0x97, 0x8f, 0x8c, 0x91, 0x7d, 0x47, 0x79,
+	0xd9, 0x1d, 0x59, 0x21, 0x19, 0x79, 0xe1, 0x91, 0x82, 0x85, 0xa4, 0xf2, 0x33, 0x28, 0x09, 0x7d,
+	0x36, 0x19, 0x84, 0xae, 0x8f, 0x36, 0x20, 0x6b, 0x39, 0x03, 0x7b, 0x6c, 0x72, 0x6b, 0x0a, 0x1b,
+	0x68, 0x46, 0x6b, 0x8f, 0x84, 0xf5, 0x14, 0x55, 0x8c, 0x23, 0x20, 0x95, 0x21, 0x87, 0x5c, 0x26,
+	0x71, 0x91, 0x8c, 0x00, 0xde, 0x49, 0x7d, 0xfa, 0x59, 0x75, 0x41, 0xf9, 0x4f, 0x0e, 0x0a, 0xef,
+	0xb8, 0x63, 0xdf, 0x31, 0xec, 0x9e, 0x47, 0x06, 0xe8, 0xb5, 0xb8, 0x23, 0xea, 0x6b, 0x73, 0x6d,
+	0xff, 0xfa, 0xb8, 0x9a, 0x15, 0x32, 0xc2, 0x55, 0x6f, 0x40, 0xc1, 0x27, 0x9e, 0x6d, 0x0d, 0x98,
+	0xf7, 0x99, 0x0d, 0xe9, 0xfa, 0xe5, 0xf9, 0x1b, 0x8f, 0x23, 0x51, 0x77, 0xe2, 0xc1, 0xe4, 0x99,
+	0x76, 0xbf, 0x40, 0xed, 0xfe, 0xe2, 0xb8, 0x2a, 0x3d, 0x3e, 0xae, 0x96, 0x67, 0xf5, 0xbd, 0x6c,
+	0x39, 0xb6, 0xe5, 0x90, 0x89, 0x3f, 0xd1, 0x2e, 0xe4, 0xf6, 0x7d, 0x63, 0x38, 0x22, 0x4e, 0x58,
+	0x4e, 0x31, 0x9d, 0xab, 0x53, 0x9d, 0xb1, 0x9d, 0xd6, 0xb6, 0x04, 0xea, 0xbc, 0x20, 0x4d, 0x54,
+	0xa1, 0xb7, 0x21, 0xbd, 0x6f, 0x1b, 0xc3, 0xa0, 0x9c, 0x59, 0x93, 0xd6, 0x4b, 0xf5, 0x97, 0xce,
+	0x72, 0x8c, 0x1c, 0xfb, 0x85, 0xbe, 0x65, 0x1b, 0x43, 0xcc, 0xe5, 0x50, 0x0b, 0x96, 0x46, 0xc6,
+	0xa1, 0x6e, 0x78, 0x1e, 0x71, 0x4c, 0x3d, 0xb0, 0x3e, 0x22, 0xe5, 0xec, 0x9a, 0xb4, 0x9e, 0xac,
+	0xbf, 0xf0, 0xf8, 0xb8, 0xba, 0xc6, 0x55, 0xcd, 0x00, 0xe2, 0x96, 0x94, 0x46, 0xc6, 0xa1, 0xca,
+	0x58, 0x3d, 0xeb, 0x23, 0x32, 0xa3, 0xcd, 0x37, 0x42, 0x52, 0xce, 0x9d, 0xa3, 0x8d, 0x02, 0xe6,
+	0x6b, 0xc3, 0x46, 0x48, 0xd0, 0x4f, 0x61, 0x69, 0xcf, 0x77, 0x1f, 0x12, 0x5f, 0x0f, 0x44, 0x16,
+	0x96, 0xf3, 0xcc, 0x75, 0x57, 0x4e, 0x85, 0x83, 0xb3, 0xeb, 0x77, 0xa8, 0xcf, 0xfe, 0x76, 0x5c,
+	0x5d, 0xdc, 0x21, 0xa3, 0x3d, 0xe2, 0x47, 0xf4, 0xe9, 0xcf, 0x67, 0xf4, 0xc5, 0x7f, 0xbe, 0xc8,
+	0x79, 0x91, 0x4c, 0xe5, 0xf7, 0x49, 0xc8, 0x45, 0xc1, 0x40, 0x37, 0x21, 0x63, 0x13, 0x67, 0x18,
+	0x1e, 0xb0, 0x0c, 0x4c, 0x9e, 0x95, 0x44, 0x02, 0x84, 0x5c, 0x58, 0x1e, 0xb8, 0x23, 0xcf, 0x27,
+	0x41, 0x60, 0xb9, 0x8e, 0x3e, 0x70, 0x4d, 0x32, 0x60, 0xe9, 0xb7, 0xb8, 0x51, 0x99, 0xda, 0xbe,
+	0x39, 0x85, 0x6c, 0x52, 0x44, 0xfd, 0xc5, 0xc7, 0xc7, 0x55, 0x85, 0x6b, 0x3d, 0x25, 0x1e, 0xff,
+	0x8d, 0x3c, 0x98, 0x91, 0x44, 0xdf, 0x87, 0x4c, 0x10, 0xba, 0x3e, 0xa1, 0x09, 0x9b, 0x5c, 0xcf,
+	0xd7, 0x5f, 0x9c, 0x6b, 0xdf, 0xd7, 0xc7, 0xd5, 0x52, 0xb4, 0xa5, 0x1e, 0x85, 0x63, 0x21, 0x85,
+	0x02, 0x90, 0x7d, 0xb2, 0xef, 0x93, 0xe0, 0x40, 0xb7, 0x9c, 0x90, 0xf8, 0x1f, 0x18, 0xb6, 0x48,
+	0xd3, 0xab, 0x35, 0xde, 0xad, 0x6a, 0x51, 0xb7, 0xaa, 0x35, 0x44, 0x37, 0xab, 0xdf, 0x14, 0x19,
+	0xfa, 0x1c, 0xff, 0xd1, 0xac, 0x82, 0xd8, 0x8f, 0x3f, 0xfd, 0xb2, 0x2a, 0xe1, 0x25, 0x01, 0x68,
+	0x0a, 0x3e, 0xba, 0x07, 0x79, 0x9f, 0x84, 0xc4, 0x61, 0xc5, 0x99, 0xbe, 0xe8, 0x6f, 0xd7, 0xcf,
+	0xac, 0x07, 0xa6, 0x7d, 0xaa, 0x4a, 0x51, 0x21, 0x45, 0x53, 0x1c, 0x2d, 0x43, 0xa9, 0xdd, 0xe9,
+	0xeb, 0xbd, 0xae, 0xb6, 0xd9, 0xdc, 0x6a, 0x6a, 0x0d, 0x79, 0x01, 0x15, 0x21, 0xd7, 0xd1, 0x71,
+	0xa3, 0xd3, 0x6e, 0x3d, 0x90, 0x25, 0xbe, 0xba, 0x8f, 0xd9, 0x2a, 0x81, 0x00, 0x32, 0x94, 0x77,
+	0x1f, 0xcb, 0x29, 0xe5, 0xd7, 0x09, 0x28, 0x74, 0x7d, 0x77, 0x40, 0x82, 0x80, 0xf5, 0x9f, 0x1a,
+	0x24, 0x2c, 0x53, 0x34, 0xbe, 0xf2, 0x34, 0x82, 0x31, 0x48, 0xad, 0xd9, 0x10, 0xad, 0x2c, 0x61,
+	0x99, 0x68, 0x1d, 0x72, 0xc4, 0x31, 0x3d, 0xd7, 0x72, 0x42, 0xde, 0xa7, 0xeb, 0xc5, 0xaf, 0x8f,
+	0xab, 0x39, 0x4d, 0xd0, 0xf0, 0x84, 0x8b, 0xb4, 0x6f, 0xd0, 0x6a, 0x2e, 0xee, 0xd7, 0x34, 0x41,
+	0x07, 0xae, 0x6f, 0xba, 0x0e, 0x0b, 0x5b, 0xee, 0xcc, 0x04, 0xe5, 0xa0, 0xca, 0x6d, 0x48, 0x34,
+	0x1b, 0xf4, 0x78, 0xf9, 0xc8, 0x75, 0x26, 0xc7, 0x0b, 0xfd, 0x46, 0x2b, 0x90, 0x09, 0xc6, 0xfb,
+	0xfb, 0xd6, 0xa1, 0x38, 0x5f, 0xc4, 0xea, 0x4e, 0xea, 0x17, 0x9f, 0x55, 0x25, 0xe5, 0x0f, 0x12,
+	0x40, 0x9d, 0xd7, 0x09, 0x75, 0x4b, 0x1f, 0x8a, 0x1e, 0x77, 0x81, 0x1e, 0x78, 0x64, 0x20, 0x1c,
+	0x74, 0x79, 0xae, 0x83, 0xea, 0x95, 0x58, 0xc3, 0x5c, 0x14, 0xa6, 0x45, 0x6d, 0xb2, 0xe0, 0xc5,
+	0x9c, 0xfd, 0x3c, 0x94, 0x7e, 0xcc, 0xdb, 0x95, 0x6e, 0x5b, 0x23, 0x8b, 0x7b, 0xb0, 0x84, 0x8b,
+	0x82, 0xd8, 0xa2, 0x34, 0x74, 0x1b, 0x2e, 0x45, 0xa0, 0x0f, 0x89, 0x35, 0x3c, 0x08, 0x05, 0x36,
+	0xc9, 0xb0, 0x48, 0xf0, 0xee, 0x33, 0x16, 0x93, 0x50, 0xfe, 0x9c, 0x88, 0x15, 0xf4, 0xb7, 0x20,
+	0x2b, 0x20, 0xe2, 0x4c, 0x29, 0xc4, 0x8f, 0x8f, 0x88, 0x47, 0x0f, 0xdb, 0x3d, 0x32, 0xb4, 0xf8,
+	0xd9, 0x91, 0xc4, 0x7c, 0x81, 0x64, 0x48, 0x12, 0xc7, 0x64, 0xbf, 0x4a, 0x62, 0xfa, 0x89, 0x5e,
+	0x82, 0x64, 0x30, 0x1e, 0x89, 0x92, 0x59, 0x9e, 0xee, 0xbf, 0x77, 0x57, 0x7d, 0xa5, 0x37, 0x1e,
+	0x89, 0xcc, 0xa0, 0x18, 0xb4, 0x3d, 0xaf, 0x37, 0xa4, 0x2f, 0xea, 0x0d, 0x73, 0x6a, 0xfe, 0x75,
+	0x28, 0xed, 0x19, 0x83, 0x87, 0x96, 0x33, 0xd4, 0x59, 0x15, 0xb3, 0x33, 0x20, 0x5f, 0x5f, 0x3e,
+	0x5d, 0xe5, 0x45, 0x81, 0x63, 0x2b, 0xf4, 0x36, 0xe4, 0x46, 0xae, 0xa9, 0xd3, 0x4b, 0x07, 0xeb,
+	0xf5, 0x85, 0x8d, 0xca, 0xa9, 0xaa, 0xeb, 0x47, 0x37, 0x92, 0x7a, 0x8e, 0x5a, 0xfe, 0x09, 0xad,
+	0xb0, 0xec, 0xc8, 0x35, 0x29, 0x5d, 0x79, 0x17, 0xb2, 0x62, 0x5f, 0xd4, 0x3f, 0x9e, 0xe1, 0x87,
+	0xaf, 0x30, 0x27, 0x66, 0x30, 0x5f, 0x44, 0xd4, 0x8d, 0x72, 0x62, 0x4a, 0xdd, 0x88, 0xa8, 0xaf,
+	0x32, 0xbf, 0x65, 0x39, 0xf5, 0x55, 0xe5, 0x2f, 0x12, 0x14, 0x30, 0x31, 0x4c, 0x4c, 0xde, 0x1f,
+	0x93, 0x20, 0x44, 0xeb, 0x90, 0x39, 0x20, 0x86, 0x49, 0x7c, 0x91, 0x4c, 0xf2, 0xd4, 0x27, 0x77,
+	0x19, 0x1d, 0x0b, 0x7e, 0x3c, 0x84, 0x89, 0x73, 0x42, 0xb8, 0x02, 0x19, 0x77, 0x7f, 0x3f, 0x20,
+	0xa1, 0x88, 0x97, 0x58, 0xb1, 0xd0, 0xda, 0xee, 0xe0, 0x21, 0x2f, 0x18, 0xcc, 0x17, 0x68, 0x0d,
+	0x8a, 0xa6, 0xab, 0x3b, 0x6e, 0xa8, 0x7b, 0xbe, 0x7b, 0x78, 0xc4, 0x02, 0x93, 0xc3, 0x60, 0xba,
+	0x6d, 0x37, 0xec, 0x52, 0x0a, 0xcd, 0xce, 0x11, 0x09, 0x0d, 0xd3, 0x08, 0x0d, 0xdd, 0x75, 0xec,
+	0x23, 0xe6, 0xf6, 0x1c, 0x2e, 0x46, 0xc4, 0x8e, 0x63, 0x1f, 0x29, 0x1f, 0x27, 0xa0, 0xc8, 0x77,
+	0x15, 0x78, 0xae, 0x13, 0x10, 0xba, 0xad, 0x20, 0x34, 0xc2, 0x71, 0xc0, 0xb6, 0xb5, 0x18, 0xdf,
+	0x56, 0x8f, 0xd1, 0xb1, 0xe0, 0xc7, 0x1c, 0x90, 0xb8, 0xc0, 0x01, 0x67, 0xed, 0xec, 0x3a, 0xc0,
+	0x87, 0xbe, 0x15, 0x12, 0x9d, 0xe2, 0xd8, 0xf6, 0x92, 0x38, 0xcf, 0x28, 0x54, 0x01, 0xaa, 0xc5,
+	0xae, 0x22, 0xe9, 0xd9, 0x9e, 0x13, 0x25, 0x4e, 0xec, 0x8e, 0xf1, 0x1c, 0x14, 0xa3, 0x6f, 0x7d,
+	0xec, 0xdb, 0x3c, 0xcd, 0x70, 0x21, 0xa2, 0xed, 0xfa, 0x36, 0x2a, 0x43, 0x76, 0xe0, 0x3a, 0xb4,
+	0xff, 0xb2, 0x8c, 0x2a, 0xe2, 0x68, 0xa9, 0xfc, 0x4e, 0x82, 0x92, 0x38, 0xd2, 0x9f, 0x56, 0x80,
+	0x67, 0x43, 0x96, 0x3c, 0x15, 0xb2, 0x98, 0x79, 0xa9, 0x13, 0xe6, 0xc5, 0x5c, 0x98, 0x8e, 0xbb,
+	0x50, 0xf9, 0x44, 0x82, 0xc5, 0xc8, 0xec, 0xa7, 0x18, 0xc1, 0x1b, 0xb4, 0x6b, 0x8f, 0xa2, 0xb6,
+	0x35, 0x3f, 0x10, 0x02, 0xa1, 0xfc, 0x5b, 0x02, 0x19, 0x8b, 0x3b, 0x2a, 0x79, 0x6a, 0xce, 0xac,
+	0x01, 0x1d, 0x7b, 0x3c, 0x37, 0x30, 0xec, 0x73, 0x6c, 0x9a, 0x60, 0xce, 0x71, 0xed, 0xf3, 0x50,
+	0x12, 0x9f, 0xba, 0x49, 0xec, 0xd0, 0x10, 0x1e, 0x2e, 0x0a, 0x62, 0x83, 0xd2, 0xd0, 0x1a, 0x14,
+	0x8c, 0xc1, 0x43, 0xc7, 0xfd, 0xd0, 0x26, 0xe6, 0x90, 0x88, 0x52, 0x8a, 0x93, 0x94, 0xdf, 0x48,
+	0xb0, 0x1c, 0xdb, 0xf6, 0x53, 0x0c, 0x46, 0xbc, 0x2e, 0x92, 0x17, 0xd7, 0x85, 0xf2, 0xb1, 0x04,
+	0x85, 0x96, 0x15, 0x84, 0x51, 0x2c, 0xbe, 0x07, 0xb9, 0xc9, 0x3d, 0x55, 0x3a, 0xff, 0x9e, 0xca,
+	0x8f, 0x83, 0x09, 0x9c, 0x56, 0xac, 0x67, 0x0c, 0xc9, 0x89, 0xe3, 0x2e, 0x4f, 0x29, 0xfc, 0xac,
+	0x8b, 0xd8, 0xa1, 0xfb, 0x90, 0x38, 0xcc, 0xb6, 0x3c, 0x67, 0xf7, 0x29, 0x41, 0xf9, 0x32, 0x01,
+	0x45, 0x6e, 0xc8, 0x13, 0x7b, 0xa7, 0x76, 0x91, 0x77, 0x84, 0xa9, 0x91, 0x8f, 0x7e, 0x00, 0x39,
+	0x91, 0x29, 0xfc, 0xa6, 0x79, 0x62, 0x8c, 0x89, 0xdb, 0x10, 0xcd, 0x34, 0xd1, 0x56, 0x23, 0x29,
+	0xf4, 0x22, 0x2c, 0x39, 0xe4, 0x30, 0xd4, 0x63, 0x1b, 0x4a, 0xb1, 0x0d, 0x95, 0x28, 0xb9, 0x1b,
+	0x6d, 0xaa, 0xf2, 0x4b, 0x09, 0xa2, 0xec, 0x44, 0xb7, 0x20, 0x35, 0xff, 0x7a, 0x11, 0x9b, 0x6a,
+	0xc4, 0x8f, 0x18, 0x90, 0xb6, 0x2c, 0x7a, 0xc4, 0xf9, 0xe4, 0x03, 0x2b, 0x88, 0x26, 0xbf, 0x24,
+	0x2e, 0x8c, 0x5c, 0x13, 0x0b, 0x12, 0xfa, 0x0e, 0xa4, 0x7d, 0x77, 0x1c, 0x12, 0x11, 0xea, 0xd8,
+	0x8c, 0x8c, 0x29, 0x59, 0xa8, 0xe3, 0x18, 0xe5, 0xef, 0x12, 0x14, 0x55, 0xcf, 0xb3, 0x8f, 0xa2,
+	0x58, 0xbf, 0x05, 0xd9, 0xc1, 0x81, 0xe1, 0x0c, 0x49, 0x34, 0x63, 0x5f, 0x9f, 0xca, 0xc7, 0x81,
+	0xb5, 0x4d, 0x86, 0x8a, 0x86, 0x5c, 0x21, 0x53, 0xf9, 0x95, 0x04, 0x19, 0xce, 0x41, 0x35, 0x78,
+	0x86, 0x1c, 0x7a, 0x64, 0x10, 0xea, 0x27, 0x2c, 0x66, 0x63, 0x06, 0x5e, 0xe6, 0xac, 0x9d, 0x98,
+	0xdd, 0x37, 0x21, 0x33, 0xf6, 0x02, 0xe2, 0x87, 0xe5, 0xc4, 0x39, 0xde, 0xc0, 0x02, 0x84, 0x9e,
+	0x87, 0x8c, 0x49, 0x6c, 0x22, 0xf6, 0x39, 0x53, 0xf5, 0x82, 0xa5, 0x58, 0x50, 0x12, 0x46, 0x3f,
+	0xed, 0x04, 0x52, 0x5a, 0xb0, 0x24, 0xaa, 0x39, 0xf8, 0xdf, 0xeb, 0x46, 0xf9, 0x47, 0x76, 0xd2,
+	0x13, 0x83, 0xff, 0x43, 0xf6, 0x37, 0x20, 0x27, 0x5e, 0x09, 0xa2, 0xec, 0x57, 0xa6, 0x12, 0xb3,
+	0x76, 0x44, 0x84, 0xc8, 0xe8, 0x48, 0x12, 0xbd, 0x0e, 0x57, 0x3c, 0xe2, 0x07, 0x56, 0x10, 0x12,
+	0x5f, 0x7f, 0x7f, 0x4c, 0xc6, 0xc4, 0xd4, 0x03, 0xcf, 0x75, 0xed, 0x40, 0x9c, 0xd5, 0x97, 0x27,
+	0xec, 0x1f, 0x32, 0x6e, 0x8f, 0x31, 0xd1, 0x6b, 0xb0, 0x72, 0x4a, 0x6e, 0xef, 0x28, 0x24, 0x81,
+	0xe8, 0xac, 0x97, 0x66, 0xc4, 0xea, 0x94, 0x57, 0xf9, 0x53, 0x1a, 0xb2, 0xc2, 0x92, 0x27, 0xaf,
+	0xa3, 0x49, 0x91, 0x24, 0x2e, 0x2e, 0x12, 0x54, 0x83, 0x34, 0xdb, 0xc6, 0xd9, 0xcd, 0x33, 0xc2,
+	0x33, 0x18, 0xba, 0x0f, 0x8b, 0x9e, 0xe5, 0x11, 0x3a, 0x00, 0xe8, 0x34, 0x20, 0x84, 0x6d, 0x7f,
+	0x71, 0xe3, 0xf6, 0xc5, 0x3e, 0xad, 0x75, 0x85, 0x20, 0x0d, 0x28, 0xc1, 0x25, 0x2f, 0xbe, 0x44,
+	0xaf, 0xc7, 0x14, 0x73, 0xf3, 0xd3, 0x73, 0xcd, 0x9f, 0xca, 0xb1, 0x25, 0xda, 0x84, 0xd5, 0xa9,
+	0x1c, 0x31, 0x4c, 0x3d, 0x3c, 0xf0, 0xdd, 0xf1, 0xf0, 0x60, 0x5a, 0x95, 0x19, 0xe6, 0xe8, 0x6b,
+	0x13, 0x31, 0x62, 0x98, 0x7d, 0x8e, 0x99, 0xd4, 0xe7, 0xb7, 0x61, 0xc9, 0x72, 0x4c, 0x72, 0xa8,
+	0x47, 0xe7, 0x44, 0xc0, 0xae, 0x44, 0x69, 0xbc, 0xc8, 0xc8, 0x91, 0x2b, 0x02, 0xb4, 0x01, 0x97,
+	0x39, 0xd0, 0x76, 0x07, 0x86, 0x1d, 0x83, 0xe7, 0x18, 0xfc, 0x19, 0xc6, 0x6c, 0x51, 0xde, 0x54,
+	0xe6, 0x65, 0x40, 0x5c, 0x86, 0xcd, 0x21, 0xba, 0xb8, 0xba, 0xe4, 0x99, 0x55, 0x32, 0xe3, 0xd4,
+	0x29, 0xa3, 0xc3, 0xe8, 0x68, 0x1d, 0x38, 0x4d, 0xa7, 0x6f, 0x2d, 0x02, 0x0b, 0x0c, 0xcb, 0x6d,
+	0xd1, 0x1c, 0x53, 0x20, 0x71, 0xa4, 0x37, 0x9a, 0xe1, 0xd9, 0x70, 0x50, 0x78, 0x82, 0xe1, 0x80,
+	0xff, 0x09, 0x73, 0x71, 0x36, 0x25, 0x68, 0x50, 0x3a, 0x11, 0x25, 0x94, 0x83, 0x54, 0xbb, 0xd3,
+	0xd6, 0xe4, 0x05, 0x94, 0x87, 0x34, 0xd6, 0xd4, 0x06, 0x1d, 0xc1, 0x73, 0x90, 0xaa, 0xef, 0xf6,
+	0xe8, 0xf8, 0x7d, 0x19, 0x96, 0xd5, 0xfb, 0x6a, 0xb3, 0xdf, 0x6c, 0x6f, 0xeb, 0x58, 0xbb, 0xd7,
+	0xec, 0x35, 0x3b, 0x6d, 0x39, 0xa9, 0xfc, 0x5c, 0x82, 0x34, 0x0f, 0xcf, 0x9b, 0x90, 0x1d, 0xb1,
+	0x67, 0x9d, 0xa8, 0xe7, 0x5e, 0x34, 0x88, 0x47, 0x70, 0x7a, 0x49, 0xf1, 0x7c, 0x6b, 0x64, 0xf8,
+	0x47, 0xfc, 0x0d, 0x10, 0x47, 0x4b, 0x74, 0x03, 0xf2, 0xd1, 0x24, 0x1e, 0x3d, 0x9d, 0x9c, 0x1c,
+	0xd4, 0xa7, 0x6c, 0xe5, 0xb7, 0x09, 0xc8, 0xf0, 0xb6, 0x80, 0xde, 0x02, 0x88, 0xe6, 0xde, 0x6f,
+	0xfc, 0x2c, 0x90, 0x17, 0x12, 0x4d, 0xf3, 0xc9, 0xca, 0xea, 0x16, 0xa4, 0x48, 0x38, 0x30, 0xcb,
+	0xc9, 0xd9, 0xa2, 0xe5, 0xb6, 0xd4, 0xb4, 0x70, 0x60, 0x46, 0x45, 0x4b, 0x81, 0x95, 0x9f, 0x40,
+	0x8a, 0xd2, 0xe8, 0xad, 0x61, 0x60, 0x8f, 0x59, 0xb7, 0x10, 0x46, 0xa6, 0x70, 0x5e, 0x50, 0x9a,
+	0x26, 0xba, 0x06, 0x79, 0xee, 0x1f, 0xca, 0x4d, 0x30, 0x6e, 0x8e, 0x13, 0x9a, 0x26, 0xaa, 0xd0,
+	0x4e, 0x27, 0x92, 0x9e, 0x0f, 0x17, 0x93, 0x35, 0x15, 0xf4, 0x8d, 0xfd, 0x50, 0x0f, 0x89, 0xcf,
+	0x27, 0xde, 0x14, 0xce, 0x51, 0x42, 0x9f, 0xf8, 0xa3, 0x1b, 0xff, 0x4a, 0x40, 0x86, 0x77, 0x59,
+	0x94, 0x81, 0x44, 0xe7, 0x5d, 0x79, 0x81, 0x06, 0xf6, 0x9d, 0xce, 0x2e, 0x6e, 0xab, 0x2d, 0x9d,
+	0x3e, 0xc7, 0x6c, 0x75, 0x76, 0xdb, 0x0d, 0x59, 0x42, 0xd7, 0xe1, 0x6a, 0xbb, 0xa3, 0x47, 0x9c,
+	0x2e, 0x6e, 0xee, 0xa8, 0xf8, 0x81, 0x5e, 0xc7, 0x9d, 0x77, 0x35, 0x2c, 0x27, 0xd0, 0x2a, 0x54,
+	0x28, 0xfa, 0x0c, 0x7e, 0x12, 0xad, 0x00, 0x8a, 0xf3, 0x05, 0x3d, 0x8d, 0xd6, 0xe0, 0xd9, 0x66,
+	0xbb, 0xb7, 0xbb, 0xb5, 0xd5, 0xdc, 0x6c, 0x6a, 0xed, 0x59, 0x40, 0x4f, 0x4e, 0xa1, 0x67, 0xa1,
+	0xdc, 0xd9, 0xda, 0xea, 0x69, 0x7d, 0x66, 0xce, 0x03, 0xad, 0xaf, 0xab, 0xf7, 0xd4, 0x66, 0x4b,
+	0xad, 0xb7, 0x34, 0x39, 0x83, 0x96, 0xa0, 0x40, 0x5f, 0x84, 0xb6, 0x75, 0xdc, 0xd9, 0xed, 0x6b,
+	0x72, 0x96, 0x9a, 0xbf, 0x85, 0xd5, 0xed, 0x1d, 0xaa, 0x6c, 0xa7, 0xd9, 0xdb, 0x51, 0xfb, 0x9b,
+	0x77, 0xe5, 0x1c, 0xba, 0x06, 0x57, 0xb4, 0xfe, 0x66, 0x43, 0xef, 0x63, 0xb5, 0xdd, 0x53, 0x37,
+	0xfb, 0xcd, 0x4e, 0x5b, 0xdf, 0x52, 0x9b, 0x2d, 0xad, 0x21, 0xe7, 0xa9, 0x12, 0xaa, 0x5b, 0x6d,
+	0xb5, 0x3a, 0xf7, 0xb5, 0x86, 0x0c, 0xe8, 0x0a, 0x3c, 0xc3, 0xb5, 0xaa, 0xdd, 0xae, 0xd6, 0x6e,
+	0xe8, 0xdc, 0x00, 0xb9, 0x40, 0x8d, 0x69, 0xb6, 0x1b, 0xda, 0x8f, 0xf4, 0xbb, 0x6a, 0x4f, 0xdf,
+	0xc6, 0x9a, 0xda, 0xd7, 0x70, 0xc4, 0x2d, 0x22, 0x19, 0x8a, 0x58, 0xed, 0x6b, 0x7a, 0xab, 0xb9,
+	0xd3, 0xec, 0x6b, 0x0d, 0xb9, 0x84, 0x2e, 0x81, 0x2c, 0x54, 0xf4, 0x3b, 0x1d, 0xbd, 0xa5, 0xe2,
+	0x6d, 0x4d, 0x5e, 0xbc, 0xe1, 0x80, 0x3c, 0xfb, 0x60, 0x80, 0x0a, 0x90, 0x6d, 0xb6, 0xef, 0xa9,
+	0xad, 0x26, 0x7d, 0xf7, 0x8a, 0x6a, 0x8f, 0x15, 0xdc, 0xf6, 0x7b, 0xcd, 0xae, 0x9c, 0x40, 0x25,
+	0xc8, 0xbf, 0xd7, 0xeb, 0xab, 0xed, 0x86, 0x8a, 0x1b, 0x72, 0x92, 0x3e, 0x7f, 0xf5, 0xda, 0x6a,
+	0xb7, 0xfb, 0x40, 0x4e, 0x51, 0xe7, 0x53, 0x10, 0x35, 0xa4, 0xd5, 0x51, 0x1b, 0x7a, 0x43, 0xdb,
+	0xec, 0xec, 0x74, 0xb1, 0xd6, 0x63, 0x45, 0x99, 0xde, 0xf8, 0x63, 0x62, 0x7a, 0x39, 0xfb, 0x2e,
+	0xa4, 0xe8, 0xc5, 0x0f, 0x5d, 0x9e, 0xbd, 0x08, 0xb2, 0xd3, 0xbd, 0xb2, 0x32, 0xff, 0x7e, 0x88,
+	0xde, 0x84, 0x34, 0xbb, 0x73, 0xa0, 0x95, 0xf9, 0x37, 0xa7, 0xca, 0x95, 0x53, 0x74, 0x21, 0xf9,
+	0x06, 0xa4, 0x68, 0xe3, 0x8d, 0xff, 0x30, 0xf6, 0x80, 0x50, 0x59, 0x99, 0x25, 0x73, 0xb1, 0xdb,
+	0x12, 0x7a, 0x0b, 0x32, 0x7c, 0xa6, 0x43, 0x27, 0x75, 0x4f, 0x87, 0xd3, 0x4a, 0xf9, 0x34, 0x83,
+	0x8b, 0xaf, 0x4b, 0xe8, 0x2e, 0xe4, 0x27, 0x83, 0x08, 0xaa, 0x9c, 0x3a, 0xa4, 0x26, 0x43, 0x59,
+	0xe5, 0xda, 0x5c, 0x5e, 0xa4, 0xe7, 0xb6, 0xb4, 0xf1, 0x0e, 0xa4, 0x55, 0x73, 0x64, 0x39, 0x48,
+	0x85, 0x1c, 0x8e, 0xae, 0x05, 0x57, 0xe7, 0x1d, 0x7b, 0x5c, 0x61, 0xe5, 0xec, 0x13, 0xb1, 0xfe,
+	0xec, 0xe7, 0xff, 0x5c, 0x5d, 0xf8, 0xfc, 0xab, 0x55, 0xe9, 0x8b, 0xaf, 0x56, 0xa5, 0x4f, 0x1e,
+	0xad, 0x2e, 0x7c, 0xf6, 0x68, 0x55, 0xfa, 0xe2, 0xd1, 0xea, 0xc2, 0x5f, 0x1f, 0xad, 0x2e, 0xec,
+	0x65, 0x98, 0xe0, 0xab, 0xff, 0x1d, 0x00, 0xc3, 0x31, 0x33, 0x3a, 0xdc, 0x1a, 0x00, 0x00,
 }
@@ -226,18 +226,8 @@ func (m *ReplicateResponse) Validate() error {
 }
 
 func (m *ListRequest) Validate() error {
-	if err := m.Selector.Validate(); err != nil {
-		return ExtendContext(err, "Selector")
-	}
-	for _, v := range m.Selector.Include.ValuesOf("prefix") {
-		if !strings.HasSuffix(v, "/") {
-			return NewValidationError("Selector.Include.Labels[\"prefix\"]: expected trailing '/' (%+v)", v)
-		}
-	}
-	for _, v := range m.Selector.Exclude.ValuesOf("prefix") {
-		if !strings.HasSuffix(v, "/") {
-			return NewValidationError("Selector.Exclude.Labels[\"prefix\"]: expected trailing '/' (%+v)", v)
-		}
+	if err := validateJournalSelector(m.Selector); err != nil {
+		return err
 	}
 
 	// PageLimit and PageToken require no extra validation.
@@ -312,6 +302,86 @@ func (m *ApplyResponse) Validate() error {
 	return nil
 }
 
+func (m *ReplicasRequest) Validate() error {
+	return validateJournalSelector(m.Selector)
+}
+
+func (m *ReplicasResponse) Validate() error {
+	if err := m.Status.Validate(); err != nil {
+		return ExtendContext(err, "Status")
+	} else if err = m.Header.Validate(); err != nil {
+		return ExtendContext(err, "Header")
+	}
+	for i, r := range m.Replicas {
+		if err := r.Validate(); err != nil {
+			return ExtendContext(err, "Replicas[%d]", i)
+		}
+	}
+	if m.PersisterQueuedSpools < 0 {
+		return NewValidationError("invalid PersisterQueuedSpools (%d; expected >= 0)", m.PersisterQueuedSpools)
+	} else if m.PersisterQueuedBytes < 0 {
+		return NewValidationError("invalid PersisterQueuedBytes (%d; expected >= 0)", m.PersisterQueuedBytes)
+	}
+	return nil
+}
+
+func (m *ReplicasResponse_Replica) Validate() error {
+	if err := m.Spec.Validate(); err != nil {
+		return ExtendContext(err, "Spec")
+	} else if err = m.Route.Validate(); err != nil {
+		return ExtendContext(err, "Route")
+	} else if err = m.Spool.Validate(); err != nil {
+		return ExtendContext(err, "Spool")
+	} else if m.Spool.Journal != m.Spec.Name {
+		return NewValidationError("Spool.Journal and Spec.Name differ (%s vs %s)", m.Spool.Journal, m.Spec.Name)
+	} else if err = m.PipelineState.Validate(); err != nil {
+		return ExtendContext(err, "PipelineState")
+	}
+
+	if m.PipelineRoute != nil {
+		if m.PipelineState != ReplicasResponse_Replica_READY {
+			return NewValidationError("unexpected PipelineRoute (PipelineState is %s)", m.PipelineState)
+		} else if err := m.PipelineRoute.Validate(); err != nil {
+			return ExtendContext(err, "PipelineRoute")
+		}
+	}
+	if m.IndexLocalFragments < 0 || m.IndexLocalFragments > m.IndexFragments {
+		return NewValidationError("invalid IndexLocalFragments (%d; expected 0 <= IndexLocalFragments <= %d)",
+			m.IndexLocalFragments, m.IndexFragments)
+	} else if m.IndexBeginOffset > m.IndexEndOffset {
+		return NewValidationError("expected IndexBeginOffset <= IndexEndOffset (have %d, %d)",
+			m.IndexBeginOffset, m.IndexEndOffset)
+	}
+	return nil
+}
+
+// Validate returns an error if the PipelineState is not well-formed.
+func (x ReplicasResponse_Replica_PipelineState) Validate() error {
+	if _, ok := ReplicasResponse_Replica_PipelineState_name[int32(x)]; !ok {
+		return NewValidationError("invalid PipelineState (%s)", x)
+	}
+	return nil
+}
+
+// validateJournalSelector validates a LabelSelector of journals, which may
+// include "prefix" meta-labels.
+func validateJournalSelector(sel LabelSelector) error {
+	if err := sel.Validate(); err != nil {
+		return ExtendContext(err, "Selector")
+	}
+	for _, v := range sel.Include.ValuesOf("prefix") {
+		if !strings.HasSuffix(v, "/") {
+			return NewValidationError("Selector.Include.Labels[\"prefix\"]: expected trailing '/' (%+v)", v)
+		}
+	}
+	for _, v := range sel.Exclude.ValuesOf("prefix") {
+		if !strings.HasSuffix(v, "/") {
+			return NewValidationError("Selector.Exclude.Labels[\"prefix\"]: expected trailing '/' (%+v)", v)
+		}
+	}
+	return nil
+}
+
 // Validate returns an error if the Status is not well-formed.
 func (x Status) Validate() error {
 	if _, ok := Status_name[int32(x)]; !ok {
@@ -373,6 +373,85 @@ func (s *RPCSuite) TestApplyResponseValidationCases(c *gc.C) {
 	c.Check(resp.Validate(), gc.IsNil)
 }
 
+func (s *RPCSuite) TestReplicasRequestValidationCases(c *gc.C) {
+	var req = ReplicasRequest{
+		Selector: LabelSelector{
+			Include: LabelSet{Labels: []Label{{Name: "a invalid name", Value: "foo"}}},
+			Exclude: LabelSet{Labels: []Label{{Name: "prefix", Value: "no/trailing/slash"}}},
+		},
+	}
+	c.Check(req.Validate(), gc.ErrorMatches,
+		`Selector.Include.Labels\[0\].Name: not a valid token \(a invalid name\)`)
+	req.Selector.Include.Labels[0].Name = "a-valid-name"
+	c.Check(req.Validate(), gc.ErrorMatches,
+		`Selector.Exclude.Labels\["prefix"\]: expected trailing '/' \(no/trailing/slash\)`)
+	req.Selector.Exclude.Labels[0].Value = "trailing/slash/"
+
+	c.Check(req.Validate(), gc.IsNil)
+}
+
+func (s *RPCSuite) TestReplicasResponseValidationCases(c *gc.C) {
+	var resp = ReplicasResponse{
+		Status: 9101,
+		Header: *badHeaderFixture(),
+		Replicas: []ReplicasResponse_Replica{
+			{
+				Spec: JournalSpec{
+					Name:        "a/journal invalid name",
+					Replication: 1,
+					Fragment: JournalSpec_Fragment{
+						Length:           1024,
+						CompressionCodec: CompressionCodec_NONE,
+						RefreshInterval:  time.Minute,
+						Retention:        time.Hour,
+					},
+				},
+				Route:               Route{Primary: 0},
+				Spool:               Fragment{Journal: "other/journal", Begin: 10, End: 5, CompressionCodec: CompressionCodec_NONE},
+				PipelineState:       ReplicasResponse_Replica_AWAITING_REVISION,
+				PipelineRoute:       &Route{Primary: 0},
+				IndexFragments:      2,
+				IndexLocalFragments: 3,
+				IndexBeginOffset:    100,
+				IndexEndOffset:      50,
+			},
+		},
+		PersisterQueuedSpools: -1,
+		PersisterQueuedBytes:  -1,
+	}
+
+	c.Check(resp.Validate(), gc.ErrorMatches, `Status: invalid status \(9101\)`)
+	resp.Status = Status_OK
+	c.Check(resp.Validate(), gc.ErrorMatches, `Header.Etcd: invalid ClusterId .*`)
+	resp.Header.Etcd.ClusterId = 1234
+	c.Check(resp.Validate(), gc.ErrorMatches, `Replicas\[0\].Spec.Name: not a valid token \(.*\)`)
+	resp.Replicas[0].Spec.Name = "a/journal"
+	c.Check(resp.Validate(), gc.ErrorMatches, `Replicas\[0\].Route: invalid Primary .*`)
+	resp.Replicas[0].Route.Primary = -1
+	c.Check(resp.Validate(), gc.ErrorMatches, `Replicas\[0\].Spool: expected Begin <= End \(have 10, 5\)`)
+	resp.Replicas[0].Spool.End = 20
+	c.Check(resp.Validate(), gc.ErrorMatches,
+		`Replicas\[0\]: Spool.Journal and Spec.Name differ \(other/journal vs a/journal\)`)
+	resp.Replicas[0].Spool.Journal = "a/journal"
+	c.Check(resp.Validate(), gc.ErrorMatches,
+		`Replicas\[0\]: unexpected PipelineRoute \(PipelineState is AWAITING_REVISION\)`)
+	resp.Replicas[0].PipelineState = ReplicasResponse_Replica_READY
+	c.Check(resp.Validate(), gc.ErrorMatches, `Replicas\[0\].PipelineRoute: invalid Primary .*`)
+	resp.Replicas[0].PipelineRoute.Primary = -1
+	c.Check(resp.Validate(), gc.ErrorMatches,
+		`Replicas\[0\]: invalid IndexLocalFragments \(3; expected 0 <= IndexLocalFragments <= 2\)`)
+	resp.Replicas[0].IndexLocalFragments = 1
+	c.Check(resp.Validate(), gc.ErrorMatches,
+		`Replicas\[0\]: expected IndexBeginOffset <= IndexEndOffset \(have 100, 50\)`)
+	resp.Replicas[0].IndexEndOffset = 200
+	c.Check(resp.Validate(), gc.ErrorMatches, `invalid PersisterQueuedSpools \(-1; expected >= 0\)`)
+	resp.PersisterQueuedSpools = 1
+	c.Check(resp.Validate(), gc.ErrorMatches, `invalid PersisterQueuedBytes \(-1; expected >= 0\)`)
+	resp.PersisterQueuedBytes = 1024
+
+	c.Check(resp.Validate(), gc.IsNil)
+}
+
 func badHeaderFixture() *Header {
 	return &Header{
 		ProcessId: ProcessSpec_ID{Zone: "zone", Suffix: "name"},
@@ -0,0 +1,340 @@
+package recoverylog
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/LiveRamp/gazette/v2/pkg/fragment"
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// Checkpoint captures the live files of the Recorder, persists them to the
+// fragment |store|, and then updates the Recorder FSM to reference the
+// Checkpoint. FSMHints subsequently built by the Recorder allow a Player to
+// restore Checkpoint files directly from the fragment store, and to read only
+// those portions of the log which follow the Checkpoint.
+//
+// Checkpoint is equivalent to CaptureCheckpoint followed by Persist of the
+// returned CapturedCheckpoint.
+func (r *Recorder) Checkpoint(ctx context.Context, store pb.FragmentStore) error {
+	var cp, err = r.CaptureCheckpoint(store)
+	if err != nil {
+		return err
+	}
+	return cp.Persist(ctx)
+}
+
+// CaptureCheckpoint captures the live files of the Recorder, for a subsequent
+// Persist to the fragment |store|. At most one CapturedCheckpoint of a Recorder
+// may be pending at a time.
+//
+// Live files are hard-linked into a staging directory while the Recorder is
+// locked, and only the lengths they had at capture are persisted. The caller
+// must ensure that live files are not removed (or truncated) during this brief
+// window. Once captured, files may again be created, appended, renamed and
+// removed while the CapturedCheckpoint is persisted, but must never be
+// re-written in place. Stores implementing consumer.Checkpointer rely on this
+// contract, and capture between consumer transactions.
+//
+// Files which are unchanged since the FSM's current Checkpoint are not staged,
+// and their persisted content is referenced by the captured Checkpoint as-is.
+func (r *Recorder) CaptureCheckpoint(store pb.FragmentStore) (*CapturedCheckpoint, error) {
+	if err := store.Validate(); err != nil {
+		return nil, extendErr(err, "FragmentStore")
+	}
+	var staging = r.dir + checkpointStagingSuffix
+
+	if err := os.RemoveAll(staging); err != nil {
+		return nil, extendErr(err, "removing staging directory")
+	} else if err = os.MkdirAll(staging, 0700); err != nil {
+		return nil, extendErr(err, "creating staging directory")
+	}
+
+	var cp, staged, err = r.captureCheckpoint(staging)
+	if err != nil {
+		removeStaging(staging)
+		return nil, err
+	}
+	return &CapturedCheckpoint{
+		rec:     r,
+		store:   store,
+		staging: staging,
+		cp:      cp,
+		staged:  staged,
+	}, nil
+}
+
+// CapturedCheckpoint is a Checkpoint of a Recorder which has been captured,
+// but not yet persisted.
+type CapturedCheckpoint struct {
+	rec     *Recorder
+	store   pb.FragmentStore
+	staging string
+	cp      *Checkpoint
+	// Captured lengths of Checkpoint Files which were staged, and must be
+	// persisted. Other Files reference content of a previous Checkpoint.
+	staged map[Fnode]int64
+}
+
+// Persist the files of the CapturedCheckpoint to its fragment store, and then
+// update the Recorder FSM to reference the Checkpoint. Persist may be called
+// concurrently with further operations of the Recorder. Files are persisted
+// under content-addressed names, and files already present in the store are
+// not persisted again.
+func (c *CapturedCheckpoint) Persist(ctx context.Context) error {
+	defer removeStaging(c.staging)
+
+	for i := range c.cp.Files {
+		var file = &c.cp.Files[i]
+		var size, ok = c.staged[file.Fnode]
+		if !ok {
+			continue // Content is unchanged from a previous Checkpoint.
+		}
+		var path = filepath.Join(c.staging, strconv.FormatInt(int64(file.Fnode), 10))
+		var err error
+
+		if file.Content, err = persistCheckpointFile(ctx, c.store,
+			checkpointJournal(c.rec.fsm.Log, file.Fnode), path, size); err != nil {
+			return extendErr(err, "persisting checkpoint file %s", file.Links[0])
+		}
+	}
+
+	// The Checkpoint is fully persisted, and may now be referenced by FSMHints.
+	var txn = c.rec.lockAndBeginTxn()
+	c.rec.fsm.applyCheckpoint(c.cp)
+	c.rec.unlockAndReleaseTxn(txn)
+
+	log.WithFields(log.Fields{
+		"log":       c.rec.fsm.Log,
+		"nextSeqNo": c.cp.NextSeqNo,
+		"files":     len(c.cp.Files),
+		"persisted": len(c.staged),
+	}).Info("recorded checkpoint")
+
+	return nil
+}
+
+// captureCheckpoint links each changed live file of the Recorder into
+// |staging|, and returns a Checkpoint of all live files along with the
+// captured lengths of those which were staged. The Recorder is locked throughout.
+func (r *Recorder) captureCheckpoint(staging string) (*Checkpoint, map[Fnode]int64, error) {
+	var txn = r.lockAndBeginTxn()
+	defer r.unlockAndReleaseTxn(txn)
+
+	var cp = &Checkpoint{
+		NextSeqNo:    r.fsm.NextSeqNo,
+		NextChecksum: r.fsm.NextChecksum,
+		LogOffset:    r.writeHead,
+	}
+	var previous = make(map[Fnode]pb.Fragment)
+	if r.fsm.Checkpoint != nil {
+		for _, f := range r.fsm.Checkpoint.Files {
+			previous[f.Fnode] = f.Content
+		}
+	}
+	var staged = make(map[Fnode]int64)
+
+	for fnode, node := range r.fsm.LiveNodes {
+		var file = Checkpoint_File{Fnode: fnode}
+
+		for link := range node.Links {
+			file.Links = append(file.Links, link)
+		}
+		sort.Strings(file.Links)
+
+		// A checkpointed Fnode has no Segments if it's unchanged since the Checkpoint.
+		if content, ok := previous[fnode]; ok && len(node.Segments) == 0 {
+			file.Content = content
+			cp.Files = append(cp.Files, file)
+			continue
+		}
+
+		// The file of an in-progress rebase is that of the Fnode being rebased.
+		// Its recorded content may be partial, but is restored in full.
+		var src = filepath.Join(r.dir, filepath.FromSlash(file.Links[0]))
+		if rs := r.rebasing; rs != nil && rs.to == fnode {
+			src = filepath.Join(r.dir, filepath.FromSlash(firstLink(r.fsm.LiveNodes[rs.from])))
+		}
+		var dst = filepath.Join(staging, strconv.FormatInt(int64(fnode), 10))
+
+		if err := os.Link(src, dst); err != nil {
+			return nil, nil, extendErr(err, "linking checkpoint file %s", file.Links[0])
+		} else if info, err := os.Stat(dst); err != nil {
+			return nil, nil, extendErr(err, "stat of checkpoint file %s", file.Links[0])
+		} else {
+			staged[fnode] = info.Size()
+		}
+		cp.Files = append(cp.Files, file)
+	}
+	sort.Slice(cp.Files, func(i, j int) bool { return cp.Files[i].Fnode < cp.Files[j].Fnode })
+
+	return cp, staged, nil
+}
+
+// persistCheckpointFile persists the first |size| bytes of the file at |path|
+// to |store|, as a Fragment of |journal| which spans the persisted content.
+// An empty file is not persisted.
+func persistCheckpointFile(ctx context.Context, store pb.FragmentStore, journal pb.Journal, path string, size int64) (pb.Fragment, error) {
+	var f, err = os.Open(path)
+	if err != nil {
+		return pb.Fragment{}, err
+	}
+	defer f.Close()
+
+	return persistFile(ctx, store, journal, f, size)
+}
+
+// persistFile persists the first |size| bytes of |f| to |store|, as a
+// content-addressed Fragment of |journal| which spans the persisted content.
+// Empty content is not persisted.
+func persistFile(ctx context.Context, store pb.FragmentStore, journal pb.Journal, f *os.File, size int64) (pb.Fragment, error) {
+	var summer = sha1.New()
+
+	if n, err := io.Copy(summer, io.NewSectionReader(f, 0, size)); err != nil {
+		return pb.Fragment{}, err
+	} else if n != size {
+		return pb.Fragment{}, errors.Errorf("file is shorter than captured length (%d vs %d)", n, size)
+	}
+	var frag = pb.Fragment{
+		Journal:          journal,
+		Begin:            0,
+		End:              size,
+		CompressionCodec: pb.CompressionCodec_NONE,
+		BackingStore:     store,
+	}
+	if size == 0 {
+		return frag, nil
+	}
+	frag.Sum = pb.SHA1SumFromDigest(summer.Sum(nil))
+
+	// Persist is a no-op if the content-addressed Fragment already exists.
+	var err = fragment.Persist(ctx, fragment.Spool{Fragment: fragment.Fragment{Fragment: frag, File: f}})
+	return frag, err
+}
+
+// PruneCheckpoints removes persisted checkpoint files of |log| from |store|
+// which are not referenced by any of |hints|, and which are older than the
+// most recently persisted file that is. Newer files may belong to a
+// Checkpoint which is still being persisted, and are retained. It returns the
+// number of removed files.
+func PruneCheckpoints(ctx context.Context, store pb.FragmentStore, log pb.Journal, hints ...FSMHints) (int, error) {
+	var referenced = make(map[string]struct{})
+	for _, h := range hints {
+		if h.Log != log {
+			return 0, errors.Errorf("hints.Log doesn't match log (%s vs %s)", h.Log, log)
+		} else if h.Checkpoint == nil {
+			continue
+		}
+		for _, f := range h.Checkpoint.Files {
+			if f.Content.ContentLength() != 0 {
+				referenced[f.Content.ContentPath()] = struct{}{}
+			}
+		}
+	}
+	var removed, err = pruneUnreferenced(ctx, store, log.String()+checkpointJournalSuffix+"/", referenced)
+	if err != nil {
+		err = extendErr(err, "pruning checkpoint files")
+	}
+	return removed, err
+}
+
+// pruneUnreferenced removes Fragments of |store| under |prefix| which are not
+// |referenced| by ContentPath, and which are older than the most recently
+// persisted Fragment that is. Nothing is removed if no Fragment is referenced.
+func pruneUnreferenced(ctx context.Context, store pb.FragmentStore, prefix string, referenced map[string]struct{}) (int, error) {
+	if len(referenced) == 0 {
+		return 0, nil // Nothing to date unreferenced Fragments against.
+	}
+
+	var listed []pb.Fragment
+	var horizon time.Time
+
+	if err := fragment.List(ctx, store, prefix, func(f pb.Fragment) {
+		if _, ok := referenced[f.ContentPath()]; !ok {
+			listed = append(listed, f)
+		} else if f.ModTime.After(horizon) {
+			horizon = f.ModTime
+		}
+	}); err != nil {
+		return 0, extendErr(err, "listing %s", prefix)
+	}
+
+	var removed int
+	for _, f := range listed {
+		if !f.ModTime.Before(horizon) {
+			continue
+		} else if err := fragment.Remove(ctx, f); err != nil {
+			return removed, extendErr(err, "removing %s", f.ContentPath())
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// restoreCheckpoint restores each File of the Checkpoint from its fragment
+// store, into a staged and open Fnode of |files|.
+func restoreCheckpoint(ctx context.Context, cp *Checkpoint, dir string, files fnodeFileMap) error {
+	for _, f := range cp.Files {
+		if err := create(dir, f.Fnode, files); err != nil {
+			return extendErr(err, "creating %d", f.Fnode)
+		} else if f.Content.ContentLength() == 0 {
+			continue
+		}
+
+		var rc, err = fragment.Open(ctx, f.Content)
+		if err != nil {
+			return extendErr(err, "opening %s", f.Content.ContentPath())
+		}
+		var summer = sha1.New()
+		var n int64
+
+		n, err = io.Copy(io.MultiWriter(files[f.Fnode], summer), rc)
+		rc.Close()
+
+		if err != nil {
+			return extendErr(err, "restoring %s", f.Content.ContentPath())
+		} else if n != f.Content.ContentLength() {
+			return errors.Errorf("restored %s has unexpected length %d", f.Content.ContentPath(), n)
+		} else if sum := summer.Sum(nil); !bytes.Equal(sum, digestOf(f.Content.Sum)) {
+			return errors.Errorf("restored %s has unexpected SHA1 %x", f.Content.ContentPath(), sum)
+		}
+	}
+	return nil
+}
+
+// checkpointJournal returns the Journal name under which content of |fnode|
+// is persisted. It's a sibling of the |log| name (rather than a child),
+// ensuring checkpoint content isn't listed with Fragments of the |log| itself.
+// Persisted FSMHints use a sibling journal for the same reason.
+func checkpointJournal(log pb.Journal, fnode Fnode) pb.Journal {
+	return pb.Journal(fmt.Sprintf("%s%s/%d", log, checkpointJournalSuffix, fnode))
+}
+
+func removeStaging(staging string) {
+	if err := os.RemoveAll(staging); err != nil {
+		log.WithFields(log.Fields{"err": err, "dir": staging}).
+			Warn("failed to remove checkpoint staging directory")
+	}
+}
+
+func digestOf(sum pb.SHA1Sum) []byte {
+	var d = sum.ToDigest()
+	return d[:]
+}
+
+const (
+	// Suffix of the Recorder directory, into which checkpoint files are staged.
+	checkpointStagingSuffix = ".checkpoint"
+	// Suffix of the recovery log name under which checkpoint files are persisted.
+	checkpointJournalSuffix = ".checkpoints"
+)
@@ -0,0 +1,250 @@
+package recoverylog
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/LiveRamp/gazette/v2/pkg/fragment"
+	gc "github.com/go-check/check"
+	"github.com/spf13/afero"
+)
+
+type CheckpointSuite struct{}
+
+func (s *CheckpointSuite) TestCheckpointAndPlayback(c *gc.C) {
+	var bk, cleanup = newBrokerAndLog(c)
+	defer cleanup()
+
+	var ctx = context.Background()
+	var recDir, playDir, storeRoot = tempDir(c), tempDir(c), tempDir(c)
+	defer os.RemoveAll(recDir)
+	defer os.RemoveAll(playDir)
+	defer os.RemoveAll(storeRoot)
+
+	defer func(prior string) { fragment.FileSystemStoreRoot = prior }(fragment.FileSystemStoreRoot)
+	fragment.FileSystemStoreRoot = storeRoot
+
+	var fsm, err = NewFSM(FSMHints{Log: aRecoveryLog})
+	c.Assert(err, gc.IsNil)
+
+	var rec = NewRecorder(fsm, anAuthor, recDir, bk)
+	var fs = RecordedAferoFS{Recorder: rec, Fs: afero.NewOsFs()}
+
+	// Record files which are captured by the checkpoint.
+	foo, err := fs.Create(filepath.Join(recDir, "foo"))
+	c.Assert(err, gc.IsNil)
+	_, err = foo.Write([]byte("hello"))
+	c.Check(err, gc.IsNil)
+
+	c.Check(afero.WriteFile(fs, filepath.Join(recDir, "bar"), []byte("bing"), 0600), gc.IsNil)
+	c.Check(afero.WriteFile(fs, filepath.Join(recDir, "empty"), nil, 0600), gc.IsNil)
+
+	c.Check(rec.Checkpoint(ctx, "file:///"), gc.IsNil)
+
+	// Expect checkpoint files were persisted under the checkpoint journal, and
+	// that the empty file was not.
+	persisted, err := filepath.Glob(filepath.Join(storeRoot, string(aRecoveryLog)+".checkpoints", "*", "*"))
+	c.Check(err, gc.IsNil)
+	c.Check(persisted, gc.HasLen, 2)
+
+	// Record further operations which follow the checkpoint.
+	_, err = foo.Write([]byte(" world"))
+	c.Check(err, gc.IsNil)
+	c.Check(fs.Remove(filepath.Join(recDir, "bar")), gc.IsNil)
+	c.Check(afero.WriteFile(fs, filepath.Join(recDir, "baz"), []byte("new"), 0600), gc.IsNil)
+	<-rec.WeakBarrier().Done() // Flush all recorded ops.
+
+	// Expect hints reference the checkpoint, and only Segments of operations
+	// which follow it.
+	var hints = rec.BuildHints()
+	c.Assert(hints.Checkpoint, gc.NotNil)
+	c.Check(hints.Checkpoint.Files, gc.HasLen, 2) // "foo" and "empty".
+
+	for _, n := range hints.LiveNodes {
+		c.Check(n.Segments[0].FirstSeqNo >= hints.Checkpoint.NextSeqNo, gc.Equals, true)
+	}
+
+	// Expect a Player restores the checkpoint, and plays the log which follows.
+	var player = NewPlayer()
+	go func() { c.Check(player.Play(ctx, hints, playDir, bk), gc.IsNil) }()
+
+	player.FinishAtWriteHead()
+	<-player.Done()
+
+	expectFileContent(c, filepath.Join(playDir, "foo"), "hello world")
+	expectFileContent(c, filepath.Join(playDir, "empty"), "")
+	expectFileContent(c, filepath.Join(playDir, "baz"), "new")
+
+	_, err = os.Stat(filepath.Join(playDir, "bar"))
+	c.Check(os.IsNotExist(err), gc.Equals, true)
+
+	// Expect the Player FSM reflects the checkpoint.
+	c.Check(player.FSM.BuildHints().Checkpoint, gc.DeepEquals, hints.Checkpoint)
+}
+
+func (s *CheckpointSuite) TestIncrementalCheckpointOfCapturedLengths(c *gc.C) {
+	var bk, cleanup = newBrokerAndLog(c)
+	defer cleanup()
+
+	var ctx = context.Background()
+	var recDir, playDir, storeRoot = tempDir(c), tempDir(c), tempDir(c)
+	defer os.RemoveAll(recDir)
+	defer os.RemoveAll(playDir)
+	defer os.RemoveAll(storeRoot)
+
+	defer func(prior string) { fragment.FileSystemStoreRoot = prior }(fragment.FileSystemStoreRoot)
+	fragment.FileSystemStoreRoot = storeRoot
+
+	var fsm, err = NewFSM(FSMHints{Log: aRecoveryLog})
+	c.Assert(err, gc.IsNil)
+
+	var rec = NewRecorder(fsm, anAuthor, recDir, bk)
+	var fs = RecordedAferoFS{Recorder: rec, Fs: afero.NewOsFs()}
+
+	foo, err := fs.Create(filepath.Join(recDir, "foo"))
+	c.Assert(err, gc.IsNil)
+	_, err = foo.Write([]byte("hello"))
+	c.Check(err, gc.IsNil)
+	c.Check(afero.WriteFile(fs, filepath.Join(recDir, "bar"), []byte("bing"), 0600), gc.IsNil)
+
+	c.Check(rec.Checkpoint(ctx, "file:///"), gc.IsNil)
+	var first = rec.BuildHints()
+
+	// Extend "foo", and capture a checkpoint. Expect only "foo" is staged.
+	_, err = foo.Write([]byte(" world"))
+	c.Check(err, gc.IsNil)
+
+	captured, err := rec.CaptureCheckpoint("file:///")
+	c.Assert(err, gc.IsNil)
+	c.Check(captured.staged, gc.HasLen, 1)
+
+	// Writes which follow the capture are not persisted with the checkpoint.
+	_, err = foo.Write([]byte("!"))
+	c.Check(err, gc.IsNil)
+	c.Check(captured.Persist(ctx), gc.IsNil)
+	<-rec.WeakBarrier().Done()
+
+	var hints = rec.BuildHints()
+	c.Assert(hints.Checkpoint.Files, gc.HasLen, 2)
+	c.Check(hints.Checkpoint.Files[0].Content.ContentLength(), gc.Equals, int64(len("hello world")))
+	c.Check(hints.Checkpoint.Files[1].Content, gc.DeepEquals, first.Checkpoint.Files[1].Content)
+
+	// Expect the Player restores the checkpoint, and replays the trailing write.
+	var player = NewPlayer()
+	go func() { c.Check(player.Play(ctx, hints, playDir, bk), gc.IsNil) }()
+
+	player.FinishAtWriteHead()
+	<-player.Done()
+
+	expectFileContent(c, filepath.Join(playDir, "foo"), "hello world!")
+	expectFileContent(c, filepath.Join(playDir, "bar"), "bing")
+}
+
+func (s *CheckpointSuite) TestPruneCheckpoints(c *gc.C) {
+	var bk, cleanup = newBrokerAndLog(c)
+	defer cleanup()
+
+	var ctx = context.Background()
+	var recDir, storeRoot = tempDir(c), tempDir(c)
+	defer os.RemoveAll(recDir)
+	defer os.RemoveAll(storeRoot)
+
+	defer func(prior string) { fragment.FileSystemStoreRoot = prior }(fragment.FileSystemStoreRoot)
+	fragment.FileSystemStoreRoot = storeRoot
+
+	var fsm, err = NewFSM(FSMHints{Log: aRecoveryLog})
+	c.Assert(err, gc.IsNil)
+
+	var rec = NewRecorder(fsm, anAuthor, recDir, bk)
+	var fs = RecordedAferoFS{Recorder: rec, Fs: afero.NewOsFs()}
+
+	// Without a referenced checkpoint, nothing is pruned.
+	removed, err := PruneCheckpoints(ctx, "file:///", aRecoveryLog, rec.BuildHints())
+	c.Check(err, gc.IsNil)
+	c.Check(removed, gc.Equals, 0)
+
+	foo, err := fs.Create(filepath.Join(recDir, "foo"))
+	c.Assert(err, gc.IsNil)
+	_, err = foo.Write([]byte("hello"))
+	c.Check(err, gc.IsNil)
+	c.Check(afero.WriteFile(fs, filepath.Join(recDir, "bar"), []byte("bing"), 0600), gc.IsNil)
+
+	c.Check(rec.Checkpoint(ctx, "file:///"), gc.IsNil)
+	var first = rec.BuildHints()
+
+	// Age the first content of "foo", which is then superseded.
+	var fooPath = filepath.Join(storeRoot, first.Checkpoint.Files[0].Content.ContentPath())
+	var aged = time.Now().Add(-time.Hour)
+	c.Assert(os.Chtimes(fooPath, aged, aged), gc.IsNil)
+
+	_, err = foo.Write([]byte(" world"))
+	c.Check(err, gc.IsNil)
+	c.Check(rec.Checkpoint(ctx, "file:///"), gc.IsNil)
+
+	persisted, err := filepath.Glob(filepath.Join(storeRoot, string(aRecoveryLog)+".checkpoints", "*", "*"))
+	c.Check(err, gc.IsNil)
+	c.Check(persisted, gc.HasLen, 3)
+
+	// Files referenced by any of the hints are retained, as are unreferenced
+	// files which are newer than referenced ones.
+	removed, err = PruneCheckpoints(ctx, "file:///", aRecoveryLog, first)
+	c.Check(err, gc.IsNil)
+	c.Check(removed, gc.Equals, 0)
+
+	removed, err = PruneCheckpoints(ctx, "file:///", aRecoveryLog, rec.BuildHints())
+	c.Check(err, gc.IsNil)
+	c.Check(removed, gc.Equals, 1)
+
+	_, err = os.Stat(fooPath)
+	c.Check(os.IsNotExist(err), gc.Equals, true)
+
+	// Hints of another log are rejected.
+	_, err = PruneCheckpoints(ctx, "file:///", "other/log", first)
+	c.Check(err, gc.ErrorMatches, `hints.Log doesn't match log .*`)
+}
+
+func (s *CheckpointSuite) TestRestoreVerifiesContent(c *gc.C) {
+	var ctx = context.Background()
+	var srcDir, playDir, storeRoot = tempDir(c), tempDir(c), tempDir(c)
+	defer os.RemoveAll(srcDir)
+	defer os.RemoveAll(playDir)
+	defer os.RemoveAll(storeRoot)
+
+	defer func(prior string) { fragment.FileSystemStoreRoot = prior }(fragment.FileSystemStoreRoot)
+	fragment.FileSystemStoreRoot = storeRoot
+
+	var path = filepath.Join(srcDir, "file")
+	c.Assert(ioutil.WriteFile(path, []byte("content"), 0600), gc.IsNil)
+
+	var frag, err = persistCheckpointFile(ctx, "file:///", checkpointJournal(aRecoveryLog, 42), path, 7)
+	c.Assert(err, gc.IsNil)
+
+	var cp = &Checkpoint{Files: []Checkpoint_File{{Fnode: 42, Links: []string{"/file"}, Content: frag}}}
+
+	var files = make(fnodeFileMap)
+	c.Check(preparePlayback(playDir), gc.IsNil)
+	c.Check(restoreCheckpoint(ctx, cp, playDir, files), gc.IsNil)
+	c.Check(files[42].Close(), gc.IsNil)
+
+	// Corrupt the persisted content. Expect restoration fails.
+	persisted, err := filepath.Glob(filepath.Join(storeRoot, frag.Journal.String(), "*"))
+	c.Assert(err, gc.IsNil)
+	c.Assert(persisted, gc.HasLen, 1)
+	c.Assert(ioutil.WriteFile(persisted[0], []byte("CONTENT"), 0600), gc.IsNil)
+
+	files = make(fnodeFileMap)
+	c.Check(preparePlayback(playDir), gc.IsNil)
+	c.Check(restoreCheckpoint(ctx, cp, playDir, files), gc.ErrorMatches,
+		`restored .* has unexpected SHA1 .*`)
+}
+
+func tempDir(c *gc.C) string {
+	var dir, err = ioutil.TempDir("", "checkpoint-suite")
+	c.Assert(err, gc.IsNil)
+	return dir
+}
+
+var _ = gc.Suite(&CheckpointSuite{})
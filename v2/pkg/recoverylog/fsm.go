@@ -67,7 +67,12 @@ type FSM struct {
 	LiveNodes map[Fnode]*fnodeState
 	// Indexes current target paths of LiveNodes.
 	Links map[string]Fnode
+	// Most recent Checkpoint, or nil if there is none. Segments of LiveNodes
+	// which were also checkpointed reflect only operations which follow it.
+	Checkpoint *Checkpoint
 
+	// Ordered Segments of all applied operations. See LocalState.History.
+	history []Segment
 	// Ordered, non-overlapping segments of log to process.
 	hintedSegments []Segment
 	// Ordered Fnodes which are still live at |hintedSegments| completion.
@@ -75,18 +80,40 @@ type FSM struct {
 }
 
 // LiveLogSegments flattens hinted LiveNodes into an ordered list of Fnodes,
-// and the set of recovery log Segments which fully contain them.
+// and the set of recovery log Segments which fully contain them. Fnodes of
+// the Checkpoint are not returned, as they're not created by hinted Segments.
 func (m FSMHints) LiveLogSegments() ([]Fnode, SegmentSet, error) {
 	var fnodes []Fnode
 	var set SegmentSet
 
+	var checkpointed = make(map[Fnode]struct{})
+	if m.Checkpoint != nil {
+		for i, f := range m.Checkpoint.Files {
+			if i != 0 && m.Checkpoint.Files[i-1].Fnode >= f.Fnode {
+				return nil, nil, fmt.Errorf("expected monotonic Checkpoint Fnode ordering: %v vs %v",
+					m.Checkpoint.Files[i-1].Fnode, f.Fnode)
+			} else if len(f.Links) == 0 {
+				return nil, nil, fmt.Errorf("expected Checkpoint File to have links: %v", f.Fnode)
+			}
+			checkpointed[f.Fnode] = struct{}{}
+		}
+	}
+
 	for i, n := range m.LiveNodes {
-		if len(n.Segments) == 0 || Fnode(n.Segments[0].FirstSeqNo) != n.Fnode {
+		var _, isCheckpointed = checkpointed[n.Fnode]
+
+		if isCheckpointed && (len(n.Segments) == 0 || n.Segments[0].FirstSeqNo < m.Checkpoint.NextSeqNo) {
+			return nil, nil, fmt.Errorf("expected Segments of checkpointed Fnode to follow the Checkpoint: %v", n)
+		} else if !isCheckpointed && (len(n.Segments) == 0 || Fnode(n.Segments[0].FirstSeqNo) != n.Fnode) {
 			return nil, nil, fmt.Errorf("expected Fnode to match Segment FirstSeqNo: %v", n)
-		} else if i != 0 && fnodes[i-1] >= n.Fnode {
-			return nil, nil, fmt.Errorf("expected monotonic Fnode ordering: %v vs %v", fnodes[i-1], n.Fnode)
+		} else if i != 0 && m.LiveNodes[i-1].Fnode >= n.Fnode {
+			return nil, nil, fmt.Errorf("expected monotonic Fnode ordering: %v vs %v", m.LiveNodes[i-1].Fnode, n.Fnode)
+		} else if !isCheckpointed && m.Checkpoint != nil && int64(n.Fnode) < m.Checkpoint.NextSeqNo {
+			return nil, nil, fmt.Errorf("expected Fnode to be checkpointed: %v", n.Fnode)
+		}
+		if !isCheckpointed {
+			fnodes = append(fnodes, n.Fnode)
 		}
-		fnodes = append(fnodes, n.Fnode)
 
 		for _, s := range n.Segments {
 			if err := set.Add(s); err != nil {
@@ -118,6 +145,25 @@ func NewFSM(hints FSMHints) (*FSM, error) {
 		hintedFnodes: fnodes,
 	}
 
+	// Checkpoint Files are live at the onset of playback.
+	if cp := hints.Checkpoint; cp != nil {
+		fsm.NextSeqNo, fsm.NextChecksum = cp.NextSeqNo, cp.NextChecksum
+		fsm.Checkpoint = cp
+
+		for _, f := range cp.Files {
+			var node = &fnodeState{Links: make(map[string]struct{}, len(f.Links))}
+
+			for _, link := range f.Links {
+				if _, ok := fsm.Links[link]; ok {
+					return nil, fmt.Errorf("duplicate Checkpoint link: %v", link)
+				}
+				node.Links[link] = struct{}{}
+				fsm.Links[link] = f.Fnode
+			}
+			fsm.LiveNodes[f.Fnode] = node
+		}
+	}
+
 	if len(set) != 0 {
 		fsm.NextSeqNo, fsm.NextChecksum = set[0].FirstSeqNo, set[0].FirstChecksum
 		fsm.hintedSegments = []Segment(set)
@@ -175,6 +221,20 @@ func (m *FSM) Apply(op *RecordedOp, frame []byte) error {
 		return err
 	}
 
+	// Extend the FSM history with the operation.
+	if l := len(m.history) - 1; l >= 0 && m.history[l].Author == op.Author && m.history[l].LastSeqNo+1 == op.SeqNo {
+		m.history[l].LastSeqNo = op.SeqNo
+		m.history[l].LastOffset = op.LastOffset
+	} else {
+		m.history = append(m.history, Segment{
+			Author:        op.Author,
+			FirstSeqNo:    op.SeqNo,
+			FirstOffset:   op.FirstOffset,
+			FirstChecksum: op.Checksum,
+			LastSeqNo:     op.SeqNo,
+			LastOffset:    op.LastOffset,
+		})
+	}
 	// Step the FSM to the next state.
 	m.NextSeqNo += 1
 	m.NextChecksum = crc32.Update(m.NextChecksum, crcTable, frame)
@@ -282,8 +342,12 @@ func (m *FSM) applyProperty(op *Property) error {
 func (m *FSM) BuildHints() FSMHints {
 	var hints = FSMHints{Log: m.Log}
 
-	// Flatten LiveNodes into deep-copied FnodeSegments.
+	// Flatten LiveNodes into deep-copied FnodeSegments. A checkpointed
+	// Fnode has no Segments if it's unchanged since the Checkpoint.
 	for fnode, state := range m.LiveNodes {
+		if len(state.Segments) == 0 {
+			continue
+		}
 		hints.LiveNodes = append(hints.LiveNodes, FnodeSegments{
 			Fnode:    fnode,
 			Segments: append([]Segment(nil), state.Segments...),
@@ -297,9 +361,51 @@ func (m *FSM) BuildHints() FSMHints {
 	for path, content := range m.Properties {
 		hints.Properties = append(hints.Properties, Property{Path: path, Content: content})
 	}
+	// Filter Checkpoint Files to those which remain live.
+	if m.Checkpoint != nil {
+		var cp = *m.Checkpoint
+		cp.Files = nil
+
+		for _, f := range m.Checkpoint.Files {
+			if _, ok := m.LiveNodes[f.Fnode]; ok {
+				cp.Files = append(cp.Files, f)
+			}
+		}
+		hints.Checkpoint = &cp
+	}
 	return hints
 }
 
+// applyCheckpoint sets the Checkpoint of the FSM, which was captured when
+// Checkpoint.NextSeqNo was the next FSM operation. Segments of checkpointed
+// LiveNodes are trimmed to begin at or after the Checkpoint. A Segment which
+// spans the Checkpoint is trimmed to begin at Checkpoint.NextSeqNo (which must
+// also be of the Segment's Author, as a Segment spans operations of one Author).
+func (m *FSM) applyCheckpoint(cp *Checkpoint) {
+	for _, f := range cp.Files {
+		var node, ok = m.LiveNodes[f.Fnode]
+		if !ok {
+			continue
+		}
+		var segments []Segment
+
+		for _, s := range node.Segments {
+			if s.LastSeqNo < cp.NextSeqNo {
+				continue // Reflected by the Checkpoint.
+			} else if s.FirstSeqNo < cp.NextSeqNo {
+				s.FirstSeqNo, s.FirstChecksum = cp.NextSeqNo, cp.NextChecksum
+
+				if s.FirstOffset < cp.LogOffset {
+					s.FirstOffset = cp.LogOffset
+				}
+			}
+			segments = append(segments, s)
+		}
+		node.Segments = segments
+	}
+	m.Checkpoint = cp
+}
+
 func (m *FSM) hasRemainingHints() bool {
 	return len(m.hintedSegments) != 0 || len(m.hintedFnodes) != 0
 }
@@ -471,6 +471,118 @@ func (s *FSMSuite) TestUseOfHintedAuthors(c *gc.C) {
 	})
 }
 
+func (s *FSMSuite) TestCheckpointTrimsSegmentsAndFiltersHints(c *gc.C) {
+	s.fsm = s.newFSM(c, FSMHints{Log: aRecoveryLog})
+
+	c.Check(s.create(1, s.fsm.NextChecksum, 100, "/path/A"), gc.IsNil)
+	c.Check(s.write(2, s.fsm.NextChecksum, 100, 1), gc.IsNil)
+	c.Check(s.create(3, s.fsm.NextChecksum, 100, "/path/B"), gc.IsNil)
+	c.Check(s.write(4, s.fsm.NextChecksum, 100, 3), gc.IsNil)
+
+	// Checkpoint while operation 4 is only partially reflected by the
+	// checkpointed content of Fnode 3.
+	var cp = &Checkpoint{
+		NextSeqNo:    4,
+		NextChecksum: 0xfeedbeef,
+		LogOffset:    4,
+		Files: []Checkpoint_File{
+			{Fnode: 1, Links: []string{"/path/A"}},
+			{Fnode: 3, Links: []string{"/path/B"}},
+		},
+	}
+	s.fsm.applyCheckpoint(cp)
+
+	// Fnode 1 is fully reflected by the Checkpoint. Fnode 3's Segment is
+	// trimmed to begin at the Checkpoint.
+	c.Check(s.fsm.LiveNodes[1].Segments, gc.HasLen, 0)
+	c.Check(s.fsm.LiveNodes[3].Segments, gc.DeepEquals, []Segment{
+		{Author: 100, FirstSeqNo: 4, FirstOffset: 4, FirstChecksum: 0xfeedbeef, LastSeqNo: 4, LastOffset: 5},
+	})
+
+	// Further operations create and remove Fnodes.
+	c.Check(s.create(5, s.fsm.NextChecksum, 100, "/path/C"), gc.IsNil)
+	c.Check(s.unlink(6, s.fsm.NextChecksum, 100, 3, "/path/B"), gc.IsNil)
+	c.Check(s.write(7, s.fsm.NextChecksum, 100, 1), gc.IsNil)
+
+	// Expect hints reference only operations which follow the Checkpoint,
+	// and only Checkpoint Files which remain live.
+	var hints = s.fsm.BuildHints()
+	c.Check(hints.LiveNodes, gc.DeepEquals, []FnodeSegments{
+		{Fnode: 1, Segments: []Segment{
+			{Author: 100, FirstSeqNo: 7, FirstOffset: 7, FirstChecksum: hints.LiveNodes[0].Segments[0].FirstChecksum,
+				LastSeqNo: 7, LastOffset: 8}}},
+		{Fnode: 5, Segments: []Segment{
+			{Author: 100, FirstSeqNo: 5, FirstOffset: 5, FirstChecksum: hints.LiveNodes[1].Segments[0].FirstChecksum,
+				LastSeqNo: 5, LastOffset: 6}}},
+	})
+	c.Check(hints.Checkpoint, gc.DeepEquals, &Checkpoint{
+		NextSeqNo:    4,
+		NextChecksum: 0xfeedbeef,
+		LogOffset:    4,
+		Files:        []Checkpoint_File{{Fnode: 1, Links: []string{"/path/A"}}},
+	})
+
+	// Expect a new FSM begins from the first hinted Segment, with Checkpoint
+	// Files already live.
+	var fnodes, _, err = hints.LiveLogSegments()
+	c.Check(err, gc.IsNil)
+	c.Check(fnodes, gc.DeepEquals, []Fnode{5})
+
+	var fsm = s.newFSM(c, hints)
+	c.Check(fsm.NextSeqNo, gc.Equals, int64(5))
+	c.Check(fsm.Links, gc.DeepEquals, map[string]Fnode{"/path/A": 1})
+	c.Check(fsm.LiveNodes, gc.DeepEquals, map[Fnode]*fnodeState{
+		1: {Links: map[string]struct{}{"/path/A": {}}},
+	})
+}
+
+func (s *FSMSuite) TestCheckpointedLiveLogSegmentsValidation(c *gc.C) {
+	var hints = FSMHints{
+		Log: aRecoveryLog,
+		LiveNodes: []FnodeSegments{
+			{Fnode: 2, Segments: []Segment{
+				{Author: 0x1, FirstSeqNo: 12, LastSeqNo: 12, FirstOffset: 1200, LastOffset: 1201, FirstChecksum: 0x12},
+			}},
+			{Fnode: 14, Segments: []Segment{
+				{Author: 0x1, FirstSeqNo: 14, LastSeqNo: 14, FirstOffset: 1400, LastOffset: 1401, FirstChecksum: 0x14},
+			}},
+		},
+		Checkpoint: &Checkpoint{
+			NextSeqNo: 10,
+			Files: []Checkpoint_File{
+				{Fnode: 2, Links: []string{"/path/A"}},
+				{Fnode: 5, Links: []string{"/path/B"}},
+			},
+		},
+	}
+
+	var fnodes, set, err = hints.LiveLogSegments()
+	c.Check(err, gc.IsNil)
+	c.Check(fnodes, gc.DeepEquals, []Fnode{14})
+	c.Check(set, gc.DeepEquals, SegmentSet{
+		{Author: 0x1, FirstSeqNo: 12, LastSeqNo: 12, FirstOffset: 1200, LastOffset: 1201, FirstChecksum: 0x12},
+		{Author: 0x1, FirstSeqNo: 14, LastSeqNo: 14, FirstOffset: 1400, LastOffset: 1401, FirstChecksum: 0x14},
+	})
+
+	// Expect it complains if a non-checkpointed Fnode precedes the Checkpoint.
+	hints.Checkpoint.NextSeqNo = 15
+	_, _, err = hints.LiveLogSegments()
+	c.Check(err, gc.ErrorMatches, "expected Segments of checkpointed Fnode to follow the Checkpoint: .*")
+
+	hints.LiveNodes = hints.LiveNodes[1:]
+	_, _, err = hints.LiveLogSegments()
+	c.Check(err, gc.ErrorMatches, "expected Fnode to be checkpointed: 14")
+
+	// Or if Checkpoint Files are mis-ordered, or without links.
+	hints.Checkpoint.Files[0].Fnode = 7
+	_, _, err = hints.LiveLogSegments()
+	c.Check(err, gc.ErrorMatches, "expected monotonic Checkpoint Fnode ordering: .*")
+
+	hints.Checkpoint.Files[0].Fnode, hints.Checkpoint.Files[0].Links = 2, nil
+	_, _, err = hints.LiveLogSegments()
+	c.Check(err, gc.ErrorMatches, "expected Checkpoint File to have links: 2")
+}
+
 func (s *FSMSuite) apply(op RecordedOp) error {
 	// Create a unique "frame" from |offset| for FSM to digest over, in production of checksums.
 	s.offset += 1
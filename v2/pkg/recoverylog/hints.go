@@ -0,0 +1,99 @@
+package recoverylog
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"io/ioutil"
+	"os"
+
+	"github.com/LiveRamp/gazette/v2/pkg/fragment"
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	"github.com/pkg/errors"
+)
+
+// PersistHints persists the encoding of |hints| to the fragment |store|, and
+// returns the content-addressed Fragment under which it was persisted. The
+// Fragment may then be stored in place of |hints| (eg, in Etcd), and passed
+// to FetchPersistedHints to retrieve them. This is useful for hints of large
+// recorded file-systems, which may be too large to store directly.
+//
+// Hints are persisted under a sibling journal of the hinted log, as are
+// checkpoint files (see checkpointJournal).
+func PersistHints(ctx context.Context, store pb.FragmentStore, hints FSMHints) (pb.Fragment, error) {
+	if err := store.Validate(); err != nil {
+		return pb.Fragment{}, extendErr(err, "FragmentStore")
+	}
+	var b, err = hints.Marshal()
+	if err != nil {
+		return pb.Fragment{}, extendErr(err, "marshal FSMHints")
+	}
+
+	// Spools are backed by a File, so stage |b| to a temporary one.
+	f, err := ioutil.TempFile("", "persisted-hints")
+	if err != nil {
+		return pb.Fragment{}, err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	if _, err = f.Write(b); err != nil {
+		return pb.Fragment{}, extendErr(err, "staging FSMHints")
+	}
+	frag, err := persistFile(ctx, store, hints.Log+hintsJournalSuffix, f, int64(len(b)))
+	if err != nil {
+		return pb.Fragment{}, extendErr(err, "persisting FSMHints")
+	}
+	return frag, nil
+}
+
+// PruneHints removes FSMHints of |log| persisted to |store| by PersistHints,
+// which are not |referenced| (eg, by a stored pointer), and which are older
+// than the most recently persisted FSMHints which are. Newer FSMHints may not
+// yet have been stored, and are retained. It returns the number of removed
+// FSMHints.
+func PruneHints(ctx context.Context, store pb.FragmentStore, log pb.Journal, referenced ...pb.Fragment) (int, error) {
+	var paths = make(map[string]struct{})
+	for _, frag := range referenced {
+		if frag.Journal != log+hintsJournalSuffix {
+			return 0, errors.Errorf("referenced Fragment journal doesn't match log (%s vs %s)",
+				frag.Journal, log)
+		}
+		paths[frag.ContentPath()] = struct{}{}
+	}
+	var removed, err = pruneUnreferenced(ctx, store, log.String()+hintsJournalSuffix+"/", paths)
+	if err != nil {
+		err = extendErr(err, "pruning persisted FSMHints")
+	}
+	return removed, err
+}
+
+// FetchPersistedHints fetches FSMHints previously persisted as Fragment |frag|
+// by PersistHints. Fetched content is verified against the Fragment's SHA1 sum.
+func FetchPersistedHints(ctx context.Context, frag pb.Fragment) (FSMHints, error) {
+	var hints FSMHints
+
+	var rc, err = fragment.Open(ctx, frag)
+	if err != nil {
+		return hints, extendErr(err, "opening %s", frag.ContentPath())
+	}
+	defer rc.Close()
+
+	b, err := ioutil.ReadAll(rc)
+	if err != nil {
+		return hints, extendErr(err, "reading %s", frag.ContentPath())
+	} else if sum := sha1.Sum(b); int64(len(b)) != frag.ContentLength() ||
+		!bytes.Equal(sum[:], digestOf(frag.Sum)) {
+		return hints, errors.Errorf("persisted %s has unexpected content (length %d, SHA1 %x)",
+			frag.ContentPath(), len(b), sum)
+	} else if err = hints.Unmarshal(b); err != nil {
+		return hints, extendErr(err, "unmarshal FSMHints")
+	} else if frag.Journal != hints.Log+hintsJournalSuffix {
+		return hints, errors.Errorf("persisted hints log %s doesn't match Fragment journal %s",
+			hints.Log, frag.Journal)
+	}
+	return hints, nil
+}
+
+// Suffix of the recovery log name under which FSMHints are persisted.
+const hintsJournalSuffix = ".hints"
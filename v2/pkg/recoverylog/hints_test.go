@@ -0,0 +1,107 @@
+package recoverylog
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/LiveRamp/gazette/v2/pkg/fragment"
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	gc "github.com/go-check/check"
+)
+
+type HintsSuite struct{}
+
+func (s *HintsSuite) TestPersistAndFetchRoundTrip(c *gc.C) {
+	var ctx = context.Background()
+	var storeRoot = tempDir(c)
+	defer os.RemoveAll(storeRoot)
+
+	defer func(prior string) { fragment.FileSystemStoreRoot = prior }(fragment.FileSystemStoreRoot)
+	fragment.FileSystemStoreRoot = storeRoot
+
+	var hints = hintsFixture()
+
+	var frag, err = PersistHints(ctx, "file:///", hints)
+	c.Check(err, gc.IsNil)
+	c.Check(frag.Journal, gc.Equals, aRecoveryLog+".hints")
+	c.Check(frag.Begin, gc.Equals, int64(0))
+	c.Check(frag.End, gc.Equals, int64(hints.ProtoSize()))
+
+	// Expect hints were persisted under their content-addressed path.
+	persisted, err := filepath.Glob(filepath.Join(storeRoot, string(aRecoveryLog)+".hints", "*"))
+	c.Check(err, gc.IsNil)
+	c.Check(persisted, gc.DeepEquals, []string{filepath.Join(storeRoot, frag.ContentPath())})
+
+	// Expect persisting again is a no-op which returns the same Fragment.
+	again, err := PersistHints(ctx, "file:///", hints)
+	c.Check(err, gc.IsNil)
+	c.Check(again, gc.DeepEquals, frag)
+
+	fetched, err := FetchPersistedHints(ctx, frag)
+	c.Check(err, gc.IsNil)
+	c.Check(fetched, gc.DeepEquals, hints)
+
+	// Expect corrupted content is detected.
+	c.Check(ioutil.WriteFile(persisted[0], []byte("garbage"), 0600), gc.IsNil)
+	_, err = FetchPersistedHints(ctx, frag)
+	c.Check(err, gc.ErrorMatches, `persisted .* has unexpected content \(length 7, SHA1 [0-9a-f]+\)`)
+
+	// Expect a missing Fragment is an error.
+	c.Check(os.Remove(persisted[0]), gc.IsNil)
+	_, err = FetchPersistedHints(ctx, frag)
+	c.Check(err, gc.ErrorMatches, `opening .*: .* no such file or directory`)
+}
+
+func (s *HintsSuite) TestPruneHints(c *gc.C) {
+	var ctx = context.Background()
+	var storeRoot = tempDir(c)
+	defer os.RemoveAll(storeRoot)
+
+	defer func(prior string) { fragment.FileSystemStoreRoot = prior }(fragment.FileSystemStoreRoot)
+	fragment.FileSystemStoreRoot = storeRoot
+
+	var persist = func(seqNo int64, age time.Duration) pb.Fragment {
+		var hints = hintsFixture()
+		hints.LiveNodes[0].Segments[0].LastSeqNo = seqNo
+
+		var frag, err = PersistHints(ctx, "file:///", hints)
+		c.Assert(err, gc.IsNil)
+
+		var ts = time.Now().Add(-age)
+		c.Assert(os.Chtimes(filepath.Join(storeRoot, frag.ContentPath()), ts, ts), gc.IsNil)
+		return frag
+	}
+	var stale, older, newer, pending = persist(100, 3*time.Hour), persist(101, 2*time.Hour),
+		persist(102, time.Hour), persist(103, 0)
+
+	// Without referenced hints, nothing is pruned.
+	removed, err := PruneHints(ctx, "file:///", aRecoveryLog)
+	c.Check(err, gc.IsNil)
+	c.Check(removed, gc.Equals, 0)
+
+	// Expect unreferenced hints older than the newest referenced hints are
+	// removed, and others are retained.
+	removed, err = PruneHints(ctx, "file:///", aRecoveryLog, older, newer)
+	c.Check(err, gc.IsNil)
+	c.Check(removed, gc.Equals, 1)
+
+	for _, frag := range []pb.Fragment{stale, older, newer, pending} {
+		var _, err = os.Stat(filepath.Join(storeRoot, frag.ContentPath()))
+		c.Check(os.IsNotExist(err), gc.Equals, frag == stale)
+	}
+
+	// Fragments of another log are rejected.
+	newer.Journal = "other/log.hints"
+	_, err = PruneHints(ctx, "file:///", aRecoveryLog, newer)
+	c.Check(err, gc.ErrorMatches, `referenced Fragment journal doesn't match log .*`)
+}
+
+func (s *HintsSuite) TestPersistErrorCases(c *gc.C) {
+	var _, err = PersistHints(context.Background(), "invalid", hintsFixture())
+	c.Check(err, gc.ErrorMatches, `FragmentStore: .*`)
+}
+
+var _ = gc.Suite(&HintsSuite{})
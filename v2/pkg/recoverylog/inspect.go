@@ -0,0 +1,146 @@
+package recoverylog
+
+import (
+	"bufio"
+	"context"
+	"io/ioutil"
+	"sort"
+
+	"github.com/LiveRamp/gazette/v2/pkg/client"
+	"github.com/LiveRamp/gazette/v2/pkg/message"
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// ReadOperations reads recovery log |name| from |offset| through |endOffset|
+// (or through the current write head, if |endOffset| is zero), and invokes
+// |fn| with each decoded RecordedOp and its frame. FirstOffset and LastOffset
+// of each RecordedOp are populated, and payloads of Write operations are
+// skipped. Frames which cannot be decoded (eg, due to a de-synchronization)
+// are logged and skipped. An error returned by |fn| aborts the read, and is
+// returned by ReadOperations.
+//
+// ReadOperations does not apply RecordedOps to an FSM, and invokes |fn| with
+// every operation of the log, including those of dead branches of its history.
+func ReadOperations(ctx context.Context, name pb.Journal, offset, endOffset int64,
+	rjc pb.RoutedJournalClient, fn func(op RecordedOp, frame []byte) error) error {
+
+	var rr = client.NewRetryReader(ctx, rjc, pb.ReadRequest{
+		Journal: name,
+		Offset:  offset,
+	})
+	defer rr.Cancel()
+
+	var br = bufio.NewReaderSize(rr, 32*1024)
+
+	for {
+		if offset = rr.AdjustedOffset(br); endOffset != 0 && offset >= endOffset {
+			return nil
+		}
+		var op, frame, err = decodeOperation(br, offset)
+
+		if err == nil && op.Write != nil {
+			if err = copyFixed(ioutil.Discard, br, op.Write.Length); err != nil {
+				err = extendErr(err, "copyFixed(%d)", op.Write.Length)
+			}
+		}
+
+		switch errors.Cause(err) {
+		case nil:
+			if err = fn(op, frame); err != nil {
+				return err
+			}
+		case message.ErrDesyncDetected:
+			log.WithFields(log.Fields{"offset": offset, "log": name}).
+				Warn("detected de-synchronization")
+		case client.ErrOffsetJump:
+			log.WithFields(log.Fields{"offset": offset, "log": name, "next": rr.Offset()}).
+				Warn("recovery log offset jump")
+		case client.ErrOffsetNotYetAvailable:
+			return nil // Reached the write head.
+		default:
+			return extendErr(err, "reading operation at offset %d", offset)
+		}
+	}
+}
+
+// LiveFile is a live file of a recorded file-system.
+type LiveFile struct {
+	// Fnode of the file.
+	Fnode Fnode
+	// Ordered paths linked to the Fnode.
+	Links []string
+	// Size of the file's content, in bytes.
+	Size int64
+	// Hinted Segments of the Fnode.
+	Segments []Segment
+}
+
+// LiveFiles plays the RecordedOps of |hints| (without reenacting them) to
+// determine the links and content size of each file live as-of the |hints|.
+// Returned LiveFiles are ordered on Fnode.
+func LiveFiles(ctx context.Context, hints FSMHints, rjc pb.RoutedJournalClient) ([]LiveFile, error) {
+	var fsm, err = NewFSM(hints)
+	if err != nil {
+		return nil, extendErr(err, "NewFSM")
+	}
+
+	var sizes = make(map[Fnode]int64)
+	if cp := hints.Checkpoint; cp != nil {
+		for _, f := range cp.Files {
+			sizes[f.Fnode] = f.Content.ContentLength()
+		}
+	}
+
+	if fsm.hasRemainingHints() {
+		err = ReadOperations(ctx, hints.Log, fsm.hintedSegments[0].FirstOffset, 0, rjc,
+			func(op RecordedOp, frame []byte) error {
+				if !applyOperation(op, frame, fsm) {
+					return nil
+				} else if op.Write != nil {
+					if end := op.Write.Offset + op.Write.Length; end > sizes[op.Write.Fnode] {
+						sizes[op.Write.Fnode] = end
+					}
+				}
+				if !fsm.hasRemainingHints() {
+					return errHintsPlayed
+				}
+				return nil
+			})
+
+		if err == nil {
+			err = errors.Errorf("reached write-head of %v with remaining hints; possible data loss", hints.Log)
+		} else if err == errHintsPlayed {
+			err = nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var segments = make(map[Fnode][]Segment)
+	for _, n := range hints.LiveNodes {
+		segments[n.Fnode] = n.Segments
+	}
+
+	var out []LiveFile
+	for fnode, node := range fsm.LiveNodes {
+		var file = LiveFile{
+			Fnode:    fnode,
+			Size:     sizes[fnode],
+			Segments: segments[fnode],
+		}
+		for link := range node.Links {
+			file.Links = append(file.Links, link)
+		}
+		sort.Strings(file.Links)
+		out = append(out, file)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Fnode < out[j].Fnode })
+
+	return out, nil
+}
+
+// errHintsPlayed is used by LiveFiles to stop reading the log.
+var errHintsPlayed = errors.New("hints played")
@@ -0,0 +1,141 @@
+package recoverylog
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/LiveRamp/gazette/v2/pkg/client"
+	gc "github.com/go-check/check"
+	"github.com/spf13/afero"
+)
+
+type InspectSuite struct{}
+
+func (s *InspectSuite) TestReadOperations(c *gc.C) {
+	var bk, cleanup = newBrokerAndLog(c)
+	defer cleanup()
+
+	var hints = s.recordFixture(c, bk)
+
+	type opSummary struct {
+		seqNo       int64
+		kind        string
+		first, last int64
+	}
+	var read = func(offset, endOffset int64) (out []opSummary) {
+		c.Check(ReadOperations(context.Background(), aRecoveryLog, offset, endOffset, bk,
+			func(op RecordedOp, frame []byte) error {
+				var kind string
+				switch {
+				case op.Create != nil:
+					kind = "create"
+				case op.Link != nil:
+					kind = "link"
+				case op.Unlink != nil:
+					kind = "unlink"
+				case op.Write != nil:
+					kind = "write"
+				case op.Property != nil:
+					kind = "property"
+				}
+				c.Check(op.LastOffset-op.FirstOffset >= int64(len(frame)), gc.Equals, true)
+				out = append(out, opSummary{op.SeqNo, kind, op.FirstOffset, op.LastOffset})
+				return nil
+			}), gc.IsNil)
+		return
+	}
+
+	// Expect all operations are read through the write head, with contiguous offsets.
+	var all = read(0, 0)
+	c.Assert(all, gc.HasLen, 13)
+
+	var kinds []string
+	for i, op := range all {
+		c.Check(op.seqNo, gc.Equals, int64(i+1))
+		if i != 0 {
+			c.Check(op.first, gc.Equals, all[i-1].last)
+		}
+		kinds = append(kinds, op.kind)
+	}
+	c.Check(kinds, gc.DeepEquals, []string{"create", "write", "write", "link",
+		"create", "write", "create", "write", "unlink", "create", "write", "property", "unlink"})
+	c.Check(hints.LiveNodes[0].Segments[0].FirstOffset, gc.Equals, all[0].first)
+
+	// Expect a read may begin and end at offsets within the log.
+	c.Check(read(all[3].first, all[6].first), gc.DeepEquals, all[3:6])
+
+	// Expect an error returned by the callback is passed through.
+	c.Check(ReadOperations(context.Background(), aRecoveryLog, 0, 0, bk,
+		func(RecordedOp, []byte) error { return os.ErrInvalid }), gc.Equals, os.ErrInvalid)
+}
+
+func (s *InspectSuite) TestLiveFiles(c *gc.C) {
+	var bk, cleanup = newBrokerAndLog(c)
+	defer cleanup()
+
+	var hints = s.recordFixture(c, bk)
+
+	var files, err = LiveFiles(context.Background(), hints, bk)
+	c.Check(err, gc.IsNil)
+	c.Check(files, gc.DeepEquals, []LiveFile{
+		{
+			Fnode:    1,
+			Links:    []string{"/foo", "/foo.link"},
+			Size:     11,
+			Segments: hints.LiveNodes[0].Segments,
+		},
+		{
+			Fnode:    5,
+			Links:    []string{"/bar"},
+			Size:     3,
+			Segments: hints.LiveNodes[1].Segments,
+		},
+	})
+
+	// Expect hints which reference operations beyond the log write-head fail.
+	hints.LiveNodes[1].Segments[0].LastSeqNo = 100
+	_, err = LiveFiles(context.Background(), hints, bk)
+	c.Check(err, gc.ErrorMatches, `reached write-head of .* with remaining hints; possible data loss`)
+
+	// Expect hints without LiveNodes produce no files.
+	files, err = LiveFiles(context.Background(), FSMHints{Log: aRecoveryLog}, bk)
+	c.Check(err, gc.IsNil)
+	c.Check(files, gc.HasLen, 0)
+}
+
+// recordFixture records files "foo" (linked to "foo.link") and "bar", as well
+// as a since-removed file "baz" and property "/IDENTITY", and returns built hints.
+func (s *InspectSuite) recordFixture(c *gc.C, bk client.AsyncJournalClient) FSMHints {
+	var dir = tempDir(c)
+	defer os.RemoveAll(dir)
+
+	var fsm, err = NewFSM(FSMHints{Log: aRecoveryLog})
+	c.Assert(err, gc.IsNil)
+
+	var rec = NewRecorder(fsm, anAuthor, dir, bk)
+	var fs = RecordedAferoFS{Recorder: rec, Fs: afero.NewOsFs()}
+
+	foo, err := fs.Create(filepath.Join(dir, "foo"))
+	c.Assert(err, gc.IsNil)
+	_, err = foo.Write([]byte("hello"))
+	c.Check(err, gc.IsNil)
+	_, err = foo.Write([]byte(" world"))
+	c.Check(err, gc.IsNil)
+	c.Check(foo.Close(), gc.IsNil)
+
+	c.Check(os.Link(filepath.Join(dir, "foo"), filepath.Join(dir, "foo.link")), gc.IsNil)
+	rec.RecordLink(filepath.Join(dir, "foo"), filepath.Join(dir, "foo.link"))
+
+	c.Check(afero.WriteFile(fs, filepath.Join(dir, "bar"), []byte("bar"), 0600), gc.IsNil)
+	c.Check(afero.WriteFile(fs, filepath.Join(dir, "baz"), []byte("baz"), 0600), gc.IsNil)
+	c.Check(fs.Remove(filepath.Join(dir, "baz")), gc.IsNil)
+
+	c.Check(afero.WriteFile(fs, filepath.Join(dir, "tmp"), []byte("value"), 0600), gc.IsNil)
+	c.Check(fs.Rename(filepath.Join(dir, "tmp"), filepath.Join(dir, "IDENTITY")), gc.IsNil)
+
+	<-rec.WeakBarrier().Done()
+	return rec.BuildHints()
+}
+
+var _ = gc.Suite(&InspectSuite{})
@@ -0,0 +1,331 @@
+package recoverylog
+
+import (
+	"crypto/sha1"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// persistLocalState writes a LocalState of |fsm| and its staged Fnode |files|
+// into |dir|, from which a future Player of |dir| may resume. |fsm| must have
+// read the log through |offset|, and must have no remaining hints. |files| are
+// closed once the LocalState has been written.
+func persistLocalState(dir string, fsm *FSM, offset int64, files fnodeFileMap) error {
+	if fsm.hasRemainingHints() {
+		return errors.New("FSM has remaining hints")
+	}
+	var state = LocalState{
+		Hints:        fsm.BuildHints(),
+		NextSeqNo:    fsm.NextSeqNo,
+		NextChecksum: fsm.NextChecksum,
+		LogOffset:    offset,
+		History:      append([]Segment(nil), fsm.history...),
+	}
+	for fnode, node := range fsm.LiveNodes {
+		var file = LocalState_File{Fnode: fnode}
+
+		for link := range node.Links {
+			file.Links = append(file.Links, link)
+		}
+		sort.Strings(file.Links)
+
+		var err error
+		if file.Size, file.Sum, err = sumFile(stagedPath(dir, fnode)); err != nil {
+			return extendErr(err, "summing %d", fnode)
+		}
+		state.Files = append(state.Files, file)
+	}
+	sort.Slice(state.Files, func(i, j int) bool { return state.Files[i].Fnode < state.Files[j].Fnode })
+
+	// Write the LocalState to a temporary file, and then atomically rename it
+	// such that a partial LocalState is never observed.
+	var b, err = state.Marshal()
+	if err != nil {
+		return extendErr(err, "marshaling LocalState")
+	}
+	var path = filepath.Join(dir, localStateFile)
+
+	if err = ioutil.WriteFile(path+".tmp", b, 0666); err != nil {
+		return extendErr(err, "writing LocalState")
+	} else if err = os.Rename(path+".tmp", path); err != nil {
+		return extendErr(err, "renaming LocalState")
+	}
+
+	for fnode, file := range files {
+		if err := file.Close(); err != nil {
+			log.WithFields(log.Fields{"err": err, "fnode": fnode}).Warn("failed to close fnode")
+		}
+		delete(files, fnode)
+	}
+	log.WithFields(log.Fields{
+		"log":       fsm.Log,
+		"dir":       dir,
+		"offset":    offset,
+		"nextSeqNo": fsm.NextSeqNo,
+		"files":     len(state.Files),
+	}).Info("persisted local state")
+
+	return nil
+}
+
+// resumeLocalState resumes from a LocalState persisted within |dir|, if there
+// is one. The LocalState is verified against |hints| and the staged content
+// of |dir|, and is consumed (removed) in the process. On success, it returns
+// the resumed FSM, open staged Fnode |files|, and the log offset from which
+// playback should continue. If |dir| has no LocalState, a nil FSM is returned.
+func resumeLocalState(hints FSMHints, dir string) (*FSM, fnodeFileMap, int64, error) {
+	var path = filepath.Join(dir, localStateFile)
+
+	var b, err = ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil, 0, nil
+	} else if err != nil {
+		return nil, nil, 0, extendErr(err, "reading LocalState")
+	} else if err = os.Remove(path); err != nil {
+		return nil, nil, 0, extendErr(err, "removing LocalState")
+	}
+
+	var state LocalState
+	if err = state.Unmarshal(b); err != nil {
+		return nil, nil, 0, extendErr(err, "unmarshaling LocalState")
+	}
+	fsm, err := newResumedFSM(&state, hints)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	// Remove all content of |dir| other than staged Fnodes.
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	for _, e := range entries {
+		if e.Name() == fnodeStagingDir {
+			continue
+		} else if err = os.RemoveAll(filepath.Join(dir, e.Name())); err != nil {
+			return nil, nil, 0, err
+		}
+	}
+	// Remove staged Fnodes which are not live in the resumed FSM.
+	if entries, err = ioutil.ReadDir(filepath.Join(dir, fnodeStagingDir)); err != nil {
+		return nil, nil, 0, err
+	}
+	for _, e := range entries {
+		var fnode, _ = strconv.ParseInt(e.Name(), 10, 64)
+
+		if _, ok := fsm.LiveNodes[Fnode(fnode)]; ok {
+			continue
+		} else if err = os.Remove(filepath.Join(dir, fnodeStagingDir, e.Name())); err != nil {
+			return nil, nil, 0, err
+		}
+	}
+
+	// Verify and open remaining staged Fnodes.
+	var files = make(fnodeFileMap)
+	for _, f := range state.Files {
+		if _, ok := fsm.LiveNodes[f.Fnode]; !ok {
+			continue
+		}
+		var path = stagedPath(dir, f.Fnode)
+
+		if size, sum, err := sumFile(path); err != nil {
+			return nil, files, 0, extendErr(err, "summing %d", f.Fnode)
+		} else if size != f.Size || sum != f.Sum {
+			return nil, files, 0, errors.Errorf("local Fnode %d has unexpected content (size %d vs %d; SHA1 %x vs %x)",
+				f.Fnode, size, f.Size, digestOf(sum), digestOf(f.Sum))
+		} else if files[f.Fnode], err = os.OpenFile(path, os.O_WRONLY, 0666); err != nil {
+			return nil, files, 0, err
+		}
+	}
+
+	log.WithFields(log.Fields{
+		"log":       fsm.Log,
+		"dir":       dir,
+		"offset":    state.LogOffset,
+		"nextSeqNo": state.NextSeqNo,
+		"files":     len(files),
+	}).Info("resuming from local state")
+
+	return fsm, files, state.LogOffset, nil
+}
+
+// newResumedFSM returns an FSM which resumes from the LocalState, and which is
+// prepared to apply remaining |hints|. An error is returned if the LocalState
+// is not consistent with |hints|. FSMHints may have been produced before the
+// LocalState (in which case the LocalState reflects all hinted operations), or
+// after it (in which case the resumed FSM applies hinted operations which
+// follow the LocalState).
+func newResumedFSM(state *LocalState, hints FSMHints) (*FSM, error) {
+	if state.Hints.Log != hints.Log {
+		return nil, errors.Errorf("local state log %s differs from hinted log %s",
+			state.Hints.Log, hints.Log)
+	}
+	var fnodes, set, err = hints.LiveLogSegments()
+	if err != nil {
+		return nil, err
+	}
+	var next = state.NextSeqNo
+
+	if cp := hints.Checkpoint; cp != nil && cp.NextSeqNo > next {
+		return nil, errors.Errorf("local state (NextSeqNo %d) precedes hinted Checkpoint (NextSeqNo %d)",
+			next, cp.NextSeqNo)
+	}
+	// Hinted Segments which precede the LocalState must also be in its History.
+	for _, s := range set {
+		if s.FirstSeqNo < next && !historyCovers(state.History, s, next) {
+			return nil, errors.Errorf("hinted Segment is not in local state history: %#v", s)
+		}
+	}
+
+	// Build an FSM equivalent to that which produced the LocalState.
+	var fsm = &FSM{
+		Log:          state.Hints.Log,
+		NextSeqNo:    next,
+		NextChecksum: state.NextChecksum,
+		Properties:   make(map[string]string),
+		LiveNodes:    make(map[Fnode]*fnodeState),
+		Links:        make(map[string]Fnode),
+		Checkpoint:   state.Hints.Checkpoint,
+		history:      append([]Segment(nil), state.History...),
+	}
+	var segments = make(map[Fnode][]Segment)
+	for _, n := range state.Hints.LiveNodes {
+		segments[n.Fnode] = n.Segments
+	}
+	for _, f := range state.Files {
+		var node = &fnodeState{
+			Links:    make(map[string]struct{}, len(f.Links)),
+			Segments: segments[f.Fnode],
+		}
+		for _, link := range f.Links {
+			node.Links[link] = struct{}{}
+			fsm.Links[link] = f.Fnode
+		}
+		fsm.LiveNodes[f.Fnode] = node
+	}
+	for _, p := range state.Hints.Properties {
+		fsm.Properties[p.Path] = p.Content
+	}
+
+	// If hinted Segments extend beyond the LocalState, then |hints| were
+	// produced after it. Prepare the FSM to apply remaining hinted operations.
+	var ind = sort.Search(len(set), func(i int) bool { return set[i].LastSeqNo >= next })
+	if ind != len(set) {
+		if err = fsm.resumeHints(hints, fnodes, set[ind:], state); err != nil {
+			return nil, err
+		}
+	}
+	// Apply a hinted Checkpoint which is more recent than that of the LocalState.
+	if cp := hints.Checkpoint; cp != nil && (fsm.Checkpoint == nil || cp.NextSeqNo > fsm.Checkpoint.NextSeqNo) {
+		fsm.applyCheckpoint(cp)
+	}
+	return fsm, nil
+}
+
+// resumeHints prepares an FSM resumed from the LocalState to apply |remaining|
+// hinted Segments, which begin at or after the LocalState.
+func (m *FSM) resumeHints(hints FSMHints, fnodes []Fnode, remaining []Segment, state *LocalState) error {
+	// Hinted Fnodes which precede the LocalState must be live within it.
+	var hinted = make(map[Fnode]struct{})
+	for _, n := range hints.LiveNodes {
+		hinted[n.Fnode] = struct{}{}
+	}
+	if hints.Checkpoint != nil {
+		for _, f := range hints.Checkpoint.Files {
+			hinted[f.Fnode] = struct{}{}
+		}
+	}
+	for fnode := range hinted {
+		if _, ok := m.LiveNodes[fnode]; !ok && int64(fnode) < m.NextSeqNo {
+			return errors.Errorf("hinted Fnode %d is not live in local state", fnode)
+		}
+	}
+	// Local Fnodes which are not hinted are removed later in the log.
+	for fnode, node := range m.LiveNodes {
+		if _, ok := hinted[fnode]; ok {
+			continue
+		}
+		for link := range node.Links {
+			delete(m.Links, link)
+		}
+		delete(m.LiveNodes, fnode)
+	}
+	// Properties are never removed, and may only be added.
+	for path, content := range m.Properties {
+		var found bool
+		for _, p := range hints.Properties {
+			found = found || (p.Path == path && p.Content == content)
+		}
+		if !found {
+			return errors.Errorf("local state property %s is not hinted", path)
+		}
+	}
+	for _, p := range hints.Properties {
+		m.Properties[p.Path] = p.Content
+	}
+
+	// Trim a Segment which spans the LocalState to begin with its next operation.
+	m.hintedSegments = append([]Segment(nil), remaining...)
+
+	if s := &m.hintedSegments[0]; s.FirstSeqNo < m.NextSeqNo {
+		s.FirstSeqNo, s.FirstChecksum = m.NextSeqNo, m.NextChecksum
+
+		if s.FirstOffset < state.LogOffset {
+			s.FirstOffset = state.LogOffset
+		}
+	} else if s.FirstSeqNo == m.NextSeqNo && s.FirstChecksum != m.NextChecksum {
+		return errors.Errorf("hinted Segment checksum differs from local state: %#v", *s)
+	}
+	m.NextSeqNo, m.NextChecksum = m.hintedSegments[0].FirstSeqNo, m.hintedSegments[0].FirstChecksum
+
+	for _, fnode := range fnodes {
+		if int64(fnode) >= state.NextSeqNo {
+			m.hintedFnodes = append(m.hintedFnodes, fnode)
+		}
+	}
+	return nil
+}
+
+// historyCovers returns true if Segment |s|, through SeqNo |next| (exclusive),
+// is covered by a Segment of |history| having the same Author.
+func historyCovers(history []Segment, s Segment, next int64) bool {
+	var last = s.LastSeqNo
+	if last >= next {
+		last = next - 1
+	}
+	for _, h := range history {
+		if h.Author != s.Author || h.FirstSeqNo > s.FirstSeqNo || h.LastSeqNo < last {
+			continue
+		}
+		return h.FirstSeqNo != s.FirstSeqNo || h.FirstChecksum == s.FirstChecksum
+	}
+	return false
+}
+
+// sumFile returns the size and SHA1 sum of the file at |path|.
+func sumFile(path string) (int64, pb.SHA1Sum, error) {
+	var f, err = os.Open(path)
+	if err != nil {
+		return 0, pb.SHA1Sum{}, err
+	}
+	defer f.Close()
+
+	var summer = sha1.New()
+	var size int64
+
+	if size, err = io.Copy(summer, f); err != nil {
+		return 0, pb.SHA1Sum{}, err
+	}
+	return size, pb.SHA1SumFromDigest(summer.Sum(nil)), nil
+}
+
+// Name of the LocalState file persisted within a Player directory.
+const localStateFile = ".localstate"
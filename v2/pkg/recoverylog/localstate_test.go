@@ -0,0 +1,267 @@
+package recoverylog
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/LiveRamp/gazette/v2/pkg/client"
+	gc "github.com/go-check/check"
+	"github.com/spf13/afero"
+)
+
+type LocalStateSuite struct{}
+
+func (s *LocalStateSuite) TestResumedFSMWithPriorHints(c *gc.C) {
+	// Hints were produced prior to the LocalState, and reference Fnode 2
+	// which has since been removed.
+	var fsm, err = newResumedFSM(localStateFixture(), FSMHints{
+		Log: aRecoveryLog,
+		LiveNodes: []FnodeSegments{
+			{Fnode: 1, Segments: []Segment{{Author: anAuthor, FirstSeqNo: 1, LastSeqNo: 3}}},
+			{Fnode: 2, Segments: []Segment{{Author: anAuthor, FirstSeqNo: 2, LastSeqNo: 3}}},
+		},
+	})
+	c.Assert(err, gc.IsNil)
+
+	// Expect the FSM reflects the LocalState, with no remaining hints.
+	c.Check(fsm.NextSeqNo, gc.Equals, int64(10))
+	c.Check(fsm.NextChecksum, gc.Equals, uint32(0xaaaaaaaa))
+	c.Check(fsm.Links, gc.DeepEquals, map[string]Fnode{"/one": 1, "/five": 5, "/five.link": 5})
+	c.Check(fsm.Properties, gc.DeepEquals, map[string]string{"/prop": "value"})
+	c.Check(fsm.hasRemainingHints(), gc.Equals, false)
+	c.Check(fsm.BuildHints(), gc.DeepEquals, localStateFixture().Hints)
+}
+
+func (s *LocalStateSuite) TestResumedFSMWithFollowingHints(c *gc.C) {
+	// Hints were produced after the LocalState. Fnode 5 has since been removed,
+	// and Fnode 11 was created.
+	var fsm, err = newResumedFSM(localStateFixture(), FSMHints{
+		Log: aRecoveryLog,
+		LiveNodes: []FnodeSegments{
+			{Fnode: 1, Segments: []Segment{{Author: anAuthor, FirstSeqNo: 1, LastSeqNo: 12}}},
+			{Fnode: 11, Segments: []Segment{{Author: anAuthor, FirstSeqNo: 11, FirstChecksum: 0xbbbbbbbb, LastSeqNo: 12}}},
+		},
+		Properties: []Property{{Path: "/prop", Content: "value"}, {Path: "/other", Content: "other"}},
+	})
+	c.Assert(err, gc.IsNil)
+
+	// Expect the hinted Segment is trimmed to begin at the LocalState.
+	c.Check(fsm.NextSeqNo, gc.Equals, int64(10))
+	c.Check(fsm.NextChecksum, gc.Equals, uint32(0xaaaaaaaa))
+	c.Check(fsm.hintedSegments, gc.DeepEquals, []Segment{
+		{Author: anAuthor, FirstSeqNo: 10, FirstChecksum: 0xaaaaaaaa, FirstOffset: 1000, LastSeqNo: 12},
+	})
+	c.Check(fsm.hintedFnodes, gc.DeepEquals, []Fnode{11})
+
+	// Expect Fnode 5 was removed, and properties were updated.
+	c.Check(fsm.Links, gc.DeepEquals, map[string]Fnode{"/one": 1})
+	c.Check(fsm.LiveNodes, gc.HasLen, 1)
+	c.Check(fsm.Properties, gc.DeepEquals, map[string]string{"/prop": "value", "/other": "other"})
+}
+
+func (s *LocalStateSuite) TestResumedFSMErrorCases(c *gc.C) {
+	var cases = []struct {
+		hints  FSMHints
+		expect string
+	}{
+		{ // Log differs.
+			hints:  FSMHints{Log: "other/log"},
+			expect: `local state log .* differs from hinted log other/log`,
+		},
+		{ // Hinted Segment has a different Author than the LocalState history.
+			hints: FSMHints{Log: aRecoveryLog, LiveNodes: []FnodeSegments{
+				{Fnode: 1, Segments: []Segment{{Author: 999, FirstSeqNo: 1, LastSeqNo: 3}}}}},
+			expect: `hinted Segment is not in local state history: .*`,
+		},
+		{ // Hinted Segment has a different FirstChecksum than the LocalState history.
+			hints: FSMHints{Log: aRecoveryLog, LiveNodes: []FnodeSegments{
+				{Fnode: 1, Segments: []Segment{{Author: anAuthor, FirstSeqNo: 1, FirstChecksum: 0x1234, LastSeqNo: 3}}}}},
+			expect: `hinted Segment is not in local state history: .*`,
+		},
+		{ // Hinted Fnode preceding the LocalState is not live within it.
+			hints: FSMHints{Log: aRecoveryLog, LiveNodes: []FnodeSegments{
+				{Fnode: 3, Segments: []Segment{{Author: anAuthor, FirstSeqNo: 3, LastSeqNo: 12}}}}},
+			expect: `hinted Fnode 3 is not live in local state`,
+		},
+		{ // Hinted Segment beginning at the LocalState has a different checksum.
+			hints: FSMHints{Log: aRecoveryLog, LiveNodes: []FnodeSegments{
+				{Fnode: 10, Segments: []Segment{{Author: anAuthor, FirstSeqNo: 10, FirstChecksum: 0x1234, LastSeqNo: 12}}}},
+				Properties: []Property{{Path: "/prop", Content: "value"}}},
+			expect: `hinted Segment checksum differs from local state: .*`,
+		},
+		{ // Hinted properties don't include a local property.
+			hints: FSMHints{Log: aRecoveryLog, LiveNodes: []FnodeSegments{
+				{Fnode: 10, Segments: []Segment{{Author: anAuthor, FirstSeqNo: 10, FirstChecksum: 0xaaaaaaaa, LastSeqNo: 12}}}}},
+			expect: `local state property /prop is not hinted`,
+		},
+		{ // Hinted Checkpoint follows the LocalState.
+			hints:  FSMHints{Log: aRecoveryLog, Checkpoint: &Checkpoint{NextSeqNo: 11}},
+			expect: `local state \(NextSeqNo 10\) precedes hinted Checkpoint \(NextSeqNo 11\)`,
+		},
+	}
+	for _, tc := range cases {
+		var _, err = newResumedFSM(localStateFixture(), tc.hints)
+		c.Check(err, gc.ErrorMatches, tc.expect)
+	}
+}
+
+func (s *LocalStateSuite) TestPersistWithRemainingHintsFails(c *gc.C) {
+	var fsm, err = NewFSM(FSMHints{
+		Log: aRecoveryLog,
+		LiveNodes: []FnodeSegments{
+			{Fnode: 1, Segments: []Segment{{Author: anAuthor, FirstSeqNo: 1, LastSeqNo: 3}}},
+		},
+	})
+	c.Assert(err, gc.IsNil)
+
+	var dir = tempDir(c)
+	defer os.RemoveAll(dir)
+
+	c.Check(persistLocalState(dir, fsm, 0, nil), gc.ErrorMatches, `FSM has remaining hints`)
+}
+
+func (s *LocalStateSuite) TestPlaybackResumesFromLocalState(c *gc.C) {
+	var bk, cleanup = newBrokerAndLog(c)
+	defer cleanup()
+
+	var recDir, playDir = tempDir(c), tempDir(c)
+	defer os.RemoveAll(recDir)
+	defer os.RemoveAll(playDir)
+
+	var fsm, err = NewFSM(FSMHints{Log: aRecoveryLog})
+	c.Assert(err, gc.IsNil)
+
+	var rec = NewRecorder(fsm, anAuthor, recDir, bk)
+	var fs = RecordedAferoFS{Recorder: rec, Fs: afero.NewOsFs()}
+
+	foo, err := fs.Create(filepath.Join(recDir, "foo"))
+	c.Assert(err, gc.IsNil)
+	_, err = foo.Write([]byte("hello"))
+	c.Check(err, gc.IsNil)
+	c.Check(afero.WriteFile(fs, filepath.Join(recDir, "bar"), []byte("bar"), 0600), gc.IsNil)
+	<-rec.WeakBarrier().Done()
+
+	// Play and tail the log, and then cancel. Expect a LocalState is persisted.
+	playAndRetain(c, bk, FSMHints{Log: aRecoveryLog}, playDir)
+
+	// Record further operations, and build hints which follow the LocalState.
+	_, err = foo.Write([]byte(" world"))
+	c.Check(err, gc.IsNil)
+	c.Check(fs.Remove(filepath.Join(recDir, "bar")), gc.IsNil)
+	c.Check(afero.WriteFile(fs, filepath.Join(recDir, "baz"), []byte("baz"), 0600), gc.IsNil)
+
+	var hints = rec.BuildHints()
+
+	// Expect the LocalState may be resumed. "bar" is removed as it's not hinted.
+	resumed, files, offset, err := resumeLocalState(hints, playDir)
+	c.Assert(err, gc.IsNil)
+	c.Check(resumed, gc.NotNil)
+	c.Check(files, gc.HasLen, 1)
+	c.Check(offset > 0, gc.Equals, true)
+
+	for _, f := range files {
+		c.Check(f.Close(), gc.IsNil)
+	}
+	_, err = os.Stat(filepath.Join(playDir, localStateFile))
+	c.Check(os.IsNotExist(err), gc.Equals, true) // LocalState was consumed.
+
+	// Play and retain again, and this time resume playback to completion.
+	playAndRetain(c, bk, FSMHints{Log: aRecoveryLog}, playDir)
+
+	var player = NewPlayer()
+	go func() { c.Check(player.Play(context.Background(), hints, playDir, bk), gc.IsNil) }()
+
+	player.FinishAtWriteHead()
+	<-player.Done()
+
+	expectFileContent(c, filepath.Join(playDir, "foo"), "hello world")
+	expectFileContent(c, filepath.Join(playDir, "baz"), "baz")
+
+	_, err = os.Stat(filepath.Join(playDir, "bar"))
+	c.Check(os.IsNotExist(err), gc.Equals, true)
+	_, err = os.Stat(filepath.Join(playDir, localStateFile))
+	c.Check(os.IsNotExist(err), gc.Equals, true)
+}
+
+func (s *LocalStateSuite) TestPlaybackFallsBackOnCorruptLocalState(c *gc.C) {
+	var bk, cleanup = newBrokerAndLog(c)
+	defer cleanup()
+
+	var recDir, playDir = tempDir(c), tempDir(c)
+	defer os.RemoveAll(recDir)
+	defer os.RemoveAll(playDir)
+
+	var fsm, err = NewFSM(FSMHints{Log: aRecoveryLog})
+	c.Assert(err, gc.IsNil)
+
+	var rec = NewRecorder(fsm, anAuthor, recDir, bk)
+	var fs = RecordedAferoFS{Recorder: rec, Fs: afero.NewOsFs()}
+	c.Check(afero.WriteFile(fs, filepath.Join(recDir, "foo"), []byte("foo"), 0600), gc.IsNil)
+	<-rec.WeakBarrier().Done()
+
+	playAndRetain(c, bk, FSMHints{Log: aRecoveryLog}, playDir)
+
+	// Corrupt the staged content of "foo".
+	var staged = stagedPath(playDir, rec.fsm.Links["/foo"])
+	c.Check(ioutil.WriteFile(staged, []byte("FOO"), 0600), gc.IsNil)
+
+	var hints = rec.BuildHints()
+	var _, _, _, rerr = resumeLocalState(hints, playDir)
+	c.Check(rerr, gc.ErrorMatches, `local Fnode 1 has unexpected content .*`)
+
+	// Again, but this time expect Play falls back to playback from scratch.
+	playAndRetain(c, bk, FSMHints{Log: aRecoveryLog}, playDir)
+	c.Check(ioutil.WriteFile(staged, []byte("FOO"), 0600), gc.IsNil)
+
+	var player = NewPlayer()
+	go func() { c.Check(player.Play(context.Background(), hints, playDir, bk), gc.IsNil) }()
+
+	player.FinishAtWriteHead()
+	<-player.Done()
+
+	expectFileContent(c, filepath.Join(playDir, "foo"), "foo")
+}
+
+// playAndRetain plays |hints| into |dir| until tailing the log, and then
+// cancels playback. It expects a LocalState to be persisted.
+func playAndRetain(c *gc.C, ajc client.AsyncJournalClient, hints FSMHints, dir string) {
+	var ctx, cancel = context.WithCancel(context.Background())
+	var player = NewPlayer()
+	player.RetainLocalState = true
+
+	go func() { c.Check(player.Play(ctx, hints, dir, ajc), gc.ErrorMatches, `.*context canceled`) }()
+
+	<-player.Tailing()
+	cancel()
+	<-player.Done()
+
+	var _, err = os.Stat(filepath.Join(dir, localStateFile))
+	c.Check(err, gc.IsNil)
+}
+
+func localStateFixture() *LocalState {
+	return &LocalState{
+		Hints: FSMHints{
+			Log: aRecoveryLog,
+			LiveNodes: []FnodeSegments{
+				{Fnode: 1, Segments: []Segment{{Author: anAuthor, FirstSeqNo: 1, LastSeqNo: 7, LastOffset: 700}}},
+				{Fnode: 5, Segments: []Segment{{Author: anAuthor, FirstSeqNo: 5, FirstOffset: 500, LastSeqNo: 9, LastOffset: 1000}}},
+			},
+			Properties: []Property{{Path: "/prop", Content: "value"}},
+		},
+		NextSeqNo:    10,
+		NextChecksum: 0xaaaaaaaa,
+		LogOffset:    1000,
+		History: []Segment{
+			{Author: anAuthor, FirstSeqNo: 1, LastSeqNo: 9, LastOffset: 1000},
+		},
+		Files: []LocalState_File{
+			{Fnode: 1, Links: []string{"/one"}},
+			{Fnode: 5, Links: []string{"/five", "/five.link"}},
+		},
+	}
+}
+
+var _ = gc.Suite(&LocalStateSuite{})
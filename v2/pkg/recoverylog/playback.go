@@ -24,9 +24,17 @@ type Player struct {
 	FSM *FSM   // FSM recovered at Play completion. Nil if an error was encountered.
 	Dir string // Local directory into which the log is recovered.
 
-	handoffCh chan Author   // Coordinates Player completion (& hand-off to a new Recorder).
-	tailingCh chan struct{} // Closed when Player reaches (and is tailing) the live log.
-	doneCh    chan struct{} // Closed when Player.Play completes.
+	// RetainLocalState, if set, causes a Player which is cancelled while
+	// tailing the log to persist a LocalState into its directory (rather than
+	// removing the directory). A future Play of the directory verifies and
+	// resumes from the LocalState, rather than playing back from scratch.
+	RetainLocalState bool
+
+	handoffCh chan Author      // Coordinates Player completion (& hand-off to a new Recorder).
+	tailingCh chan struct{}    // Closed when Player reaches (and is tailing) the live log.
+	doneCh    chan struct{}    // Closed when Player.Play completes.
+	viewCh    chan viewRequest // Requests of LinkView.
+	progress  readProgress     // Log offset through which the Player has read.
 }
 
 // NewPlayer returns a new Player for recovering a log.
@@ -35,6 +43,7 @@ func NewPlayer() *Player {
 		handoffCh: make(chan Author, 1),
 		tailingCh: make(chan struct{}),
 		doneCh:    make(chan struct{}),
+		viewCh:    make(chan viewRequest),
 	}
 }
 
@@ -44,7 +53,8 @@ func NewPlayer() *Player {
 func (p *Player) Play(ctx context.Context, hints FSMHints, dir string, ajc client.AsyncJournalClient) error {
 	defer close(p.doneCh)
 
-	if fsm, err := playLog(ctx, hints, dir, ajc, p.tailingCh, p.handoffCh); err != nil {
+	if fsm, err := playLog(ctx, hints, dir, ajc, p.RetainLocalState,
+		p.tailingCh, p.handoffCh, p.viewCh, &p.progress); err != nil {
 		return err
 	} else {
 		p.Dir, p.FSM = dir, fsm
@@ -205,34 +215,61 @@ const (
 // and otherwise blocks indefinitely until signalled by |handoffCh|. If signaled
 // with a zero-valued Author, playLog exits upon reaching the log head. Otherwise,
 // playLog exits upon injecting a properly sequenced no-op RecordedOp which encodes
-// the provided Author. The recovered FSM is returned on success.
+// the provided Author. The recovered FSM is returned on success. If |retain|,
+// a LocalState is persisted to |dir| should playback be cancelled while tailing.
+// Requests of |viewCh| are served between operations, and |progress| is updated
+// with each operation read.
 func playLog(ctx context.Context, hints FSMHints, dir string, ajc client.AsyncJournalClient,
-	tailingCh chan<- struct{}, handoffCh <-chan Author) (fsm *FSM, err error) {
+	retain bool, tailingCh chan<- struct{}, handoffCh <-chan Author, viewCh <-chan viewRequest,
+	progress *readProgress) (fsm *FSM, err error) {
 
 	var state = playerStateBackfill
-	var files = make(fnodeFileMap) // Live Fnodes backed by local files.
-	var handoff Author             // Author we will hand-off to on exit.
+	var files fnodeFileMap // Live Fnodes backed by local files.
+	var handoff Author     // Author we will hand-off to on exit.
+	var retained bool      // Whether |dir| was retained on abort.
 
 	// Error checks in this function consistently use |err| prior to returning.
 	defer func() {
-		if err != nil {
+		if err != nil && !retained {
 			cleanupOnAbort(dir, files)
-		} else if state != playerStateComplete {
+		} else if err == nil && state != playerStateComplete {
 			// |err| should be nil only on a successful playback completion.
 			log.WithField("state", state).Panic("unexpected state on return")
 		}
 	}()
 
+	// Next |offset| to read, and minimum offset we must |readThrough| during playback.
+	var offset, readThrough int64
+	var resumed *FSM
+
 	if fsm, err = NewFSM(hints); err != nil {
 		err = extendErr(err, "NewFSM")
 		return
-	} else if err = preparePlayback(dir); err != nil {
-		err = extendErr(err, "preparePlayback(%v)", dir)
-		return
+	} else if resumed, files, offset, err = resumeLocalState(hints, dir); err != nil {
+		log.WithFields(log.Fields{"err": err, "dir": dir}).
+			Warn("failed to resume from local state (will play back from scratch)")
+
+		for _, file := range files {
+			file.Close()
+		}
+		files, offset, err = nil, 0, nil
 	}
 
-	// Next |offset| to read, and minimum offset we must |readThrough| during playback.
-	var offset, readThrough int64
+	if resumed != nil {
+		fsm = resumed
+	} else {
+		files = make(fnodeFileMap)
+
+		if err = preparePlayback(dir); err != nil {
+			err = extendErr(err, "preparePlayback(%v)", dir)
+			return
+		} else if hints.Checkpoint != nil {
+			if err = restoreCheckpoint(ctx, hints.Checkpoint, dir, files); err != nil {
+				err = extendErr(err, "restoreCheckpoint")
+				return
+			}
+		}
+	}
 
 	// Issue a write barrier to determine the transactional, current log head.
 	// We issue the barrier as a direct Append (rather than using AppendService)
@@ -257,7 +294,26 @@ func playLog(ctx context.Context, hints FSMHints, dir string, ajc client.AsyncJo
 	var reader = newPlayerReader(ctx, hints.Log, ajc)
 	defer reader.close()
 
+	// Begin reading from the resumed LocalState or Checkpoint, if there is one.
+	// The log which precedes it is reflected by local or restored files.
+	if resumed != nil {
+		if offset > readThrough {
+			err = errors.Errorf("max write-head of %v is %d, vs local state offset %d; possible data loss",
+				hints.Log, readThrough, offset)
+			return
+		}
+		offset = reader.seek(offset)
+	} else if cp := hints.Checkpoint; cp != nil {
+		if cp.LogOffset > readThrough {
+			err = errors.Errorf("max write-head of %v is %d, vs checkpoint offset %d; possible data loss",
+				hints.Log, readThrough, cp.LogOffset)
+			return
+		}
+		offset = reader.seek(cp.LogOffset)
+	}
+
 	for {
+		progress.set(offset)
 
 		if s := fsm.hintedSegments; len(s) != 0 {
 			// There are unread, remaining hinted segments of the log.
@@ -303,6 +359,11 @@ func playLog(ctx context.Context, hints FSMHints, dir string, ajc client.AsyncJo
 			}
 			handoffCh = nil // Do not select again.
 			continue
+
+		case req := <-viewCh:
+			var view, verr = linkView(dir, fsm)
+			req.respCh <- viewResponse{dir: view, err: verr}
+			continue
 		}
 
 		if err == client.ErrOffsetNotYetAvailable {
@@ -344,7 +405,10 @@ func playLog(ctx context.Context, hints FSMHints, dir string, ajc client.AsyncJo
 					err = extendErr(err, "injecting no-op")
 					return
 				}
-				<-txn.Done()
+				if <-txn.Done(); txn.Err() != nil {
+					err = extendErr(txn.Err(), "injecting no-op")
+					return
+				}
 
 				// We next must read through the op we just wrote.
 				state, readThrough = playerStateReadHandoffBarrier, txn.Response().Commit.End
@@ -373,7 +437,16 @@ func playLog(ctx context.Context, hints FSMHints, dir string, ajc client.AsyncJo
 			continue
 
 		} else if err != nil {
-			// Any other Peek error aborts playback.
+			// Any other Peek error aborts playback. If we were cancelled while
+			// tailing, retain local playback state if requested.
+			if retain && ctx.Err() != nil && !fsm.hasRemainingHints() {
+				if perr := persistLocalState(dir, fsm, offset, files); perr != nil {
+					log.WithFields(log.Fields{"err": perr, "dir": dir}).
+						Warn("failed to persist local state")
+				} else {
+					retained = true
+				}
+			}
 			err = extendErr(err, "playerReader.peek")
 			return
 		}
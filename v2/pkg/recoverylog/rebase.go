@@ -0,0 +1,287 @@
+package recoverylog
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// Rebase re-records each live file of the Recorder having hinted Segments
+// which begin before log |offset|, such that FSMHints subsequently built by
+// the Recorder reference only log content at or after |offset| (or which was
+// written by another Author). Recovery log content which precedes |offset|
+// may then be pruned once current FSMHints have been stored.
+//
+// Each file is rebased in turn (see RecordRebase), and files which are removed
+// before they can be rebased are skipped. Rebase also removes the files of
+// rebases which were abandoned by a prior Recorder of the log. Rebase and
+// RecordRebase must not be called concurrently.
+func (r *Recorder) Rebase(offset int64) error {
+	var txn = r.lockAndBeginTxn()
+	var fnodes = r.fsm.fnodesBefore(offset)
+	var abandoned = r.fsm.rebaseLinks()
+	r.unlockAndReleaseTxn(txn)
+
+	for _, link := range abandoned {
+		var path = filepath.Join(r.dir, filepath.FromSlash(link))
+
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return extendErr(err, "removing abandoned rebase %s", link)
+		}
+		r.RecordRemove(path)
+	}
+
+	for _, fnode := range fnodes {
+		txn = r.lockAndBeginTxn()
+
+		// Re-check |fnode|, which may have been removed or re-written since.
+		var link string
+		if node, ok := r.fsm.LiveNodes[fnode]; ok && len(node.Segments) != 0 &&
+			node.Segments[0].FirstOffset < offset {
+			link = firstLink(node)
+		}
+		r.unlockAndReleaseTxn(txn)
+
+		if link == "" {
+			continue
+		} else if err := r.rebase(fnode, link); os.IsNotExist(errors.Cause(err)) {
+			continue // File is being removed.
+		} else if err != nil {
+			return err
+		}
+	}
+	log.WithFields(log.Fields{
+		"log":       r.fsm.Log,
+		"offset":    offset,
+		"files":     len(fnodes),
+		"abandoned": len(abandoned),
+	}).Info("rebased recorded files")
+
+	return nil
+}
+
+// RecordRebase re-records the current content of the file at |path| as a newly
+// created Fnode, which replaces the file's current Fnode at each of its links.
+// Log Segments of the replaced Fnode are no longer referenced by FSMHints.
+//
+// Content is recorded in chunks of rebaseChunkSize, each in a separate
+// transaction, under a temporary link of the new Fnode. Once all content is
+// recorded, a final transaction hands-off links of the current Fnode to the
+// new Fnode. Until then, FSMHints continue to reference the current Fnode in
+// full, and if the Recorder exits before the hand-off, the rebase is abandoned
+// and its file is removed by a future Rebase.
+//
+// The file may be open for writing, but must not be replaced (eg, renamed
+// over) while it's rebased. Writes of its FileRecorder which overlap content
+// already recorded into the new Fnode are also recorded against the new Fnode,
+// and writes which follow the hand-off are recorded only against the new Fnode.
+// Writes which raced the rebase (eg, which were applied to the file but not yet
+// recorded) are harmlessly recorded again, as the new Fnode already reflects
+// them.
+func (r *Recorder) RecordRebase(path string) error {
+	path = r.normalizePath(path)
+	var txn = r.lockAndBeginTxn()
+	var fnode, ok = r.fsm.Links[path]
+	r.unlockAndReleaseTxn(txn)
+
+	if !ok {
+		log.WithFields(log.Fields{"path": path}).Panic("rebase of unknown path")
+	}
+	return r.rebase(fnode, path)
+}
+
+// rebase re-records live |fnode| having |link| as a new Fnode. The rebase is
+// skipped if |link| is no longer a link of |fnode|.
+func (r *Recorder) rebase(fnode Fnode, link string) error {
+	// Open the file before recording any operations, so that a failure leaves
+	// the recorded log unchanged.
+	var file, err = os.Open(filepath.Join(r.dir, filepath.FromSlash(link)))
+	if err != nil {
+		return extendErr(err, "opening rebased file %s", link)
+	}
+	defer file.Close()
+
+	var rs *rebaseState
+	if rs, err = r.beginRebase(fnode, link); rs == nil || err != nil {
+		return err
+	}
+	for done := false; !done && err == nil; done, err = r.stepRebase(rs, file) {
+	}
+	return err
+}
+
+// rebaseState is the state of an in-progress rebase.
+type rebaseState struct {
+	// Fnode being rebased, and the new Fnode into which it's being recorded.
+	from, to Fnode
+	// Temporary link of |to|, which is removed upon hand-off.
+	link string
+	// Length of |from| content which has been recorded into |to|.
+	progress int64
+}
+
+// beginRebase records the creation of a new Fnode into which |fnode| is
+// rebased, under a temporary link. It returns nil if |link| is no longer a
+// link of |fnode|.
+func (r *Recorder) beginRebase(fnode Fnode, link string) (*rebaseState, error) {
+	var txn = r.lockAndBeginTxn()
+	defer r.unlockAndReleaseTxn(txn)
+
+	var rs = &rebaseState{from: fnode, link: link + rebaseLinkSuffix}
+
+	if r.fsm.Links[link] != fnode {
+		return nil, nil // |link| was removed, or now references another Fnode.
+	} else if _, ok := r.fsm.Links[rs.link]; ok {
+		return nil, errors.Errorf("rebase link %s already exists", rs.link)
+	}
+	r.process(newCreateOp(rs.link), txn.Writer())
+	rs.to = r.fsm.Links[rs.link]
+	r.rebasing = rs
+
+	return rs, nil
+}
+
+// stepRebase records the next chunk of |file| content into the rebased Fnode,
+// and hands-off links of the rebased Fnode once all content is recorded. The
+// rebase is abandoned if its Fnode is removed or |file| is replaced, or if
+// |file| cannot be read.
+func (r *Recorder) stepRebase(rs *rebaseState, file *os.File) (done bool, err error) {
+	var txn = r.lockAndBeginTxn()
+	defer r.unlockAndReleaseTxn(txn)
+
+	var node, ok = r.fsm.LiveNodes[rs.from]
+	if !ok {
+		r.abandonRebase(rs, txn.Writer())
+		return true, nil
+	}
+
+	if r.rebaseBuf == nil {
+		r.rebaseBuf = make([]byte, rebaseChunkSize)
+	}
+	// Content is read while the Recorder is locked, as FileRecorder writes which
+	// overlap recorded content are also recorded into the rebased Fnode.
+	var n int
+	switch n, err = io.ReadFull(file, r.rebaseBuf); err {
+	case nil:
+	case io.EOF, io.ErrUnexpectedEOF:
+		done, err = true, nil
+	default:
+		r.abandonRebase(rs, txn.Writer())
+		return true, extendErr(err, "reading rebased file")
+	}
+	if n != 0 {
+		r.recordWrite(rs.to, rs.progress, r.rebaseBuf[:n], txn.Writer())
+		rs.progress += int64(n)
+	}
+	if !done {
+		return false, nil
+	}
+
+	var links = sortedLinks(node)
+
+	// Verify the file we read is still the one recorded at |links[0]|.
+	if info, err := file.Stat(); err != nil {
+		r.abandonRebase(rs, txn.Writer())
+		return true, extendErr(err, "stat of rebased file")
+	} else if cur, err := os.Stat(filepath.Join(r.dir, filepath.FromSlash(links[0]))); err != nil || !os.SameFile(info, cur) {
+		log.WithFields(log.Fields{"path": links[0], "err": err}).
+			Warn("abandoning rebase of replaced file")
+		r.abandonRebase(rs, txn.Writer())
+		return true, nil
+	}
+
+	// Hand-off each link of the rebased Fnode, and remove the temporary link.
+	for _, link := range links {
+		r.process(newUnlinkOp(rs.from, link), txn.Writer())
+		r.process(newLinkOp(rs.to, link), txn.Writer())
+	}
+	r.process(newUnlinkOp(rs.to, rs.link), txn.Writer())
+
+	if r.rebased == nil {
+		r.rebased = make(map[Fnode]Fnode)
+	}
+	r.rebased[rs.from] = rs.to
+	r.rebasing = nil
+	r.pruneRebased()
+
+	return true, nil
+}
+
+// abandonRebase removes the temporary link (and Fnode) of rebase |rs|.
+// The Recorder must be locked.
+func (r *Recorder) abandonRebase(rs *rebaseState, bw *bufio.Writer) {
+	r.process(newUnlinkOp(rs.to, rs.link), bw)
+	r.rebasing = nil
+}
+
+// rebasedFnode returns the live Fnode which replaced |fnode| through one or
+// more rebases, or |fnode| itself if it was never rebased.
+func (r *Recorder) rebasedFnode(fnode Fnode) Fnode {
+	for {
+		if next, ok := r.rebased[fnode]; ok {
+			fnode = next
+		} else {
+			return fnode
+		}
+	}
+}
+
+// pruneRebased drops rebased Fnodes which can no longer be referenced. A
+// FileRecorder may record writes only while its file is live, and the file of
+// a rebased Fnode is the Fnode which (ultimately) replaced it. The Recorder
+// must be locked.
+func (r *Recorder) pruneRebased() {
+	for fnode := range r.rebased {
+		if _, ok := r.fsm.LiveNodes[r.rebasedFnode(fnode)]; !ok {
+			delete(r.rebased, fnode)
+		}
+	}
+}
+
+// fnodesBefore returns ordered live Fnodes having Segments which begin before
+// log |offset|.
+func (m *FSM) fnodesBefore(offset int64) []Fnode {
+	var out []Fnode
+	for fnode, node := range m.LiveNodes {
+		if len(node.Segments) != 0 && node.Segments[0].FirstOffset < offset {
+			out = append(out, fnode)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
+
+// rebaseLinks returns ordered, temporary links of rebases.
+func (m *FSM) rebaseLinks() []string {
+	var out []string
+	for link := range m.Links {
+		if strings.HasSuffix(link, rebaseLinkSuffix) {
+			out = append(out, link)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+func sortedLinks(node *fnodeState) []string {
+	var links []string
+	for link := range node.Links {
+		links = append(links, link)
+	}
+	sort.Strings(links)
+	return links
+}
+
+func firstLink(node *fnodeState) string { return sortedLinks(node)[0] }
+
+// rebaseChunkSize is the maximum length of a single recorded Write of a rebase.
+var rebaseChunkSize = 1 << 20 // 1MB.
+
+// Suffix of the temporary link of an in-progress rebase.
+const rebaseLinkSuffix = ".rebase"
@@ -0,0 +1,252 @@
+package recoverylog
+
+import (
+	"context"
+	"math"
+	"os"
+	"path/filepath"
+
+	"github.com/LiveRamp/gazette/v2/pkg/fragment"
+	gc "github.com/go-check/check"
+	"github.com/spf13/afero"
+)
+
+type RebaseSuite struct{}
+
+func (s *RebaseSuite) TestRebaseAndPlayback(c *gc.C) {
+	var bk, cleanup = newBrokerAndLog(c)
+	defer cleanup()
+
+	var recDir, playDir = tempDir(c), tempDir(c)
+	defer os.RemoveAll(recDir)
+	defer os.RemoveAll(playDir)
+
+	// Use a small chunk size, to exercise rebases of multiple recorded Writes.
+	defer func(prior int) { rebaseChunkSize = prior }(rebaseChunkSize)
+	rebaseChunkSize = 3
+
+	var fsm, err = NewFSM(FSMHints{Log: aRecoveryLog})
+	c.Assert(err, gc.IsNil)
+
+	var rec = NewRecorder(fsm, anAuthor, recDir, bk)
+	var fs = RecordedAferoFS{Recorder: rec, Fs: afero.NewOsFs()}
+
+	// Record "foo" (which remains open) with an additional link, and "bar".
+	foo, err := fs.Create(filepath.Join(recDir, "foo"))
+	c.Assert(err, gc.IsNil)
+	_, err = foo.Write([]byte("hello"))
+	c.Check(err, gc.IsNil)
+
+	c.Check(os.Link(filepath.Join(recDir, "foo"), filepath.Join(recDir, "foo.link")), gc.IsNil)
+	rec.RecordLink(filepath.Join(recDir, "foo"), filepath.Join(recDir, "foo.link"))
+	c.Check(afero.WriteFile(fs, filepath.Join(recDir, "bar"), []byte("bar"), 0600), gc.IsNil)
+
+	// Commit, and then record "baz" at a larger offset.
+	<-rec.WeakBarrier().Done()
+	<-rec.WeakBarrier().Done()
+	c.Check(afero.WriteFile(fs, filepath.Join(recDir, "baz"), []byte("baz"), 0600), gc.IsNil)
+
+	var before = rec.BuildHints()
+	c.Assert(before.LiveNodes, gc.HasLen, 3)
+
+	var offset = before.LiveNodes[2].Segments[0].FirstOffset
+	c.Check(before.LiveNodes[0].Segments[0].FirstOffset < offset, gc.Equals, true)
+
+	// Expect "foo" and "bar" are rebased, and "baz" is not.
+	c.Check(rec.Rebase(offset), gc.IsNil)
+
+	var after = rec.BuildHints()
+	c.Assert(after.LiveNodes, gc.HasLen, 3)
+	c.Check(after.LiveNodes[0], gc.DeepEquals, before.LiveNodes[2]) // "baz".
+
+	for _, n := range after.LiveNodes[1:] {
+		c.Check(n.Segments[0].FirstOffset >= offset, gc.Equals, true)
+		c.Check(n.Fnode > before.LiveNodes[2].Fnode, gc.Equals, true)
+	}
+	c.Check(rec.fsm.Links["/foo"], gc.Equals, rec.fsm.Links["/foo.link"])
+	c.Check(rec.fsm.Links["/foo"], gc.Equals, after.LiveNodes[1].Fnode)
+
+	// Expect writes of the open "foo" are recorded against its rebased Fnode.
+	_, err = foo.Write([]byte(" world"))
+	c.Check(err, gc.IsNil)
+	c.Check(foo.Close(), gc.IsNil)
+
+	var hints = rec.BuildHints()
+	c.Check(hints.LiveNodes[1].Fnode, gc.Equals, after.LiveNodes[1].Fnode)
+
+	// Expect a Player reconstructs the recorded file-system.
+	var player = NewPlayer()
+	go func() { c.Check(player.Play(context.Background(), hints, playDir, bk), gc.IsNil) }()
+
+	player.FinishAtWriteHead()
+	<-player.Done()
+
+	expectFileContent(c, filepath.Join(playDir, "foo"), "hello world")
+	expectFileContent(c, filepath.Join(playDir, "foo.link"), "hello world")
+	expectFileContent(c, filepath.Join(playDir, "bar"), "bar")
+	expectFileContent(c, filepath.Join(playDir, "baz"), "baz")
+}
+
+func (s *RebaseSuite) TestChunkedRebaseWithRacedWritesAndCheckpoint(c *gc.C) {
+	var bk, cleanup = newBrokerAndLog(c)
+	defer cleanup()
+
+	var ctx = context.Background()
+	var recDir, playDir, storeRoot = tempDir(c), tempDir(c), tempDir(c)
+	defer os.RemoveAll(recDir)
+	defer os.RemoveAll(playDir)
+	defer os.RemoveAll(storeRoot)
+
+	defer func(prior string) { fragment.FileSystemStoreRoot = prior }(fragment.FileSystemStoreRoot)
+	fragment.FileSystemStoreRoot = storeRoot
+
+	defer func(prior int) { rebaseChunkSize = prior }(rebaseChunkSize)
+	rebaseChunkSize = 4
+
+	var fsm, err = NewFSM(FSMHints{Log: aRecoveryLog})
+	c.Assert(err, gc.IsNil)
+
+	var rec = NewRecorder(fsm, anAuthor, recDir, bk)
+	var fs = RecordedAferoFS{Recorder: rec, Fs: afero.NewOsFs()}
+
+	foo, err := fs.Create(filepath.Join(recDir, "foo"))
+	c.Assert(err, gc.IsNil)
+	_, err = foo.Write([]byte("hello world"))
+	c.Check(err, gc.IsNil)
+
+	var fnode = rec.fsm.Links["/foo"]
+	file, err := os.Open(filepath.Join(recDir, "foo"))
+	c.Assert(err, gc.IsNil)
+	defer file.Close()
+
+	// Begin a rebase, and record its first chunk.
+	rs, err := rec.beginRebase(fnode, "/foo")
+	c.Assert(err, gc.IsNil)
+	done, err := rec.stepRebase(rs, file)
+	c.Check(done, gc.Equals, false)
+	c.Check(err, gc.IsNil)
+
+	// Expect hints continue to reference |fnode| in full.
+	var hints = rec.BuildHints()
+	c.Check(hints.LiveNodes[0].Fnode, gc.Equals, fnode)
+	c.Check(hints.LiveNodes[1].Fnode, gc.Equals, rs.to)
+	c.Check(rec.fsm.Links["/foo.rebase"], gc.Equals, rs.to)
+
+	// Expect a checkpoint may be taken of the in-progress rebase.
+	c.Check(rec.Checkpoint(ctx, "file:///"), gc.IsNil)
+
+	// Write over recorded content, and then complete the rebase.
+	_, err = foo.WriteAt([]byte("J"), 0)
+	c.Check(err, gc.IsNil)
+
+	for done := false; !done; {
+		done, err = rec.stepRebase(rs, file)
+		c.Check(err, gc.IsNil)
+	}
+	c.Check(rec.fsm.Links["/foo"], gc.Equals, rs.to)
+	c.Check(rec.fsm.Links, gc.HasLen, 1)
+	c.Check(rec.rebasing, gc.IsNil)
+
+	// Writes which follow the hand-off are recorded against the rebased Fnode.
+	_, err = foo.Write([]byte("!"))
+	c.Check(err, gc.IsNil)
+	<-rec.WeakBarrier().Done()
+
+	hints = rec.BuildHints()
+	c.Check(hints.LiveNodes, gc.HasLen, 1)
+	c.Check(hints.LiveNodes[0].Fnode, gc.Equals, rs.to)
+
+	var player = NewPlayer()
+	go func() { c.Check(player.Play(ctx, hints, playDir, bk), gc.IsNil) }()
+
+	player.FinishAtWriteHead()
+	<-player.Done()
+
+	expectFileContent(c, filepath.Join(playDir, "foo"), "Jello world!")
+	_, err = os.Stat(filepath.Join(playDir, "foo.rebase"))
+	c.Check(os.IsNotExist(err), gc.Equals, true)
+}
+
+func (s *RebaseSuite) TestAbandonedRebaseIsRemoved(c *gc.C) {
+	var bk, cleanup = newBrokerAndLog(c)
+	defer cleanup()
+
+	var recDir, playDir = tempDir(c), tempDir(c)
+	defer os.RemoveAll(recDir)
+	defer os.RemoveAll(playDir)
+
+	defer func(prior int) { rebaseChunkSize = prior }(rebaseChunkSize)
+	rebaseChunkSize = 2
+
+	var fsm, err = NewFSM(FSMHints{Log: aRecoveryLog})
+	c.Assert(err, gc.IsNil)
+
+	var rec = NewRecorder(fsm, anAuthor, recDir, bk)
+	var fs = RecordedAferoFS{Recorder: rec, Fs: afero.NewOsFs()}
+	c.Check(afero.WriteFile(fs, filepath.Join(recDir, "foo"), []byte("hello"), 0600), gc.IsNil)
+
+	file, err := os.Open(filepath.Join(recDir, "foo"))
+	c.Assert(err, gc.IsNil)
+	defer file.Close()
+
+	// Begin a rebase which is never completed.
+	rs, err := rec.beginRebase(rec.fsm.Links["/foo"], "/foo")
+	c.Assert(err, gc.IsNil)
+	_, err = rec.stepRebase(rs, file)
+	c.Check(err, gc.IsNil)
+	<-rec.WeakBarrier().Done()
+
+	// Play the log, and hand-off to a new Recorder.
+	var player = NewPlayer()
+	go func() { c.Check(player.Play(context.Background(), rec.BuildHints(), playDir, bk), gc.IsNil) }()
+
+	player.FinishAtWriteHead()
+	<-player.Done()
+
+	expectFileContent(c, filepath.Join(playDir, "foo.rebase"), "he")
+	rec = NewRecorder(player.FSM, anAuthor+1, playDir, bk)
+
+	// Expect Rebase removes the abandoned rebase, and rebases "foo".
+	c.Check(rec.Rebase(math.MaxInt64), gc.IsNil)
+
+	_, err = os.Stat(filepath.Join(playDir, "foo.rebase"))
+	c.Check(os.IsNotExist(err), gc.Equals, true)
+	c.Check(rec.fsm.Links, gc.HasLen, 1)
+	c.Check(rec.rebased, gc.HasLen, 1)
+
+	// Once "foo" is removed, its rebased Fnode is dropped by the next rebase.
+	c.Check(afero.WriteFile(RecordedAferoFS{Recorder: rec, Fs: afero.NewOsFs()},
+		filepath.Join(playDir, "bar"), []byte("bar"), 0600), gc.IsNil)
+	c.Check(os.Remove(filepath.Join(playDir, "foo")), gc.IsNil)
+	rec.RecordRemove(filepath.Join(playDir, "foo"))
+
+	var bar = rec.fsm.Links["/bar"]
+	c.Check(rec.RecordRebase(filepath.Join(playDir, "bar")), gc.IsNil)
+	c.Check(rec.rebased, gc.DeepEquals, map[Fnode]Fnode{bar: rec.fsm.Links["/bar"]})
+}
+
+func (s *RebaseSuite) TestRebaseOfMissingFile(c *gc.C) {
+	var bk, cleanup = newBrokerAndLog(c)
+	defer cleanup()
+
+	var recDir = tempDir(c)
+	defer os.RemoveAll(recDir)
+
+	var fsm, err = NewFSM(FSMHints{Log: aRecoveryLog})
+	c.Assert(err, gc.IsNil)
+
+	var rec = NewRecorder(fsm, anAuthor, recDir, bk)
+	var fs = RecordedAferoFS{Recorder: rec, Fs: afero.NewOsFs()}
+
+	var path = filepath.Join(recDir, "foo")
+	c.Check(afero.WriteFile(fs, path, []byte("foo"), 0600), gc.IsNil)
+	var hints = rec.BuildHints()
+
+	// Remove the file without recording it. Expect the rebase fails, and that
+	// no operations were recorded.
+	c.Check(os.Remove(path), gc.IsNil)
+	c.Check(rec.RecordRebase(path), gc.ErrorMatches, `opening rebased file /foo: .*`)
+	c.Check(rec.BuildHints(), gc.DeepEquals, hints)
+}
+
+var _ = gc.Suite(&RebaseSuite{})
@@ -6,6 +6,7 @@ package recoverylog
 import proto "github.com/gogo/protobuf/proto"
 import fmt "fmt"
 import math "math"
+import protocol "github.com/LiveRamp/gazette/v2/pkg/protocol"
 import _ "github.com/gogo/protobuf/gogoproto"
 
 import github_com_LiveRamp_gazette_v2_pkg_protocol "github.com/LiveRamp/gazette/v2/pkg/protocol"
@@ -55,7 +56,7 @@ func (m *RecordedOp) Reset()         { *m = RecordedOp{} }
 func (m *RecordedOp) String() string { return proto.CompactTextString(m) }
 func (*RecordedOp) ProtoMessage()    {}
 func (*RecordedOp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_recorded_op_1b5934276ddb646a, []int{0}
+	return fileDescriptor_recorded_op_633baa51715b3a44, []int{0}
 }
 func (m *RecordedOp) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -97,7 +98,7 @@ func (m *RecordedOp_Create) Reset()         { *m = RecordedOp_Create{} }
 func (m *RecordedOp_Create) String() string { return proto.CompactTextString(m) }
 func (*RecordedOp_Create) ProtoMessage()    {}
 func (*RecordedOp_Create) Descriptor() ([]byte, []int) {
-	return fileDescriptor_recorded_op_1b5934276ddb646a, []int{0, 0}
+	return fileDescriptor_recorded_op_633baa51715b3a44, []int{0, 0}
 }
 func (m *RecordedOp_Create) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -138,7 +139,7 @@ func (m *RecordedOp_Link) Reset()         { *m = RecordedOp_Link{} }
 func (m *RecordedOp_Link) String() string { return proto.CompactTextString(m) }
 func (*RecordedOp_Link) ProtoMessage()    {}
 func (*RecordedOp_Link) Descriptor() ([]byte, []int) {
-	return fileDescriptor_recorded_op_1b5934276ddb646a, []int{0, 1}
+	return fileDescriptor_recorded_op_633baa51715b3a44, []int{0, 1}
 }
 func (m *RecordedOp_Link) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -183,7 +184,7 @@ func (m *RecordedOp_Write) Reset()         { *m = RecordedOp_Write{} }
 func (m *RecordedOp_Write) String() string { return proto.CompactTextString(m) }
 func (*RecordedOp_Write) ProtoMessage()    {}
 func (*RecordedOp_Write) Descriptor() ([]byte, []int) {
-	return fileDescriptor_recorded_op_1b5934276ddb646a, []int{0, 2}
+	return fileDescriptor_recorded_op_633baa51715b3a44, []int{0, 2}
 }
 func (m *RecordedOp_Write) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -225,7 +226,7 @@ func (m *Property) Reset()         { *m = Property{} }
 func (m *Property) String() string { return proto.CompactTextString(m) }
 func (*Property) ProtoMessage()    {}
 func (*Property) Descriptor() ([]byte, []int) {
-	return fileDescriptor_recorded_op_1b5934276ddb646a, []int{1}
+	return fileDescriptor_recorded_op_633baa51715b3a44, []int{1}
 }
 func (m *Property) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -289,7 +290,7 @@ func (m *Segment) Reset()         { *m = Segment{} }
 func (m *Segment) String() string { return proto.CompactTextString(m) }
 func (*Segment) ProtoMessage()    {}
 func (*Segment) Descriptor() ([]byte, []int) {
-	return fileDescriptor_recorded_op_1b5934276ddb646a, []int{2}
+	return fileDescriptor_recorded_op_633baa51715b3a44, []int{2}
 }
 func (m *Segment) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -338,7 +339,7 @@ func (m *FnodeSegments) Reset()         { *m = FnodeSegments{} }
 func (m *FnodeSegments) String() string { return proto.CompactTextString(m) }
 func (*FnodeSegments) ProtoMessage()    {}
 func (*FnodeSegments) Descriptor() ([]byte, []int) {
-	return fileDescriptor_recorded_op_1b5934276ddb646a, []int{3}
+	return fileDescriptor_recorded_op_633baa51715b3a44, []int{3}
 }
 func (m *FnodeSegments) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -374,7 +375,7 @@ var xxx_messageInfo_FnodeSegments proto.InternalMessageInfo
 // a Player to resolve all possible conflicts it could encounter while reading
 // the log, to arrive at a consistent view of file state which exactly matches
 // that of the Recorder producing the FSMHints.
-// Next tag: 4.
+// Next tag: 5.
 type FSMHints struct {
 	// Log is the Journal name holding recorded log content.
 	Log github_com_LiveRamp_gazette_v2_pkg_protocol.Journal `protobuf:"bytes,1,opt,name=log,proto3,casttype=github.com/LiveRamp/gazette/v2/pkg/protocol.Journal" json:"log,omitempty"`
@@ -382,13 +383,17 @@ type FSMHints struct {
 	LiveNodes []FnodeSegments `protobuf:"bytes,2,rep,name=live_nodes,json=liveNodes" json:"live_nodes"`
 	// Property files and contents as-of the generation of these FSMHints.
 	Properties []Property `protobuf:"bytes,3,rep,name=properties" json:"properties"`
+	// Checkpoint of Fnodes which were live as-of the Checkpoint, and remain live
+	// as-of the generation of these FSMHints. Hinted Segments of LiveNodes which
+	// are also Checkpoint Files begin after the Checkpoint.
+	Checkpoint *Checkpoint `protobuf:"bytes,4,opt,name=checkpoint" json:"checkpoint,omitempty"`
 }
 
 func (m *FSMHints) Reset()         { *m = FSMHints{} }
 func (m *FSMHints) String() string { return proto.CompactTextString(m) }
 func (*FSMHints) ProtoMessage()    {}
 func (*FSMHints) Descriptor() ([]byte, []int) {
-	return fileDescriptor_recorded_op_1b5934276ddb646a, []int{4}
+	return fileDescriptor_recorded_op_633baa51715b3a44, []int{4}
 }
 func (m *FSMHints) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -417,6 +422,197 @@ func (m *FSMHints) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_FSMHints proto.InternalMessageInfo
 
+// Checkpoint is a snapshot of live Fnodes of a recorded file-system as-of a
+// specific RecordedOp of the log, with Fnode content persisted to a fragment
+// store. A Player restores Checkpoint Files from the fragment store, and then
+// reads only RecordedOps of the log which follow the Checkpoint.
+type Checkpoint struct {
+	// Sequence number and checksum of the first RecordedOp which is not
+	// reflected by the Checkpoint.
+	NextSeqNo    int64  `protobuf:"varint,1,opt,name=next_seq_no,json=nextSeqNo,proto3" json:"next_seq_no,omitempty"`
+	NextChecksum uint32 `protobuf:"fixed32,2,opt,name=next_checksum,json=nextChecksum,proto3" json:"next_checksum,omitempty"`
+	// Lower-bound log offset of the RecordedOp having |next_seq_no|.
+	LogOffset int64 `protobuf:"varint,3,opt,name=log_offset,json=logOffset,proto3" json:"log_offset,omitempty"`
+	// Files of the Checkpoint, ordered on ascending Fnode.
+	Files []Checkpoint_File `protobuf:"bytes,4,rep,name=files" json:"files"`
+}
+
+func (m *Checkpoint) Reset()         { *m = Checkpoint{} }
+func (m *Checkpoint) String() string { return proto.CompactTextString(m) }
+func (*Checkpoint) ProtoMessage()    {}
+func (*Checkpoint) Descriptor() ([]byte, []int) {
+	return fileDescriptor_recorded_op_633baa51715b3a44, []int{5}
+}
+func (m *Checkpoint) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Checkpoint) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Checkpoint.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *Checkpoint) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Checkpoint.Merge(dst, src)
+}
+func (m *Checkpoint) XXX_Size() int {
+	return m.ProtoSize()
+}
+func (m *Checkpoint) XXX_DiscardUnknown() {
+	xxx_messageInfo_Checkpoint.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Checkpoint proto.InternalMessageInfo
+
+// File is a checkpointed Fnode.
+type Checkpoint_File struct {
+	// Fnode of the File.
+	Fnode Fnode `protobuf:"varint,1,opt,name=fnode,proto3,casttype=Fnode" json:"fnode,omitempty"`
+	// Filesystem paths linked to the Fnode as-of the Checkpoint, relative to
+	// the common base directory.
+	Links []string `protobuf:"bytes,2,rep,name=links" json:"links,omitempty"`
+	// Content of the Fnode as-of the Checkpoint, persisted to a fragment store.
+	// Content may also reflect writes which follow the Checkpoint, which are
+	// then re-applied during playback.
+	Content protocol.Fragment `protobuf:"bytes,3,opt,name=content" json:"content"`
+}
+
+func (m *Checkpoint_File) Reset()         { *m = Checkpoint_File{} }
+func (m *Checkpoint_File) String() string { return proto.CompactTextString(m) }
+func (*Checkpoint_File) ProtoMessage()    {}
+func (*Checkpoint_File) Descriptor() ([]byte, []int) {
+	return fileDescriptor_recorded_op_633baa51715b3a44, []int{5, 0}
+}
+func (m *Checkpoint_File) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Checkpoint_File) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Checkpoint_File.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *Checkpoint_File) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Checkpoint_File.Merge(dst, src)
+}
+func (m *Checkpoint_File) XXX_Size() int {
+	return m.ProtoSize()
+}
+func (m *Checkpoint_File) XXX_DiscardUnknown() {
+	xxx_messageInfo_Checkpoint_File.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Checkpoint_File proto.InternalMessageInfo
+
+// LocalState is a snapshot of a recovery log played back into a local
+// directory, which is persisted within that directory. A Player of the
+// directory may verify and resume from the LocalState, rather than playing
+// back the log from scratch.
+type LocalState struct {
+	// Hints of the played back FSM as-of the LocalState.
+	Hints FSMHints `protobuf:"bytes,1,opt,name=hints" json:"hints"`
+	// Sequence number and checksum of the next RecordedOp to apply.
+	NextSeqNo    int64  `protobuf:"varint,2,opt,name=next_seq_no,json=nextSeqNo,proto3" json:"next_seq_no,omitempty"`
+	NextChecksum uint32 `protobuf:"fixed32,3,opt,name=next_checksum,json=nextChecksum,proto3" json:"next_checksum,omitempty"`
+	// Log offset through which all RecordedOps have been read.
+	LogOffset int64 `protobuf:"varint,4,opt,name=log_offset,json=logOffset,proto3" json:"log_offset,omitempty"`
+	// Ordered Segments of all RecordedOps which were applied to the FSM,
+	// including those of Fnodes which are no longer live. History is used to
+	// verify that the LocalState is consistent with a future FSMHints.
+	History []Segment `protobuf:"bytes,5,rep,name=history" json:"history"`
+	// Files of the LocalState, ordered on ascending Fnode.
+	Files []LocalState_File `protobuf:"bytes,6,rep,name=files" json:"files"`
+}
+
+func (m *LocalState) Reset()         { *m = LocalState{} }
+func (m *LocalState) String() string { return proto.CompactTextString(m) }
+func (*LocalState) ProtoMessage()    {}
+func (*LocalState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_recorded_op_633baa51715b3a44, []int{6}
+}
+func (m *LocalState) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LocalState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LocalState.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *LocalState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LocalState.Merge(dst, src)
+}
+func (m *LocalState) XXX_Size() int {
+	return m.ProtoSize()
+}
+func (m *LocalState) XXX_DiscardUnknown() {
+	xxx_messageInfo_LocalState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LocalState proto.InternalMessageInfo
+
+// File is a live Fnode of the LocalState.
+type LocalState_File struct {
+	// Fnode of the File.
+	Fnode Fnode `protobuf:"varint,1,opt,name=fnode,proto3,casttype=Fnode" json:"fnode,omitempty"`
+	// Filesystem paths linked to the Fnode, relative to the common base directory.
+	Links []string `protobuf:"bytes,2,rep,name=links" json:"links,omitempty"`
+	// Size and SHA1 sum of the local content of the Fnode.
+	Size int64            `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	Sum  protocol.SHA1Sum `protobuf:"bytes,4,opt,name=sum" json:"sum"`
+}
+
+func (m *LocalState_File) Reset()         { *m = LocalState_File{} }
+func (m *LocalState_File) String() string { return proto.CompactTextString(m) }
+func (*LocalState_File) ProtoMessage()    {}
+func (*LocalState_File) Descriptor() ([]byte, []int) {
+	return fileDescriptor_recorded_op_633baa51715b3a44, []int{6, 0}
+}
+func (m *LocalState_File) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LocalState_File) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LocalState_File.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *LocalState_File) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LocalState_File.Merge(dst, src)
+}
+func (m *LocalState_File) XXX_Size() int {
+	return m.ProtoSize()
+}
+func (m *LocalState_File) XXX_DiscardUnknown() {
+	xxx_messageInfo_LocalState_File.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LocalState_File proto.InternalMessageInfo
+
 func init() {
 	proto.RegisterType((*RecordedOp)(nil), "recoverylog.RecordedOp")
 	proto.RegisterType((*RecordedOp_Create)(nil), "recoverylog.RecordedOp.Create")
@@ -426,6 +622,10 @@ func init() {
 	proto.RegisterType((*Segment)(nil), "recoverylog.Segment")
 	proto.RegisterType((*FnodeSegments)(nil), "recoverylog.FnodeSegments")
 	proto.RegisterType((*FSMHints)(nil), "recoverylog.FSMHints")
+	proto.RegisterType((*Checkpoint)(nil), "recoverylog.Checkpoint")
+	proto.RegisterType((*Checkpoint_File)(nil), "recoverylog.Checkpoint.File")
+	proto.RegisterType((*LocalState)(nil), "recoverylog.LocalState")
+	proto.RegisterType((*LocalState_File)(nil), "recoverylog.LocalState.File")
 }
 func (m *RecordedOp) Marshal() (dAtA []byte, err error) {
 	size := m.ProtoSize()
@@ -768,6 +968,225 @@ func (m *FSMHints) MarshalTo(dAtA []byte) (int, error) {
 			i += n
 		}
 	}
+	if m.Checkpoint != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintRecordedOp(dAtA, i, uint64(m.Checkpoint.ProtoSize()))
+		n6, err := m.Checkpoint.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n6
+	}
+	return i, nil
+}
+
+func (m *Checkpoint) Marshal() (dAtA []byte, err error) {
+	size := m.ProtoSize()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Checkpoint) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.NextSeqNo != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintRecordedOp(dAtA, i, uint64(m.NextSeqNo))
+	}
+	if m.NextChecksum != 0 {
+		dAtA[i] = 0x15
+		i++
+		encoding_binary.LittleEndian.PutUint32(dAtA[i:], uint32(m.NextChecksum))
+		i += 4
+	}
+	if m.LogOffset != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintRecordedOp(dAtA, i, uint64(m.LogOffset))
+	}
+	if len(m.Files) > 0 {
+		for _, msg := range m.Files {
+			dAtA[i] = 0x22
+			i++
+			i = encodeVarintRecordedOp(dAtA, i, uint64(msg.ProtoSize()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *Checkpoint_File) Marshal() (dAtA []byte, err error) {
+	size := m.ProtoSize()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Checkpoint_File) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Fnode != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintRecordedOp(dAtA, i, uint64(m.Fnode))
+	}
+	if len(m.Links) > 0 {
+		for _, s := range m.Links {
+			dAtA[i] = 0x12
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	dAtA[i] = 0x1a
+	i++
+	i = encodeVarintRecordedOp(dAtA, i, uint64(m.Content.ProtoSize()))
+	n7, err := m.Content.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n7
+	return i, nil
+}
+
+func (m *LocalState) Marshal() (dAtA []byte, err error) {
+	size := m.ProtoSize()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LocalState) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	dAtA[i] = 0xa
+	i++
+	i = encodeVarintRecordedOp(dAtA, i, uint64(m.Hints.ProtoSize()))
+	n8, err := m.Hints.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n8
+	if m.NextSeqNo != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintRecordedOp(dAtA, i, uint64(m.NextSeqNo))
+	}
+	if m.NextChecksum != 0 {
+		dAtA[i] = 0x1d
+		i++
+		encoding_binary.LittleEndian.PutUint32(dAtA[i:], uint32(m.NextChecksum))
+		i += 4
+	}
+	if m.LogOffset != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintRecordedOp(dAtA, i, uint64(m.LogOffset))
+	}
+	if len(m.History) > 0 {
+		for _, msg := range m.History {
+			dAtA[i] = 0x2a
+			i++
+			i = encodeVarintRecordedOp(dAtA, i, uint64(msg.ProtoSize()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.Files) > 0 {
+		for _, msg := range m.Files {
+			dAtA[i] = 0x32
+			i++
+			i = encodeVarintRecordedOp(dAtA, i, uint64(msg.ProtoSize()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *LocalState_File) Marshal() (dAtA []byte, err error) {
+	size := m.ProtoSize()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LocalState_File) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Fnode != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintRecordedOp(dAtA, i, uint64(m.Fnode))
+	}
+	if len(m.Links) > 0 {
+		for _, s := range m.Links {
+			dAtA[i] = 0x12
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if m.Size != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintRecordedOp(dAtA, i, uint64(m.Size))
+	}
+	dAtA[i] = 0x22
+	i++
+	i = encodeVarintRecordedOp(dAtA, i, uint64(m.Sum.ProtoSize()))
+	n9, err := m.Sum.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n9
 	return i, nil
 }
 
@@ -955,23 +1374,126 @@ func (m *FSMHints) ProtoSize() (n int) {
 			n += 1 + l + sovRecordedOp(uint64(l))
 		}
 	}
+	if m.Checkpoint != nil {
+		l = m.Checkpoint.ProtoSize()
+		n += 1 + l + sovRecordedOp(uint64(l))
+	}
 	return n
 }
 
-func sovRecordedOp(x uint64) (n int) {
-	for {
-		n++
-		x >>= 7
-		if x == 0 {
-			break
+func (m *Checkpoint) ProtoSize() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.NextSeqNo != 0 {
+		n += 1 + sovRecordedOp(uint64(m.NextSeqNo))
+	}
+	if m.NextChecksum != 0 {
+		n += 5
+	}
+	if m.LogOffset != 0 {
+		n += 1 + sovRecordedOp(uint64(m.LogOffset))
+	}
+	if len(m.Files) > 0 {
+		for _, e := range m.Files {
+			l = e.ProtoSize()
+			n += 1 + l + sovRecordedOp(uint64(l))
 		}
 	}
 	return n
 }
-func sozRecordedOp(x uint64) (n int) {
-	return sovRecordedOp(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+
+func (m *Checkpoint_File) ProtoSize() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Fnode != 0 {
+		n += 1 + sovRecordedOp(uint64(m.Fnode))
+	}
+	if len(m.Links) > 0 {
+		for _, s := range m.Links {
+			l = len(s)
+			n += 1 + l + sovRecordedOp(uint64(l))
+		}
+	}
+	l = m.Content.ProtoSize()
+	n += 1 + l + sovRecordedOp(uint64(l))
+	return n
 }
-func (m *RecordedOp) Unmarshal(dAtA []byte) error {
+
+func (m *LocalState) ProtoSize() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Hints.ProtoSize()
+	n += 1 + l + sovRecordedOp(uint64(l))
+	if m.NextSeqNo != 0 {
+		n += 1 + sovRecordedOp(uint64(m.NextSeqNo))
+	}
+	if m.NextChecksum != 0 {
+		n += 5
+	}
+	if m.LogOffset != 0 {
+		n += 1 + sovRecordedOp(uint64(m.LogOffset))
+	}
+	if len(m.History) > 0 {
+		for _, e := range m.History {
+			l = e.ProtoSize()
+			n += 1 + l + sovRecordedOp(uint64(l))
+		}
+	}
+	if len(m.Files) > 0 {
+		for _, e := range m.Files {
+			l = e.ProtoSize()
+			n += 1 + l + sovRecordedOp(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *LocalState_File) ProtoSize() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Fnode != 0 {
+		n += 1 + sovRecordedOp(uint64(m.Fnode))
+	}
+	if len(m.Links) > 0 {
+		for _, s := range m.Links {
+			l = len(s)
+			n += 1 + l + sovRecordedOp(uint64(l))
+		}
+	}
+	if m.Size != 0 {
+		n += 1 + sovRecordedOp(uint64(m.Size))
+	}
+	l = m.Sum.ProtoSize()
+	n += 1 + l + sovRecordedOp(uint64(l))
+	return n
+}
+
+func sovRecordedOp(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozRecordedOp(x uint64) (n int) {
+	return sovRecordedOp(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *RecordedOp) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2021,6 +2543,633 @@ func (m *FSMHints) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Checkpoint", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRecordedOp
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRecordedOp
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Checkpoint == nil {
+				m.Checkpoint = &Checkpoint{}
+			}
+			if err := m.Checkpoint.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRecordedOp(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRecordedOp
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Checkpoint) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRecordedOp
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Checkpoint: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Checkpoint: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NextSeqNo", wireType)
+			}
+			m.NextSeqNo = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRecordedOp
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NextSeqNo |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 5 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NextChecksum", wireType)
+			}
+			m.NextChecksum = 0
+			if (iNdEx + 4) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NextChecksum = uint32(encoding_binary.LittleEndian.Uint32(dAtA[iNdEx:]))
+			iNdEx += 4
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LogOffset", wireType)
+			}
+			m.LogOffset = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRecordedOp
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LogOffset |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Files", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRecordedOp
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRecordedOp
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Files = append(m.Files, Checkpoint_File{})
+			if err := m.Files[len(m.Files)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRecordedOp(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRecordedOp
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Checkpoint_File) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRecordedOp
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: File: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: File: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Fnode", wireType)
+			}
+			m.Fnode = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRecordedOp
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Fnode |= (Fnode(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Links", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRecordedOp
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRecordedOp
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Links = append(m.Links, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Content", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRecordedOp
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRecordedOp
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Content.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRecordedOp(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRecordedOp
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LocalState) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRecordedOp
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LocalState: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LocalState: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hints", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRecordedOp
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRecordedOp
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Hints.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NextSeqNo", wireType)
+			}
+			m.NextSeqNo = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRecordedOp
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NextSeqNo |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 5 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NextChecksum", wireType)
+			}
+			m.NextChecksum = 0
+			if (iNdEx + 4) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NextChecksum = uint32(encoding_binary.LittleEndian.Uint32(dAtA[iNdEx:]))
+			iNdEx += 4
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LogOffset", wireType)
+			}
+			m.LogOffset = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRecordedOp
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LogOffset |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field History", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRecordedOp
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRecordedOp
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.History = append(m.History, Segment{})
+			if err := m.History[len(m.History)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Files", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRecordedOp
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRecordedOp
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Files = append(m.Files, LocalState_File{})
+			if err := m.Files[len(m.Files)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRecordedOp(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRecordedOp
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LocalState_File) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRecordedOp
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: File: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: File: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Fnode", wireType)
+			}
+			m.Fnode = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRecordedOp
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Fnode |= (Fnode(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Links", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRecordedOp
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRecordedOp
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Links = append(m.Links, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Size", wireType)
+			}
+			m.Size = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRecordedOp
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Size |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sum", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRecordedOp
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRecordedOp
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Sum.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRecordedOp(dAtA[iNdEx:])
@@ -2147,50 +3296,65 @@ var (
 	ErrIntOverflowRecordedOp   = fmt.Errorf("proto: integer overflow")
 )
 
-func init() { proto.RegisterFile("recorded_op.proto", fileDescriptor_recorded_op_1b5934276ddb646a) }
-
-var fileDescriptor_recorded_op_1b5934276ddb646a = []byte{
-	// 667 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0x4d, 0x6f, 0xd3, 0x4c,
-	0x10, 0x8e, 0xe3, 0x8f, 0x24, 0x93, 0xb7, 0xaf, 0xf4, 0xae, 0xde, 0x22, 0x2b, 0x2a, 0x76, 0x88,
-	0x84, 0x94, 0x0b, 0x09, 0xb4, 0xa8, 0x48, 0x20, 0x81, 0x9a, 0x4a, 0x15, 0xa0, 0xd2, 0xa2, 0xed,
-	0x81, 0x1b, 0x91, 0xeb, 0x6e, 0x1c, 0x2b, 0x8e, 0xd7, 0xb5, 0x37, 0x41, 0xe5, 0x57, 0xf0, 0x13,
-	0xe0, 0xdf, 0xf4, 0xd8, 0x23, 0x5c, 0x22, 0x68, 0xfe, 0x45, 0xc5, 0x01, 0x79, 0x76, 0x93, 0x26,
-	0xfd, 0x50, 0xb9, 0x44, 0x99, 0x67, 0x9f, 0x67, 0x76, 0x66, 0x9f, 0x19, 0xc3, 0x7f, 0x29, 0xf3,
-	0x79, 0x7a, 0xc4, 0x8e, 0xba, 0x3c, 0x69, 0x25, 0x29, 0x17, 0x9c, 0x54, 0x73, 0x68, 0xcc, 0xd2,
-	0x93, 0x88, 0x07, 0xb5, 0x47, 0x41, 0x28, 0xfa, 0xa3, 0xc3, 0x96, 0xcf, 0x87, 0xed, 0x80, 0x07,
-	0xbc, 0x8d, 0x9c, 0xc3, 0x51, 0x0f, 0x23, 0x0c, 0xf0, 0x9f, 0xd4, 0x36, 0x7e, 0x1b, 0x00, 0x54,
-	0x65, 0xdc, 0x4f, 0xc8, 0x2a, 0x58, 0x19, 0x3b, 0xee, 0xc6, 0xdc, 0xd6, 0xea, 0x5a, 0x53, 0xa7,
-	0x66, 0xc6, 0x8e, 0xf7, 0x38, 0xa9, 0x41, 0xd9, 0xef, 0x33, 0x7f, 0x90, 0x8d, 0x86, 0x76, 0xb1,
-	0xae, 0x35, 0x4b, 0x74, 0x1e, 0x93, 0x06, 0x58, 0xde, 0x48, 0xf4, 0x79, 0x6a, 0xeb, 0xf9, 0x49,
-	0x07, 0x2e, 0x26, 0xae, 0xb5, 0x85, 0x08, 0x55, 0x27, 0x64, 0x13, 0x2c, 0x3f, 0x65, 0x9e, 0x60,
-	0xb6, 0x51, 0xd7, 0x9a, 0xd5, 0x75, 0xa7, 0xb5, 0x50, 0x72, 0xeb, 0xf2, 0xfe, 0xd6, 0x36, 0xb2,
-	0xa8, 0x62, 0x93, 0xc7, 0x60, 0x44, 0x61, 0x3c, 0xb0, 0x4d, 0x54, 0xad, 0xdd, 0xa6, 0xda, 0x0d,
-	0xe3, 0x01, 0x45, 0x26, 0x79, 0x0a, 0xd6, 0x28, 0x46, 0x8d, 0xf5, 0x17, 0x1a, 0xc5, 0x25, 0x1b,
-	0x60, 0x7e, 0x4a, 0x43, 0xc1, 0xec, 0x12, 0x8a, 0xee, 0xdf, 0x26, 0xfa, 0x90, 0x93, 0xa8, 0xe4,
-	0x92, 0x27, 0x50, 0x4e, 0x52, 0x9e, 0xb0, 0x54, 0x9c, 0xd8, 0x65, 0xd4, 0xad, 0x2e, 0xe9, 0xde,
-	0xab, 0x43, 0x3a, 0xa7, 0x91, 0x07, 0xf0, 0x4f, 0x2f, 0x4c, 0x33, 0xd1, 0xe5, 0xbd, 0x5e, 0xc6,
-	0x84, 0x5d, 0xc1, 0x47, 0xae, 0x22, 0xb6, 0x8f, 0x10, 0x71, 0xa1, 0x1a, 0x79, 0x97, 0x0c, 0x40,
-	0x06, 0xe4, 0x90, 0x24, 0xd4, 0x1a, 0x60, 0xc9, 0x57, 0x22, 0x04, 0x8c, 0xc4, 0x13, 0x7d, 0xb4,
-	0xaa, 0x42, 0xf1, 0xff, 0x73, 0xe3, 0xec, 0x9b, 0x5b, 0xa8, 0x6d, 0x81, 0x91, 0xf7, 0x47, 0x5c,
-	0x30, 0x7b, 0x31, 0x3f, 0x62, 0xd2, 0xcd, 0x4e, 0xe5, 0x62, 0xe2, 0x9a, 0x3b, 0x39, 0x40, 0x25,
-	0x3e, 0x4f, 0x51, 0xbc, 0x96, 0xe2, 0x23, 0x98, 0xd8, 0xed, 0xdd, 0x39, 0xee, 0x81, 0xa5, 0x8a,
-	0x2d, 0x62, 0xb1, 0x2a, 0xca, 0xf1, 0x88, 0xc5, 0x81, 0xe8, 0xe3, 0x60, 0xe8, 0x54, 0x45, 0x32,
-	0xbf, 0xfc, 0x6d, 0xbc, 0x84, 0xf2, 0xec, 0x99, 0x6e, 0x6a, 0x87, 0xd8, 0x50, 0xf2, 0x79, 0x2c,
-	0x58, 0x2c, 0x54, 0x89, 0xb3, 0x50, 0xe9, 0x7f, 0x6a, 0x50, 0x3a, 0x60, 0xc1, 0x90, 0xc5, 0x62,
-	0x61, 0x10, 0xb5, 0x5b, 0x07, 0xb1, 0x3e, 0x33, 0x40, 0x4d, 0xb9, 0xac, 0x18, 0x10, 0x3b, 0xc0,
-	0x51, 0xbf, 0x6a, 0x91, 0x7e, 0xdd, 0xa2, 0x87, 0xf0, 0xaf, 0xa4, 0xcc, 0x77, 0xc2, 0xc0, 0x9d,
-	0x58, 0x41, 0x74, 0x7b, 0xb6, 0x18, 0x8e, 0x72, 0x52, 0x5d, 0x65, 0x62, 0xa2, 0x4a, 0x0e, 0xc9,
-	0x9b, 0xae, 0x38, 0x6d, 0x5d, 0x75, 0x5a, 0xb5, 0x18, 0xc3, 0x0a, 0x3e, 0xb7, 0x6a, 0x33, 0xbb,
-	0xdb, 0x90, 0x4d, 0x28, 0x67, 0x8a, 0x6c, 0x17, 0xeb, 0x7a, 0xb3, 0xba, 0xfe, 0xff, 0xd2, 0x60,
-	0xaa, 0x4c, 0x1d, 0xe3, 0x74, 0xe2, 0x16, 0xe8, 0x9c, 0xab, 0xee, 0xfb, 0xa1, 0x41, 0x79, 0xe7,
-	0xe0, 0xdd, 0xeb, 0x30, 0xbf, 0xeb, 0x0d, 0xe8, 0x11, 0x0f, 0xa4, 0x25, 0x9d, 0x67, 0x17, 0x13,
-	0x77, 0x63, 0xe1, 0xf3, 0xb2, 0x1b, 0x8e, 0x19, 0xf5, 0x86, 0x49, 0x3b, 0xf0, 0x3e, 0x33, 0x21,
-	0x58, 0x7b, 0xbc, 0xde, 0x4e, 0x06, 0x81, 0xfc, 0xe2, 0xf8, 0x3c, 0x6a, 0xbd, 0xe5, 0xa3, 0x34,
-	0xf6, 0x22, 0x9a, 0xe7, 0x20, 0xaf, 0x00, 0xa2, 0x70, 0xcc, 0xba, 0x79, 0x89, 0xb3, 0xba, 0x6a,
-	0x4b, 0x75, 0x2d, 0xb5, 0xa9, 0xaa, 0xab, 0xe4, 0x9a, 0xbd, 0x5c, 0x42, 0x5e, 0x00, 0xa8, 0x45,
-	0x0a, 0x59, 0x66, 0xeb, 0x98, 0xe0, 0xe6, 0x8d, 0x53, 0xda, 0x05, 0xba, 0xec, 0xad, 0xb3, 0x76,
-	0xfa, 0xcb, 0x29, 0x9c, 0x9e, 0x3b, 0xda, 0xd9, 0xb9, 0xa3, 0x7d, 0x99, 0x3a, 0x85, 0xaf, 0x53,
-	0x47, 0x3b, 0x9b, 0x3a, 0x85, 0xef, 0x53, 0xa7, 0x70, 0x68, 0x61, 0xe1, 0x1b, 0x7f, 0x02, 0x00,
-	0x00, 0xff, 0xff, 0xa5, 0x42, 0x9b, 0xff, 0x63, 0x05, 0x00, 0x00,
+func init() { proto.RegisterFile("recorded_op.proto", fileDescriptor_recorded_op_633baa51715b3a44) }
+
+var fileDescriptor_recorded_op_633baa51715b3a44 = []byte{
+	// 898 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0xcd, 0x6e, 0xdb, 0x46,
+	0x10, 0x16, 0xc5, 0x1f, 0x49, 0xa3, 0xb8, 0x40, 0x16, 0x49, 0x4b, 0x08, 0x09, 0xa5, 0xba, 0x28,
+	0xe0, 0x1e, 0x2a, 0x35, 0x72, 0x90, 0x14, 0x29, 0xd0, 0xc2, 0x32, 0x60, 0xa4, 0x85, 0x9b, 0x14,
+	0xab, 0x43, 0x6f, 0x35, 0x68, 0x7a, 0x45, 0x11, 0xa6, 0xb8, 0x0c, 0xb9, 0x72, 0xea, 0x1c, 0xfb,
+	0x04, 0x45, 0x1f, 0xa0, 0x68, 0x8f, 0x7d, 0x13, 0x1f, 0x7d, 0xec, 0xc9, 0x68, 0xe3, 0xb7, 0x30,
+	0x7a, 0x28, 0x76, 0x76, 0x49, 0x4a, 0xb2, 0x0d, 0xb5, 0xc8, 0x85, 0xe0, 0xce, 0x7e, 0xdf, 0xec,
+	0xcc, 0x7c, 0x33, 0x03, 0x77, 0x33, 0x16, 0xf0, 0xec, 0x88, 0x1d, 0x1d, 0xf0, 0xb4, 0x9f, 0x66,
+	0x5c, 0x70, 0xd2, 0x96, 0xa6, 0x13, 0x96, 0x9d, 0xc6, 0x3c, 0xec, 0x3c, 0x0b, 0x23, 0x31, 0x9d,
+	0x1f, 0xf6, 0x03, 0x3e, 0x1b, 0xec, 0x47, 0x27, 0x8c, 0xfa, 0xb3, 0x74, 0x10, 0xfa, 0x6f, 0x98,
+	0x10, 0x6c, 0x70, 0x32, 0x1c, 0xa4, 0xc7, 0xe1, 0x00, 0x69, 0x01, 0x8f, 0xcb, 0x1f, 0xe5, 0xa8,
+	0xf3, 0xe9, 0x02, 0x37, 0xe4, 0x21, 0x57, 0xf7, 0x87, 0xf3, 0x09, 0x9e, 0xf0, 0x80, 0x7f, 0x0a,
+	0xbe, 0xf9, 0x8f, 0x05, 0x40, 0x75, 0x34, 0x2f, 0x53, 0x72, 0x1f, 0x9c, 0x9c, 0xbd, 0x3a, 0x48,
+	0xb8, 0x6b, 0xf4, 0x8c, 0x2d, 0x93, 0xda, 0x39, 0x7b, 0xf5, 0x82, 0x93, 0x0e, 0x34, 0x83, 0x29,
+	0x0b, 0x8e, 0xf3, 0xf9, 0xcc, 0xad, 0xf7, 0x8c, 0xad, 0x06, 0x2d, 0xcf, 0x64, 0x13, 0x1c, 0x7f,
+	0x2e, 0xa6, 0x3c, 0x73, 0x4d, 0x79, 0x33, 0x82, 0xab, 0x8b, 0xae, 0xb3, 0x83, 0x16, 0xaa, 0x6f,
+	0xc8, 0x13, 0x70, 0x82, 0x8c, 0xf9, 0x82, 0xb9, 0x56, 0xcf, 0xd8, 0x6a, 0x0f, 0xbd, 0xfe, 0x42,
+	0xba, 0xfd, 0xea, 0xfd, 0xfe, 0x2e, 0xa2, 0xa8, 0x46, 0x93, 0xcf, 0xc0, 0x8a, 0xa3, 0xe4, 0xd8,
+	0xb5, 0x91, 0xf5, 0xe0, 0x36, 0xd6, 0x7e, 0x94, 0x1c, 0x53, 0x44, 0x92, 0xc7, 0xe0, 0xcc, 0x13,
+	0xe4, 0x38, 0xff, 0x81, 0xa3, 0xb1, 0x64, 0x1b, 0xec, 0xd7, 0x59, 0x24, 0x98, 0xdb, 0x40, 0xd2,
+	0xc3, 0xdb, 0x48, 0xdf, 0x4b, 0x10, 0x55, 0x58, 0xf2, 0x08, 0x9a, 0x69, 0xc6, 0x53, 0x96, 0x89,
+	0x53, 0xb7, 0x89, 0xbc, 0xfb, 0x4b, 0xbc, 0xef, 0xf4, 0x25, 0x2d, 0x61, 0xe4, 0x43, 0xb8, 0x33,
+	0x89, 0xb2, 0x5c, 0x1c, 0xf0, 0xc9, 0x24, 0x67, 0xc2, 0x6d, 0x61, 0x91, 0xdb, 0x68, 0x7b, 0x89,
+	0x26, 0xd2, 0x85, 0x76, 0xec, 0x57, 0x08, 0x40, 0x04, 0xc4, 0x7e, 0x01, 0xe8, 0x6c, 0x82, 0xa3,
+	0xaa, 0x44, 0x08, 0x58, 0xa9, 0x2f, 0xa6, 0x28, 0x55, 0x8b, 0xe2, 0xff, 0x33, 0xeb, 0xfc, 0xf7,
+	0x6e, 0xad, 0xb3, 0x03, 0x96, 0xcc, 0x8f, 0x74, 0xc1, 0x9e, 0x24, 0xfc, 0x88, 0x29, 0x35, 0x47,
+	0xad, 0xab, 0x8b, 0xae, 0xbd, 0x27, 0x0d, 0x54, 0xd9, 0x4b, 0x17, 0xf5, 0x6b, 0x2e, 0x7e, 0x00,
+	0x1b, 0xb3, 0x5d, 0xef, 0xe3, 0x7d, 0x70, 0x74, 0xb0, 0x75, 0x0c, 0x56, 0x9f, 0xa4, 0x3d, 0x66,
+	0x49, 0x28, 0xa6, 0xd8, 0x18, 0x26, 0xd5, 0x27, 0xe5, 0x5f, 0x7d, 0x37, 0xbf, 0x84, 0x66, 0x51,
+	0xa6, 0x9b, 0xd2, 0x21, 0x2e, 0x34, 0x02, 0x9e, 0x08, 0x96, 0x08, 0x1d, 0x62, 0x71, 0xd4, 0xfc,
+	0xbf, 0x0c, 0x68, 0x8c, 0x59, 0x38, 0x63, 0x89, 0x58, 0x68, 0x44, 0xe3, 0xd6, 0x46, 0xec, 0x15,
+	0x02, 0xe8, 0x2e, 0x57, 0x11, 0x03, 0xda, 0xc6, 0xd8, 0xea, 0xab, 0x12, 0x99, 0xd7, 0x25, 0xfa,
+	0x18, 0xde, 0x53, 0x90, 0x72, 0x26, 0x2c, 0x9c, 0x89, 0x0d, 0xb4, 0xee, 0x6a, 0x23, 0xf1, 0xb4,
+	0x92, 0xfa, 0x29, 0x1b, 0x1d, 0xb5, 0x62, 0xbf, 0x78, 0x69, 0x45, 0x69, 0x67, 0x55, 0x69, 0x9d,
+	0x62, 0x02, 0x1b, 0x58, 0x6e, 0x9d, 0x66, 0xbe, 0x5e, 0x90, 0x27, 0xd0, 0xcc, 0x35, 0xd8, 0xad,
+	0xf7, 0xcc, 0xad, 0xf6, 0xf0, 0xde, 0x52, 0x63, 0x6a, 0x4f, 0x23, 0xeb, 0xec, 0xa2, 0x5b, 0xa3,
+	0x25, 0x56, 0xbf, 0xf7, 0x4b, 0x1d, 0x9a, 0x7b, 0xe3, 0x6f, 0x9f, 0x47, 0xf2, 0xad, 0xaf, 0xc1,
+	0x8c, 0x79, 0xa8, 0x24, 0x19, 0x3d, 0xbd, 0xba, 0xe8, 0x6e, 0xff, 0x8f, 0xd5, 0xd4, 0xff, 0x86,
+	0xcf, 0xb3, 0xc4, 0x8f, 0xa9, 0xf4, 0x41, 0xbe, 0x02, 0x88, 0xa3, 0x13, 0x76, 0x20, 0x43, 0x2c,
+	0xe2, 0xea, 0x2c, 0xc5, 0xb5, 0x94, 0xa6, 0x8e, 0xae, 0x25, 0x39, 0x2f, 0x24, 0x85, 0x7c, 0x01,
+	0xa0, 0x07, 0x29, 0x62, 0xb9, 0x6b, 0xf6, 0xcc, 0x5b, 0x27, 0x4e, 0x73, 0x17, 0xe0, 0xe4, 0x29,
+	0x00, 0xaa, 0x95, 0xf2, 0x28, 0x11, 0x7a, 0x0b, 0x7d, 0xb0, 0x44, 0xde, 0x2d, 0xaf, 0xe9, 0x02,
+	0x54, 0x17, 0xe5, 0x8f, 0x3a, 0x40, 0x05, 0x90, 0xd2, 0x26, 0xec, 0xc7, 0x52, 0x5a, 0xb5, 0x2b,
+	0x5b, 0xd2, 0xa4, 0xa4, 0xfd, 0x08, 0x36, 0xf0, 0x7e, 0x65, 0x69, 0xde, 0x91, 0xc6, 0xb2, 0x3f,
+	0x1e, 0x02, 0xc4, 0x3c, 0x5c, 0xee, 0xb3, 0x56, 0xcc, 0x43, 0xdd, 0x65, 0x9f, 0x83, 0x3d, 0x89,
+	0x62, 0x96, 0xbb, 0x56, 0xcf, 0xbc, 0xb6, 0xc8, 0xaa, 0x58, 0xfa, 0x7b, 0x51, 0xcc, 0x74, 0xc2,
+	0x8a, 0xd0, 0x79, 0x0d, 0x96, 0x34, 0xae, 0x6f, 0x94, 0x7b, 0x60, 0xcb, 0xf5, 0xa7, 0xd4, 0x68,
+	0x51, 0x75, 0x20, 0xc3, 0x6a, 0xe6, 0x4c, 0xac, 0x13, 0xe9, 0x97, 0x8a, 0xee, 0x65, 0xfe, 0x62,
+	0xef, 0x2c, 0x4f, 0xa3, 0xae, 0xd5, 0xaf, 0x26, 0xc0, 0x3e, 0x0f, 0xfc, 0x78, 0x2c, 0x7c, 0x5c,
+	0x93, 0xf6, 0x54, 0xf6, 0x12, 0x46, 0xb1, 0xaa, 0x58, 0xd1, 0x68, 0x45, 0x02, 0x88, 0x5c, 0x2d,
+	0x6f, 0x7d, 0x6d, 0x79, 0xcd, 0xb5, 0xe5, 0xb5, 0x56, 0xcb, 0xfb, 0x18, 0x1a, 0xd3, 0x28, 0x17,
+	0x3c, 0x3b, 0x75, 0xed, 0xb5, 0x33, 0x52, 0x40, 0x2b, 0x51, 0x9c, 0x1b, 0x44, 0xa9, 0x92, 0xbe,
+	0x41, 0x94, 0x9f, 0x8c, 0x77, 0x53, 0x85, 0x80, 0x95, 0x47, 0x6f, 0x98, 0xee, 0x13, 0xfc, 0x27,
+	0x9f, 0x80, 0x59, 0x6c, 0x9f, 0xf6, 0xf0, 0x6e, 0xa5, 0xd2, 0xf8, 0xf9, 0xce, 0xa3, 0xf1, 0x7c,
+	0xa6, 0x03, 0x90, 0x98, 0x45, 0x81, 0x46, 0x0f, 0xce, 0xfe, 0xf6, 0x6a, 0x67, 0x6f, 0x3d, 0xe3,
+	0xfc, 0xad, 0x67, 0xfc, 0x7c, 0xe9, 0xd5, 0x7e, 0xbb, 0xf4, 0x8c, 0xf3, 0x4b, 0xaf, 0xf6, 0xe7,
+	0xa5, 0x57, 0x3b, 0x74, 0xd0, 0xcd, 0xf6, 0xbf, 0x03, 0x00, 0x3c, 0x8a, 0xe0, 0x71, 0xa5, 0x08,
+	0x00, 0x00,
 }
@@ -8,6 +8,7 @@ import (
 	"math/big"
 	"path"
 	"path/filepath"
+	"sync"
 
 	"github.com/LiveRamp/gazette/v2/pkg/client"
 	"github.com/LiveRamp/gazette/v2/pkg/message"
@@ -27,11 +28,18 @@ var propertyFiles = map[string]struct{}{
 // then return control back to the database (and its client). To do so would
 // allow for inconsistency in the local database state, vs the recorded log. For
 // this reason, Recorder's implementation is crash-only and panics on error.
+//
+// The exception is a failed append of the recovery log, after which the log no
+// longer reflects the FSM. The Recorder latches the failure, and all later
+// appends of the Recorder (including barriers) fail with its error. Clients
+// must check Err after awaiting a barrier, and before using built FSMHints.
 type Recorder struct {
 	// State machine managing RecordedOp transitions.
 	fsm *FSM
 	// Generated unique ID of this Recorder.
 	id Author
+	// Local directory which is recorded.
+	dir string
 	// Prefix length to strip from filenames in recorded operations.
 	stripLen int
 	// Appender to the recovery log. We also rely on AsyncJournalClient to guard the
@@ -39,8 +47,9 @@ type Recorder struct {
 	cl client.AsyncJournalClient
 	// Last observed write head of the recovery log journal.
 	writeHead int64
-	// A recent append transaction, which we monitor for completion and, when Done,
-	// use to update |writeHead| to the new maximum observed offset of the log.
+	// Append transactions of the Recorder, in log order, which have not yet
+	// been observed to complete. As each completes, we use it to update
+	// |writeHead| to the new maximum observed offset of the log.
 	//
 	// Regularly shifting |writeHead| forward results in a tighter lower-bound
 	// on recorded operation offsets which are fed to the FSM (and to FSMHints),
@@ -48,9 +57,20 @@ type Recorder struct {
 	// We additionally want to use offsets returned directly from Gazette
 	// (rather than, eg, counting bytes), as they better account for writes from
 	// competing Recorders and are guaranteed to align with message boundaries.
-	recentTxn *client.AsyncAppend
+	pending []*client.AsyncAppend
+	// First append transaction of the Recorder which failed, or nil. Later
+	// transactions depend upon |failed|, and fail with its error.
+	failed *client.AsyncAppend
+	// Guards |pending| and |failed|, which are also accessed by Err.
+	mu sync.Mutex
 	// Scratch buffer for framing RecordedOps.
 	buf []byte
+	// Fnodes which were rebased, mapped to the Fnode which replaced them.
+	rebased map[Fnode]Fnode
+	// In-progress rebase, or nil if there is none.
+	rebasing *rebaseState
+	// Scratch buffer for reading content of rebased files.
+	rebaseBuf []byte
 }
 
 // NewRecorder creates and returns a Recorder.
@@ -58,6 +78,7 @@ func NewRecorder(fsm *FSM, id Author, dir string, cl client.AsyncJournalClient)
 	var recorder = &Recorder{
 		fsm:      fsm,
 		id:       id,
+		dir:      filepath.Clean(dir),
 		stripLen: len(filepath.Clean(dir)),
 		cl:       cl,
 	}
@@ -207,32 +228,78 @@ func (r *Recorder) WeakBarrier() *client.AsyncAppend {
 	return txn
 }
 
+// Err returns the error of a failed append of the recovery log, which
+// may have been a prior append of recorded operations, or nil. Once
+// Err returns an error, all later appends of the Recorder fail with it.
+// Callers should check Err after the Done of a barrier, to learn of any
+// failure of appends which preceded the barrier.
+func (r *Recorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.observeCompletedTxns()
+
+	if r.failed != nil {
+		return r.failed.Err()
+	}
+	return nil
+}
+
 func (r *Recorder) normalizePath(fpath string) string {
 	return path.Clean(filepath.ToSlash(fpath[r.stripLen:]))
 }
 
 func (r *Recorder) lockAndBeginTxn(dependencies ...*client.AsyncAppend) *client.AsyncAppend {
+	r.mu.Lock()
+	if r.failed != nil {
+		// Ensure |txn| fails, rather than committing operations which follow
+		// lost ones (and which the log therefore can't reflect).
+		dependencies = append(dependencies, r.failed)
+	}
+	r.mu.Unlock()
+
 	// Locking is implied by StartAppend, which allows just one writer per journal.
 	// The lock is held until Release is called by unlockAndReleaseTxn.
 	var txn = r.cl.StartAppend(r.fsm.Log, dependencies...)
 
-	if r.recentTxn == nil {
-		r.recentTxn = txn
+	r.mu.Lock()
+	r.observeCompletedTxns()
+
+	// Consecutive operations are frequently batched into the same transaction.
+	if l := len(r.pending); l == 0 || r.pending[l-1] != txn {
+		r.pending = append(r.pending, txn)
 	}
-	select {
-	default:
-		// Don't block unless Done is ready.
-	case <-r.recentTxn.Done():
-		// A previous write has completed. Update our |writeHead|.
-		if end := r.recentTxn.Response().Commit.End; end < r.writeHead {
+	r.mu.Unlock()
+
+	return txn
+}
+
+// observeCompletedTxns pops completed transactions of |pending|, updating
+// |writeHead| or latching a failed transaction. r.mu must be held.
+func (r *Recorder) observeCompletedTxns() {
+	for ; len(r.pending) != 0; r.pending = r.pending[1:] {
+		var txn = r.pending[0]
+
+		select {
+		default:
+			return // Don't block unless Done is ready.
+		case <-txn.Done():
+		}
+
+		if err := txn.Err(); err != nil {
+			if r.failed == nil {
+				// Recorded operations were lost, and the log no longer reflects the FSM.
+				log.WithFields(log.Fields{"err": err, "log": r.fsm.Log}).
+					Error("recovery log append failed")
+				r.failed = txn
+			}
+		} else if end := txn.Response().Commit.End; end < r.writeHead {
 			log.WithFields(log.Fields{"writeHead": r.writeHead, "end": end, "log": r.fsm.Log}).
 				Panic("invalid writeHead at lockAndBeginTxn")
 		} else {
 			r.writeHead = end
-			r.recentTxn = txn
 		}
 	}
-	return txn
 }
 
 func (r *Recorder) unlockAndReleaseTxn(txn *client.AsyncAppend) {
@@ -279,10 +346,21 @@ func (r *FileRecorder) RecordWrite(data []byte) {
 }
 
 func (r *FileRecorder) frameAppend(b []byte, bw *bufio.Writer) {
-	var l = int64(len(b))
-	r.process(newWriteOp(r.fnode, r.offset, l), bw)
+	// The file may have been rebased since the FileRecorder was created.
+	r.fnode = r.Recorder.rebasedFnode(r.fnode)
+	r.recordWrite(r.fnode, r.offset, b, bw)
+
+	// If the file is being rebased, and the write overlaps content already
+	// recorded into the rebased Fnode, then also record it there.
+	if rs := r.Recorder.rebasing; rs != nil && rs.from == r.fnode && r.offset < rs.progress {
+		r.recordWrite(rs.to, r.offset, b, bw)
+	}
+	r.offset += int64(len(b))
+}
+
+func (r *Recorder) recordWrite(fnode Fnode, offset int64, b []byte, bw *bufio.Writer) {
+	r.process(newWriteOp(fnode, offset, int64(len(b))), bw)
 	bw.Write(b)
-	r.offset += l
 }
 
 func newCreateOp(path string) RecordedOp {
@@ -1,4 +1,4 @@
-// +build !norocksdb
+// +build !norocksdb,cgo
 
 package recoverylog
 
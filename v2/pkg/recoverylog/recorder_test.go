@@ -6,6 +6,7 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/LiveRamp/gazette/v2/pkg/brokertest"
@@ -337,6 +338,53 @@ func (s *RecorderSuite) TestMixedNewFileWriteAndDelete(c *gc.C) {
 	})
 }
 
+func (s *RecorderSuite) TestAppendFailureIsLatched(c *gc.C) {
+	var etcd = etcdtest.TestClient()
+	defer etcdtest.Cleanup()
+
+	var broker = brokertest.NewBroker(c, etcd, "local", "broker")
+	var small = brokertest.Journal(pb.JournalSpec{Name: "examples/integration-tests/small"})
+	small.MaxAppendSize = 16
+
+	brokertest.CreateJournals(c, broker,
+		brokertest.Journal(pb.JournalSpec{Name: aRecoveryLog}), small)
+
+	var rjc = pb.NewRoutedJournalClient(broker.Client(), pb.NoopDispatchRouter{})
+	var as = client.NewAppendService(context.Background(), rjc)
+
+	var fsm, _ = NewFSM(FSMHints{Log: aRecoveryLog})
+	var rec = NewRecorder(fsm, anAuthor, "/strip", as)
+
+	rec.RecordCreate("/strip/path/to/file")
+	var barrier = rec.WeakBarrier()
+	<-barrier.Done()
+	c.Check(barrier.Err(), gc.IsNil)
+	c.Check(rec.Err(), gc.IsNil)
+
+	// Begin an append of another journal which will fail. A StrongBarrier
+	// depends upon it, and its recorded operations fail to commit.
+	var aa = as.StartAppend(small.Name)
+	_, _ = aa.Writer().WriteString(strings.Repeat("x", 32))
+	c.Check(aa.Release(), gc.IsNil)
+
+	rec.RecordRemove("/strip/path/to/file")
+	barrier = rec.StrongBarrier()
+	<-barrier.Done()
+	c.Check(barrier.Err(), gc.Equals, client.ErrAppendTooLarge)
+	c.Check(rec.Err(), gc.Equals, client.ErrAppendTooLarge)
+
+	// Expect the failure is latched: later operations and barriers also fail,
+	// though their own dependencies succeed.
+	rec.RecordCreate("/strip/other/file")
+	barrier = rec.WeakBarrier()
+	<-barrier.Done()
+	c.Check(barrier.Err(), gc.Equals, client.ErrAppendTooLarge)
+	c.Check(rec.Err(), gc.Equals, client.ErrAppendTooLarge)
+
+	broker.RevokeLease(c)
+	broker.WaitForExit()
+}
+
 func (s *RecorderSuite) TestRandomAuthorGeneration(c *gc.C) {
 	var author, err = NewRandomAuthorID()
 	c.Check(err, gc.IsNil)
@@ -0,0 +1,148 @@
+package recoverylog
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// LinkView hard-links a view of the file-system, as currently played back by
+// the Player, into a new directory which is a sibling of the playback
+// directory, and returns the view directory. The Player must have read all
+// operations of its FSMHints. Typically LinkView is used by a Player
+// which is tailing the log, to serve read-only queries of the recovered
+// file-system (eg, by a hot standby).
+//
+// Files of the view share content with the Player's files, and reflect further
+// Writes which the Player applies to them. The view must not be modified, except
+// to create new files (such as lock files), and the caller must remove it
+// when no longer needed.
+func (p *Player) LinkView(ctx context.Context) (string, error) {
+	var respCh = make(chan viewResponse, 1)
+	var req = viewRequest{respCh: respCh}
+
+	select {
+	case p.viewCh <- req:
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case <-p.doneCh:
+		return "", ErrPlayerDone
+	}
+
+	// The Player responds to a received request, even if it then exits.
+	var resp = <-respCh
+	return resp.dir, resp.err
+}
+
+// ReadThrough blocks until the Player has read through log |offset|, such
+// that all operations of the log which precede |offset| have been applied.
+// ReadThrough is useful in bounding the staleness of a LinkView: for example,
+// a caller may issue a write barrier to the log and then ReadThrough its end
+// offset, ensuring a subsequent view reflects all operations of the log which
+// were written prior to the barrier.
+func (p *Player) ReadThrough(ctx context.Context, offset int64) error {
+	return p.progress.await(ctx, offset, p.doneCh)
+}
+
+// ErrPlayerDone is returned by LinkView or ReadThrough of a completed Player.
+var ErrPlayerDone = errors.New("player is done")
+
+// viewRequest is a request of LinkView, served by playLog.
+type viewRequest struct {
+	respCh chan<- viewResponse
+}
+
+type viewResponse struct {
+	dir string
+	err error
+}
+
+// linkView hard-links live files and properties of the |fsm| played into
+// |dir| into a new sibling view directory, and returns the view directory.
+func linkView(dir string, fsm *FSM) (string, error) {
+	if fsm.hasRemainingHints() {
+		return "", errors.New("FSM has remaining hints")
+	}
+	var view, err = ioutil.TempDir(filepath.Dir(dir), filepath.Base(dir)+ViewDirSuffix)
+	if err != nil {
+		return "", extendErr(err, "creating view directory")
+	}
+
+	for fnode, liveNode := range fsm.LiveNodes {
+		for link := range liveNode.Links {
+			var targetPath = filepath.Join(view, link)
+
+			if err = os.MkdirAll(filepath.Dir(targetPath), 0777); err == nil {
+				err = os.Link(stagedPath(dir, fnode), targetPath)
+			}
+			if err != nil {
+				os.RemoveAll(view)
+				return "", extendErr(err, "linking %s", link)
+			}
+		}
+	}
+	for path, content := range fsm.Properties {
+		var targetPath = filepath.Join(view, path)
+
+		if err = os.MkdirAll(filepath.Dir(targetPath), 0777); err == nil {
+			err = ioutil.WriteFile(targetPath, []byte(content), 0666)
+		}
+		if err != nil {
+			os.RemoveAll(view)
+			return "", extendErr(err, "writing property %s", path)
+		}
+	}
+	return view, nil
+}
+
+// readProgress tracks the log offset through which a Player has read.
+type readProgress struct {
+	mu     sync.Mutex
+	offset int64
+	ch     chan struct{} // Closed and cleared when |offset| increases.
+}
+
+// set the read-through |offset|, waking any awaiting goroutines.
+func (rp *readProgress) set(offset int64) {
+	rp.mu.Lock()
+	if offset > rp.offset {
+		rp.offset = offset
+
+		if rp.ch != nil {
+			close(rp.ch)
+			rp.ch = nil
+		}
+	}
+	rp.mu.Unlock()
+}
+
+// await the read-through of |offset|, context cancellation, or |doneCh|.
+func (rp *readProgress) await(ctx context.Context, offset int64, doneCh <-chan struct{}) error {
+	for {
+		rp.mu.Lock()
+		if rp.offset >= offset {
+			rp.mu.Unlock()
+			return nil
+		} else if rp.ch == nil {
+			rp.ch = make(chan struct{})
+		}
+		var ch = rp.ch
+		rp.mu.Unlock()
+
+		select {
+		case <-ch:
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-doneCh:
+			return ErrPlayerDone
+		}
+	}
+}
+
+// ViewDirSuffix is the suffix (followed by a random string) of sibling
+// directories of the playback directory, into which views are linked.
+const ViewDirSuffix = ".view-"
@@ -0,0 +1,103 @@
+package recoverylog
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	gc "github.com/go-check/check"
+	"github.com/spf13/afero"
+)
+
+type ViewSuite struct{}
+
+func (s *ViewSuite) TestLinkViewsOfTailingPlayer(c *gc.C) {
+	var bk, cleanup = newBrokerAndLog(c)
+	defer cleanup()
+
+	var ctx = context.Background()
+	var recDir, playDir = tempDir(c), tempDir(c)
+	defer os.RemoveAll(recDir)
+	defer os.RemoveAll(playDir)
+
+	var fsm, err = NewFSM(FSMHints{Log: aRecoveryLog})
+	c.Assert(err, gc.IsNil)
+
+	var rec = NewRecorder(fsm, anAuthor, recDir, bk)
+	var fs = RecordedAferoFS{Recorder: rec, Fs: afero.NewOsFs()}
+
+	foo, err := fs.Create(filepath.Join(recDir, "foo"))
+	c.Assert(err, gc.IsNil)
+	_, err = foo.Write([]byte("hello"))
+	c.Check(err, gc.IsNil)
+
+	var player = NewPlayer()
+	go func() { c.Check(player.Play(ctx, FSMHints{Log: aRecoveryLog}, playDir, bk), gc.IsNil) }()
+	<-player.Tailing()
+
+	// readThroughHead awaits the Player's read-through of the current log head.
+	var readThroughHead = func() {
+		var txn = rec.WeakBarrier()
+		<-txn.Done()
+		c.Check(player.ReadThrough(ctx, txn.Response().Commit.End), gc.IsNil)
+	}
+
+	// Record a linked file, and a property.
+	c.Check(os.MkdirAll(filepath.Join(recDir, "sub/dir"), 0777), gc.IsNil)
+	c.Check(os.Link(filepath.Join(recDir, "foo"), filepath.Join(recDir, "sub/dir/foo.link")), gc.IsNil)
+	rec.RecordLink(filepath.Join(recDir, "foo"), filepath.Join(recDir, "sub/dir/foo.link"))
+
+	c.Check(afero.WriteFile(fs, filepath.Join(recDir, "tmp"), []byte("an-id"), 0600), gc.IsNil)
+	c.Check(fs.Rename(filepath.Join(recDir, "tmp"), filepath.Join(recDir, "IDENTITY")), gc.IsNil)
+
+	readThroughHead()
+	view1, err := player.LinkView(ctx)
+	c.Assert(err, gc.IsNil)
+	defer os.RemoveAll(view1)
+
+	c.Check(filepath.Dir(view1), gc.Equals, filepath.Dir(playDir))
+	c.Check(strings.HasPrefix(filepath.Base(view1), filepath.Base(playDir)+".view-"), gc.Equals, true)
+
+	expectFileContent(c, filepath.Join(view1, "foo"), "hello")
+	expectFileContent(c, filepath.Join(view1, "sub/dir/foo.link"), "hello")
+	expectFileContent(c, filepath.Join(view1, "IDENTITY"), "an-id")
+
+	// Write to "foo", and remove its link. Expect the prior view reflects the
+	// write (as it shares file content), but retains its link.
+	_, err = foo.Write([]byte(" world"))
+	c.Check(err, gc.IsNil)
+	c.Check(fs.Remove(filepath.Join(recDir, "sub/dir/foo.link")), gc.IsNil)
+	c.Check(afero.WriteFile(fs, filepath.Join(recDir, "bar"), []byte("bar"), 0600), gc.IsNil)
+
+	readThroughHead()
+	expectFileContent(c, filepath.Join(view1, "foo"), "hello world")
+	expectFileContent(c, filepath.Join(view1, "sub/dir/foo.link"), "hello world")
+
+	// Expect a new view reflects current links.
+	view2, err := player.LinkView(ctx)
+	c.Assert(err, gc.IsNil)
+	defer os.RemoveAll(view2)
+
+	c.Check(view2, gc.Not(gc.Equals), view1)
+	expectFileContent(c, filepath.Join(view2, "foo"), "hello world")
+	expectFileContent(c, filepath.Join(view2, "bar"), "bar")
+	_, err = os.Stat(filepath.Join(view2, "sub/dir/foo.link"))
+	c.Check(os.IsNotExist(err), gc.Equals, true)
+
+	// Expect ReadThrough of a future offset respects context cancellation.
+	var timeoutCtx, cancel = context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	c.Check(player.ReadThrough(timeoutCtx, 1<<30), gc.Equals, context.DeadlineExceeded)
+
+	player.FinishAtWriteHead()
+	<-player.Done()
+
+	// Expect a completed Player fails further requests.
+	_, err = player.LinkView(ctx)
+	c.Check(err, gc.Equals, ErrPlayerDone)
+	c.Check(player.ReadThrough(ctx, 1<<30), gc.Equals, ErrPlayerDone)
+}
+
+var _ = gc.Suite(&ViewSuite{})
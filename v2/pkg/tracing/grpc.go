@@ -0,0 +1,140 @@
+package tracing
+
+import (
+	"context"
+	"io"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// UnaryServerInterceptor is a grpc.UnaryServerInterceptor which runs each
+// unary RPC within a server Span, which is a child of the caller's Span (if
+// its SpanContext was propagated).
+func UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	var span *Span
+	ctx, span = StartSpan(extract(ctx), info.FullMethod, SpanKindServer)
+
+	var resp, err = handler(ctx, req)
+	span.Finish(err)
+	return resp, err
+}
+
+// StreamServerInterceptor is a grpc.StreamServerInterceptor which runs each
+// streaming RPC within a server Span, which is a child of the caller's Span
+// (if its SpanContext was propagated). The Span is attached to the Context of
+// the handler's grpc.ServerStream, and RPCs issued with it (eg, to proxy the
+// stream to a peer) are its children.
+func StreamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	var ctx, span = StartSpan(extract(ss.Context()), info.FullMethod, SpanKindServer)
+
+	var err = handler(srv, serverStream{ServerStream: ss, ctx: ctx})
+	span.Finish(err)
+	return err
+}
+
+// UnaryClientInterceptor is a grpc.UnaryClientInterceptor which runs each
+// unary RPC within a client Span, and propagates its SpanContext to the server.
+func UnaryClientInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	var span *Span
+	ctx, span = StartSpan(ctx, method, SpanKindClient)
+
+	var err = invoker(inject(ctx), method, req, reply, cc, opts...)
+	span.Finish(err)
+	return err
+}
+
+// StreamClientInterceptor is a grpc.StreamClientInterceptor which runs each
+// streaming RPC within a client Span, and propagates its SpanContext to the
+// server. The Span is Finished when the stream is read to its end (or its
+// single response is read, if the RPC isn't server-streaming), or fails.
+func StreamClientInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	var span *Span
+	ctx, span = StartSpan(ctx, method, SpanKindClient)
+
+	var cs, err = streamer(inject(ctx), desc, cc, method, opts...)
+	if err != nil {
+		span.Finish(err)
+		return nil, err
+	} else if span == nil {
+		return cs, nil
+	}
+	return &clientStream{ClientStream: cs, span: span, serverStreams: desc.ServerStreams}, nil
+}
+
+// ServerOptions returns grpc.ServerOptions which install the interceptors of
+// this package.
+func ServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.UnaryInterceptor(UnaryServerInterceptor),
+		grpc.StreamInterceptor(StreamServerInterceptor),
+	}
+}
+
+// DialOptions returns grpc.DialOptions which install the interceptors of
+// this package.
+func DialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithUnaryInterceptor(UnaryClientInterceptor),
+		grpc.WithStreamInterceptor(StreamClientInterceptor),
+	}
+}
+
+// inject the SpanContext of |ctx|, if any, into its outgoing gRPC metadata.
+func inject(ctx context.Context) context.Context {
+	if sc := SpanContextFromContext(ctx); sc.IsValid() {
+		return metadata.AppendToOutgoingContext(ctx, traceparentKey, sc.Traceparent())
+	}
+	return ctx
+}
+
+// extract a propagated SpanContext from the incoming gRPC metadata of |ctx|,
+// and attach it to the returned Context. A SpanContext which is invalid is
+// ignored, and a new trace is started.
+func extract(ctx context.Context) context.Context {
+	var md, _ = metadata.FromIncomingContext(ctx)
+
+	if v := md.Get(traceparentKey); len(v) == 0 {
+		return ctx
+	} else if sc, err := ParseTraceparent(v[0]); err != nil {
+		return ctx
+	} else {
+		return ContextWithRemoteSpanContext(ctx, sc)
+	}
+}
+
+// serverStream overrides the Context of a grpc.ServerStream.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s serverStream) Context() context.Context { return s.ctx }
+
+// clientStream Finishes its Span once the grpc.ClientStream completes or fails.
+type clientStream struct {
+	grpc.ClientStream
+	span          *Span
+	serverStreams bool
+}
+
+func (s *clientStream) SendMsg(m interface{}) error {
+	var err = s.ClientStream.SendMsg(m)
+	if err != nil && err != io.EOF {
+		s.span.Finish(err) // io.EOF is followed by a RecvMsg of the actual error.
+	}
+	return err
+}
+
+func (s *clientStream) RecvMsg(m interface{}) error {
+	var err = s.ClientStream.RecvMsg(m)
+	if err == io.EOF || (err == nil && !s.serverStreams) {
+		s.span.Finish(nil)
+	} else if err != nil {
+		s.span.Finish(err)
+	}
+	return err
+}
+
+// Metadata key of a propagated W3C Trace Context.
+const traceparentKey = "traceparent"
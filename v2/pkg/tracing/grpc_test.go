@@ -0,0 +1,150 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	gc "github.com/go-check/check"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type GRPCSuite struct{}
+
+func (s *GRPCSuite) TestUnaryPropagation(c *gc.C) {
+	var rec = installRecorder()
+	defer SetExporter(nil)
+
+	var caller, callerSpan = StartSpan(context.Background(), "caller", SpanKindInternal)
+	var serverSpan *Span
+
+	var err = UnaryClientInterceptor(caller, "/Svc/Method", nil, nil, nil,
+		func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			// Deliver outgoing metadata to the server as incoming metadata.
+			var md, _ = metadata.FromOutgoingContext(ctx)
+			ctx = metadata.NewIncomingContext(context.Background(), md)
+
+			var _, err = UnaryServerInterceptor(ctx, req, &grpc.UnaryServerInfo{FullMethod: method},
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					serverSpan = FromContext(ctx)
+					return nil, errors.New("whoops")
+				})
+			return err
+		})
+	c.Check(err, gc.ErrorMatches, "whoops")
+	callerSpan.Finish(nil)
+
+	c.Assert(rec.spans, gc.HasLen, 3)
+	var server, client = rec.spans[0], rec.spans[1]
+
+	c.Check(server, gc.Equals, serverSpan)
+	c.Check(server.Name, gc.Equals, "/Svc/Method")
+	c.Check(server.Kind, gc.Equals, SpanKindServer)
+	c.Check(client.Kind, gc.Equals, SpanKindClient)
+
+	// Spans share a trace, and are children of one another.
+	c.Check(client.TraceID, gc.Equals, callerSpan.TraceID)
+	c.Check(server.TraceID, gc.Equals, callerSpan.TraceID)
+	c.Check(client.Parent, gc.Equals, callerSpan.SpanID)
+	c.Check(server.Parent, gc.Equals, client.SpanID)
+
+	c.Check(server.Err(), gc.ErrorMatches, "whoops")
+	c.Check(client.Err(), gc.ErrorMatches, "whoops")
+}
+
+func (s *GRPCSuite) TestStreamPropagation(c *gc.C) {
+	var rec = installRecorder()
+	defer SetExporter(nil)
+
+	var serverSpan *Span
+	var mockCS = &mockClientStream{}
+
+	var cs, err = StreamClientInterceptor(context.Background(), &grpc.StreamDesc{ServerStreams: true}, nil, "/Svc/Stream",
+		func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			var md, _ = metadata.FromOutgoingContext(ctx)
+			var ss = &mockServerStream{ctx: metadata.NewIncomingContext(context.Background(), md)}
+
+			c.Check(StreamServerInterceptor(nil, ss, &grpc.StreamServerInfo{FullMethod: method},
+				func(srv interface{}, stream grpc.ServerStream) error {
+					serverSpan = FromContext(stream.Context())
+					return nil
+				}), gc.IsNil)
+			return mockCS, nil
+		})
+	c.Assert(err, gc.IsNil)
+
+	c.Assert(rec.spans, gc.HasLen, 1) // Server Span only.
+	c.Check(rec.spans[0], gc.Equals, serverSpan)
+
+	// The client Span is Finished on reading the end of the stream.
+	mockCS.recvErrs = []error{nil, io.EOF}
+	c.Check(cs.RecvMsg(nil), gc.IsNil)
+	c.Check(rec.spans, gc.HasLen, 1)
+	c.Check(cs.RecvMsg(nil), gc.Equals, io.EOF)
+	c.Assert(rec.spans, gc.HasLen, 2)
+
+	var client = rec.spans[1]
+	c.Check(client.Name, gc.Equals, "/Svc/Stream")
+	c.Check(client.Err(), gc.IsNil)
+	c.Check(serverSpan.TraceID, gc.Equals, client.TraceID)
+	c.Check(serverSpan.Parent, gc.Equals, client.SpanID)
+}
+
+func (s *GRPCSuite) TestClientStreamingRPCFinishesOnResponse(c *gc.C) {
+	var rec = installRecorder()
+	defer SetExporter(nil)
+
+	var mockCS = &mockClientStream{
+		sendErrs: []error{nil, io.EOF},
+		recvErrs: []error{nil},
+	}
+	var cs, err = StreamClientInterceptor(context.Background(), &grpc.StreamDesc{ClientStreams: true}, nil, "/Svc/Append",
+		func(context.Context, *grpc.StreamDesc, *grpc.ClientConn, string, ...grpc.CallOption) (grpc.ClientStream, error) {
+			return mockCS, nil
+		})
+	c.Assert(err, gc.IsNil)
+
+	c.Check(cs.SendMsg(nil), gc.IsNil)
+	c.Check(cs.SendMsg(nil), gc.Equals, io.EOF) // Doesn't Finish the Span.
+	c.Check(rec.spans, gc.HasLen, 0)
+
+	c.Check(cs.RecvMsg(nil), gc.IsNil)
+	c.Assert(rec.spans, gc.HasLen, 1)
+	c.Check(rec.spans[0].Err(), gc.IsNil)
+
+	// Failure to start a stream Finishes its Span.
+	_, err = StreamClientInterceptor(context.Background(), &grpc.StreamDesc{}, nil, "/Svc/Append",
+		func(context.Context, *grpc.StreamDesc, *grpc.ClientConn, string, ...grpc.CallOption) (grpc.ClientStream, error) {
+			return nil, errors.New("whoops")
+		})
+	c.Check(err, gc.ErrorMatches, "whoops")
+	c.Assert(rec.spans, gc.HasLen, 2)
+	c.Check(rec.spans[1].Err(), gc.ErrorMatches, "whoops")
+}
+
+type mockClientStream struct {
+	grpc.ClientStream
+	sendErrs, recvErrs []error
+}
+
+func (s *mockClientStream) SendMsg(interface{}) error {
+	var err = s.sendErrs[0]
+	s.sendErrs = s.sendErrs[1:]
+	return err
+}
+
+func (s *mockClientStream) RecvMsg(interface{}) error {
+	var err = s.recvErrs[0]
+	s.recvErrs = s.recvErrs[1:]
+	return err
+}
+
+type mockServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *mockServerStream) Context() context.Context { return s.ctx }
+
+var _ = gc.Suite(&GRPCSuite{})
@@ -0,0 +1,222 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// OTLPExporter is an Exporter which batches Finished Spans, and exports them
+// to an OpenTelemetry collector using the OTLP/HTTP protocol, with JSON
+// encoding. Spans are dropped if the collector falls behind.
+type OTLPExporter struct {
+	endpoint string
+	service  string
+	client   *http.Client
+	spanCh   chan *Span
+	doneCh   chan struct{}
+}
+
+// NewOTLPExporter returns an OTLPExporter which exports to the collector at
+// |endpoint| (eg, "http://localhost:4318"), with resource attribute
+// "service.name" of |service|. Spans are exported until |ctx| is cancelled.
+func NewOTLPExporter(ctx context.Context, endpoint, service string) *OTLPExporter {
+	var e = &OTLPExporter{
+		endpoint: strings.TrimSuffix(endpoint, "/") + otlpTracesPath,
+		service:  service,
+		client:   &http.Client{Timeout: otlpTimeout},
+		spanCh:   make(chan *Span, otlpMaxBatchSize*4),
+		doneCh:   make(chan struct{}),
+	}
+	go e.serve(ctx)
+	return e
+}
+
+// ExportSpan queues the Span for export, or drops it if the queue is full.
+func (e *OTLPExporter) ExportSpan(span *Span) {
+	select {
+	case e.spanCh <- span:
+	default:
+		// Drop |span|.
+	}
+}
+
+// Done returns a channel which is closed after the OTLPExporter exits, having
+// exported queued Spans.
+func (e *OTLPExporter) Done() <-chan struct{} { return e.doneCh }
+
+func (e *OTLPExporter) serve(ctx context.Context) {
+	defer close(e.doneCh)
+
+	var ticker = time.NewTicker(otlpFlushInterval)
+	defer ticker.Stop()
+
+	var batch []*Span
+	var flush = func() {
+		if len(batch) == 0 {
+			return
+		} else if err := e.post(batch); err != nil {
+			log.WithFields(log.Fields{"err": err, "spans": len(batch), "endpoint": e.endpoint}).
+				Warn("failed to export trace spans")
+		}
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case span := <-e.spanCh:
+			if batch = append(batch, span); len(batch) == otlpMaxBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-ctx.Done():
+			for done := false; !done; {
+				select {
+				case span := <-e.spanCh:
+					batch = append(batch, span)
+				default:
+					done = true
+				}
+			}
+			flush()
+			return
+		}
+	}
+}
+
+// post a batch of Spans to the collector.
+func (e *OTLPExporter) post(spans []*Span) error {
+	var b, err = json.Marshal(buildOTLPRequest(e.service, spans))
+	if err != nil {
+		return errors.WithMessage(err, "marshal")
+	}
+	resp, err := e.client.Post(e.endpoint, "application/json", bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errors.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// buildOTLPRequest builds an OTLP ExportTraceServiceRequest of |spans|.
+// See https://github.com/open-telemetry/opentelemetry-proto for the protocol,
+// and its JSON encoding (which hex-encodes trace and span IDs).
+func buildOTLPRequest(service string, spans []*Span) otlpRequest {
+	var out = make([]otlpSpan, 0, len(spans))
+
+	for _, s := range spans {
+		var o = otlpSpan{
+			TraceID:           s.TraceID.String(),
+			SpanID:            s.SpanID.String(),
+			Name:              s.Name,
+			Kind:              int(s.Kind),
+			StartTimeUnixNano: unixNano(s.StartTime),
+			EndTimeUnixNano:   unixNano(s.EndTime),
+			Attributes:        otlpAttributes(s.Attributes()),
+		}
+		if s.Parent != (SpanID{}) {
+			o.ParentSpanID = s.Parent.String()
+		}
+		for _, ev := range s.Events() {
+			o.Events = append(o.Events, otlpEvent{
+				TimeUnixNano: unixNano(ev.Time),
+				Name:         ev.Message,
+			})
+		}
+		o.DroppedEventsCount = s.DroppedEvents()
+		if err := s.Err(); err != nil {
+			o.Status = otlpStatus{Code: otlpStatusError, Message: err.Error()}
+		}
+		out = append(out, o)
+	}
+
+	return otlpRequest{ResourceSpans: []otlpResourceSpans{{
+		Resource: otlpResource{Attributes: otlpAttributes(map[string]string{"service.name": service})},
+		ScopeSpans: []otlpScopeSpans{{
+			Scope: otlpScope{Name: otlpScopeName},
+			Spans: out,
+		}},
+	}}}
+}
+
+func otlpAttributes(m map[string]string) []otlpKeyValue {
+	var out []otlpKeyValue
+	for k, v := range m {
+		out = append(out, otlpKeyValue{Key: k, Value: otlpAnyValue{StringValue: v}})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Key < out[j].Key })
+	return out
+}
+
+func unixNano(t time.Time) string { return strconv.FormatInt(t.UnixNano(), 10) }
+
+// JSON models of the OTLP ExportTraceServiceRequest.
+type (
+	otlpRequest struct {
+		ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+	}
+	otlpResourceSpans struct {
+		Resource   otlpResource     `json:"resource"`
+		ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+	}
+	otlpResource struct {
+		Attributes []otlpKeyValue `json:"attributes"`
+	}
+	otlpScopeSpans struct {
+		Scope otlpScope  `json:"scope"`
+		Spans []otlpSpan `json:"spans"`
+	}
+	otlpScope struct {
+		Name string `json:"name"`
+	}
+	otlpSpan struct {
+		TraceID            string         `json:"traceId"`
+		SpanID             string         `json:"spanId"`
+		ParentSpanID       string         `json:"parentSpanId,omitempty"`
+		Name               string         `json:"name"`
+		Kind               int            `json:"kind"`
+		StartTimeUnixNano  string         `json:"startTimeUnixNano"`
+		EndTimeUnixNano    string         `json:"endTimeUnixNano"`
+		Attributes         []otlpKeyValue `json:"attributes,omitempty"`
+		Events             []otlpEvent    `json:"events,omitempty"`
+		DroppedEventsCount int            `json:"droppedEventsCount,omitempty"`
+		Status             otlpStatus     `json:"status"`
+	}
+	otlpKeyValue struct {
+		Key   string       `json:"key"`
+		Value otlpAnyValue `json:"value"`
+	}
+	otlpAnyValue struct {
+		StringValue string `json:"stringValue"`
+	}
+	otlpEvent struct {
+		TimeUnixNano string `json:"timeUnixNano"`
+		Name         string `json:"name"`
+	}
+	otlpStatus struct {
+		Code    int    `json:"code,omitempty"`
+		Message string `json:"message,omitempty"`
+	}
+)
+
+const (
+	otlpTracesPath    = "/v1/traces"
+	otlpScopeName     = "github.com/LiveRamp/gazette/v2/pkg/tracing"
+	otlpStatusError   = 2
+	otlpMaxBatchSize  = 512
+	otlpFlushInterval = time.Second
+	otlpTimeout       = 10 * time.Second
+)
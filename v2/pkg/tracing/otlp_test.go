@@ -0,0 +1,97 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	gc "github.com/go-check/check"
+)
+
+type OTLPSuite struct{}
+
+func (s *OTLPSuite) TestExportToCollector(c *gc.C) {
+	var reqCh = make(chan otlpRequest, 1)
+
+	var srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Check(r.URL.Path, gc.Equals, "/v1/traces")
+		c.Check(r.Header.Get("Content-Type"), gc.Equals, "application/json")
+
+		var req otlpRequest
+		c.Check(json.NewDecoder(r.Body).Decode(&req), gc.IsNil)
+		reqCh <- req
+	}))
+	defer srv.Close()
+
+	defer func(fn func() time.Time) { timeNow = fn }(timeNow)
+	timeNow = func() time.Time { return time.Unix(1500000000, 0) }
+
+	var ctx, cancel = context.WithCancel(context.Background())
+	var exp = NewOTLPExporter(ctx, srv.URL+"/", "a-service")
+	SetExporter(exp)
+	defer SetExporter(nil)
+
+	var spanCtx, parent = StartSpan(context.Background(), "parent", SpanKindServer)
+	var _, child = StartSpan(spanCtx, "child", SpanKindInternal)
+	child.SetAttribute("journal", "a/journal")
+	child.AddEvent("an event")
+	child.Finish(errors.New("whoops"))
+	parent.Finish(nil)
+
+	// Cancellation flushes queued Spans.
+	cancel()
+	<-exp.Done()
+
+	var req = <-reqCh
+	c.Assert(req.ResourceSpans, gc.HasLen, 1)
+	c.Check(req.ResourceSpans[0].Resource.Attributes, gc.DeepEquals, []otlpKeyValue{
+		{Key: "service.name", Value: otlpAnyValue{StringValue: "a-service"}},
+	})
+	c.Assert(req.ResourceSpans[0].ScopeSpans, gc.HasLen, 1)
+	c.Check(req.ResourceSpans[0].ScopeSpans[0].Scope.Name, gc.Equals, otlpScopeName)
+
+	c.Check(req.ResourceSpans[0].ScopeSpans[0].Spans, gc.DeepEquals, []otlpSpan{
+		{
+			TraceID:           child.TraceID.String(),
+			SpanID:            child.SpanID.String(),
+			ParentSpanID:      parent.SpanID.String(),
+			Name:              "child",
+			Kind:              1,
+			StartTimeUnixNano: "1500000000000000000",
+			EndTimeUnixNano:   "1500000000000000000",
+			Attributes: []otlpKeyValue{
+				{Key: "journal", Value: otlpAnyValue{StringValue: "a/journal"}},
+			},
+			Events: []otlpEvent{{TimeUnixNano: "1500000000000000000", Name: "an event"}},
+			Status: otlpStatus{Code: otlpStatusError, Message: "whoops"},
+		},
+		{
+			TraceID:           parent.TraceID.String(),
+			SpanID:            parent.SpanID.String(),
+			Name:              "parent",
+			Kind:              2,
+			StartTimeUnixNano: "1500000000000000000",
+			EndTimeUnixNano:   "1500000000000000000",
+		},
+	})
+}
+
+func (s *OTLPSuite) TestPostErrors(c *gc.C) {
+	var srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	var ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+
+	var exp = NewOTLPExporter(ctx, srv.URL, "a-service")
+	<-exp.Done()
+
+	c.Check(exp.post(nil), gc.ErrorMatches, "unexpected status 400 Bad Request")
+}
+
+var _ = gc.Suite(&OTLPSuite{})
@@ -0,0 +1,318 @@
+// Package tracing records Spans of Gazette RPCs and operations, propagates
+// their context across processes using W3C Trace Context "traceparent" gRPC
+// metadata, and exports completed Spans to an OpenTelemetry collector (see
+// OTLPExporter).
+//
+// Spans are recorded only if an Exporter has been set by SetExporter. If not,
+// StartSpan returns a nil *Span, upon which all methods are no-ops, though a
+// trace context received from a caller is still propagated to further RPCs.
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// TraceID uniquely identifies a trace.
+type TraceID [16]byte
+
+// SpanID identifies a Span within its trace.
+type SpanID [8]byte
+
+// String returns the lower-case hex encoding of the TraceID.
+func (id TraceID) String() string { return hex.EncodeToString(id[:]) }
+
+// String returns the lower-case hex encoding of the SpanID.
+func (id SpanID) String() string { return hex.EncodeToString(id[:]) }
+
+// SpanContext identifies a Span, and is propagated to the callees of RPCs
+// which the Span issues.
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+}
+
+// IsValid returns true iff the SpanContext has non-zero TraceID and SpanID.
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID != (TraceID{}) && sc.SpanID != (SpanID{})
+}
+
+// Traceparent returns the W3C Trace Context "traceparent" encoding of the
+// SpanContext. See https://www.w3.org/TR/trace-context/#traceparent-header.
+func (sc SpanContext) Traceparent() string {
+	return fmt.Sprintf("00-%s-%s-01", sc.TraceID, sc.SpanID)
+}
+
+// ParseTraceparent parses a W3C Trace Context "traceparent" into a SpanContext.
+func ParseTraceparent(s string) (SpanContext, error) {
+	var sc SpanContext
+	var parts = strings.Split(s, "-")
+
+	// Future versions may append further fields, but version "ff" is invalid.
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" ||
+		(parts[0] == "00" && len(parts) != 4) {
+		return sc, errors.Errorf("invalid traceparent %q", s)
+	} else if err := decodeHex(sc.TraceID[:], parts[1]); err != nil {
+		return sc, errors.Errorf("invalid traceparent trace-id %q", parts[1])
+	} else if err = decodeHex(sc.SpanID[:], parts[2]); err != nil {
+		return sc, errors.Errorf("invalid traceparent parent-id %q", parts[2])
+	} else if !sc.IsValid() {
+		return sc, errors.Errorf("invalid traceparent %q (zero trace-id or parent-id)", s)
+	}
+	return sc, nil
+}
+
+// SpanKind describes the relationship of a Span to its parent and children.
+type SpanKind int
+
+const (
+	// SpanKindInternal is an operation internal to a process.
+	SpanKindInternal SpanKind = 1
+	// SpanKindServer is the server-side handling of an RPC.
+	SpanKindServer SpanKind = 2
+	// SpanKindClient is the client-side invocation of an RPC.
+	SpanKindClient SpanKind = 3
+)
+
+// Span is a timed operation within a trace. A Span is started by StartSpan,
+// and is exported once Finished. Methods of a Span may be called concurrently,
+// and all methods of a nil *Span are no-ops.
+type Span struct {
+	SpanContext
+	// Parent of the Span, which is zero-valued if the Span is a trace root.
+	Parent SpanID
+	// Name and SpanKind of the Span.
+	Name string
+	Kind SpanKind
+	// Times at which the Span started and finished.
+	StartTime, EndTime time.Time
+
+	mu sync.Mutex
+	// Attributes, Events and Error of the Span. Guarded by |mu|.
+	attributes map[string]string
+	events     []Event
+	dropped    int // Number of Events dropped (see maxSpanEvents).
+	err        error
+	finished   bool
+
+	exporter Exporter
+}
+
+// Event is a timestamped annotation of a Span.
+type Event struct {
+	Time    time.Time
+	Message string
+}
+
+// Exporter exports Spans as they're Finished. ExportSpan must not block.
+type Exporter interface {
+	ExportSpan(*Span)
+}
+
+// SetExporter sets the Exporter of Spans. If nil, Spans are not recorded.
+func SetExporter(e Exporter) {
+	exporterMu.Lock()
+	exporter = e
+	exporterMu.Unlock()
+}
+
+// StartSpan starts a Span having |name| and |kind|, and returns it with a
+// derived Context to which it's attached. The Span is a child of the Span of
+// |ctx|, or of a remote SpanContext of |ctx| (see ContextWithRemoteSpanContext),
+// or is otherwise a new trace root. If no Exporter is set, the returned Span
+// is nil and |ctx| is returned unmodified.
+func StartSpan(ctx context.Context, name string, kind SpanKind) (context.Context, *Span) {
+	exporterMu.RLock()
+	var e = exporter
+	exporterMu.RUnlock()
+
+	if e == nil {
+		return ctx, nil
+	}
+	var span = &Span{
+		Name:      name,
+		Kind:      kind,
+		StartTime: timeNow(),
+		exporter:  e,
+	}
+	if parent := SpanContextFromContext(ctx); parent.IsValid() {
+		span.TraceID, span.Parent = parent.TraceID, parent.SpanID
+	} else {
+		span.TraceID = newTraceID()
+	}
+	span.SpanID = newSpanID()
+
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+// FromContext returns the Span of the Context, or nil if there is none.
+func FromContext(ctx context.Context) *Span {
+	var span, _ = ctx.Value(spanKey{}).(*Span)
+	return span
+}
+
+// ContextWithRemoteSpanContext returns a Context derived from |ctx| having the
+// SpanContext |sc| of a remote parent Span (eg, as received from a caller).
+func ContextWithRemoteSpanContext(ctx context.Context, sc SpanContext) context.Context {
+	return context.WithValue(ctx, remoteKey{}, sc)
+}
+
+// SpanContextFromContext returns the SpanContext of the Span of |ctx|, or the
+// remote SpanContext of |ctx| if it has no Span. The returned SpanContext
+// is zero-valued if |ctx| has neither.
+func SpanContextFromContext(ctx context.Context) SpanContext {
+	if span := FromContext(ctx); span != nil {
+		return span.SpanContext
+	}
+	var sc, _ = ctx.Value(remoteKey{}).(SpanContext)
+	return sc
+}
+
+// SetAttribute sets attribute |key| of the Span to |value|.
+func (s *Span) SetAttribute(key string, value interface{}) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	if s.attributes == nil {
+		s.attributes = make(map[string]string)
+	}
+	s.attributes[key] = fmt.Sprint(value)
+	s.mu.Unlock()
+}
+
+// AddEvent adds an Event to the Span, having a message formatted from
+// |format| and |args|. A Span retains at most maxSpanEvents: beyond that,
+// the first and most recent Events are retained, and others are dropped.
+func (s *Span) AddEvent(format string, args ...interface{}) {
+	if s == nil {
+		return
+	}
+	var ev = Event{Time: timeNow(), Message: fmt.Sprintf(format, args...)}
+
+	s.mu.Lock()
+	if s.finished {
+		// Pass.
+	} else if len(s.events) != maxSpanEvents {
+		s.events = append(s.events, ev)
+	} else {
+		// The latter half of |events| is a ring of the most recent Events,
+		// of which the oldest is at index |dropped| (modulo its length).
+		var ring = s.events[maxSpanEvents/2:]
+		ring[s.dropped%len(ring)] = ev
+		s.dropped++
+	}
+	s.mu.Unlock()
+}
+
+// Finish the Span with the operation's |err|, which is nil if the operation
+// succeeded, and export it. Only the first call of Finish has an effect.
+func (s *Span) Finish(err error) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	if s.finished {
+		s.mu.Unlock()
+		return
+	}
+	s.EndTime, s.err, s.finished = timeNow(), err, true
+	s.mu.Unlock()
+
+	s.exporter.ExportSpan(s)
+}
+
+// Attributes returns a copy of the Span's attributes.
+func (s *Span) Attributes() map[string]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var out = make(map[string]string, len(s.attributes))
+	for k, v := range s.attributes {
+		out[k] = v
+	}
+	return out
+}
+
+// Events returns a copy of the Span's retained Events, in the order added.
+func (s *Span) Events() []Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.dropped == 0 {
+		return append([]Event(nil), s.events...)
+	}
+	var ring = s.events[maxSpanEvents/2:]
+	var oldest = s.dropped % len(ring)
+
+	var out = append([]Event(nil), s.events[:maxSpanEvents/2]...)
+	out = append(out, ring[oldest:]...)
+	return append(out, ring[:oldest]...)
+}
+
+// DroppedEvents returns the number of Events added to the Span which were
+// dropped, rather than retained (see AddEvent).
+func (s *Span) DroppedEvents() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.dropped
+}
+
+// Err returns the error with which the Span was Finished.
+func (s *Span) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.err
+}
+
+func decodeHex(dst []byte, s string) error {
+	if hex.DecodedLen(len(s)) != len(dst) {
+		return errors.Errorf("unexpected length %d", len(s))
+	}
+	var _, err = hex.Decode(dst, []byte(s))
+	return err
+}
+
+func newTraceID() (id TraceID) {
+	for id == (TraceID{}) {
+		readRandom(id[:])
+	}
+	return
+}
+
+func newSpanID() (id SpanID) {
+	for id == (SpanID{}) {
+		readRandom(id[:])
+	}
+	return
+}
+
+func readRandom(b []byte) {
+	if _, err := rand.Read(b); err != nil {
+		// Fall back to the current time, which is unique enough for tracing.
+		var t [8]byte
+		binary.LittleEndian.PutUint64(t[:], uint64(timeNow().UnixNano()))
+		copy(b, t[:])
+	}
+}
+
+type spanKey struct{}
+type remoteKey struct{}
+
+var (
+	exporterMu sync.RWMutex
+	exporter   Exporter
+	timeNow    = time.Now
+	// maxSpanEvents is the maximum number of Events retained by a Span.
+	maxSpanEvents = 128
+)
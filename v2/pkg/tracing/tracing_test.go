@@ -0,0 +1,149 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	gc "github.com/go-check/check"
+)
+
+type TracingSuite struct{}
+
+func (s *TracingSuite) TestTraceparentRoundTrip(c *gc.C) {
+	var sc = SpanContext{TraceID: newTraceID(), SpanID: newSpanID()}
+	c.Check(sc.IsValid(), gc.Equals, true)
+
+	var out, err = ParseTraceparent(sc.Traceparent())
+	c.Check(err, gc.IsNil)
+	c.Check(out, gc.Equals, sc)
+
+	// A fixture from the W3C specification.
+	out, err = ParseTraceparent("00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01")
+	c.Check(err, gc.IsNil)
+	c.Check(out.TraceID.String(), gc.Equals, "0af7651916cd43dd8448eb211c80319c")
+	c.Check(out.SpanID.String(), gc.Equals, "b7ad6b7169203331")
+
+	// Future versions may append fields.
+	_, err = ParseTraceparent("01-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01-extra")
+	c.Check(err, gc.IsNil)
+}
+
+func (s *TracingSuite) TestTraceparentParseErrors(c *gc.C) {
+	for _, tc := range []struct {
+		in, err string
+	}{
+		{"", `invalid traceparent ""`},
+		{"00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331", `invalid traceparent .*`},
+		{"00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01-extra", `invalid traceparent .*`},
+		{"ff-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01", `invalid traceparent .*`},
+		{"00-0af7651916cd43dd-b7ad6b7169203331-01", `invalid traceparent trace-id "0af7651916cd43dd"`},
+		{"00-0af7651916cd43dd8448eb211c80319c-zzad6b7169203331-01", `invalid traceparent parent-id "zzad6b7169203331"`},
+		{"00-00000000000000000000000000000000-b7ad6b7169203331-01", `invalid traceparent .* \(zero trace-id or parent-id\)`},
+	} {
+		var _, err = ParseTraceparent(tc.in)
+		c.Check(err, gc.ErrorMatches, tc.err)
+	}
+}
+
+func (s *TracingSuite) TestSpansAreNilWithoutExporter(c *gc.C) {
+	SetExporter(nil)
+
+	var ctx, span = StartSpan(context.Background(), "op", SpanKindInternal)
+	c.Check(span, gc.IsNil)
+	c.Check(ctx, gc.Equals, context.Background())
+	c.Check(FromContext(ctx), gc.IsNil)
+
+	// Methods of a nil *Span are no-ops.
+	span.SetAttribute("key", "value")
+	span.AddEvent("event %d", 1)
+	span.Finish(nil)
+
+	// A remote SpanContext is still propagated.
+	var sc = SpanContext{TraceID: newTraceID(), SpanID: newSpanID()}
+	ctx, _ = StartSpan(ContextWithRemoteSpanContext(ctx, sc), "op", SpanKindServer)
+	c.Check(SpanContextFromContext(ctx), gc.Equals, sc)
+}
+
+func (s *TracingSuite) TestParentAndChildSpans(c *gc.C) {
+	var rec = installRecorder()
+	defer SetExporter(nil)
+
+	var remote = SpanContext{TraceID: newTraceID(), SpanID: newSpanID()}
+	var ctx = ContextWithRemoteSpanContext(context.Background(), remote)
+
+	ctx, parent := StartSpan(ctx, "parent", SpanKindServer)
+	c.Check(FromContext(ctx), gc.Equals, parent)
+	c.Check(parent.TraceID, gc.Equals, remote.TraceID)
+	c.Check(parent.Parent, gc.Equals, remote.SpanID)
+	c.Check(SpanContextFromContext(ctx), gc.Equals, parent.SpanContext)
+
+	var _, child = StartSpan(ctx, "child", SpanKindInternal)
+	c.Check(child.TraceID, gc.Equals, remote.TraceID)
+	c.Check(child.Parent, gc.Equals, parent.SpanID)
+	c.Check(child.SpanID, gc.Not(gc.Equals), parent.SpanID)
+
+	child.SetAttribute("bytes", 1234)
+	child.AddEvent("did %s", "something")
+	child.Finish(errors.New("whoops"))
+	child.AddEvent("ignored after Finish")
+	child.Finish(nil) // Ignored.
+
+	parent.Finish(nil)
+
+	c.Check(rec.spans, gc.DeepEquals, []*Span{child, parent})
+	c.Check(child.Attributes(), gc.DeepEquals, map[string]string{"bytes": "1234"})
+	c.Check(child.Events(), gc.HasLen, 1)
+	c.Check(child.Events()[0].Message, gc.Equals, "did something")
+	c.Check(child.Err(), gc.ErrorMatches, "whoops")
+	c.Check(parent.Err(), gc.IsNil)
+
+	// Without a parent, a new trace is started.
+	var _, root = StartSpan(context.Background(), "root", SpanKindInternal)
+	c.Check(root.TraceID, gc.Not(gc.Equals), remote.TraceID)
+	c.Check(root.Parent, gc.Equals, SpanID{})
+}
+
+func (s *TracingSuite) TestEventsAreBounded(c *gc.C) {
+	installRecorder()
+	defer SetExporter(nil)
+
+	defer func(n int) { maxSpanEvents = n }(maxSpanEvents)
+	maxSpanEvents = 4
+
+	var _, span = StartSpan(context.Background(), "span", SpanKindInternal)
+	for i := 0; i != 7; i++ {
+		span.AddEvent("event %d", i)
+	}
+	span.Finish(nil)
+
+	// Expect the first and most recent Events are retained, in order.
+	var messages []string
+	for _, ev := range span.Events() {
+		messages = append(messages, ev.Message)
+	}
+	c.Check(messages, gc.DeepEquals, []string{"event 0", "event 1", "event 5", "event 6"})
+	c.Check(span.DroppedEvents(), gc.Equals, 3)
+}
+
+type recorder struct {
+	mu    sync.Mutex
+	spans []*Span
+}
+
+func (r *recorder) ExportSpan(span *Span) {
+	r.mu.Lock()
+	r.spans = append(r.spans, span)
+	r.mu.Unlock()
+}
+
+func installRecorder() *recorder {
+	var r = new(recorder)
+	SetExporter(r)
+	return r
+}
+
+var _ = gc.Suite(&TracingSuite{})
+
+func Test(t *testing.T) { gc.TestingT(t) }